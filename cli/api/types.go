@@ -46,6 +46,34 @@ type MultipleNodeResponse struct {
 	Items []utils.Node `json:"items"`
 }
 
+type MultipleQuotaResponse struct {
+	Items []storage.QuotaExternal `json:"items"`
+}
+
+type MultipleGroupSnapshotResponse struct {
+	Items []storage.GroupSnapshotExternal `json:"items"`
+}
+
+type MultipleVolumeMigrationResponse struct {
+	Items []storage.VolumeMigration `json:"items"`
+}
+
+// ImportVolumeResult reports the outcome of importing one of the volumes of a bulk import.
+type ImportVolumeResult struct {
+	InternalName string                  `json:"internalName"`
+	PVC          string                  `json:"pvc"`
+	Volume       *storage.VolumeExternal `json:"volume,omitempty"`
+	Error        string                  `json:"error,omitempty"`
+}
+
+type MultipleImportVolumeResultResponse struct {
+	Items []ImportVolumeResult `json:"items"`
+}
+
+type MultipleEventResponse struct {
+	Items []storage.Event `json:"items"`
+}
+
 type Version struct {
 	Version       string `json:"version"`
 	MajorVersion  uint   `json:"majorVersion"`
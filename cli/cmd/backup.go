@@ -0,0 +1,168 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/persistent_store"
+)
+
+var (
+	backupFilename          string
+	backupRedactSecrets     bool
+	backupEncryptionKeyFile string
+	backupEncryptionKey     string
+)
+
+func init() {
+	RootCmd.AddCommand(backupCmd)
+	backupCmd.Flags().StringVarP(&backupFilename, "filename", "f", "",
+		"Path of the file to write the backup to, or standard output if not set")
+	backupCmd.Flags().BoolVarP(&backupRedactSecrets, "redact-secrets", "", false,
+		"Leave the backend credentials out of the backup, which is the default without a key file")
+	backupCmd.Flags().StringVarP(&backupEncryptionKeyFile, "encryption-key-file", "", "",
+		"Path of a file whose contents are used to encrypt the backend credentials")
+	backupCmd.Flags().StringVarP(&backupEncryptionKey, "encryption-key", "", "", "Base64 encryption key")
+	backupCmd.Flags().MarkHidden("encryption-key")
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [-f <file>] [--redact-secrets | --encryption-key-file <file>]",
+	Short: "Back up the state of Trident",
+	Long: `Back up the state of Trident
+
+The backup holds the backends, storage classes, volumes, snapshots, nodes and
+pending transactions in Trident's persistent store, and may be loaded into an
+empty store with "tridentctl restore".  Trident never returns the backend
+credentials in plaintext: they are redacted unless they are encrypted with a key
+file.  An encrypted backup may only be restored with the same key file.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := discoverOperatingMode(cmd)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		if backupRedactSecrets && backupEncryptionKeyFile != "" {
+			return errors.New("--redact-secrets and --encryption-key-file may not both be specified")
+		}
+
+		var key []byte
+		var err error
+		if backupEncryptionKeyFile != "" {
+			key, err = readEncryptionKey(backupEncryptionKeyFile)
+		} else if backupEncryptionKey != "" {
+			key, err = base64.StdEncoding.DecodeString(backupEncryptionKey)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Trident encrypts or redacts the secrets, so that they are never sent in plaintext
+		var backup *persistentstore.Backup
+		if OperatingMode == ModeTunnel {
+			backup, err = getTunneledBackup(key)
+		} else {
+			var baseURL string
+			if baseURL, err = GetBaseURL(); err == nil {
+				backup, err = GetBackup(baseURL, key)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		return backupWrite(backup, backupFilename)
+	},
+}
+
+func getTunneledBackup(key []byte) (*persistentstore.Backup, error) {
+
+	command := []string{"backup"}
+	if len(key) > 0 {
+		command = append(command, "--encryption-key", base64.StdEncoding.EncodeToString(key))
+	}
+	output, err := TunnelCommandRaw(command)
+	if err != nil {
+		return nil, fmt.Errorf("%s; %v", string(output), err)
+	}
+
+	backup := new(persistentstore.Backup)
+	if err = json.Unmarshal(output, backup); err != nil {
+		return nil, fmt.Errorf("could not parse backup: %v", err)
+	}
+	return backup, nil
+}
+
+func GetBackup(baseURL string, key []byte) (*persistentstore.Backup, error) {
+
+	url := baseURL + "/backup"
+
+	postData, err := json.Marshal(&rest.BackupRequest{EncryptionKey: key})
+	if err != nil {
+		return nil, err
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, postData, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not back up Trident: %v",
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var backupResponse rest.BackupResponse
+	err = json.Unmarshal(responseBody, &backupResponse)
+	if err != nil {
+		return nil, err
+	}
+	if backupResponse.Backup == nil {
+		return nil, errors.New("could not back up Trident: no backup was returned")
+	}
+
+	return backupResponse.Backup, nil
+}
+
+func backupWrite(backup *persistentstore.Backup, filename string) error {
+
+	backupBytes, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+	backupBytes = append(backupBytes, '\n')
+
+	if filename == "" || filename == "-" {
+		_, err = os.Stdout.Write(backupBytes)
+		return err
+	}
+	return ioutil.WriteFile(filename, backupBytes, 0600)
+}
+
+// readEncryptionKey derives a 256-bit AES key from the contents of a key file.
+// Surrounding whitespace is ignored, so that a trailing newline doesn't matter.
+func readEncryptionKey(keyFile string) ([]byte, error) {
+
+	keyBytes, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read encryption key file: %v", err)
+	}
+	keyBytes = bytes.TrimSpace(keyBytes)
+	if len(keyBytes) == 0 {
+		return nil, fmt.Errorf("encryption key file %s is empty", keyFile)
+	}
+
+	key := sha256.Sum256(keyBytes)
+	return key[:], nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var createGroupSnapshotAllowSequential bool
+
+func init() {
+	createCmd.AddCommand(createGroupSnapshotCmd)
+	createGroupSnapshotCmd.Flags().BoolVarP(&createGroupSnapshotAllowSequential, "allow-sequential", "", false,
+		"Snapshot the volumes one after another if the storage can't snapshot them together")
+}
+
+var createGroupSnapshotCmd = &cobra.Command{
+	Use:     "groupsnapshot <name> <volume> [<volume>...]",
+	Short:   "Snapshot several volumes at the same point in time",
+	Aliases: []string{"gs"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"create", "groupsnapshot"}
+			if createGroupSnapshotAllowSequential {
+				command = append(command, "--allow-sequential")
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return groupSnapshotCreate(args)
+		}
+	},
+}
+
+func groupSnapshotCreate(args []string) error {
+
+	if len(args) < 2 {
+		return errors.New("group snapshot name and at least one volume name must be specified")
+	}
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	postData, err := json.Marshal(&storage.GroupSnapshotConfig{
+		Name:            args[0],
+		VolumeNames:     args[1:],
+		AllowSequential: createGroupSnapshotAllowSequential,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/groupsnapshot"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, postData, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("could not create group snapshot: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var groupSnapshotResponse rest.GroupSnapshotResponse
+	err = json.Unmarshal(responseBody, &groupSnapshotResponse)
+	if err != nil {
+		return err
+	}
+
+	WriteGroupSnapshots([]storage.GroupSnapshotExternal{*groupSnapshotResponse.GroupSnapshot})
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	createCmd.AddCommand(createQuotaCmd)
+	createQuotaCmd.Flags().StringVarP(&createFilename, "filename", "f", "", "Path to YAML or JSON file")
+	createQuotaCmd.Flags().StringVarP(&createBase64Data, "base64", "", "", "Base64 encoding")
+	createQuotaCmd.Flags().MarkHidden("base64")
+}
+
+var createQuotaCmd = &cobra.Command{
+	Use:     "quota",
+	Short:   "Add a quota to Trident",
+	Aliases: []string{"q"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		jsonData, err := getBackendData(createFilename, createBase64Data)
+		if err != nil {
+			return err
+		}
+
+		if OperatingMode == ModeTunnel {
+			command := []string{"create", "quota", "--base64", base64.StdEncoding.EncodeToString(jsonData)}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return quotaCreate(jsonData)
+		}
+	},
+}
+
+func quotaCreate(postData []byte) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	// Send the file to Trident
+	url := baseURL + "/quota"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, postData, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("could not create quota: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var quotaResponse rest.QuotaResponse
+	err = json.Unmarshal(responseBody, &quotaResponse)
+	if err != nil {
+		return err
+	}
+
+	WriteQuotas([]storage.QuotaExternal{*quotaResponse.Quota})
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+)
+
+func init() {
+	deleteCmd.AddCommand(deleteGroupSnapshotCmd)
+}
+
+var deleteGroupSnapshotCmd = &cobra.Command{
+	Use:     "groupsnapshot <name> [<name>...]",
+	Short:   "Delete one or more group snapshots and their snapshots from Trident",
+	Aliases: []string{"gs", "groupsnapshots"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"delete", "groupsnapshot"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return groupSnapshotDelete(args)
+		}
+	},
+}
+
+func groupSnapshotDelete(groupSnapshotNames []string) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	if len(groupSnapshotNames) == 0 {
+		return errors.New("group snapshot name not specified")
+	}
+
+	for _, groupSnapshotName := range groupSnapshotNames {
+		url := baseURL + "/groupsnapshot/" + groupSnapshotName
+
+		response, responseBody, err := api.InvokeRESTAPI("DELETE", url, nil, Debug)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("could not delete group snapshot %s: %v", groupSnapshotName,
+				GetErrorFromHTTPResponse(response, responseBody))
+		}
+	}
+
+	return nil
+}
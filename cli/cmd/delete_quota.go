@@ -0,0 +1,58 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+)
+
+func init() {
+	deleteCmd.AddCommand(deleteQuotaCmd)
+}
+
+var deleteQuotaCmd = &cobra.Command{
+	Use:     "quota <name> [<name>...]",
+	Short:   "Delete one or more quotas from Trident",
+	Aliases: []string{"q", "quotas"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"delete", "quota"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return quotaDelete(args)
+		}
+	},
+}
+
+func quotaDelete(quotaNames []string) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	if len(quotaNames) == 0 {
+		return errors.New("quota name not specified")
+	}
+
+	for _, quotaName := range quotaNames {
+		url := baseURL + "/quota/" + quotaName
+
+		response, responseBody, err := api.InvokeRESTAPI("DELETE", url, nil, Debug)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("could not delete quota %s: %v", quotaName,
+				GetErrorFromHTTPResponse(response, responseBody))
+		}
+	}
+
+	return nil
+}
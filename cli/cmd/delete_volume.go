@@ -50,7 +50,7 @@ func volumeDelete(volumeNames []string) error {
 		}
 
 		// Get list of volume names so we can delete them all
-		volumeNames, err = GetVolumes(baseURL)
+		volumeNames, err = GetVolumes(baseURL, "")
 		if err != nil {
 			return err
 		}
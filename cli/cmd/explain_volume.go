@@ -0,0 +1,220 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_class"
+)
+
+var (
+	explainFilename     string
+	explainBase64Data   string
+	explainStorageClass string
+	explainSize         string
+	explainProtocol     string
+	explainAccessMode   string
+)
+
+func init() {
+	explainCmd.AddCommand(explainVolumeCmd)
+	explainVolumeCmd.Flags().StringVarP(&explainFilename, "filename", "f", "",
+		"Path to YAML or JSON volume config file")
+	explainVolumeCmd.Flags().StringVarP(&explainStorageClass, "storage-class", "", "", "Storage class")
+	explainVolumeCmd.Flags().StringVarP(&explainSize, "size", "", "", "Volume size, e.g. 1Gi")
+	explainVolumeCmd.Flags().StringVarP(&explainProtocol, "protocol", "", "", "Protocol (file|block)")
+	explainVolumeCmd.Flags().StringVarP(&explainAccessMode, "access-mode", "", "",
+		"Access mode (ReadWriteOnce|ReadOnlyMany|ReadWriteMany)")
+	explainVolumeCmd.Flags().StringVarP(&explainBase64Data, "base64", "", "", "Base64 encoding")
+	explainVolumeCmd.Flags().MarkHidden("base64")
+}
+
+var explainVolumeCmd = &cobra.Command{
+	Use:   "volume [<name>]",
+	Short: "Explain which storage pools could hold a volume, and why",
+	Long: `Explain which storage pools could hold a volume, and why
+
+Trident checks the volume request against its storage class, every storage
+pool and each pool's backend, exactly as it would when creating the volume,
+but nothing is created.  Describe the volume with flags, or specify a volume
+config file.`,
+	Aliases: []string{"v"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		volumeJSON, err := getExplainVolumeData(args)
+		if err != nil {
+			return err
+		}
+
+		if OperatingMode == ModeTunnel {
+			command := []string{"explain", "volume", "--base64", base64.StdEncoding.EncodeToString(volumeJSON)}
+			TunnelCommand(command)
+			return nil
+		} else {
+			return volumeExplain(volumeJSON)
+		}
+	},
+}
+
+// getExplainVolumeData returns a volume config in JSON, read from a file or built from
+// the command's arguments.
+func getExplainVolumeData(args []string) ([]byte, error) {
+
+	if explainFilename != "" || explainBase64Data != "" {
+		if len(args) > 0 || explainStorageClass != "" || explainSize != "" || explainProtocol != "" ||
+			explainAccessMode != "" {
+			return nil, errors.New("a volume config file may not be combined with other volume arguments")
+		}
+		return getBackendData(explainFilename, explainBase64Data)
+	}
+
+	if explainStorageClass == "" || explainSize == "" {
+		return nil, errors.New("a storage class and size, or a volume config file, must be specified")
+	}
+	volumeConfig := &storage.VolumeConfig{
+		Name:         "explain",
+		Size:         explainSize,
+		Protocol:     config.Protocol(explainProtocol),
+		AccessMode:   config.AccessMode(explainAccessMode),
+		StorageClass: explainStorageClass,
+	}
+	if len(args) > 0 {
+		volumeConfig.Name = args[0]
+	}
+	return json.Marshal(volumeConfig)
+}
+
+func volumeExplain(postData []byte) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/volume/explain"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, postData, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not explain volume: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var explainResponse rest.ExplainVolumeResponse
+	err = json.Unmarshal(responseBody, &explainResponse)
+	if err != nil {
+		return err
+	}
+
+	WriteVolumeExplanation(explainResponse.Explanation)
+
+	return nil
+}
+
+func WriteVolumeExplanation(explanation *storageclass.VolumeExplanation) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(explanation)
+	case FormatYAML:
+		WriteYAML(explanation)
+	case FormatName:
+		writeMatchedPoolNames(explanation)
+	case FormatWide:
+		writeVolumeExplanationSummary(explanation)
+		writeWideVolumeExplanationTable(explanation)
+	default:
+		writeVolumeExplanationSummary(explanation)
+		writeVolumeExplanationTable(explanation)
+	}
+}
+
+func writeVolumeExplanationSummary(explanation *storageclass.VolumeExplanation) {
+
+	if explanation.Provisionable {
+		fmt.Printf("Volume %s may be provisioned with storage class %s.\n",
+			explanation.Volume, explanation.StorageClass)
+	} else {
+		fmt.Printf("Volume %s can't be provisioned with storage class %s.\n",
+			explanation.Volume, explanation.StorageClass)
+	}
+	for _, check := range explanation.Checks {
+		if !check.Passed {
+			fmt.Printf("  %s: %s\n", check.Criterion, check.Reason)
+		}
+	}
+}
+
+// failedCheckReasons summarizes the checks that excluded a storage pool.
+func failedCheckReasons(pool *storageclass.PoolExplanation) string {
+
+	reasons := make([]string, 0)
+	for _, check := range pool.Checks {
+		if !check.Passed {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", check.Criterion, check.Reason))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func writeVolumeExplanationTable(explanation *storageclass.VolumeExplanation) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Backend", "Pool", "Matched", "Reason"})
+
+	for _, pool := range explanation.Pools {
+		table.Append([]string{
+			pool.Backend,
+			pool.Pool,
+			fmt.Sprintf("%t", pool.Matched),
+			failedCheckReasons(pool),
+		})
+	}
+
+	table.Render()
+}
+
+func writeWideVolumeExplanationTable(explanation *storageclass.VolumeExplanation) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Backend", "Pool", "Criterion", "Requested", "Offered", "Passed", "Reason"})
+
+	for _, pool := range explanation.Pools {
+		for _, check := range pool.Checks {
+			table.Append([]string{
+				pool.Backend,
+				pool.Pool,
+				check.Criterion,
+				check.Requested,
+				check.Offered,
+				fmt.Sprintf("%t", check.Passed),
+				check.Reason,
+			})
+		}
+	}
+
+	table.Render()
+}
+
+func writeMatchedPoolNames(explanation *storageclass.VolumeExplanation) {
+
+	for _, pool := range explanation.Pools {
+		if pool.Matched {
+			fmt.Printf("%s/%s\n", pool.Backend, pool.Pool)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getCmd.AddCommand(getEventCmd)
+}
+
+var getEventCmd = &cobra.Command{
+	Use:     "event",
+	Short:   "Get the recent operations recorded by Trident",
+	Aliases: []string{"e", "events"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "event"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return eventList()
+		}
+	},
+}
+
+func eventList() error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	events, err := GetEvents(baseURL)
+	if err != nil {
+		return err
+	}
+
+	WriteEvents(events)
+
+	return nil
+}
+
+func GetEvents(baseURL string) ([]storage.Event, error) {
+
+	url := baseURL + "/event"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get events: %v",
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listEventsResponse rest.ListEventsResponse
+	err = json.Unmarshal(responseBody, &listEventsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]storage.Event, 0, len(listEventsResponse.Events))
+	for _, event := range listEventsResponse.Events {
+		events = append(events, *event)
+	}
+
+	return events, nil
+}
+
+func WriteEvents(events []storage.Event) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleEventResponse{Items: events})
+	case FormatYAML:
+		WriteYAML(api.MultipleEventResponse{Items: events})
+	case FormatName:
+		writeEventNames(events)
+	case FormatWide:
+		writeWideEventTable(events)
+	default:
+		writeEventTable(events)
+	}
+}
+
+func writeEventTable(events []storage.Event) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Time", "Operation", "Object", "Source", "Outcome"})
+
+	for _, e := range events {
+		table.Append([]string{
+			e.Time,
+			e.Operation,
+			e.ObjectName,
+			e.Source,
+			e.Outcome,
+		})
+	}
+
+	table.Render()
+}
+
+func writeWideEventTable(events []storage.Event) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{
+		"Time",
+		"Operation",
+		"Object Type",
+		"Object",
+		"Source",
+		"Outcome",
+		"Duration (ms)",
+		"Error",
+	}
+	table.SetHeader(header)
+
+	for _, e := range events {
+
+		table.Append([]string{
+			e.Time,
+			e.Operation,
+			e.ObjectType,
+			e.ObjectName,
+			e.Source,
+			e.Outcome,
+			strconv.FormatInt(e.DurationMs, 10),
+			e.Error,
+		})
+	}
+
+	table.Render()
+}
+
+func writeEventNames(events []storage.Event) {
+
+	for _, e := range events {
+		fmt.Println(e.ID)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getCmd.AddCommand(getGroupSnapshotCmd)
+}
+
+var getGroupSnapshotCmd = &cobra.Command{
+	Use:     "groupsnapshot [<name>...]",
+	Short:   "Get one or more group snapshots from Trident",
+	Aliases: []string{"gs", "groupsnapshots"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "groupsnapshot"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return groupSnapshotList(args)
+		}
+	},
+}
+
+func groupSnapshotList(groupSnapshotNames []string) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	// If no group snapshots were specified, we'll get all of them
+	if len(groupSnapshotNames) == 0 {
+		groupSnapshotNames, err = GetGroupSnapshots(baseURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	groupSnapshots := make([]storage.GroupSnapshotExternal, 0, 10)
+
+	// Get the actual group snapshot objects
+	for _, groupSnapshotName := range groupSnapshotNames {
+
+		groupSnapshot, err := GetGroupSnapshot(baseURL, groupSnapshotName)
+		if err != nil {
+			return err
+		}
+		groupSnapshots = append(groupSnapshots, *groupSnapshot)
+	}
+
+	WriteGroupSnapshots(groupSnapshots)
+
+	return nil
+}
+
+func GetGroupSnapshots(baseURL string) ([]string, error) {
+
+	url := baseURL + "/groupsnapshot"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get group snapshots: %v",
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listGroupSnapshotsResponse rest.ListGroupSnapshotsResponse
+	err = json.Unmarshal(responseBody, &listGroupSnapshotsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return listGroupSnapshotsResponse.GroupSnapshots, nil
+}
+
+func GetGroupSnapshot(baseURL, groupSnapshotName string) (*storage.GroupSnapshotExternal, error) {
+
+	url := baseURL + "/groupsnapshot/" + groupSnapshotName
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get group snapshot %s: %v", groupSnapshotName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var getGroupSnapshotResponse rest.GetGroupSnapshotResponse
+	err = json.Unmarshal(responseBody, &getGroupSnapshotResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return getGroupSnapshotResponse.GroupSnapshot, nil
+}
+
+func WriteGroupSnapshots(groupSnapshots []storage.GroupSnapshotExternal) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleGroupSnapshotResponse{Items: groupSnapshots})
+	case FormatYAML:
+		WriteYAML(api.MultipleGroupSnapshotResponse{Items: groupSnapshots})
+	case FormatName:
+		writeGroupSnapshotNames(groupSnapshots)
+	default:
+		writeGroupSnapshotTable(groupSnapshots)
+	}
+}
+
+func writeGroupSnapshotTable(groupSnapshots []storage.GroupSnapshotExternal) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Volumes", "Created", "Native", "Snapshots"})
+
+	for _, g := range groupSnapshots {
+		table.Append([]string{
+			g.Config.Name,
+			strings.Join(g.Config.VolumeNames, "\n"),
+			g.Created,
+			strconv.FormatBool(g.Native),
+			fmt.Sprintf("%d/%d", len(g.Snapshots), len(g.Config.VolumeNames)),
+		})
+	}
+
+	table.Render()
+}
+
+func writeGroupSnapshotNames(groupSnapshots []storage.GroupSnapshotExternal) {
+
+	for _, g := range groupSnapshots {
+		fmt.Println(g.Config.Name)
+	}
+}
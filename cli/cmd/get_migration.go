@@ -0,0 +1,127 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getCmd.AddCommand(getMigrationCmd)
+}
+
+var getMigrationCmd = &cobra.Command{
+	Use:     "migration <volume>...",
+	Short:   "Get the progress of the latest migration of one or more volumes",
+	Aliases: []string{"m", "migrations"},
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "migration"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return migrationList(args)
+		}
+	},
+}
+
+func migrationList(volumeNames []string) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	migrations := make([]storage.VolumeMigration, 0, len(volumeNames))
+
+	for _, volumeName := range volumeNames {
+
+		migration, err := GetVolumeMigration(baseURL, volumeName)
+		if err != nil {
+			return err
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	WriteVolumeMigrations(migrations)
+
+	return nil
+}
+
+func GetVolumeMigration(baseURL, volumeName string) (*storage.VolumeMigration, error) {
+
+	url := baseURL + "/volume/" + volumeName + "/migration"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get migration of volume %s: %v", volumeName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var migrationResponse rest.VolumeMigrationResponse
+	err = json.Unmarshal(responseBody, &migrationResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationResponse.Migration, nil
+}
+
+func WriteVolumeMigrations(migrations []storage.VolumeMigration) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleVolumeMigrationResponse{Items: migrations})
+	case FormatYAML:
+		WriteYAML(api.MultipleVolumeMigrationResponse{Items: migrations})
+	case FormatName:
+		writeVolumeMigrationNames(migrations)
+	default:
+		writeVolumeMigrationTable(migrations)
+	}
+}
+
+func writeVolumeMigrationTable(migrations []storage.VolumeMigration) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Volume", "Source Backend", "Target Backend", "Method", "State", "Started", "Error"})
+
+	for _, m := range migrations {
+
+		method := "copy"
+		if m.Native {
+			method = "replication"
+		}
+
+		table.Append([]string{
+			m.VolumeName,
+			m.SourceBackend,
+			m.TargetBackend,
+			method,
+			string(m.State),
+			m.Started,
+			m.Error,
+		})
+	}
+
+	table.Render()
+}
+
+func writeVolumeMigrationNames(migrations []storage.VolumeMigration) {
+
+	for _, m := range migrations {
+		fmt.Println(m.VolumeName)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getCmd.AddCommand(getQuotaCmd)
+}
+
+var getQuotaCmd = &cobra.Command{
+	Use:     "quota [<name>...]",
+	Short:   "Get one or more quotas and their usage from Trident",
+	Aliases: []string{"q", "quotas"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "quota"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return quotaList(args)
+		}
+	},
+}
+
+func quotaList(quotaNames []string) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	// If no quotas were specified, we'll get all of them
+	if len(quotaNames) == 0 {
+		quotaNames, err = GetQuotas(baseURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	quotas := make([]storage.QuotaExternal, 0, 10)
+
+	// Get the actual quota objects
+	for _, quotaName := range quotaNames {
+
+		quota, err := GetQuota(baseURL, quotaName)
+		if err != nil {
+			return err
+		}
+		quotas = append(quotas, *quota)
+	}
+
+	WriteQuotas(quotas)
+
+	return nil
+}
+
+func GetQuotas(baseURL string) ([]string, error) {
+
+	url := baseURL + "/quota"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get quotas: %v",
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listQuotasResponse rest.ListQuotasResponse
+	err = json.Unmarshal(responseBody, &listQuotasResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return listQuotasResponse.Quotas, nil
+}
+
+func GetQuota(baseURL, quotaName string) (*storage.QuotaExternal, error) {
+
+	url := baseURL + "/quota/" + quotaName
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get quota %s: %v", quotaName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var getQuotaResponse rest.GetQuotaResponse
+	err = json.Unmarshal(responseBody, &getQuotaResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return getQuotaResponse.Quota, nil
+}
+
+func WriteQuotas(quotas []storage.QuotaExternal) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleQuotaResponse{Items: quotas})
+	case FormatYAML:
+		WriteYAML(api.MultipleQuotaResponse{Items: quotas})
+	case FormatName:
+		writeQuotaNames(quotas)
+	default:
+		writeQuotaTable(quotas)
+	}
+}
+
+func writeQuotaTable(quotas []storage.QuotaExternal) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Scope", "Owner", "Used Size", "Max Size", "Used Volumes", "Max Volumes"})
+
+	for _, q := range quotas {
+
+		maxVolumes := ""
+		if q.MaxVolumes > 0 {
+			maxVolumes = strconv.Itoa(q.MaxVolumes)
+		}
+
+		table.Append([]string{
+			q.Name,
+			q.Scope,
+			q.Owner,
+			humanize.IBytes(q.UsedBytes),
+			q.MaxSize,
+			strconv.Itoa(q.UsedVolumes),
+			maxVolumes,
+		})
+	}
+
+	table.Render()
+}
+
+func writeQuotaNames(quotas []storage.QuotaExternal) {
+
+	for _, q := range quotas {
+		fmt.Println(q.Name)
+	}
+}
@@ -4,10 +4,14 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/netapp/trident/cli/api"
@@ -17,8 +21,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var getVolumeSelector string
+
 func init() {
 	getCmd.AddCommand(getVolumeCmd)
+	getVolumeCmd.Flags().StringVarP(&getVolumeSelector, "selector", "l", "",
+		"Label selector to filter volumes, such as app=mysql,team!=web")
 }
 
 var getVolumeCmd = &cobra.Command{
@@ -28,6 +36,9 @@ var getVolumeCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if OperatingMode == ModeTunnel {
 			command := []string{"get", "volume"}
+			if getVolumeSelector != "" {
+				command = append(command, "--selector", getVolumeSelector)
+			}
 			TunnelCommand(append(command, args...))
 			return nil
 		} else {
@@ -43,9 +54,13 @@ func volumeList(volumeNames []string) error {
 		return err
 	}
 
-	// If no volumes were specified, we'll get all of them
+	if len(volumeNames) > 0 && getVolumeSelector != "" {
+		return errors.New("volume names may not be specified with a label selector")
+	}
+
+	// If no volumes were specified, we'll get all of them that match the selector
 	if len(volumeNames) == 0 {
-		volumeNames, err = GetVolumes(baseURL)
+		volumeNames, err = GetVolumes(baseURL, getVolumeSelector)
 		if err != nil {
 			return err
 		}
@@ -63,16 +78,32 @@ func volumeList(volumeNames []string) error {
 		volumes = append(volumes, volume)
 	}
 
-	WriteVolumes(volumes)
+	// The wide format also shows the nodes each volume is published to
+	var publishedNodes map[string][]string
+	if OutputFormat == FormatWide {
+		publishedNodes = make(map[string][]string)
+		for _, volume := range volumes {
+			nodes, err := GetVolumePublishedNodes(baseURL, volume.Config.Name)
+			if err != nil {
+				return err
+			}
+			publishedNodes[volume.Config.Name] = nodes
+		}
+	}
+
+	WriteVolumes(volumes, publishedNodes)
 
 	return nil
 }
 
-func GetVolumes(baseURL string) ([]string, error) {
+func GetVolumes(baseURL, selector string) ([]string, error) {
 
-	url := baseURL + "/volume"
+	volumesURL := baseURL + "/volume"
+	if selector != "" {
+		volumesURL += "?selector=" + url.QueryEscape(selector)
+	}
 
-	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	response, responseBody, err := api.InvokeRESTAPI("GET", volumesURL, nil, Debug)
 	if err != nil {
 		return nil, err
 	} else if response.StatusCode != http.StatusOK {
@@ -110,7 +141,37 @@ func GetVolume(baseURL, volumeName string) (storage.VolumeExternal, error) {
 	return *getVolumeResponse.Volume, nil
 }
 
-func WriteVolumes(volumes []storage.VolumeExternal) {
+// GetVolumePublishedNodes returns the sorted names of the nodes a volume is published to.
+func GetVolumePublishedNodes(baseURL, volumeName string) ([]string, error) {
+
+	url := baseURL + "/volume/" + volumeName + "/publication"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get publications for volume %s: %v", volumeName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listPublicationsResponse rest.ListVolumePublicationsResponse
+	err = json.Unmarshal(responseBody, &listPublicationsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]string, 0, len(listPublicationsResponse.VolumePublications))
+	for _, publication := range listPublicationsResponse.VolumePublications {
+		nodes = append(nodes, publication.NodeName)
+	}
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+// WriteVolumes writes volumes in the selected output format.  The wide format includes the
+// nodes each volume is published to, keyed by volume name.
+func WriteVolumes(volumes []storage.VolumeExternal, publishedNodes map[string][]string) {
 	switch OutputFormat {
 	case FormatJSON:
 		WriteJSON(api.MultipleVolumeResponse{Items: volumes})
@@ -119,7 +180,7 @@ func WriteVolumes(volumes []storage.VolumeExternal) {
 	case FormatName:
 		writeVolumeNames(volumes)
 	case FormatWide:
-		writeWideVolumeTable(volumes)
+		writeWideVolumeTable(volumes, publishedNodes)
 	default:
 		writeVolumeTable(volumes)
 	}
@@ -147,7 +208,7 @@ func writeVolumeTable(volumes []storage.VolumeExternal) {
 	table.Render()
 }
 
-func writeWideVolumeTable(volumes []storage.VolumeExternal) {
+func writeWideVolumeTable(volumes []storage.VolumeExternal, publishedNodes map[string][]string) {
 
 	table := tablewriter.NewWriter(os.Stdout)
 	header := []string{
@@ -159,6 +220,8 @@ func writeWideVolumeTable(volumes []storage.VolumeExternal) {
 		"Backend",
 		"Pool",
 		"Access Mode",
+		"Published Nodes",
+		"Labels",
 	}
 	table.SetHeader(header)
 
@@ -175,12 +238,25 @@ func writeWideVolumeTable(volumes []storage.VolumeExternal) {
 			volume.Backend,
 			volume.Pool,
 			string(volume.Config.AccessMode),
+			strings.Join(publishedNodes[volume.Config.Name], ","),
+			formatVolumeLabels(volume.Config.Labels),
 		})
 	}
 
 	table.Render()
 }
 
+// formatVolumeLabels returns volume labels as a sorted list of key=value pairs.
+func formatVolumeLabels(labels map[string]string) string {
+
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
 func writeVolumeNames(volumes []storage.VolumeExternal) {
 
 	for _, sc := range volumes {
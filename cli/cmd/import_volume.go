@@ -101,6 +101,23 @@ func volumeImport(backendName, internalVolumeName string, noManage bool, pvcData
 		return err
 	}
 
+	volume, err := importVolume(baseURL, backendName, internalVolumeName, noManage, pvcDataJSON)
+	if err != nil {
+		return err
+	}
+
+	volumes := make([]storage.VolumeExternal, 0, 10)
+	volumes = append(volumes, *volume)
+	WriteVolumes(volumes, nil)
+
+	return nil
+}
+
+// importVolume asks Trident to import one volume, creating the PVC described by the PVC data.
+func importVolume(
+	baseURL, backendName, internalVolumeName string, noManage bool, pvcDataJSON []byte,
+) (*storage.VolumeExternal, error) {
+
 	request := &storage.ImportVolumeRequest{
 		Backend:      backendName,
 		InternalName: internalVolumeName,
@@ -110,7 +127,7 @@ func volumeImport(backendName, internalVolumeName string, noManage bool, pvcData
 
 	requestBytes, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Send the request to Trident
@@ -118,22 +135,16 @@ func volumeImport(backendName, internalVolumeName string, noManage bool, pvcData
 
 	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
 	if err != nil {
-		return err
+		return nil, err
 	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("could not import volume: %v", GetErrorFromHTTPResponse(response, responseBody))
+		return nil, fmt.Errorf("could not import volume: %v", GetErrorFromHTTPResponse(response, responseBody))
 	}
 
 	var importVolumeResponse rest.ImportVolumeResponse
 	err = json.Unmarshal(responseBody, &importVolumeResponse)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	volume := *importVolumeResponse.Volume
-
-	volumes := make([]storage.VolumeExternal, 0, 10)
-	volumes = append(volumes, volume)
-	WriteVolumes(volumes)
-
-	return nil
+	return importVolumeResponse.Volume, nil
 }
@@ -0,0 +1,206 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+)
+
+var (
+	importVolumesFilename   string
+	importVolumesBase64Data string
+	importVolumesPattern    string
+	importVolumesNoManage   bool
+)
+
+// invalidClaimNameChars matches the characters that may not appear in a PVC name.
+var invalidClaimNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+func init() {
+	importCmd.AddCommand(importVolumesCmd)
+	importVolumesCmd.Flags().StringVarP(&importVolumesFilename, "filename", "f", "", "Path to YAML or JSON PVC template file")
+	importVolumesCmd.Flags().StringVarP(&importVolumesPattern, "pattern", "", "", "Import only volumes whose names match this pattern, such as 'legacy_*'")
+	importVolumesCmd.Flags().BoolVarP(&importVolumesNoManage, "no-manage", "", false, "Create PV/PVC only, don't assume volume lifecycle management")
+	importVolumesCmd.Flags().StringVarP(&importVolumesBase64Data, "base64", "", "", "Base64 encoding")
+	importVolumesCmd.Flags().MarkHidden("base64")
+}
+
+var importVolumesCmd = &cobra.Command{
+	Use:   "volumes <backendName> [--pattern <pattern>]",
+	Short: "Import all existing volumes on a backend to Trident",
+	Long: `Import all existing volumes on a backend to Trident
+
+Every volume on the backend that Trident doesn't already manage, and whose name
+matches the pattern if one is given, is imported.  The PVC template must name the
+namespace and storage class of the PVCs to create.  Each PVC is named after its
+volume, prefixed by the template's name if it has one.  A volume that can't be
+imported doesn't stop the others from being imported.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		pvcDataJSON, err := getPVCData(importVolumesFilename, importVolumesBase64Data)
+		if err != nil {
+			return err
+		}
+
+		if OperatingMode == ModeTunnel {
+			command := []string{"import", "volumes", "--base64", base64.StdEncoding.EncodeToString(pvcDataJSON),
+				"--pattern", importVolumesPattern}
+			if importVolumesNoManage {
+				command = append(command, "--no-manage")
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumesImport(args[0], importVolumesPattern, importVolumesNoManage, pvcDataJSON)
+		}
+	},
+}
+
+func volumesImport(backendName, pattern string, noManage bool, templateJSON []byte) error {
+
+	template := &v1.PersistentVolumeClaim{}
+	if err := json.Unmarshal(templateJSON, template); err != nil {
+		return fmt.Errorf("could not parse PVC template: %v", err)
+	}
+	if template.Namespace == "" {
+		return errors.New("the PVC template must specify a namespace")
+	}
+	if template.Spec.StorageClassName == nil || *template.Spec.StorageClassName == "" {
+		return errors.New("the PVC template must specify a storage class")
+	}
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	volumeNames, err := getUnmanagedVolumes(baseURL, backendName, pattern)
+	if err != nil {
+		return err
+	}
+
+	results := make([]api.ImportVolumeResult, 0, len(volumeNames))
+	failed := 0
+	for _, volumeName := range volumeNames {
+
+		claim := template.DeepCopy()
+		claim.Name = getImportClaimName(template.Name, volumeName)
+		result := api.ImportVolumeResult{
+			InternalName: volumeName,
+			PVC:          claim.Name,
+		}
+
+		claimJSON, err := json.Marshal(claim)
+		if err == nil {
+			result.Volume, err = importVolume(baseURL, backendName, volumeName, noManage, claimJSON)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	WriteImportVolumeResults(results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d volumes could not be imported", failed, len(results))
+	}
+	return nil
+}
+
+// getUnmanagedVolumes returns the names of the volumes on a backend that Trident doesn't manage.
+func getUnmanagedVolumes(baseURL, backendName, pattern string) ([]string, error) {
+
+	getURL := baseURL + "/backend/" + backendName + "/unmanagedvolume"
+	if pattern != "" {
+		getURL += "?pattern=" + url.QueryEscape(pattern)
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", getURL, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not list the volumes on backend %s: %v", backendName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listResponse rest.ListUnmanagedVolumesResponse
+	err = json.Unmarshal(responseBody, &listResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return listResponse.Volumes, nil
+}
+
+// getImportClaimName derives a valid PVC name from the name of a volume being imported,
+// prefixed by the name of the PVC template if it has one.
+func getImportClaimName(prefix, volumeName string) string {
+	name := invalidClaimNameChars.ReplaceAllString(strings.ToLower(volumeName), "-")
+	if prefix != "" {
+		name = prefix + "-" + name
+	}
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return strings.Trim(name, "-.")
+}
+
+func WriteImportVolumeResults(results []api.ImportVolumeResult) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleImportVolumeResultResponse{Items: results})
+	case FormatYAML:
+		WriteYAML(api.MultipleImportVolumeResultResponse{Items: results})
+	case FormatName:
+		writeImportVolumeResultNames(results)
+	default:
+		writeImportVolumeResultTable(results)
+	}
+}
+
+func writeImportVolumeResultTable(results []api.ImportVolumeResult) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Volume", "PVC", "Result"})
+
+	for _, r := range results {
+		outcome := "imported"
+		if r.Error != "" {
+			outcome = r.Error
+		}
+		table.Append([]string{
+			r.InternalName,
+			r.PVC,
+			outcome,
+		})
+	}
+
+	table.Render()
+}
+
+func writeImportVolumeResultNames(results []api.ImportVolumeResult) {
+
+	for _, r := range results {
+		if r.Error == "" {
+			fmt.Println(r.PVC)
+		}
+	}
+}
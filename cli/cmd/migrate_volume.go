@@ -0,0 +1,110 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+const migrationPollInterval = 5 * time.Second
+
+var (
+	migrateVolumeBackend string
+	migrateVolumeWait    bool
+)
+
+func init() {
+	migrateCmd.AddCommand(migrateVolumeCmd)
+	migrateVolumeCmd.Flags().StringVarP(&migrateVolumeBackend, "backend", "", "",
+		"Backend to move the volume to")
+	migrateVolumeCmd.Flags().BoolVarP(&migrateVolumeWait, "wait", "", false,
+		"Wait for the migration to finish")
+}
+
+var migrateVolumeCmd = &cobra.Command{
+	Use:   "volume <name> --backend <backend>",
+	Short: "Move a volume to another backend",
+	Long: `Move a volume to another backend
+
+The volume keeps its name, but it is recreated in one of the backend's storage
+pools that belongs to its storage class.  Its data is copied by the storage
+systems if they can replicate between each other, or by a job on a node
+otherwise.  The volume must not be published and must not have snapshots.
+
+The migration continues in the background; use --wait to wait for it to finish,
+or "tridentctl get migration" to check its progress.`,
+	Aliases: []string{"v"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"migrate", "volume", "--backend", migrateVolumeBackend}
+			if migrateVolumeWait {
+				command = append(command, "--wait")
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeMigrate(args[0], migrateVolumeBackend, migrateVolumeWait)
+		}
+	},
+}
+
+func volumeMigrate(volumeName, backendName string, wait bool) error {
+
+	if backendName == "" {
+		return fmt.Errorf("backend not specified")
+	}
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/volume/" + volumeName + "/migration"
+
+	request := rest.MigrateVolumeRequest{
+		Backend: backendName,
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not migrate volume %s: %v", volumeName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var migrateResponse rest.VolumeMigrationResponse
+	err = json.Unmarshal(responseBody, &migrateResponse)
+	if err != nil {
+		return err
+	}
+	migration := migrateResponse.Migration
+
+	for wait && !migration.Done() {
+		time.Sleep(migrationPollInterval)
+		if migration, err = GetVolumeMigration(baseURL, volumeName); err != nil {
+			return err
+		}
+	}
+
+	WriteVolumeMigrations([]storage.VolumeMigration{*migration})
+
+	if migration.State == storage.MigrationFailed {
+		return fmt.Errorf("could not migrate volume %s: %s", volumeName, migration.Error)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+)
+
+func init() {
+	RootCmd.AddCommand(reencryptCmd)
+}
+
+var reencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Re-encrypt the backend credentials with the active encryption key",
+	Long: `Re-encrypt the backend credentials with the active encryption key
+
+Trident reloads its encryption keyring, from the file or Kubernetes secret it was
+started with, and writes the credentials of every backend to the persistent store
+again, encrypted with the first key in the keyring.  To rotate the key, add a new
+key at the top of the keyring, run this command, and then remove the old key.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := discoverOperatingMode(cmd)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			TunnelCommand([]string{"reencrypt"})
+			return nil
+		} else {
+			return backendsReencrypt()
+		}
+	},
+}
+
+func backendsReencrypt() error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/reencrypt"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not re-encrypt the backend credentials: %v",
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var reencryptResponse rest.ReencryptBackendsResponse
+	err = json.Unmarshal(responseBody, &reencryptResponse)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Re-encrypted the credentials of %d backends.\n", len(reencryptResponse.Backends))
+
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/persistent_store"
+)
+
+var (
+	restoreFilename          string
+	restoreEncryptionKeyFile string
+)
+
+func init() {
+	RootCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().StringVarP(&restoreFilename, "filename", "f", "",
+		"Path of the backup file, or - for standard input")
+	restoreCmd.Flags().StringVarP(&restoreEncryptionKeyFile, "encryption-key-file", "", "",
+		"Path of the file used to encrypt the backend credentials in the backup")
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore -f <file> [--encryption-key-file <file>]",
+	Short: "Restore the state of Trident from a backup",
+	Long: `Restore the state of Trident from a backup
+
+The backup must have been made by "tridentctl backup" from a Trident instance
+with the same API version, and Trident must not yet have any backends, storage
+classes or volumes.  If the backend credentials were redacted from the backup,
+they must be filled in and the "secrets" field set to "plaintext" first.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := discoverOperatingMode(cmd)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		backup, err := readBackup(restoreFilename, restoreEncryptionKeyFile)
+		if err != nil {
+			return err
+		}
+
+		// The archive may be too large for an argument, so pass it on standard input
+		if OperatingMode == ModeTunnel {
+			backupBytes, err := json.Marshal(backup)
+			if err != nil {
+				return err
+			}
+			TunnelCommandWithInput([]string{"restore", "-f", "-"}, backupBytes)
+			return nil
+		} else {
+			return backupRestore(backup)
+		}
+	},
+}
+
+// readBackup reads a backup archive and decrypts its secrets, if necessary, so that
+// the key never leaves this host.
+func readBackup(filename, keyFile string) (*persistentstore.Backup, error) {
+
+	if filename == "" {
+		return nil, errors.New("no input file was specified")
+	}
+
+	var backupBytes []byte
+	var err error
+	if filename == "-" {
+		backupBytes, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		backupBytes, err = ioutil.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backup := new(persistentstore.Backup)
+	if err = json.Unmarshal(backupBytes, backup); err != nil {
+		return nil, fmt.Errorf("could not parse backup: %v", err)
+	}
+
+	if backup.Secrets == persistentstore.BackupSecretsEncrypted {
+		if keyFile == "" {
+			return nil, errors.New("the backup secrets are encrypted; specify --encryption-key-file")
+		}
+		key, err := readEncryptionKey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		if err = backup.DecryptSecrets(key); err != nil {
+			return nil, err
+		}
+	} else if keyFile != "" {
+		return nil, errors.New("the backup secrets are not encrypted")
+	}
+
+	if err = backup.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid backup: %v", err)
+	}
+	return backup, nil
+}
+
+func backupRestore(backup *persistentstore.Backup) error {
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/restore"
+
+	backupBytes, err := json.Marshal(backup)
+	if err != nil {
+		return err
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, backupBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("could not restore Trident: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var restoreResponse rest.RestoreResponse
+	err = json.Unmarshal(responseBody, &restoreResponse)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %d backends and %d volumes.\n", restoreResponse.Backends, restoreResponse.Volumes)
+
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func init() {
+	RootCmd.AddCommand(revertCmd)
+}
+
+var revertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert a resource in Trident to an earlier state",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := discoverOperatingMode(cmd)
+		return err
+	},
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+)
+
+var revertSnapshot string
+
+func init() {
+	revertCmd.AddCommand(revertVolumeCmd)
+	revertVolumeCmd.Flags().StringVarP(&revertSnapshot, "snapshot", "", "", "Snapshot to revert the volume to")
+}
+
+var revertVolumeCmd = &cobra.Command{
+	Use:   "volume <name> --snapshot <snapshot>",
+	Short: "Revert a volume to one of its snapshots",
+	Long: `Revert a volume to one of its snapshots
+
+All changes made to the volume since the snapshot was created are discarded.
+The volume must not be published to any node.  Some storage systems also
+delete any snapshots of the volume that are newer than the one restored.`,
+	Aliases: []string{"v"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"revert", "volume", "--snapshot", revertSnapshot}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeRevert(args, revertSnapshot)
+		}
+	},
+}
+
+func volumeRevert(volumeNames []string, snapshotName string) error {
+
+	switch len(volumeNames) {
+	case 0:
+		return errors.New("volume name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple volume names specified")
+	}
+	if snapshotName == "" {
+		return errors.New("snapshot name not specified")
+	}
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/volume/" + volumeNames[0] + "/snapshot/" + snapshotName + "/restore"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not revert volume %s to snapshot %s: %v", volumeNames[0], snapshotName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	return nil
+}
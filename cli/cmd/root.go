@@ -3,6 +3,7 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -304,6 +305,41 @@ func TunnelCommandRaw(commandArgs []string) ([]byte, error) {
 	return output, err
 }
 
+// TunnelCommandWithInput is like TunnelCommand, but it also passes the specified
+// data to the tunneled command's standard input, for data too large to pass as an
+// argument.
+func TunnelCommandWithInput(commandArgs []string, input []byte) {
+
+	// Build tunnel command to exec command in container
+	execCommand := []string{"exec", "-i", TridentPodName, "-n", TridentPodNamespace, "-c", config.ContainerTrident, "--"}
+
+	// Build CLI command
+	cliCommand := []string{"tridentctl", "-s", Server}
+	if Debug {
+		cliCommand = append(cliCommand, "--debug")
+	}
+	cliCommand = append(cliCommand, commandArgs...)
+
+	// Combine tunnel and CLI commands
+	execCommand = append(execCommand, cliCommand...)
+
+	if Debug {
+		fmt.Printf("Invoking tunneled command: %s %v\n", KubernetesCLI, strings.Join(execCommand, " "))
+	}
+
+	// Invoke tridentctl inside the Trident pod
+	command := exec.Command(KubernetesCLI, execCommand...)
+	command.Stdin = bytes.NewReader(input)
+	out, err := command.CombinedOutput()
+
+	SetExitCodeFromError(err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s", string(out))
+	} else {
+		fmt.Print(string(out))
+	}
+}
+
 func GetErrorFromHTTPResponse(response *http.Response, responseBody []byte) error {
 
 	var errorResponse api.ErrorResponse
@@ -0,0 +1,80 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+)
+
+var unmanageRename string
+
+func init() {
+	unmanageCmd.AddCommand(unmanageVolumeCmd)
+	unmanageVolumeCmd.Flags().StringVarP(&unmanageRename, "rename", "", "",
+		"Name to give the volume on its backend once it is no longer managed")
+}
+
+var unmanageVolumeCmd = &cobra.Command{
+	Use:   "volume <name> [--rename <name>]",
+	Short: "Stop managing a volume without deleting it from its backend",
+	Long: `Stop managing a volume without deleting it from its backend
+
+Trident forgets the volume and its snapshots, but the volume and its data remain
+on the storage system and may be imported again, by this or another Trident
+instance.  The volume must not be published to any node.`,
+	Aliases: []string{"v"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"unmanage", "volume", "--rename", unmanageRename}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeUnmanage(args, unmanageRename)
+		}
+	},
+}
+
+func volumeUnmanage(volumeNames []string, newName string) error {
+
+	switch len(volumeNames) {
+	case 0:
+		return errors.New("volume name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple volume names specified")
+	}
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/volume/" + volumeNames[0] + "/unmanage"
+
+	request := rest.UnmanageVolumeRequest{
+		Name: newName,
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not unmanage volume %s: %v", volumeNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	return nil
+}
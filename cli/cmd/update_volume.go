@@ -0,0 +1,92 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var updateVolumeStorageClass string
+
+func init() {
+	updateCmd.AddCommand(updateVolumeCmd)
+	updateVolumeCmd.Flags().StringVarP(&updateVolumeStorageClass, "storage-class", "", "",
+		"Storage class to assign the volume to")
+}
+
+var updateVolumeCmd = &cobra.Command{
+	Use:   "volume <name> --storage-class <storageClass>",
+	Short: "Update a volume in Trident",
+	Long: `Update a volume in Trident
+
+The volume is assigned to the storage class.  If the volume's storage pool isn't
+in that class, the volume is moved to one of its backend's pools that is, if the
+backend's driver can move volumes between pools.  Otherwise the update fails.`,
+	Aliases: []string{"v"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"update", "volume", "--storage-class", updateVolumeStorageClass}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeUpdateStorageClass(args, updateVolumeStorageClass)
+		}
+	},
+}
+
+func volumeUpdateStorageClass(volumeNames []string, storageClassName string) error {
+
+	switch len(volumeNames) {
+	case 0:
+		return errors.New("volume name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple volume names specified")
+	}
+	if storageClassName == "" {
+		return errors.New("storage class not specified")
+	}
+
+	baseURL, err := GetBaseURL()
+	if err != nil {
+		return err
+	}
+
+	url := baseURL + "/volume/" + volumeNames[0] + "/storageclass"
+
+	request := rest.UpdateVolumeStorageClassRequest{
+		StorageClass: storageClassName,
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not update storage class of volume %s: %v", volumeNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var updateResponse rest.UpdateVolumeStorageClassResponse
+	err = json.Unmarshal(responseBody, &updateResponse)
+	if err != nil {
+		return err
+	}
+
+	WriteVolumes([]storage.VolumeExternal{*updateResponse.Volume}, nil)
+
+	return nil
+}
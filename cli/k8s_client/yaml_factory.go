@@ -84,6 +84,14 @@ rules:
   - apiGroups: [""]
     resources: ["secrets"]
     verbs: ["get", "list", "watch", "create", "delete"]
+  - apiGroups: ["batch"]
+    resources: ["jobs"]
+    verbs: ["get", "create", "delete"]
+  - apiGroups: ["trident.netapp.io"]
+    resources: ["tridentversions", "tridentbackends", "tridentvolumes", "tridenttransactions",
+                "tridentstorageclasses", "tridentnodes", "tridentsnapshots", "tridentvolumepublications",
+                "tridentevents", "tridentquotas", "tridentgroupsnapshots"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
 `
 
 const clusterRoleOpenShiftCSIYAML = `---
@@ -113,6 +121,20 @@ rules:
   - apiGroups: ["storage.k8s.io"]
     resources: ["volumeattachments"]
     verbs: ["get", "list", "watch", "update"]
+  - apiGroups: ["snapshot.storage.k8s.io"]
+    resources: ["volumesnapshots", "volumesnapshotclasses"]
+    verbs: ["get", "list", "watch", "update"]
+  - apiGroups: ["snapshot.storage.k8s.io"]
+    resources: ["volumesnapshotcontents"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
+  - apiGroups: ["apiextensions.k8s.io"]
+    resources: ["customresourcedefinitions"]
+    verbs: ["create", "list", "watch", "delete"]
+  - apiGroups: ["trident.netapp.io"]
+    resources: ["tridentversions", "tridentbackends", "tridentvolumes", "tridenttransactions",
+                "tridentstorageclasses", "tridentnodes", "tridentsnapshots", "tridentvolumepublications",
+                "tridentevents", "tridentquotas", "tridentgroupsnapshots"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
 `
 
 const clusterRoleKubernetesV1YAML = `---
@@ -139,6 +161,14 @@ rules:
   - apiGroups: [""]
     resources: ["secrets"]
     verbs: ["get", "list", "watch", "create", "delete"]
+  - apiGroups: ["batch"]
+    resources: ["jobs"]
+    verbs: ["get", "create", "delete"]
+  - apiGroups: ["trident.netapp.io"]
+    resources: ["tridentversions", "tridentbackends", "tridentvolumes", "tridenttransactions",
+                "tridentstorageclasses", "tridentnodes", "tridentsnapshots", "tridentvolumepublications",
+                "tridentevents", "tridentquotas", "tridentgroupsnapshots"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
 `
 
 const clusterRoleKubernetesV1CSIYAML = `---
@@ -168,6 +198,20 @@ rules:
   - apiGroups: ["storage.k8s.io"]
     resources: ["volumeattachments"]
     verbs: ["get", "list", "watch", "update"]
+  - apiGroups: ["snapshot.storage.k8s.io"]
+    resources: ["volumesnapshots", "volumesnapshotclasses"]
+    verbs: ["get", "list", "watch", "update"]
+  - apiGroups: ["snapshot.storage.k8s.io"]
+    resources: ["volumesnapshotcontents"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
+  - apiGroups: ["apiextensions.k8s.io"]
+    resources: ["customresourcedefinitions"]
+    verbs: ["create", "list", "watch", "delete"]
+  - apiGroups: ["trident.netapp.io"]
+    resources: ["tridentversions", "tridentbackends", "tridentvolumes", "tridenttransactions",
+                "tridentstorageclasses", "tridentnodes", "tridentsnapshots", "tridentvolumepublications",
+                "tridentevents", "tridentquotas", "tridentgroupsnapshots"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
 `
 
 const clusterRoleKubernetesV1Alpha1YAML = `---
@@ -194,6 +238,14 @@ rules:
   - apiGroups: [""]
     resources: ["secrets"]
     verbs: ["get", "list", "watch", "create", "delete"]
+  - apiGroups: ["batch"]
+    resources: ["jobs"]
+    verbs: ["get", "create", "delete"]
+  - apiGroups: ["trident.netapp.io"]
+    resources: ["tridentversions", "tridentbackends", "tridentvolumes", "tridenttransactions",
+                "tridentstorageclasses", "tridentnodes", "tridentsnapshots", "tridentvolumepublications",
+                "tridentevents", "tridentquotas", "tridentgroupsnapshots"]
+    verbs: ["get", "list", "watch", "create", "update", "delete"]
 `
 
 func GetClusterRoleBindingYAML(namespace string, flavor OrchestratorFlavor, version *utils.Version, csi bool) string {
@@ -506,6 +558,17 @@ spec:
         volumeMounts:
         - name: socket-dir
           mountPath: /var/lib/csi/sockets/pluginproxy/
+      - name: csi-snapshotter
+        image: quay.io/k8scsi/csi-snapshotter:v1.0.1
+        args:
+        - "--v=9"
+        - "--csi-address=$(ADDRESS)"
+        env:
+        - name: ADDRESS
+          value: /var/lib/csi/sockets/pluginproxy/csi.sock
+        volumeMounts:
+        - name: socket-dir
+          mountPath: /var/lib/csi/sockets/pluginproxy/
       volumes:
       - name: etcd-vol
         persistentVolumeClaim:
@@ -56,9 +56,39 @@ const (
 	StorageAPITimeoutSeconds = 90
 	SANResizeDelta           = 50000000 // 50mb
 
+	// PoolCapacityRefreshInterval is how often the orchestrator asks drivers for the capacity of their pools
+	PoolCapacityRefreshInterval = 5 * time.Minute
+
+	// DefaultVolumeReconcileInterval is how often the orchestrator verifies its volumes against the backends
+	DefaultVolumeReconcileInterval = 10 * time.Minute
+
+	// BackendHealthCheckInterval is how often the orchestrator probes each backend's storage system
+	BackendHealthCheckInterval = 1 * time.Minute
+
+	// BackendHealthCheckMaxInterval limits the backoff between probes of an unhealthy backend
+	BackendHealthCheckMaxInterval = 30 * time.Minute
+
+	// BackendHealthCheckFailureThreshold is how many consecutive probes must fail before a backend is failed
+	BackendHealthCheckFailureThreshold = 3
+
+	// EventLogSize is the number of recent events the orchestrator retains
+	EventLogSize = 1000
+
+	// LeaderElectionTTL is how long a replica stays leader after it stops renewing its lease
+	LeaderElectionTTL = 15 * time.Second
+
+	// LeaderElectionRetryInterval is how long a replica waits to campaign again after a failure
+	LeaderElectionRetryInterval = 5 * time.Second
+
+	// StandbyRefreshInterval is how often a standby replica reloads the state from the persistent store
+	StandbyRefreshInterval = 30 * time.Second
+
 	/* REST frontend constants */
 	MaxRESTRequestSize = 10240
 
+	// MaxRESTRestoreRequestSize is the largest backup archive that may be restored
+	MaxRESTRestoreRequestSize = 64 * 1024 * 1024
+
 	/* Kubernetes deployment constants */
 	ContainerTrident = "trident-main"
 	ContainerEtcd    = "etcd"
@@ -106,14 +136,26 @@ var (
 	OrchestratorVersion = utils.MustParseDate(version())
 
 	/* API Server and persistent store variables */
-	BaseURL         = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion
-	VersionURL      = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/version"
-	BackendURL      = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/backend"
-	VolumeURL       = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/volume"
-	TransactionURL  = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/txn"
-	StorageClassURL = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/storageclass"
-	NodeURL         = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/node"
-	StoreURL        = "/" + OrchestratorName + "/store"
+	BaseURL          = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion
+	VersionURL       = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/version"
+	BackendURL       = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/backend"
+	VolumeURL        = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/volume"
+	TransactionURL   = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/txn"
+	StorageClassURL  = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/storageclass"
+	NodeURL          = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/node"
+	SnapshotURL      = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/snapshot"
+	PublicationURL   = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/publication"
+	EventURL         = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/event"
+	QuotaURL         = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/quota"
+	GroupSnapshotURL = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/groupsnapshot"
+	BackupURL        = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/backup"
+	RestoreURL       = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/restore"
+	ReencryptURL     = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/reencrypt"
+	StoreURL         = "/" + OrchestratorName + "/store"
+
+	// LeaderElectionKey is outside the keys that are migrated between stores, as its
+	// keys are bound to the replicas' leases
+	LeaderElectionKey = "/" + OrchestratorName + "-leader"
 
 	UsingPassthroughStore bool
 	CurrentDriverContext  DriverContext
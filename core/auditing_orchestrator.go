@@ -0,0 +1,294 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"time"
+
+	"github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+// auditingOrchestrator wraps an Orchestrator, recording an event for each call that
+// changes the orchestrator's state.  Each frontend is given its own wrapper, so that
+// events identify the frontend that requested each operation.
+type auditingOrchestrator struct {
+	Orchestrator
+	source string
+}
+
+// NewAuditingOrchestrator returns an Orchestrator that records the outcome of each
+// state-changing call made through it, attributing the calls to the specified source.
+func NewAuditingOrchestrator(orchestrator Orchestrator, source string) Orchestrator {
+	return &auditingOrchestrator{
+		Orchestrator: orchestrator,
+		source:       source,
+	}
+}
+
+// record adds an event for an operation that started at the specified time.
+func (a *auditingOrchestrator) record(operation, objectType, objectName string, start time.Time, err error) {
+	a.RecordEvent(storage.NewEvent(operation, objectType, objectName, a.source, start, err))
+}
+
+func (a *auditingOrchestrator) AddBackend(configJSON string) (*storage.BackendExternal, error) {
+	start := time.Now()
+	backend, err := a.Orchestrator.AddBackend(configJSON)
+	backendName := ""
+	if backend != nil {
+		backendName = backend.Name
+	}
+	a.record("AddBackend", storage.EventObjectBackend, backendName, start, err)
+	return backend, err
+}
+
+func (a *auditingOrchestrator) DeleteBackend(backendName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteBackend(backendName)
+	a.record("DeleteBackend", storage.EventObjectBackend, backendName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) UpdateBackend(backendName, configJSON string) (*storage.BackendExternal, error) {
+	start := time.Now()
+	backend, err := a.Orchestrator.UpdateBackend(backendName, configJSON)
+	a.record("UpdateBackend", storage.EventObjectBackend, backendName, start, err)
+	return backend, err
+}
+
+func (a *auditingOrchestrator) UpdateBackendState(backendName, backendState string) (
+	*storage.BackendExternal, error,
+) {
+	start := time.Now()
+	backend, err := a.Orchestrator.UpdateBackendState(backendName, backendState)
+	a.record("UpdateBackendState", storage.EventObjectBackend, backendName, start, err)
+	return backend, err
+}
+
+func (a *auditingOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error) {
+	start := time.Now()
+	volume, err := a.Orchestrator.AddVolume(volumeConfig)
+	a.record("AddVolume", storage.EventObjectVolume, volumeConfig.Name, start, err)
+	return volume, err
+}
+
+func (a *auditingOrchestrator) AttachVolume(
+	volumeName, mountpoint string, publishInfo *utils.VolumePublishInfo,
+) error {
+	start := time.Now()
+	err := a.Orchestrator.AttachVolume(volumeName, mountpoint, publishInfo)
+	a.record("AttachVolume", storage.EventObjectVolume, volumeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) CloneVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error) {
+	start := time.Now()
+	volume, err := a.Orchestrator.CloneVolume(volumeConfig)
+	a.record("CloneVolume", storage.EventObjectVolume, volumeConfig.Name, start, err)
+	return volume, err
+}
+
+func (a *auditingOrchestrator) DetachVolume(volumeName, mountpoint string) error {
+	start := time.Now()
+	err := a.Orchestrator.DetachVolume(volumeName, mountpoint)
+	a.record("DetachVolume", storage.EventObjectVolume, volumeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) DeleteVolume(volumeName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteVolume(volumeName)
+	a.record("DeleteVolume", storage.EventObjectVolume, volumeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) ImportVolume(
+	volumeConfig *storage.VolumeConfig, originalVolName string, backendName string, notManaged bool,
+	createPVandPVC Operation,
+) (*storage.VolumeExternal, error) {
+	start := time.Now()
+	volume, err := a.Orchestrator.ImportVolume(volumeConfig, originalVolName, backendName, notManaged, createPVandPVC)
+	a.record("ImportVolume", storage.EventObjectVolume, volumeConfig.Name, start, err)
+	return volume, err
+}
+
+func (a *auditingOrchestrator) PublishVolume(volumeName string, publishInfo *utils.VolumePublishInfo) error {
+	start := time.Now()
+	err := a.Orchestrator.PublishVolume(volumeName, publishInfo)
+	a.record("PublishVolume", storage.EventObjectVolume, volumeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) ResizeVolume(volumeName, newSize string) error {
+	start := time.Now()
+	err := a.Orchestrator.ResizeVolume(volumeName, newSize)
+	a.record("ResizeVolume", storage.EventObjectVolume, volumeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) UpdateVolumeStorageClass(
+	volumeName, storageClassName string,
+) (*storage.VolumeExternal, error) {
+	start := time.Now()
+	volume, err := a.Orchestrator.UpdateVolumeStorageClass(volumeName, storageClassName)
+	a.record("UpdateVolumeStorageClass", storage.EventObjectVolume, volumeName, start, err)
+	return volume, err
+}
+
+func (a *auditingOrchestrator) MigrateVolume(volumeName, backendName string) (*storage.VolumeMigration, error) {
+	start := time.Now()
+	migration, err := a.Orchestrator.MigrateVolume(volumeName, backendName)
+	a.record("MigrateVolume", storage.EventObjectVolume, volumeName, start, err)
+	return migration, err
+}
+
+func (a *auditingOrchestrator) UnmanageVolume(volumeName, newName string) error {
+	start := time.Now()
+	err := a.Orchestrator.UnmanageVolume(volumeName, newName)
+	a.record("UnmanageVolume", storage.EventObjectVolume, volumeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) ReloadVolumes() error {
+	start := time.Now()
+	err := a.Orchestrator.ReloadVolumes()
+	a.record("ReloadVolumes", storage.EventObjectVolume, "", start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) CreateSnapshot(snapshotConfig *storage.SnapshotConfig) (
+	*storage.SnapshotExternal, error,
+) {
+	start := time.Now()
+	snapshot, err := a.Orchestrator.CreateSnapshot(snapshotConfig)
+	a.record("CreateSnapshot", storage.EventObjectSnapshot, snapshotConfig.ID(), start, err)
+	return snapshot, err
+}
+
+func (a *auditingOrchestrator) DeleteSnapshot(volumeName, snapshotName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteSnapshot(volumeName, snapshotName)
+	a.record("DeleteSnapshot", storage.EventObjectSnapshot, storage.MakeSnapshotID(volumeName, snapshotName),
+		start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) RestoreSnapshot(volumeName, snapshotName string) error {
+	start := time.Now()
+	err := a.Orchestrator.RestoreSnapshot(volumeName, snapshotName)
+	a.record("RestoreSnapshot", storage.EventObjectSnapshot, storage.MakeSnapshotID(volumeName, snapshotName),
+		start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) CreateGroupSnapshot(groupConfig *storage.GroupSnapshotConfig) (
+	*storage.GroupSnapshotExternal, error,
+) {
+	start := time.Now()
+	groupSnapshot, err := a.Orchestrator.CreateGroupSnapshot(groupConfig)
+	a.record("CreateGroupSnapshot", storage.EventObjectGroupSnapshot, groupConfig.Name, start, err)
+	return groupSnapshot, err
+}
+
+func (a *auditingOrchestrator) DeleteGroupSnapshot(groupSnapshotName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteGroupSnapshot(groupSnapshotName)
+	a.record("DeleteGroupSnapshot", storage.EventObjectGroupSnapshot, groupSnapshotName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) CloneGroupSnapshot(groupSnapshotName, clonePrefix string) (
+	[]*storage.VolumeExternal, error,
+) {
+	start := time.Now()
+	clones, err := a.Orchestrator.CloneGroupSnapshot(groupSnapshotName, clonePrefix)
+	a.record("CloneGroupSnapshot", storage.EventObjectGroupSnapshot, groupSnapshotName, start, err)
+	return clones, err
+}
+
+func (a *auditingOrchestrator) AddVolumePublication(publication *storage.VolumePublication) error {
+	start := time.Now()
+	err := a.Orchestrator.AddVolumePublication(publication)
+	a.record("AddVolumePublication", storage.EventObjectPublication, publication.ID(), start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) DeleteVolumePublication(volumeName, nodeName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteVolumePublication(volumeName, nodeName)
+	a.record("DeleteVolumePublication", storage.EventObjectPublication,
+		storage.MakeVolumePublicationID(volumeName, nodeName), start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) AddStorageClass(scConfig *storageclass.Config) (*storageclass.External, error) {
+	start := time.Now()
+	sc, err := a.Orchestrator.AddStorageClass(scConfig)
+	a.record("AddStorageClass", storage.EventObjectStorageClass, scConfig.Name, start, err)
+	return sc, err
+}
+
+func (a *auditingOrchestrator) DeleteStorageClass(scName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteStorageClass(scName)
+	a.record("DeleteStorageClass", storage.EventObjectStorageClass, scName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) AddNode(node *utils.Node) error {
+	start := time.Now()
+	err := a.Orchestrator.AddNode(node)
+	a.record("AddNode", storage.EventObjectNode, node.Name, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) DeleteNode(nodeName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteNode(nodeName)
+	a.record("DeleteNode", storage.EventObjectNode, nodeName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) AddQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	start := time.Now()
+	external, err := a.Orchestrator.AddQuota(quota)
+	a.record("AddQuota", storage.EventObjectQuota, quota.Name, start, err)
+	return external, err
+}
+
+func (a *auditingOrchestrator) UpdateQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	start := time.Now()
+	external, err := a.Orchestrator.UpdateQuota(quota)
+	a.record("UpdateQuota", storage.EventObjectQuota, quota.Name, start, err)
+	return external, err
+}
+
+func (a *auditingOrchestrator) DeleteQuota(quotaName string) error {
+	start := time.Now()
+	err := a.Orchestrator.DeleteQuota(quotaName)
+	a.record("DeleteQuota", storage.EventObjectQuota, quotaName, start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) BackupState(encryptionKey []byte) (*persistentstore.Backup, error) {
+	start := time.Now()
+	backup, err := a.Orchestrator.BackupState(encryptionKey)
+	a.record("BackupState", storage.EventObjectOrchestrator, "", start, err)
+	return backup, err
+}
+
+func (a *auditingOrchestrator) RestoreState(backup *persistentstore.Backup) error {
+	start := time.Now()
+	err := a.Orchestrator.RestoreState(backup)
+	a.record("RestoreState", storage.EventObjectOrchestrator, "", start, err)
+	return err
+}
+
+func (a *auditingOrchestrator) ReencryptBackends() ([]string, error) {
+	start := time.Now()
+	backendNames, err := a.Orchestrator.ReencryptBackends()
+	a.record("ReencryptBackends", storage.EventObjectOrchestrator, "", start, err)
+	return backendNames, err
+}
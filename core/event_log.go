@@ -0,0 +1,137 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/netapp/trident/storage"
+)
+
+// eventLog retains the most recent events in a ring of fixed size.
+type eventLog struct {
+	mutex  *sync.Mutex
+	events []*storage.Event
+	next   int // index at which the next event will be stored
+	count  int
+	lastID int64
+
+	// Changes to the log that have yet to be saved to the persistent store, and a lock
+	// that keeps them in order while they are saved
+	pending    []eventChange
+	flushMutex *sync.Mutex
+}
+
+// eventChange is an event to be added to, or deleted from, the persistent store.
+type eventChange struct {
+	event   *storage.Event
+	deleted bool
+}
+
+func newEventLog(size int) *eventLog {
+	return &eventLog{
+		mutex:      &sync.Mutex{},
+		events:     make([]*storage.Event, size),
+		pending:    make([]eventChange, 0),
+		flushMutex: &sync.Mutex{},
+	}
+}
+
+// add stores an event, assigning it an ID if it lacks one, and returns the oldest
+// event if it had to be discarded to make room.
+func (l *eventLog) add(event *storage.Event) *storage.Event {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if event.ID == "" {
+		// IDs are timestamps made unique, and zero-padded so that they sort as strings
+		id := time.Now().UnixNano()
+		if id <= l.lastID {
+			id = l.lastID + 1
+		}
+		l.lastID = id
+		event.ID = fmt.Sprintf("%020d", id)
+	} else if id, err := strconv.ParseInt(event.ID, 10, 64); err == nil && id > l.lastID {
+		l.lastID = id
+	}
+
+	evicted := l.events[l.next]
+	l.events[l.next] = event
+	l.next = (l.next + 1) % len(l.events)
+	if l.count < len(l.events) {
+		l.count++
+	}
+	return evicted
+}
+
+// queue records that an event, and the event it evicted if any, must be saved to the
+// persistent store.
+func (l *eventLog) queue(event, evicted *storage.Event) {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.pending = append(l.pending, eventChange{event: event})
+	if evicted != nil {
+		l.pending = append(l.pending, eventChange{event: evicted, deleted: true})
+	}
+}
+
+// takePending returns the changes queued since it was last called.
+func (l *eventLog) takePending() []eventChange {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	pending := l.pending
+	l.pending = make([]eventChange, 0)
+	return pending
+}
+
+// load merges the specified events into the log, keeping only the most recent events
+// if there are too many, and returns the events that didn't fit.
+func (l *eventLog) load(events []*storage.Event) []*storage.Event {
+
+	merged := make(map[string]*storage.Event)
+	for _, event := range append(l.list(), events...) {
+		merged[event.ID] = event
+	}
+	sorted := make([]*storage.Event, 0, len(merged))
+	for _, event := range merged {
+		sorted = append(sorted, event)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	l.mutex.Lock()
+	l.events = make([]*storage.Event, len(l.events))
+	l.next = 0
+	l.count = 0
+	l.mutex.Unlock()
+
+	discarded := make([]*storage.Event, 0)
+	for _, event := range sorted {
+		if evicted := l.add(event); evicted != nil {
+			discarded = append(discarded, evicted)
+		}
+	}
+	return discarded
+}
+
+// list returns the retained events, oldest first.
+func (l *eventLog) list() []*storage.Event {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	events := make([]*storage.Event, 0, l.count)
+	start := (l.next - l.count + len(l.events)) % len(l.events)
+	for i := 0; i < l.count; i++ {
+		events = append(events, l.events[(start+i)%len(l.events)])
+	}
+	return events
+}
@@ -0,0 +1,282 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+// When several replicas share the persistent store, only the elected leader changes
+// any state, and it bootstraps from the store each time it is elected.  The others are
+// standbys: they load the state from the store without changing it, refresh it
+// periodically, and serve reads from it, while every workflow that changes state fails
+// with a NotReadyError naming the leader.  A leader that loses its lease becomes a
+// standby again.  Writes to the store are also checked against concurrent changes, and
+// are only accepted while the key of the leader's campaign exists, which is deleted when
+// its lease expires, so a leader that has lost its lease without noticing can't
+// overwrite its successor's changes.
+
+// writeError returns the error with which a workflow that changes state must fail, if
+// any.
+func (o *TridentOrchestrator) writeError() error {
+	o.statusMutex.RLock()
+	bootstrapError, standby := o.bootstrapError, o.standby
+	o.statusMutex.RUnlock()
+
+	if bootstrapError != nil {
+		return bootstrapError
+	}
+	if standby {
+		leader, err := o.elector.Leader()
+		if err != nil {
+			log.WithField("error", err).Debug("Could not determine the leader.")
+		}
+		return notLeaderError(leader)
+	}
+	return nil
+}
+
+// bootstrapStandby loads the state as a standby and joins the leader election.  The
+// background tasks only run once the replica is elected.
+func (o *TridentOrchestrator) bootstrapStandby() error {
+	o.setStandby(true)
+	if err := o.loadStandbyState(); err != nil {
+		// The state is reloaded periodically, so start with whatever could be loaded
+		log.WithField("error", err).Warning("Could not load the state from the persistent store.")
+	}
+	o.setBootstrapped(false, nil)
+	log.Infof("%s bootstrapped as a standby.", strings.Title(config.OrchestratorName))
+
+	go o.runLeaderElection()
+
+	return nil
+}
+
+// setStandby records whether another replica is the leader.
+func (o *TridentOrchestrator) setStandby(standby bool) {
+	o.statusMutex.Lock()
+	defer o.statusMutex.Unlock()
+	o.standby = standby
+}
+
+// runLeaderElection campaigns for the leadership until it is won, takes over as the
+// leader, and becomes a standby again if the leadership is lost, forever.
+func (o *TridentOrchestrator) runLeaderElection() {
+	for {
+		if err := o.campaign(); err != nil {
+			log.WithField("error", err).Error("Leader election failed.")
+			time.Sleep(config.LeaderElectionRetryInterval)
+			continue
+		}
+
+		if err := o.promote(); err != nil {
+			log.WithField("error", err).Error("Could not take over as the leader; resigning.")
+			if err = o.elector.Resign(); err != nil {
+				log.WithField("error", err).Warning("Could not resign the leadership.")
+			}
+			o.demote()
+			time.Sleep(config.LeaderElectionRetryInterval)
+			continue
+		}
+
+		<-o.elector.Lost()
+		log.Error("Lost the leadership; continuing as a standby.")
+		o.demote()
+	}
+}
+
+// campaign blocks until this replica is elected leader, refreshing the standby state
+// in the meantime.
+func (o *TridentOrchestrator) campaign() error {
+	elected := make(chan error, 1)
+	go func() {
+		elected <- o.elector.Campaign()
+	}()
+
+	ticker := time.NewTicker(config.StandbyRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-elected:
+			return err
+		case <-ticker.C:
+			if err := o.loadStandbyState(); err != nil {
+				log.WithField("error", err).Warning("Could not refresh the state from the persistent store.")
+			}
+		}
+	}
+}
+
+// promote makes this replica the leader, bootstrapping afresh from the persistent store
+// so that any transactions the previous leader left unfinished are handled.  The state
+// is bootstrapped by a private orchestrator, whose objects replace the standby's only
+// once it is complete, so that the objects being served are never changed without
+// holding the mutex lock.
+func (o *TridentOrchestrator) promote() error {
+	log.Info("Taking over as the leader.")
+
+	// Turn away all workflows until the state is bootstrapped again
+	o.setBootstrapped(false, notReadyError())
+
+	staging := NewTridentOrchestrator(o.storeClient)
+	staging.frontends = o.frontends
+	staging.events = o.events
+	staging.persistEvents = o.persistEvents
+
+	err := staging.transformPersistentState()
+	if err == nil {
+		err = staging.bootstrap()
+	}
+	if err != nil {
+		for _, staged := range staging.backends {
+			staged.Terminate()
+		}
+		return err
+	}
+
+	o.mutex.Lock()
+	oldBackends := o.backends
+	o.backends = staging.backends
+	o.volumes = staging.volumes
+	o.storageClasses = staging.storageClasses
+	o.nodes = staging.nodes
+	o.snapshots = staging.snapshots
+	o.publications = staging.publications
+	o.quotas = staging.quotas
+	o.groupSnapshots = staging.groupSnapshots
+	for name, migration := range staging.volumeMigrations {
+		o.volumeMigrations[name] = migration
+	}
+	o.mutex.Unlock()
+
+	for _, backend := range oldBackends {
+		backend.Terminate()
+	}
+
+	// The tasks skip their work until the replica is no longer a standby
+	o.startBackgroundTasks()
+
+	o.statusMutex.Lock()
+	o.standby = false
+	o.bootstrapped = true
+	o.bootstrapError = nil
+	o.statusMutex.Unlock()
+	log.Infof("%s bootstrapped as the leader.", strings.Title(config.OrchestratorName))
+
+	return nil
+}
+
+// demote makes this replica a standby, stopping the background tasks.
+func (o *TridentOrchestrator) demote() {
+	o.stopBackgroundTasks()
+
+	o.statusMutex.Lock()
+	o.standby = true
+	o.bootstrapped = false
+	o.statusMutex.Unlock()
+
+	if err := o.loadStandbyState(); err != nil {
+		log.WithField("error", err).Warning("Could not load the state from the persistent store.")
+	}
+	o.setBootstrapped(false, nil)
+}
+
+// resetState forgets every object known to the orchestrator.  The caller must hold
+// the mutex lock.
+func (o *TridentOrchestrator) resetState() {
+	o.backends = make(map[string]*storage.Backend)
+	o.volumes = make(map[string]*storage.Volume)
+	o.storageClasses = make(map[string]*storageclass.StorageClass)
+	o.nodes = make(map[string]*utils.Node)
+	o.snapshots = make(map[string]*storage.Snapshot)
+	o.publications = make(map[string]*storage.VolumePublication)
+	o.quotas = make(map[string]*storage.Quota)
+	o.groupSnapshots = make(map[string]*storage.GroupSnapshot)
+}
+
+// loadStandbyState replaces the orchestrator's objects with those in the persistent
+// store, without changing the store.  Backends whose config hasn't changed are kept,
+// and the others are initialized before the mutex is taken, so that the storage
+// systems aren't contacted while holding it.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) loadStandbyState() error {
+	persistentBackends, err := o.storeClient.GetBackends()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		return err
+	}
+
+	o.mutex.RLock()
+	current := make(map[string]*storage.Backend, len(o.backends))
+	for name, backend := range o.backends {
+		current[name] = backend
+	}
+	o.mutex.RUnlock()
+
+	// A private orchestrator initializes the new and changed backends
+	staging := NewTridentOrchestrator(o.storeClient)
+	backends := make(map[string]*storage.Backend, len(persistentBackends))
+	kept := make(map[*storage.Backend]*storage.BackendPersistent)
+	for _, b := range persistentBackends {
+		if backend, ok := current[b.Name]; ok && sameBackendConfig(backend, b) {
+			backends[b.Name] = backend
+			kept[backend] = b
+			continue
+		}
+		backend, err := staging.bootstrapBackend(b)
+		if err != nil {
+			for _, staged := range staging.backends {
+				staged.Terminate()
+			}
+			return err
+		}
+		backends[b.Name] = backend
+	}
+
+	o.mutex.Lock()
+	o.resetState()
+	for name, backend := range backends {
+		if b, ok := kept[backend]; ok {
+			backend.Volumes = make(map[string]*storage.Volume)
+			backend.Online = b.Online
+			backend.State = b.State
+			backend.MonitorFailed = b.MonitorFailed
+		}
+		o.backends[name] = backend
+	}
+	type bootstrapFunc func() error
+	for _, f := range []bootstrapFunc{o.bootstrapStorageClasses, o.bootstrapVolumes, o.bootstrapSnapshots,
+		o.bootstrapGroupSnapshots, o.bootstrapNodes, o.bootstrapVolumePublications, o.bootstrapQuotas} {
+		if err = f(); err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+			break
+		}
+		err = nil
+	}
+	o.mutex.Unlock()
+
+	// Backends that were replaced or removed are no longer needed
+	for name, backend := range current {
+		if backends[name] != backend {
+			backend.Terminate()
+		}
+	}
+
+	return err
+}
+
+// sameBackendConfig returns whether a backend has the config of a persistent backend.
+func sameBackendConfig(backend *storage.Backend, persistent *storage.BackendPersistent) bool {
+	current, err := backend.ConstructPersistent().MarshalConfig()
+	if err != nil {
+		return false
+	}
+	stored, err := persistent.MarshalConfig()
+	return err == nil && current == stored
+}
@@ -4,19 +4,23 @@ package core
 
 import (
 	"fmt"
-	"math/rand"
 	"os"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/frontend"
 	"github.com/netapp/trident/persistent_store"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/storage/factory"
+	sa "github.com/netapp/trident/storage_attribute"
 	"github.com/netapp/trident/storage_class"
 	drivers "github.com/netapp/trident/storage_drivers"
 	"github.com/netapp/trident/utils"
@@ -26,12 +30,29 @@ type TridentOrchestrator struct {
 	backends       map[string]*storage.Backend
 	volumes        map[string]*storage.Volume
 	frontends      map[string]frontend.Plugin
-	mutex          *sync.Mutex
+	mutex          *sync.RWMutex
 	storageClasses map[string]*storageclass.StorageClass
 	nodes          map[string]*utils.Node
+	snapshots      map[string]*storage.Snapshot
+	publications   map[string]*storage.VolumePublication
+	quotas         map[string]*storage.Quota
+	groupSnapshots map[string]*storage.GroupSnapshot
 	storeClient    persistentstore.Client
 	bootstrapped   bool
 	bootstrapError error
+	elector        persistentstore.LeaderElector // nil unless several replicas share the store
+	standby        bool                          // true while another replica is the leader
+	statusMutex    *sync.RWMutex                 // guards bootstrapped, bootstrapError, standby and the tasks
+
+	volumeReconcileInterval time.Duration
+	backendHealth           map[string]*backendHealth // only accessed by the health monitor
+	events                  *eventLog
+	persistEvents           bool
+	quotaReservations       map[string]*storage.VolumeConfig    // volumes being created or resized, by name
+	volumeMigrations        map[string]*storage.VolumeMigration // latest migration of each volume, by name
+	stopTasks               chan struct{}                       // closed to stop the background tasks, if running
+	stopped                 bool                                // set once Stop is called
+	tasks                   sync.WaitGroup                      // the running background tasks
 }
 
 // NewTridentOrchestrator returns a storage orchestrator instance
@@ -42,11 +63,224 @@ func NewTridentOrchestrator(client persistentstore.Client) *TridentOrchestrator
 		frontends:      make(map[string]frontend.Plugin),
 		storageClasses: make(map[string]*storageclass.StorageClass),
 		nodes:          make(map[string]*utils.Node),
-		mutex:          &sync.Mutex{},
+		snapshots:      make(map[string]*storage.Snapshot),          // key is ID, not name
+		publications:   make(map[string]*storage.VolumePublication), // key is ID
+		quotas:         make(map[string]*storage.Quota),
+		groupSnapshots: make(map[string]*storage.GroupSnapshot),
+		mutex:          &sync.RWMutex{},
+		statusMutex:    &sync.RWMutex{},
 		storeClient:    client,
 		bootstrapped:   false,
 		bootstrapError: notReadyError(),
+
+		volumeReconcileInterval: config.DefaultVolumeReconcileInterval,
+		backendHealth:           make(map[string]*backendHealth),
+		events:                  newEventLog(config.EventLogSize),
+		quotaReservations:       make(map[string]*storage.VolumeConfig),
+		volumeMigrations:        make(map[string]*storage.VolumeMigration),
+	}
+}
+
+// SetEventPersistence determines whether events are saved to the persistent store, so
+// that they survive restarts.  It must be called before Bootstrap.
+func (o *TridentOrchestrator) SetEventPersistence(enabled bool) {
+	o.persistEvents = enabled
+}
+
+// SetLeaderElector makes the orchestrator one of several replicas sharing the
+// persistent store.  Only the elected leader changes any state; the others are
+// standbys, which serve reads from the store until they are elected.  It must be
+// called before Bootstrap.
+func (o *TridentOrchestrator) SetLeaderElector(elector persistentstore.LeaderElector) {
+	o.elector = elector
+}
+
+// SetVolumeReconcileInterval sets how often the orchestrator checks that its volumes
+// still exist on their backends.  An interval of zero disables the checks.  It must
+// be called before Bootstrap.
+func (o *TridentOrchestrator) SetVolumeReconcileInterval(interval time.Duration) {
+	o.volumeReconcileInterval = interval
+}
+
+// Stop stops the orchestrator's background tasks for good.  It may be called more
+// than once.
+func (o *TridentOrchestrator) Stop() {
+	o.statusMutex.Lock()
+	o.stopped = true
+	o.statusMutex.Unlock()
+
+	o.stopBackgroundTasks()
+}
+
+// startBackgroundTasks starts the tasks that keep the leader's state current, which
+// run until stopBackgroundTasks or Stop is called.
+func (o *TridentOrchestrator) startBackgroundTasks() {
+	o.statusMutex.Lock()
+	defer o.statusMutex.Unlock()
+	if o.stopped || o.stopTasks != nil {
+		return
+	}
+	stop := make(chan struct{})
+	o.stopTasks = stop
+
+	run := func(task func(stop <-chan struct{})) {
+		o.tasks.Add(1)
+		go func() {
+			defer o.tasks.Done()
+			task(stop)
+		}()
+	}
+	run(o.refreshPoolCapacityPeriodically)
+	if o.volumeReconcileInterval > 0 {
+		run(o.reconcileVolumesPeriodically)
+	}
+	run(o.monitorBackendHealth)
+}
+
+// stopBackgroundTasks stops the tasks started by startBackgroundTasks, if they are
+// running, and waits for them to return.
+func (o *TridentOrchestrator) stopBackgroundTasks() {
+	o.statusMutex.Lock()
+	stop := o.stopTasks
+	o.stopTasks = nil
+	o.statusMutex.Unlock()
+
+	// The tasks check the status, so the lock mustn't be held while waiting for them
+	if stop != nil {
+		close(stop)
+		o.tasks.Wait()
+	}
+}
+
+// getBootstrapError returns the error with which every workflow must fail until the
+// orchestrator is bootstrapped, if any.
+func (o *TridentOrchestrator) getBootstrapError() error {
+	o.statusMutex.RLock()
+	defer o.statusMutex.RUnlock()
+	return o.bootstrapError
+}
+
+// setBootstrapped records whether the orchestrator is bootstrapped, and the error with
+// which every workflow must fail, if any.
+func (o *TridentOrchestrator) setBootstrapped(bootstrapped bool, err error) {
+	o.statusMutex.Lock()
+	defer o.statusMutex.Unlock()
+	o.bootstrapped = bootstrapped
+	o.bootstrapError = err
+}
+
+// isBootstrapped returns whether the orchestrator is bootstrapped.
+func (o *TridentOrchestrator) isBootstrapped() bool {
+	o.statusMutex.RLock()
+	defer o.statusMutex.RUnlock()
+	return o.bootstrapped
+}
+
+// isStandby returns whether another replica is the leader.
+func (o *TridentOrchestrator) isStandby() bool {
+	o.statusMutex.RLock()
+	defer o.statusMutex.RUnlock()
+	return o.standby
+}
+
+// The orchestrator mutex guards the in-memory maps (including each backend's
+// volume map) and must never be held while calling a storage backend during
+// normal operation.  Instead, volume operations serialize on a shared lock
+// per volume, and calls to a backend are made while holding a shared lock per
+// backend.  Volume operations hold the backend lock for reading, so that they
+// may run at once, while adding, updating, replacing and deleting a backend
+// hold it exclusively.  Locks are always taken in the order volume, backend,
+// orchestrator mutex; in particular, no shared lock may be acquired while
+// holding the mutex.
+
+// volumeLockID returns the ID of the shared lock that serializes operations
+// on the named volume.
+func volumeLockID(volumeName string) string {
+	return "volume-" + volumeName
+}
+
+// backendLockID returns the ID of the shared lock that must be held while
+// calling the named backend or changing its configuration.
+func backendLockID(backendName string) string {
+	return "backend-" + backendName
+}
+
+// lockBackend acquires the shared lock for a backend that the caller looked
+// up earlier for reading, which any number of operations on the backend's
+// volumes may hold at once, and then verifies that the backend wasn't
+// replaced or removed in the meantime.  The caller must not hold the
+// orchestrator mutex, and it must call unlockBackend if no error is returned.
+func (o *TridentOrchestrator) lockBackend(ctx string, backend *storage.Backend) error {
+
+	utils.RLock(ctx, backendLockID(backend.Name))
+
+	if err := o.checkBackendCurrent(backend); err != nil {
+		utils.RUnlock(ctx, backendLockID(backend.Name))
+		return err
+	}
+	return nil
+}
+
+// unlockBackend releases a backend lock acquired by lockBackend.
+func (o *TridentOrchestrator) unlockBackend(ctx string, backend *storage.Backend) {
+	utils.RUnlock(ctx, backendLockID(backend.Name))
+}
+
+// lockBackendExclusive acquires the shared lock for a backend exclusively, as
+// for changing the backend itself, and then verifies that the backend wasn't
+// replaced or removed in the meantime.  The caller must not hold the
+// orchestrator mutex, and it must call unlockBackendExclusive if no error is
+// returned.
+func (o *TridentOrchestrator) lockBackendExclusive(ctx string, backend *storage.Backend) error {
+
+	utils.Lock(ctx, backendLockID(backend.Name))
+
+	if err := o.checkBackendCurrent(backend); err != nil {
+		utils.Unlock(ctx, backendLockID(backend.Name))
+		return err
+	}
+	return nil
+}
+
+// unlockBackendExclusive releases a backend lock acquired by lockBackendExclusive.
+func (o *TridentOrchestrator) unlockBackendExclusive(ctx string, backend *storage.Backend) {
+	utils.Unlock(ctx, backendLockID(backend.Name))
+}
+
+// checkBackendCurrent returns an error if a backend that the caller looked up
+// earlier was replaced or removed since.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) checkBackendCurrent(backend *storage.Backend) error {
+
+	o.mutex.RLock()
+	current, ok := o.backends[backend.Name]
+	o.mutex.RUnlock()
+
+	if !ok || current != backend {
+		return fmt.Errorf("backend %s was updated or deleted during the operation; repeat the operation",
+			backend.Name)
+	}
+	return nil
+}
+
+// lockVolumeBackend acquires the shared lock for the backend hosting the named
+// volume.  If the volume or its backend isn't known, it returns nil without
+// taking a lock, leaving the caller's own validation to report the problem.
+func (o *TridentOrchestrator) lockVolumeBackend(ctx, volumeName string) (*storage.Backend, error) {
+
+	o.mutex.RLock()
+	var backend *storage.Backend
+	if volume, ok := o.volumes[volumeName]; ok {
+		backend = o.backends[volume.Backend]
+	}
+	o.mutex.RUnlock()
+
+	if backend == nil {
+		return nil, nil
+	}
+	if err := o.lockBackend(ctx, backend); err != nil {
+		return nil, err
 	}
+	return backend, nil
 }
 
 func (o *TridentOrchestrator) transformPersistentState() error {
@@ -101,21 +335,30 @@ func (o *TridentOrchestrator) Bootstrap() error {
 		log.Warning("Trident is bootstrapping with no frontend.")
 	}
 
+	// A standby neither transforms nor bootstraps the persistent state until it is elected
+	if o.elector != nil {
+		return o.bootstrapStandby()
+	}
+
 	// Transform persistent state, if necessary
 	if err = o.transformPersistentState(); err != nil {
-		o.bootstrapError = bootstrapError(err)
-		return o.bootstrapError
+		err = bootstrapError(err)
+		o.setBootstrapped(false, err)
+		return err
 	}
 
 	// Bootstrap state from persistent store
 	if err = o.bootstrap(); err != nil {
-		o.bootstrapError = bootstrapError(err)
-		return o.bootstrapError
+		err = bootstrapError(err)
+		o.setBootstrapped(false, err)
+		return err
 	}
 
-	o.bootstrapped = true
-	o.bootstrapError = nil
+	o.setBootstrapped(true, nil)
 	log.Infof("%s bootstrapped successfully.", strings.Title(config.OrchestratorName))
+
+	o.startBackgroundTasks()
+
 	return nil
 }
 
@@ -126,63 +369,74 @@ func (o *TridentOrchestrator) bootstrapBackends() error {
 	}
 
 	for _, b := range persistentBackends {
-		// TODO:  If the API evolves, check the Version field here.
-		serializedConfig, err := b.MarshalConfig()
-		if err != nil {
+		if _, err = o.bootstrapBackend(b); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		newBackendExternal, backendErr := o.addBackend(serializedConfig)
-		if backendErr != nil {
+// bootstrapBackend adds a backend from its persistent state.  A backend that fails
+// to initialize is added in the failed state, except for Docker.
+func (o *TridentOrchestrator) bootstrapBackend(b *storage.BackendPersistent) (*storage.Backend, error) {
+	// TODO:  If the API evolves, check the Version field here.
+	serializedConfig, err := b.MarshalConfig()
+	if err != nil {
+		return nil, err
+	}
 
-			errorLogFields := log.Fields{
-				"handler":            "Bootstrap",
-				"newBackendExternal": newBackendExternal,
-				"backendErr":         backendErr.Error(),
-			}
+	newBackendExternal, backendErr := o.addBackend(serializedConfig)
+	if backendErr != nil {
 
-			// Trident for Docker supports one backend at a time, and the Docker volume plugin
-			// should not start if the backend fails to initialize, so return any error here.
-			if config.CurrentDriverContext == config.ContextDocker {
-				log.WithFields(errorLogFields).Error("Problem adding backend.")
-				return backendErr
-			}
+		errorLogFields := log.Fields{
+			"handler":            "Bootstrap",
+			"newBackendExternal": newBackendExternal,
+			"backendErr":         backendErr.Error(),
+		}
 
-			log.WithFields(errorLogFields).Warn("Problem adding backend.")
+		// Trident for Docker supports one backend at a time, and the Docker volume plugin
+		// should not start if the backend fails to initialize, so return any error here.
+		if config.CurrentDriverContext == config.ContextDocker {
+			log.WithFields(errorLogFields).Error("Problem adding backend.")
+			return nil, backendErr
+		}
 
-			if newBackendExternal != nil {
-				newBackend, _ := factory.NewStorageBackendForConfig(serializedConfig)
-				newBackend.Name = b.Name
-				newBackendExternal.Name = b.Name // have to set it explicitly, so it's not ""
-				o.backends[newBackendExternal.Name] = newBackend
+		log.WithFields(errorLogFields).Warn("Problem adding backend.")
 
-				log.WithFields(log.Fields{
-					"newBackend":               newBackend,
-					"newBackendExternal":       newBackendExternal,
-					"newBackendExternal.Name":  newBackendExternal.Name,
-					"newBackendExternal.State": newBackendExternal.State.String(),
-				}).Debug("Backend information.")
-			}
-		}
+		if newBackendExternal != nil {
+			newBackend, _ := factory.NewStorageBackendForConfig(serializedConfig)
+			newBackend.Name = b.Name
+			newBackendExternal.Name = b.Name // have to set it explicitly, so it's not ""
+			o.backends[newBackendExternal.Name] = newBackend
 
-		// Note that addBackend returns an external copy of the newly
-		// added backend, so we have to go fetch it manually.
-		newBackend := o.backends[newBackendExternal.Name]
-		newBackend.Online = b.Online
-		if backendErr != nil {
-			newBackend.State = storage.Failed
-		} else {
-			newBackend.State = b.State
+			log.WithFields(log.Fields{
+				"newBackend":               newBackend,
+				"newBackendExternal":       newBackendExternal,
+				"newBackendExternal.Name":  newBackendExternal.Name,
+				"newBackendExternal.State": newBackendExternal.State.String(),
+			}).Debug("Backend information.")
 		}
+	}
 
-		log.WithFields(log.Fields{
-			"backend": newBackend.Name,
-			"online":  newBackend.Online,
-			"state":   newBackend.State,
-			"handler": "Bootstrap",
-		}).Info("Added an existing backend.")
+	// Note that addBackend returns an external copy of the newly
+	// added backend, so we have to go fetch it manually.
+	newBackend := o.backends[newBackendExternal.Name]
+	newBackend.Online = b.Online
+	if backendErr != nil {
+		newBackend.State = storage.Failed
+	} else {
+		newBackend.State = b.State
+		newBackend.MonitorFailed = b.MonitorFailed
 	}
-	return nil
+
+	log.WithFields(log.Fields{
+		"backend": newBackend.Name,
+		"online":  newBackend.Online,
+		"state":   newBackend.State,
+		"handler": "Bootstrap",
+	}).Info("Added an existing backend.")
+
+	return newBackend, nil
 }
 
 func (o *TridentOrchestrator) bootstrapStorageClasses() error {
@@ -235,6 +489,58 @@ func (o *TridentOrchestrator) bootstrapVolumes() error {
 	return nil
 }
 
+func (o *TridentOrchestrator) bootstrapSnapshots() error {
+	snapshots, err := o.storeClient.GetSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, s := range snapshots {
+		// TODO:  If the API evolves, check the Version field here.
+		snapshot := storage.NewSnapshot(s.Config, s.Created, s.SizeBytes, s.State)
+		if _, ok := o.volumes[s.Config.VolumeName]; !ok {
+			log.WithFields(log.Fields{
+				"snapshot": snapshot.Config.Name,
+				"volume":   snapshot.Config.VolumeName,
+			}).Warning("Couldn't find volume for snapshot.")
+			snapshot.State = storage.SnapshotStateMissingVolume
+		}
+		o.snapshots[snapshot.ID()] = snapshot
+
+		log.WithFields(log.Fields{
+			"snapshot": snapshot.Config.Name,
+			"volume":   snapshot.Config.VolumeName,
+			"state":    snapshot.State,
+			"handler":  "Bootstrap",
+		}).Info("Added an existing snapshot.")
+	}
+	return nil
+}
+
+func (o *TridentOrchestrator) bootstrapGroupSnapshots() error {
+	groupSnapshots, err := o.storeClient.GetGroupSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, g := range groupSnapshots {
+		for _, snapshotID := range g.Config.SnapshotIDs() {
+			if _, ok := o.snapshots[snapshotID]; !ok {
+				log.WithFields(log.Fields{
+					"groupSnapshot": g.Config.Name,
+					"snapshot":      snapshotID,
+				}).Warning("Couldn't find snapshot for group snapshot.")
+			}
+		}
+		o.groupSnapshots[g.Config.Name] = g
+
+		log.WithFields(log.Fields{
+			"groupSnapshot": g.Config.Name,
+			"volumes":       len(g.Config.VolumeNames),
+			"handler":       "Bootstrap",
+		}).Info("Added an existing group snapshot.")
+	}
+	return nil
+}
+
 func (o *TridentOrchestrator) bootstrapVolTxns() error {
 	volTxns, err := o.storeClient.GetVolumeTransactions()
 	if err != nil {
@@ -266,12 +572,74 @@ func (o *TridentOrchestrator) bootstrapNodes() error {
 	return nil
 }
 
+func (o *TridentOrchestrator) bootstrapVolumePublications() error {
+	publications, err := o.storeClient.GetVolumePublications()
+	if err != nil {
+		return err
+	}
+	for _, p := range publications {
+		if _, ok := o.volumes[p.VolumeName]; !ok {
+			log.WithFields(log.Fields{
+				"volume": p.VolumeName,
+				"node":   p.NodeName,
+			}).Warning("Couldn't find volume for publication.")
+		}
+		o.publications[p.ID()] = p
+
+		log.WithFields(log.Fields{
+			"volume":  p.VolumeName,
+			"node":    p.NodeName,
+			"handler": "Bootstrap",
+		}).Info("Added an existing volume publication.")
+	}
+	return nil
+}
+
+func (o *TridentOrchestrator) bootstrapQuotas() error {
+	quotas, err := o.storeClient.GetQuotas()
+	if err != nil {
+		return err
+	}
+	for _, q := range quotas {
+		o.quotas[q.Name] = q
+		log.WithFields(log.Fields{
+			"quota":   q.Name,
+			"scope":   q.Scope,
+			"owner":   q.Owner,
+			"handler": "Bootstrap",
+		}).Info("Added an existing quota.")
+	}
+	return nil
+}
+
+func (o *TridentOrchestrator) bootstrapEvents() error {
+	if !o.persistEvents {
+		return nil
+	}
+	events, err := o.storeClient.GetEvents()
+	if err != nil {
+		return err
+	}
+	for _, event := range o.events.load(events) {
+		if err = o.storeClient.DeleteEvent(event); err != nil {
+			return err
+		}
+	}
+	log.WithFields(log.Fields{
+		"events":  len(events),
+		"handler": "Bootstrap",
+	}).Info("Added existing events.")
+	return nil
+}
+
 func (o *TridentOrchestrator) bootstrap() error {
 	// Fetching backend information
 
 	type bootstrapFunc func() error
 	for _, f := range []bootstrapFunc{o.bootstrapBackends,
-		o.bootstrapStorageClasses, o.bootstrapVolumes, o.bootstrapVolTxns, o.bootstrapNodes} {
+		o.bootstrapStorageClasses, o.bootstrapVolumes, o.bootstrapSnapshots, o.bootstrapGroupSnapshots,
+		o.bootstrapVolTxns, o.bootstrapNodes, o.bootstrapVolumePublications, o.bootstrapQuotas,
+		o.bootstrapEvents} {
 		err := f()
 		if err != nil {
 			if persistentstore.MatchKeyNotFoundErr(err) {
@@ -307,12 +675,23 @@ func (o *TridentOrchestrator) bootstrap() error {
 }
 
 func (o *TridentOrchestrator) handleFailedTransaction(v *persistentstore.VolumeTransaction) error {
-	log.WithFields(log.Fields{
-		"volume":       v.Config.Name,
-		"size":         v.Config.Size,
-		"storageClass": v.Config.StorageClass,
-		"op":           v.Op,
-	}).Info("Processed volume transaction log.")
+
+	switch v.Op {
+	case persistentstore.AddSnapshot, persistentstore.DeleteSnapshot:
+		log.WithFields(log.Fields{
+			"volume":   v.SnapshotConfig.VolumeName,
+			"snapshot": v.SnapshotConfig.Name,
+			"op":       v.Op,
+		}).Info("Processed snapshot transaction log.")
+	default:
+		log.WithFields(log.Fields{
+			"volume":       v.Config.Name,
+			"size":         v.Config.Size,
+			"storageClass": v.Config.StorageClass,
+			"op":           v.Op,
+		}).Info("Processed volume transaction log.")
+	}
+
 	switch v.Op {
 	case persistentstore.AddVolume:
 		// Regardless of whether the transaction succeeded or not, we need
@@ -407,6 +786,111 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *persistentstore.VolumeT
 			}).Error("Volume for the resize transaction wasn't found.")
 		}
 		return o.resizeVolumeCleanup(err, vol, v)
+	case persistentstore.MigrateVolume:
+		// There are two possible states:
+		// 1) The migration was interrupted before the volume was cut over,
+		//    so the target volume, if any, must be destroyed.
+		// 2) The volume was cut over to the target backend, so only its
+		//    original remains to be destroyed.
+		if v.Migration == nil {
+			log.WithFields(log.Fields{
+				"volume": v.Config.Name,
+			}).Warn("Volume migration transaction has no migration details.")
+		} else if err := o.recoverVolumeMigration(v.Migration); err != nil {
+			return err
+		}
+		if err := o.deleteVolumeTransaction(v); err != nil {
+			return fmt.Errorf("failed to clean up volume migration transaction: %v", err)
+		}
+	case persistentstore.UnmanageVolume:
+		// The volume may have been renamed on its backend, and may have been
+		// removed from the persistent store, so finish unmanaging it if it
+		// was loaded into memory when we bootstrapped.
+		if volume, ok := o.volumes[v.Config.Name]; ok {
+			if err := o.unmanageVolume(volume, v.NewName); err != nil {
+				log.WithFields(log.Fields{
+					"volume": v.Config.Name,
+					"error":  err,
+				}).Errorf("Unable to finish unmanaging the volume! Repeat unmanaging the volume using %s.",
+					config.OrchestratorClientName)
+			}
+		}
+		if err := o.deleteVolumeTransaction(v); err != nil {
+			return fmt.Errorf("failed to clean up volume unmanage transaction: %v", err)
+		}
+	case persistentstore.MoveVolume:
+		// The volume may have been moved to the pool in the transaction, or be
+		// partway there, without its storage class having been updated, so the
+		// move is repeated if it was started.
+		if volume, ok := o.volumes[v.Config.Name]; ok && v.Pool != "" {
+			if err := o.finishVolumeMove(volume, v.Config.StorageClass, v.Pool); err != nil {
+				log.WithFields(log.Fields{
+					"volume": v.Config.Name,
+					"pool":   v.Pool,
+					"error":  err,
+				}).Error("Unable to finish moving the volume! Repeat updating the volume's storage class.")
+			}
+		}
+		if err := o.deleteVolumeTransaction(v); err != nil {
+			return fmt.Errorf("failed to clean up volume move transaction: %v", err)
+		}
+	case persistentstore.AddSnapshot:
+		// Regardless of whether the transaction succeeded or not, we need
+		// to roll it back.  There are three possible states:
+		// 1) Snapshot transaction created only
+		// 2) Snapshot created on backend
+		// 3) Snapshot created in persistent store
+		if _, ok := o.snapshots[v.SnapshotConfig.ID()]; ok {
+			// If the snapshot was added to the store, we will have loaded the
+			// snapshot into memory, and we can just delete it normally.
+			// Handles case 3)
+			if err := o.deleteSnapshot(v.SnapshotConfig); err != nil {
+				return fmt.Errorf("unable to clean up snapshot %s: %v", v.SnapshotConfig.Name, err)
+			}
+		} else {
+			// If the snapshot wasn't added into the store, we attempt to delete
+			// it at its backend.  Snapshot deletion is idempotent, so it's safe
+			// to delete an already deleted snapshot.
+			// Handles case 2)
+			if volume, ok := o.volumes[v.SnapshotConfig.VolumeName]; ok {
+				if backend, ok := o.backends[volume.Backend]; ok {
+					if err := backend.DeleteSnapshot(v.SnapshotConfig); err != nil {
+						return fmt.Errorf("error attempting to clean up snapshot %s from backend %s: %v",
+							v.SnapshotConfig.Name, backend.Name, err)
+					}
+				}
+			}
+		}
+		// Finally, we need to clean up the snapshot transaction.
+		// Necessary for all cases.
+		if err := o.deleteVolumeTransaction(v); err != nil {
+			return fmt.Errorf("failed to clean up snapshot addition transaction: %v", err)
+		}
+	case persistentstore.DeleteSnapshot:
+		// Because we remove the snapshot from persistent store after we remove
+		// it from the backend, we need to take any special measures only when
+		// the snapshot is still in the persistent store. In this case, the
+		// snapshot should have been loaded into memory when we bootstrapped.
+		if _, ok := o.snapshots[v.SnapshotConfig.ID()]; ok {
+
+			err := o.deleteSnapshot(v.SnapshotConfig)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"volume":   v.SnapshotConfig.VolumeName,
+					"snapshot": v.SnapshotConfig.Name,
+					"error":    err,
+				}).Errorf("Unable to finalize deletion of the snapshot! Repeat deleting the snapshot using %s.",
+					config.OrchestratorClientName)
+			}
+		} else {
+			log.WithFields(log.Fields{
+				"volume":   v.SnapshotConfig.VolumeName,
+				"snapshot": v.SnapshotConfig.Name,
+			}).Info("Snapshot for the delete transaction wasn't found.")
+		}
+		if err := o.deleteVolumeTransaction(v); err != nil {
+			return fmt.Errorf("failed to clean up snapshot deletion transaction: %v", err)
+		}
 	}
 
 	return nil
@@ -449,28 +933,46 @@ func (o *TridentOrchestrator) validateBackendUpdate(
 }
 
 func (o *TridentOrchestrator) GetVersion() (string, error) {
-	return config.OrchestratorVersion.String(), o.bootstrapError
+	return config.OrchestratorVersion.String(), o.getBootstrapError()
 }
 
 // AddBackend handles creation of a new storage backend
 func (o *TridentOrchestrator) AddBackend(configJSON string) (*storage.BackendExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	// Initializing the backend contacts the storage system, so do that before
+	// taking any locks.  The backend lock is needed in case this is an update.
+	backend, err := factory.NewStorageBackendForConfig(configJSON)
+	if backend != nil {
+		utils.Lock("AddBackend", backendLockID(backend.Name))
+		defer utils.Unlock("AddBackend", backendLockID(backend.Name))
 	}
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	return o.addBackend(configJSON)
+	return o.addStorageBackend(configJSON, backend, err)
 }
 
 // addBackend creates a new storage backend. It assumes the mutex lock is
 // already held or not required (e.g., during bootstrapping).
-func (o *TridentOrchestrator) addBackend(configJSON string) (backendExternal *storage.BackendExternal, err error) {
-	var (
-		newBackend = true
-		backend    *storage.Backend
-	)
+func (o *TridentOrchestrator) addBackend(configJSON string) (*storage.BackendExternal, error) {
+	backend, err := factory.NewStorageBackendForConfig(configJSON)
+	return o.addStorageBackend(configJSON, backend, err)
+}
+
+// addStorageBackend adds a backend created from the specified config, or
+// updates the existing backend of the same name.  The error from creating the
+// backend is passed in so that failed backends may still be recorded.  It
+// assumes the mutex lock and the backend lock are already held or not
+// required (e.g., during bootstrapping).
+func (o *TridentOrchestrator) addStorageBackend(
+	configJSON string, backend *storage.Backend, createErr error,
+) (backendExternal *storage.BackendExternal, err error) {
+
+	newBackend := true
 
 	defer func() {
 		if backend != nil {
@@ -480,8 +982,7 @@ func (o *TridentOrchestrator) addBackend(configJSON string) (backendExternal *st
 		}
 	}()
 
-	backend, err = factory.NewStorageBackendForConfig(configJSON)
-	if err != nil {
+	if err = createErr; err != nil {
 		log.WithFields(log.Fields{
 			"err":     err.Error(),
 			"backend": backend,
@@ -531,41 +1032,57 @@ func (o *TridentOrchestrator) addBackend(configJSON string) (backendExternal *st
 // UpdateBackend updates an existing backend.
 func (o *TridentOrchestrator) UpdateBackend(backendName, configJSON string) (
 	backendExternal *storage.BackendExternal, err error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.writeError(); err != nil {
+		return nil, err
 	}
 
+	utils.Lock("UpdateBackend", backendLockID(backendName))
+	defer utils.Unlock("UpdateBackend", backendLockID(backendName))
+
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
 	return o.updateBackend(backendName, configJSON)
 }
 
-// updateBackend updates an existing backend. It assumes the mutex lock is
-// already held.
+// updateBackend updates an existing backend. It assumes the mutex lock and
+// the backend lock are already held.
 func (o *TridentOrchestrator) updateBackend(backendName, configJSON string) (
 	backendExternal *storage.BackendExternal, err error) {
-	var (
-		backend *storage.Backend
-	)
+
+	// First, check whether the backend exists.
+	if _, found := o.backends[backendName]; !found {
+		return nil, notFoundError(fmt.Sprintf("backend %v was not found", backendName))
+	}
+
+	backend, err := factory.NewStorageBackendForConfig(configJSON)
+	if err != nil {
+		if backend != nil {
+			backend.Terminate()
+		}
+		return nil, err
+	}
+	return o.replaceBackend(backendName, backend)
+}
+
+// replaceBackend replaces an existing backend with a new instance created from
+// its updated config.  It assumes the mutex lock and the backend lock are
+// already held.
+func (o *TridentOrchestrator) replaceBackend(backendName string, backend *storage.Backend) (
+	backendExternal *storage.BackendExternal, err error) {
 
 	defer func() {
-		if backend != nil && err != nil {
+		if err != nil {
 			backend.Terminate()
 		}
 	}()
 
-	// First, check whether the backend exists.
 	originalBackend, found := o.backends[backendName]
 	if !found {
 		return nil, notFoundError(fmt.Sprintf("backend %v was not found", backendName))
 	}
 
-	// Second, validate the update.
-	backend, err = factory.NewStorageBackendForConfig(configJSON)
-	if err != nil {
-		return nil, err
-	}
+	// Validate the update.
 	if err = o.validateBackendUpdate(originalBackend, backend); err != nil {
 		return nil, err
 	}
@@ -573,7 +1090,7 @@ func (o *TridentOrchestrator) updateBackend(backendName, configJSON string) (
 		"backend": backend.Name,
 	}).Debug("Updating an existing backend.")
 
-	// Third, determine what type of backend update we're dealing with.
+	// Determine what type of backend update we're dealing with.
 	// Here are the major categories and their implications:
 	// 1) Backend rename
 	//    a) Affects in-memory backend, storage class, and volume objects
@@ -673,18 +1190,22 @@ func (o *TridentOrchestrator) updateBackend(backendName, configJSON string) (
 // UpdateBackend updates an existing backend.
 func (o *TridentOrchestrator) UpdateBackendState(backendName, backendState string) (
 	backendExternal *storage.BackendExternal, err error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.writeError(); err != nil {
+		return nil, err
 	}
 
+	utils.Lock("UpdateBackendState", backendLockID(backendName))
+	defer utils.Unlock("UpdateBackendState", backendLockID(backendName))
+
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	return o.updateBackendState(backendName, backendState)
+	return o.updateBackendState(backendName, backendState, false)
 }
 
-// updateBackend updates an existing backend. It assumes the mutex lock is already held.
-func (o *TridentOrchestrator) updateBackendState(backendName, backendState string) (
+// updateBackendState updates the state of an existing backend, recording whether the health
+// monitor caused the change. It assumes the mutex lock and the backend lock are already held.
+func (o *TridentOrchestrator) updateBackendState(backendName, backendState string, monitorFailed bool) (
 	backendExternal *storage.BackendExternal, err error) {
 	var (
 		backend *storage.Backend
@@ -712,17 +1233,18 @@ func (o *TridentOrchestrator) updateBackendState(backendName, backendState strin
 		backend.Terminate()
 	}
 	backend.State = newBackendState
+	backend.MonitorFailed = monitorFailed
 
 	return backend.ConstructExternal(), o.storeClient.UpdateBackend(backend)
 }
 
 func (o *TridentOrchestrator) GetBackend(backendName string) (*storage.BackendExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
 
 	backend, found := o.backends[backendName]
 	if !found {
@@ -740,15 +1262,15 @@ func (o *TridentOrchestrator) GetBackend(backendName string) (*storage.BackendEx
 }
 
 func (o *TridentOrchestrator) ListBackends() ([]*storage.BackendExternal, error) {
-	if o.bootstrapError != nil {
+	if err := o.getBootstrapError(); err != nil {
 		log.WithFields(log.Fields{
-			"bootstrapError": o.bootstrapError,
+			"bootstrapError": err,
 		}).Warn("ListBackends error")
-		return nil, o.bootstrapError
+		return nil, err
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
 
 	log.Debugf("About to list backends: %v", o.backends)
 	backends := make([]*storage.BackendExternal, 0)
@@ -759,13 +1281,16 @@ func (o *TridentOrchestrator) ListBackends() ([]*storage.BackendExternal, error)
 }
 
 func (o *TridentOrchestrator) DeleteBackend(backendName string) error {
-	if o.bootstrapError != nil {
+	if err := o.writeError(); err != nil {
 		log.WithFields(log.Fields{
-			"bootstrapError": o.bootstrapError,
+			"error": err,
 		}).Warn("DeleteBackend error")
-		return o.bootstrapError
+		return err
 	}
 
+	utils.Lock("DeleteBackend", backendLockID(backendName))
+	defer utils.Unlock("DeleteBackend", backendLockID(backendName))
+
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
@@ -804,78 +1329,143 @@ func (o *TridentOrchestrator) DeleteBackend(backendName string) error {
 func (o *TridentOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (
 	externalVol *storage.VolumeExternal, err error) {
 
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.writeError(); err != nil {
+		return nil, err
 	}
 
 	var (
-		backend *storage.Backend
-		vol     *storage.Volume
+		backend       *storage.Backend
+		vol           *storage.Volume
+		protocol      config.Protocol
+		pools         []*storage.Pool
+		volAttributes map[string]sa.Request
+		volTxn        *persistentstore.VolumeTransaction
+		sizeBytes     uint64
+		reservedPool  *storage.Pool
 	)
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
 
-	if _, ok := o.volumes[volumeConfig.Name]; ok {
-		return nil, fmt.Errorf("volume %s already exists", volumeConfig.Name)
-	}
-	volumeConfig.Version = config.OrchestratorAPIVersion
+	utils.Lock("AddVolume", volumeLockID(volumeConfig.Name))
+	defer utils.Unlock("AddVolume", volumeLockID(volumeConfig.Name))
+	defer o.releaseQuota(volumeConfig.Name)
 
-	// Get the protocol based on the specified access mode & protocol
-	protocol, err := o.getProtocol(volumeConfig.AccessMode, volumeConfig.Protocol)
-	if err != nil {
-		return nil, err
-	}
+	// Validate the request and find candidate pools
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
 
-	sc, ok := o.storageClasses[volumeConfig.StorageClass]
-	if !ok {
-		return nil, fmt.Errorf("unknown storage class: %s", volumeConfig.StorageClass)
-	}
-	pools := sc.GetStoragePoolsForProtocol(protocol)
-	if len(pools) == 0 {
-		return nil, fmt.Errorf("no available backends for storage class %s",
-			volumeConfig.StorageClass)
-	}
+		if _, ok := o.volumes[volumeConfig.Name]; ok {
+			return fmt.Errorf("volume %s already exists", volumeConfig.Name)
+		}
+		volumeConfig.Version = config.OrchestratorAPIVersion
 
-	// Add a transaction in case the operation must be rolled back later
-	volTxn := &persistentstore.VolumeTransaction{
-		Config: volumeConfig,
-		Op:     persistentstore.AddVolume,
-	}
-	if err = o.addVolumeTransaction(volTxn); err != nil {
+		// Get the protocol based on the specified access mode & protocol
+		var err error
+		if protocol, err = o.getProtocol(volumeConfig.AccessMode, volumeConfig.Protocol); err != nil {
+			return err
+		}
+
+		sc, ok := o.storageClasses[volumeConfig.StorageClass]
+		if !ok {
+			return fmt.Errorf("unknown storage class: %s", volumeConfig.StorageClass)
+		}
+
+		// Fill in the storage class's defaults before the backend applies its own
+		sc.ApplyVolumeDefaults(volumeConfig)
+		if err = sc.CheckVolumeSize(volumeConfig.Size); err != nil {
+			return err
+		}
+		if err = o.reserveQuota(volumeConfig); err != nil {
+			return err
+		}
+
+		pools = poolsOnOnlineBackends(sc.GetStoragePoolsForProtocol(protocol))
+		if len(pools) == 0 {
+			return fmt.Errorf("no available backends for storage class %s",
+				volumeConfig.StorageClass)
+		}
+		volAttributes = sc.GetAttributes()
+
+		// Skip any pools known to lack room for the volume
+		if size, err := volumeSizeBytes(volumeConfig); err == nil {
+			sizeBytes = size
+		}
+		if sizeBytes > 0 {
+			if pools = poolsWithFreeSpace(pools, sizeBytes); len(pools) == 0 {
+				return fmt.Errorf("no storage pool for storage class %s has %d bytes of free space",
+					volumeConfig.StorageClass, sizeBytes)
+			}
+		}
+
+		// Order the pools according to the storage class's placement policy
+		pools = sc.RankPools(pools, o.getPoolUsage())
+
+		// Add a transaction in case the operation must be rolled back later
+		volTxn = &persistentstore.VolumeTransaction{
+			Config: volumeConfig,
+			Op:     persistentstore.AddVolume,
+		}
+		return o.addVolumeTransaction(volTxn)
+	}()
+	if err != nil {
 		return nil, err
 	}
 
-	// Recovery function in case of error
+	// Recovery function in case of error.  The backend is still locked here
+	// if the volume was created on it.
 	defer func() {
 		err = o.addVolumeCleanup(err, backend, vol, volTxn, volumeConfig)
+		if err != nil && reservedPool != nil {
+			o.releasePoolSpace(reservedPool, sizeBytes)
+		}
+		if backend != nil {
+			o.unlockBackend("AddVolume", backend)
+		}
 	}()
 
-	// Randomize the storage pool list for better distribution of load across all pools.
-	rand.Seed(time.Now().UnixNano())
-
 	log.WithFields(log.Fields{
 		"volume": volumeConfig.Name,
 	}).Debugf("Looking through %d storage pools.", len(pools))
 
 	errorMessages := make([]string, 0)
 
-	// Choose a pool at random.
-	for _, num := range rand.Perm(len(pools)) {
+	// Try each pool in order of preference.
+	for _, pool := range pools {
 
 		// Add volume to the backend of the selected pool
-		backend = pools[num].Backend
-		vol, err = backend.AddVolume(volumeConfig, pools[num], sc.GetAttributes())
+		if err = o.lockBackend("AddVolume", pool.Backend); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("[%s]", err.Error()))
+			continue
+		}
+
+		// Other requests may have claimed the pool's free space since it was checked
+		if !o.reservePoolSpace(pool, sizeBytes) {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf("[Storage pool %s from backend %s no longer has %d bytes of free space]",
+					pool.Name, pool.Backend.Name, sizeBytes))
+			o.unlockBackend("AddVolume", pool.Backend)
+			continue
+		}
+		reservedPool = pool
+
+		backend = pool.Backend
+		vol, err = backend.AddVolume(volumeConfig, pool, volAttributes)
 		if err != nil {
 
+			o.releasePoolSpace(pool, sizeBytes)
+			reservedPool = nil
+
 			log.WithFields(log.Fields{
 				"backend": backend.Name,
-				"pool":    pools[num].Name,
+				"pool":    pool.Name,
 				"volume":  volumeConfig.Name,
 				"error":   err,
 			}).Warn("Failed to create the volume on this backend!")
 			errorMessages = append(errorMessages,
 				fmt.Sprintf("[Failed to create volume %s on storage pool %s from backend %s: %s]",
-					volumeConfig.Name, pools[num].Name, backend.Name, err.Error()))
+					volumeConfig.Name, pool.Name, backend.Name, err.Error()))
+
+			o.unlockBackend("AddVolume", backend)
+			backend = nil
 
 		} else {
 
@@ -883,14 +1473,11 @@ func (o *TridentOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (
 				vol.Config.Protocol = backend.GetProtocol()
 			}
 
-			// Add new volume to persistent store
-			err = o.storeClient.AddVolume(vol)
-			if err != nil {
+			// Add new volume to persistent store and internal cache, and
+			// return external form of the new volume
+			if err = o.addVolumeToStoreAndCache(backend, vol); err != nil {
 				return nil, err
 			}
-
-			// Update internal cache and return external form of the new volume
-			o.volumes[volumeConfig.Name] = vol
 			externalVol = vol.ConstructExternal()
 			return externalVol, nil
 		}
@@ -907,59 +1494,291 @@ func (o *TridentOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (
 	return nil, err
 }
 
+// ExplainVolume reports which storage pools could hold the specified volume, and why,
+// by checking the request against the same criteria as AddVolume without creating
+// anything.  Pools that could hold the volume are listed first, in the order that
+// AddVolume would try them.
+func (o *TridentOrchestrator) ExplainVolume(volumeConfig *storage.VolumeConfig) (
+	*storageclass.VolumeExplanation, error,
+) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	// Work on a copy, since applying defaults and preparing the volume change its config
+	volConfig := volumeConfig.ConstructClone()
+
+	var (
+		sc          *storageclass.StorageClass
+		explanation *storageclass.VolumeExplanation
+		pools       []*storage.Pool
+	)
+	volumeAllowed := true
+	poolExplanations := make(map[*storage.Pool]*storageclass.PoolExplanation)
+
+	// Check the request against the cached state
+	err := func() error {
+		o.mutex.RLock()
+		defer o.mutex.RUnlock()
+
+		protocol, err := o.getProtocol(volConfig.AccessMode, volConfig.Protocol)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if sc, ok = o.storageClasses[volConfig.StorageClass]; !ok {
+			return notFoundError(fmt.Sprintf("unknown storage class: %s", volConfig.StorageClass))
+		}
+		sc.ApplyVolumeDefaults(volConfig)
+
+		explanation = &storageclass.VolumeExplanation{
+			Volume:       volConfig.Name,
+			StorageClass: volConfig.StorageClass,
+			Protocol:     protocol,
+			Size:         volConfig.Size,
+			Checks:       make([]storageclass.Check, 0),
+			Pools:        make([]*storageclass.PoolExplanation, 0),
+		}
+
+		// Check the criteria that apply to the volume wherever it is placed
+		addVolumeCheck := func(criterion string, err error) {
+			check := storageclass.Check{Criterion: criterion, Passed: err == nil}
+			if err != nil {
+				check.Reason = err.Error()
+				volumeAllowed = false
+			}
+			explanation.Checks = append(explanation.Checks, check)
+		}
+		if _, ok := o.volumes[volConfig.Name]; ok {
+			addVolumeCheck("name", fmt.Errorf("volume %s already exists", volConfig.Name))
+		} else {
+			addVolumeCheck("name", nil)
+		}
+		addVolumeCheck("storageClassSizeLimits", sc.CheckVolumeSize(volConfig.Size))
+		addVolumeCheck("quotas", o.checkQuota(volConfig))
+
+		sizeBytes, err := volumeSizeBytes(volConfig)
+		if err != nil {
+			sizeBytes = 0
+		}
+
+		// Check every pool, not just those of the storage class, so that the explanation
+		// shows why the other pools were excluded
+		backendNames := make([]string, 0, len(o.backends))
+		for name := range o.backends {
+			backendNames = append(backendNames, name)
+		}
+		sort.Strings(backendNames)
+
+		for _, backendName := range backendNames {
+			backend := o.backends[backendName]
+
+			poolNames := make([]string, 0, len(backend.Storage))
+			for name := range backend.Storage {
+				poolNames = append(poolNames, name)
+			}
+			sort.Strings(poolNames)
+
+			for _, poolName := range poolNames {
+				pool := backend.Storage[poolName]
+				pools = append(pools, pool)
+				poolExplanations[pool] = o.explainPool(sc, pool, protocol, sizeBytes)
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	// Let each driver veto the volume.  The drivers may contact their storage systems,
+	// so this is done under each backend's lock rather than the mutex, using a copy of
+	// the config since preparing it sets its internal name.
+	matchedPools := make([]*storage.Pool, 0)
+	unmatched := make([]*storageclass.PoolExplanation, 0)
+	for _, pool := range pools {
+		poolExplanation := poolExplanations[pool]
+
+		prepareCheck := storageclass.Check{Criterion: "createPrepare", Passed: true}
+		err := o.lockBackend("ExplainVolume", pool.Backend)
+		if err == nil {
+			err = pool.Backend.Driver.CreatePrepare(volConfig.ConstructClone())
+			o.unlockBackend("ExplainVolume", pool.Backend)
+		}
+		if err != nil {
+			prepareCheck.Passed = false
+			prepareCheck.Reason = err.Error()
+		}
+		poolExplanation.AddCheck(prepareCheck)
+
+		if poolExplanation.Matched {
+			matchedPools = append(matchedPools, pool)
+		} else {
+			unmatched = append(unmatched, poolExplanation)
+		}
+	}
+
+	// Rank the pools without advancing the storage class's placement policy
+	o.mutex.RLock()
+	rankedPools := sc.PreviewRankPools(matchedPools, o.getPoolUsage())
+	o.mutex.RUnlock()
+
+	for _, pool := range rankedPools {
+		explanation.Pools = append(explanation.Pools, poolExplanations[pool])
+	}
+	explanation.Pools = append(explanation.Pools, unmatched...)
+	explanation.Provisionable = volumeAllowed && len(matchedPools) > 0
+
+	return explanation, nil
+}
+
+// explainPool checks whether a volume could be created in a storage pool, recording the
+// outcome of the storage class's criteria and of the backend's cached state.  The
+// driver's own checks are left to the caller.  It assumes the mutex lock is already held.
+func (o *TridentOrchestrator) explainPool(
+	sc *storageclass.StorageClass, pool *storage.Pool, protocol config.Protocol, sizeBytes uint64,
+) *storageclass.PoolExplanation {
+
+	backend := pool.Backend
+
+	explanation := sc.ExplainMatch(pool)
+
+	stateCheck := storageclass.Check{
+		Criterion: "backendState",
+		Requested: string(storage.Online),
+		Offered:   backend.State.String(),
+		Passed:    backend.State.IsOnline(),
+	}
+	if !stateCheck.Passed {
+		stateCheck.Reason = fmt.Sprintf("backend %s is %s", backend.Name, backend.State)
+	}
+	explanation.AddCheck(stateCheck)
+
+	protocolCheck := storageclass.Check{
+		Criterion: "protocol",
+		Requested: string(protocol),
+		Offered:   string(backend.GetProtocol()),
+		Passed:    protocol == config.ProtocolAny || backend.GetProtocol() == protocol,
+	}
+	if !protocolCheck.Passed {
+		protocolCheck.Reason = fmt.Sprintf("backend %s doesn't provide %s volumes", backend.Name, protocol)
+	}
+	explanation.AddCheck(protocolCheck)
+
+	if sizeBytes > 0 {
+		if pool.Capacity.Known() {
+			spaceCheck := storageclass.Check{
+				Criterion: "freeSpace",
+				Requested: strconv.FormatUint(sizeBytes, 10),
+				Offered:   strconv.FormatUint(pool.Capacity.FreeBytes(), 10),
+				Passed:    pool.Capacity.FreeBytes() >= sizeBytes,
+			}
+			if !spaceCheck.Passed {
+				spaceCheck.Reason = "pool doesn't have enough free space"
+			}
+			explanation.AddCheck(spaceCheck)
+		}
+
+		limitCheck := storageclass.Check{Criterion: "limitVolumeSize", Passed: true}
+		if err := backend.CheckVolumeSizeLimit(sizeBytes); err != nil {
+			limitCheck.Passed = false
+			limitCheck.Reason = err.Error()
+		}
+		explanation.AddCheck(limitCheck)
+	}
+
+	return explanation
+}
+
 func (o *TridentOrchestrator) CloneVolume(volumeConfig *storage.VolumeConfig) (
 	externalVol *storage.VolumeExternal, err error) {
 
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.writeError(); err != nil {
+		return nil, err
 	}
 
 	var (
-		found   bool
-		backend *storage.Backend
-		vol     *storage.Volume
+		backend     *storage.Backend
+		vol         *storage.Volume
+		cloneConfig *storage.VolumeConfig
+		volTxn      *persistentstore.VolumeTransaction
 	)
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
 
-	if _, ok := o.volumes[volumeConfig.Name]; ok {
-		return nil, fmt.Errorf("volume %s already exists", volumeConfig.Name)
-	}
-	volumeConfig.Version = config.OrchestratorAPIVersion
+	utils.Lock("CloneVolume", volumeLockID(volumeConfig.Name))
+	defer utils.Unlock("CloneVolume", volumeLockID(volumeConfig.Name))
+	defer o.releaseQuota(volumeConfig.Name)
 
-	// Get the source volume
-	sourceVolume, found := o.volumes[volumeConfig.CloneSourceVolume]
-	if !found {
-		return nil, notFoundError(fmt.Sprintf("source volume not found: %s", volumeConfig.CloneSourceVolume))
+	// The clone is created on the source volume's backend
+	lockedBackend, err := o.lockVolumeBackend("CloneVolume", volumeConfig.CloneSourceVolume)
+	if err != nil {
+		return nil, err
 	}
-	if sourceVolume.Orphaned {
-		log.WithFields(log.Fields{
-			"source_volume": sourceVolume.Config.Name,
-			"volume":        volumeConfig.Name,
-			"backend":       sourceVolume.Backend,
-		}).Warnf("Clone operation is likely to fail with an orphaned " +
-			"source volume!")
-	}
-
-	// Clone the source config, as most of its attributes will apply to the clone
-	cloneConfig := sourceVolume.Config.ConstructClone()
-
-	// Copy a few attributes from the request that will affect clone creation
-	cloneConfig.Name = volumeConfig.Name
-	cloneConfig.InternalName = ""
-	cloneConfig.SplitOnClone = volumeConfig.SplitOnClone
-	cloneConfig.CloneSourceVolume = volumeConfig.CloneSourceVolume
-	cloneConfig.CloneSourceVolumeInternal = sourceVolume.Config.InternalName
-	cloneConfig.CloneSourceSnapshot = volumeConfig.CloneSourceSnapshot
-	cloneConfig.QoS = volumeConfig.QoS
-	cloneConfig.QoSType = volumeConfig.QoSType
-
-	// Add transaction in case the operation must be rolled back later
-	volTxn := &persistentstore.VolumeTransaction{
-		Config: cloneConfig,
-		Op:     persistentstore.AddVolume,
+	if lockedBackend != nil {
+		defer o.unlockBackend("CloneVolume", lockedBackend)
 	}
-	if err = o.addVolumeTransaction(volTxn); err != nil {
+
+	// Validate the request and build the clone config
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		if _, ok := o.volumes[volumeConfig.Name]; ok {
+			return fmt.Errorf("volume %s already exists", volumeConfig.Name)
+		}
+		volumeConfig.Version = config.OrchestratorAPIVersion
+
+		// Get the source volume
+		sourceVolume, found := o.volumes[volumeConfig.CloneSourceVolume]
+		if !found {
+			return notFoundError(fmt.Sprintf("source volume not found: %s", volumeConfig.CloneSourceVolume))
+		}
+		if sourceVolume.Orphaned {
+			log.WithFields(log.Fields{
+				"source_volume": sourceVolume.Config.Name,
+				"volume":        volumeConfig.Name,
+				"backend":       sourceVolume.Backend,
+			}).Warnf("Clone operation is likely to fail with an orphaned " +
+				"source volume!")
+		}
+
+		sourceBackend, found := o.backends[sourceVolume.Backend]
+		if !found {
+			// Should never get here but just to be safe
+			return notFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
+				sourceVolume.Backend, volumeConfig.CloneSourceVolume))
+		}
+		if sourceBackend != lockedBackend {
+			return fmt.Errorf("backend %s for the source volume %s changed during the operation; "+
+				"repeat the operation", sourceVolume.Backend, volumeConfig.CloneSourceVolume)
+		}
+
+		// Clone the source config, as most of its attributes will apply to the clone
+		cloneConfig = sourceVolume.Config.ConstructClone()
+
+		// Copy a few attributes from the request that will affect clone creation
+		cloneConfig.Name = volumeConfig.Name
+		cloneConfig.InternalName = ""
+		cloneConfig.SplitOnClone = volumeConfig.SplitOnClone
+		cloneConfig.CloneSourceVolume = volumeConfig.CloneSourceVolume
+		cloneConfig.CloneSourceVolumeInternal = sourceVolume.Config.InternalName
+		cloneConfig.CloneSourceSnapshot = volumeConfig.CloneSourceSnapshot
+		cloneConfig.QoS = volumeConfig.QoS
+		cloneConfig.QoSType = volumeConfig.QoSType
+		cloneConfig.Labels = volumeConfig.Labels
+		cloneConfig.Namespace = volumeConfig.Namespace
+
+		if err := o.reserveQuota(cloneConfig); err != nil {
+			return err
+		}
+
+		// Add transaction in case the operation must be rolled back later
+		volTxn = &persistentstore.VolumeTransaction{
+			Config: cloneConfig,
+			Op:     persistentstore.AddVolume,
+		}
+		return o.addVolumeTransaction(volTxn)
+	}()
+	if err != nil {
 		return nil, err
 	}
 
@@ -968,13 +1787,7 @@ func (o *TridentOrchestrator) CloneVolume(volumeConfig *storage.VolumeConfig) (
 		err = o.addVolumeCleanup(err, backend, vol, volTxn, volumeConfig)
 	}()
 
-	backend, found = o.backends[sourceVolume.Backend]
-	if !found {
-		// Should never get here but just to be safe
-		return nil, notFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
-			sourceVolume.Backend, volumeConfig.CloneSourceVolume))
-	}
-
+	backend = lockedBackend
 	vol, err = backend.CloneVolume(cloneConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cloned volume %s on backend %s: %v", cloneConfig.Name,
@@ -982,1003 +1795,4519 @@ func (o *TridentOrchestrator) CloneVolume(volumeConfig *storage.VolumeConfig) (
 	}
 
 	// Save references to new volume
-	err = o.storeClient.AddVolume(vol)
-	if err != nil {
+	if err = o.addVolumeToStoreAndCache(backend, vol); err != nil {
 		return nil, err
 	}
-	o.volumes[cloneConfig.Name] = vol
 
 	return vol.ConstructExternal(), nil
 }
 
-// This func is used by volume import so it doesn't check core's o.volumes to see if the
-// volume exists or not. Instead it asks the driver if the volume exists before requesting
-// the volume size. Returns the VolumeExternal representation of the volume.
-func (o *TridentOrchestrator) GetVolumeExternal(volumeName string, backendName string) (*storage.VolumeExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
-	}
-
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
-
-	log.WithFields(log.Fields{
-		"originalName": volumeName,
-		"backendName":  backendName,
-	}).Debug("Orchestrator#GetVolumeExternal")
+// getPoolUsage totals the size and number of the volumes in each storage pool.
+// It assumes the mutex lock is already held.
+func (o *TridentOrchestrator) getPoolUsage() map[*storage.Pool]storageclass.PoolUsage {
 
-	backend, ok := o.backends[backendName]
-	if !ok {
-		return nil, notFoundError(fmt.Sprintf("backend %s not found", backendName))
+	usage := make(map[*storage.Pool]storageclass.PoolUsage)
+	for _, vol := range o.volumes {
+		backend, ok := o.backends[vol.Backend]
+		if !ok {
+			continue
+		}
+		pool, ok := backend.Storage[vol.Pool]
+		if !ok {
+			continue
+		}
+		poolUsage := usage[pool]
+		poolUsage.Volumes++
+		if sizeBytes, err := volumeSizeBytes(vol.Config); err == nil {
+			poolUsage.AllocatedBytes += sizeBytes
+		}
+		usage[pool] = poolUsage
 	}
+	return usage
+}
 
-	volExternal, err := backend.GetVolumeExternal(volumeName)
+// volumeSizeBytes returns the size requested in a volume config.
+func volumeSizeBytes(volumeConfig *storage.VolumeConfig) (uint64, error) {
+	size, err := utils.ConvertSizeToBytes(volumeConfig.Size)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-
-	return volExternal, nil
+	return strconv.ParseUint(size, 10, 64)
 }
 
-func (o *TridentOrchestrator) ImportVolume(
-	volumeConfig *storage.VolumeConfig, originalName string, backendName string, notManaged bool, createPVandPVC Operation,
-) (externalVol *storage.VolumeExternal, err error) {
+// poolsOnOnlineBackends returns the pools whose backends are online, since backends
+// may fail after their pools were added to a storage class.
+func poolsOnOnlineBackends(pools []*storage.Pool) []*storage.Pool {
 
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	online := make([]*storage.Pool, 0, len(pools))
+	for _, pool := range pools {
+		if pool.Backend.State.IsOnline() {
+			online = append(online, pool)
+		}
 	}
+	return online
+}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
-
-	log.WithFields(log.Fields{
-		"volumeConfig": volumeConfig,
-		"originalName": originalName,
-		"backendName":  backendName,
-	}).Debug("Orchestrator#ImportVolume")
+// poolsWithFreeSpace returns the pools that could fit a volume of the specified size,
+// including any pools whose capacity isn't known.
+func poolsWithFreeSpace(pools []*storage.Pool, sizeBytes uint64) []*storage.Pool {
 
-	for volumeName, volume := range o.volumes {
-		if volume.Config.InternalName == originalName && volume.Backend == backendName {
-			return nil, foundError(fmt.Sprintf("PV %s already exists for volume %s",
-				originalName, volumeName))
+	fits := make([]*storage.Pool, 0, len(pools))
+	for _, pool := range pools {
+		if pool.Capacity.Known() && pool.Capacity.FreeBytes() < sizeBytes {
+			log.WithFields(log.Fields{
+				"backend":   pool.Backend.Name,
+				"pool":      pool.Name,
+				"freeBytes": pool.Capacity.FreeBytes(),
+				"sizeBytes": sizeBytes,
+			}).Debug("Skipping storage pool without enough free space.")
+			continue
 		}
+		fits = append(fits, pool)
 	}
+	return fits
+}
 
-	backend, ok := o.backends[backendName]
-	if !ok {
-		return nil, notFoundError(fmt.Sprintf("backend %s not found", backendName))
-	}
+// reservePoolSpace counts the size of a new volume against its pool's free space
+// until the pool's capacity is next refreshed.  It returns false if the pool is
+// known to lack room for the volume.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) reservePoolSpace(pool *storage.Pool, sizeBytes uint64) bool {
 
-	sc, ok := o.storageClasses[volumeConfig.StorageClass]
-	if !ok {
-		return nil, fmt.Errorf("unknown storage class: %s", volumeConfig.StorageClass)
-	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 
-	if !sc.IsAddedToBackend(backend, volumeConfig.StorageClass) {
-		return nil, fmt.Errorf("storageClass %s is not added to backend %s", volumeConfig.StorageClass, backendName)
+	if !pool.Capacity.Known() {
+		return true
 	}
-
-	if backend.Driver.Get(originalName) != nil {
-		return nil, notFoundError(fmt.Sprintf("volume %s was not found", originalName))
+	if pool.Capacity.FreeBytes() < sizeBytes {
+		return false
 	}
+	pool.Capacity.ReservedBytes += sizeBytes
+	return true
+}
 
-	// Check that the specified protocol and access mode are compatible
-	_, err = o.getProtocol(volumeConfig.AccessMode, volumeConfig.Protocol)
-	if err != nil {
-		return nil, err
-	}
+// releasePoolSpace returns space reserved for a volume that wasn't created.  The
+// caller must not hold the mutex lock.
+func (o *TridentOrchestrator) releasePoolSpace(pool *storage.Pool, sizeBytes uint64) {
 
-	// Validate the protocol
-	if len(volumeConfig.Protocol) == 0 {
-		volumeConfig.Protocol = backend.GetProtocol()
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if pool.Capacity.ReservedBytes > sizeBytes {
+		pool.Capacity.ReservedBytes -= sizeBytes
 	} else {
-		backendProtocol := backend.GetProtocol()
-		if volumeConfig.Protocol != backendProtocol {
-			return nil, fmt.Errorf("requested protocol %s does not match backend protocol %s", volumeConfig.Protocol, backendProtocol)
-		}
+		pool.Capacity.ReservedBytes = 0
 	}
+}
 
-	// Add transaction in case operation must be rolled back
-	volTxn := &persistentstore.VolumeTransaction{
-		Config: volumeConfig,
-		Op:     persistentstore.ImportVolume,
-	}
-	if err = o.addVolumeTransaction(volTxn); err != nil {
-		return nil, fmt.Errorf("failed to add volume transaction: %v", err)
+// refreshPoolCapacityPeriodically updates the capacity of all storage pools at a
+// fixed interval, until the stop channel is closed.
+func (o *TridentOrchestrator) refreshPoolCapacityPeriodically(stop <-chan struct{}) {
+	ticker := time.NewTicker(config.PoolCapacityRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// A standby's backends are only refreshed once it is elected
+			if !o.isStandby() {
+				o.refreshPoolCapacity()
+			}
+		}
 	}
+}
 
-	// Recover function in case or error
-	defer func() {
-		err = o.importVolumeCleanup(err, volumeConfig, originalName, backend, notManaged, volTxn)
-	}()
-
-	volume, err := backend.ImportVolume(volumeConfig, originalName, notManaged)
-	if err != nil {
-		return nil, fmt.Errorf("failed to import volume %s on backend %s: %v", originalName, backendName, err)
-	}
+// refreshPoolCapacity asks each online backend for the current capacity of its
+// storage pools.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) refreshPoolCapacity() {
 
-	if !notManaged {
-		// The volume is managed and is persisted.
-		err = o.storeClient.AddVolume(volume)
-		if err != nil {
-			return nil, fmt.Errorf("failed to persist imported volume data: %v", err)
+	o.mutex.RLock()
+	backends := make([]*storage.Backend, 0, len(o.backends))
+	for _, backend := range o.backends {
+		if backend.Online && backend.State.IsOnline() {
+			backends = append(backends, backend)
 		}
-		o.volumes[volumeConfig.Name] = volume
 	}
+	o.mutex.RUnlock()
 
-	volExternal := volume.ConstructExternal()
+	for _, backend := range backends {
 
-	driverType, err := o.getDriverTypeForVolume(volExternal.Backend)
-	if err != nil {
-		return nil, fmt.Errorf("unable to determine driver type from volume %v", volExternal)
-	}
+		if err := o.lockBackend("refreshPoolCapacity", backend); err != nil {
+			continue
+		}
+		capacity, err := backend.GetPoolCapacity()
+		o.unlockBackend("refreshPoolCapacity", backend)
 
-	log.WithFields(log.Fields{
-		"backend":        volExternal.Backend,
-		"name":           volExternal.Config.Name,
-		"internalName":   volExternal.Config.InternalName,
-		"size":           volExternal.Config.Size,
-		"protocol":       volExternal.Config.Protocol,
-		"fileSystem":     volExternal.Config.FileSystem,
-		"accessInfo":     volExternal.Config.AccessInfo,
-		"accessMode":     volExternal.Config.AccessMode,
-		"encryption":     volExternal.Config.Encryption,
-		"exportPolicy":   volExternal.Config.ExportPolicy,
-		"snapshotPolicy": volExternal.Config.SnapshotPolicy,
-		"driverType":     driverType,
-	}).Debug("Driver processed volume import.")
+		if err != nil {
+			log.WithFields(log.Fields{
+				"backend": backend.Name,
+				"error":   err,
+			}).Debug("Could not refresh storage pool capacity.")
+			continue
+		}
 
-	// This function includes cleanup logic. Thus it must be called at the end of the ImportVolume func.
-	err = createPVandPVC(volExternal, driverType)
-	if err != nil {
-		log.Errorf("error occurred while creating PV and PVC %s: %v", volExternal.Config.Name, err)
-		return nil, err
+		// The reported capacity includes any volumes created since the last refresh,
+		// so their reservations are dropped here.
+		o.mutex.Lock()
+		for poolName, poolCapacity := range capacity {
+			if pool, ok := backend.Storage[poolName]; ok {
+				pool.Capacity = poolCapacity
+			}
+		}
+		o.mutex.Unlock()
 	}
-
-	return volExternal, nil
 }
 
-// addVolumeTransaction is called from the volume create, clone, and resize
-// methods to save a record of the operation in case it fails and must be
-// cleaned up later.
-func (o *TridentOrchestrator) addVolumeTransaction(volTxn *persistentstore.VolumeTransaction) error {
+// Events raised by the orchestrator's background tasks
+const (
+	eventSourceReconciler    = "reconciler"
+	eventSourceHealthMonitor = "healthMonitor"
+	eventSourceMigration     = "migration"
+
+	eventVolumeOrphaned   = "VolumeOrphaned"
+	eventVolumeRecovered  = "VolumeRecovered"
+	eventVolumeUnmanaged  = "UnmanagedVolumeFound"
+	eventVolumeMigrated   = "VolumeMigrated"
+	eventBackendFailed    = "BackendFailed"
+	eventBackendRecovered = "BackendRecovered"
+)
 
-	// Check if a transaction already exists for this volume. This condition
-	// can occur if we failed to clean up the transaction object during the
-	// last operation on the volume. The check for a preexisting transaction
-	// allows recovery from a failure by repeating the operation and without
-	// having to restart Trident. It's important to note that repeating a
-	// failed transaction may have side-effects on the new transaction (e.g.,
-	// repeating a failed volume delete before a volume resize or clone).
-	// Therefore, it's important that the implementation can take care of such
-	// scenarios. The current implementation allows only one outstanding
-	// transaction per volume.
-	// If a transaction is found, we failed in cleaning up the transaction
-	// earlier and we need to call the bootstrap cleanup code. If this fails,
-	// return an error. If no transaction existed or the operation succeeds,
-	// log a new transaction in the persistent store and proceed.
-	oldTxn, err := o.storeClient.GetExistingVolumeTransaction(volTxn)
-	if err != nil {
-		log.Warningf("Unable to check for a preexisting volume transaction: %v", err)
-		return err
-	}
-	if oldTxn != nil {
-		err = o.handleFailedTransaction(oldTxn)
-		if err != nil {
-			return fmt.Errorf("unable to process the preexisting transaction "+
-				"for volume %s:  %v", volTxn.Config.Name, err)
+// reconcileVolumesPeriodically checks the volumes known to the orchestrator against
+// the backends at the configured interval, until the stop channel is closed.
+func (o *TridentOrchestrator) reconcileVolumesPeriodically(stop <-chan struct{}) {
+	ticker := time.NewTicker(o.volumeReconcileInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// Reconciling may mark volumes orphaned, which only the leader may do
+			if !o.isStandby() {
+				o.reconcileVolumes()
+			}
 		}
-		if oldTxn.Op == persistentstore.DeleteVolume {
-			return fmt.Errorf("rejecting the %v transaction after successful completion of a preexisting %v transaction",
-				volTxn.Op, oldTxn.Op)
+	}
+}
+
+// reconcileVolumes checks that each volume in the cache still exists on its backend,
+// marking missing volumes as orphaned and reappearing ones as no longer orphaned, and
+// reports any volumes on a backend that carry its storage prefix but aren't managed by
+// Trident.  Nothing is ever deleted.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) reconcileVolumes() {
+
+	o.mutex.RLock()
+	backends := make([]*storage.Backend, 0, len(o.backends))
+	for _, backend := range o.backends {
+		if backend.Online && backend.State.IsOnline() {
+			backends = append(backends, backend)
 		}
 	}
+	o.mutex.RUnlock()
 
-	return o.storeClient.AddVolumeTransaction(volTxn)
+	for _, backend := range backends {
+		o.reconcileBackendVolumes(backend)
+	}
 }
 
-// deleteVolumeTransaction deletes a volume transaction created by
-// addVolumeTransaction.
-func (o *TridentOrchestrator) deleteVolumeTransaction(volTxn *persistentstore.VolumeTransaction) error {
-	return o.storeClient.DeleteVolumeTransaction(volTxn)
-}
+// reconcileBackendVolumes reconciles the volumes of a single backend and returns the
+// internal names of the unmanaged volumes it found there.  The backend is only locked
+// shared while it is called, so its volumes may be created, deleted or renamed at the
+// same time; each volume whose orphaned state would change is checked again while no
+// other operation may run on it.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) reconcileBackendVolumes(backend *storage.Backend) []string {
 
-// addVolumeCleanup is used as a deferred method from the volume create/clone methods
-// to clean up in case anything goes wrong during the operation.
-func (o *TridentOrchestrator) addVolumeCleanup(
-	err error, backend *storage.Backend, vol *storage.Volume,
-	volTxn *persistentstore.VolumeTransaction, volumeConfig *storage.VolumeConfig) error {
+	o.mutex.RLock()
+	internalNames := make(map[string]string, len(backend.Volumes))
+	orphaned := make(map[string]bool, len(backend.Volumes))
+	for volName, vol := range backend.Volumes {
+		internalNames[volName] = vol.Config.InternalName
+		orphaned[volName] = vol.Orphaned
+	}
+	o.mutex.RUnlock()
 
-	var (
-		cleanupErr, txErr error
-	)
-	if err != nil {
-		// We failed somewhere.  There are two possible cases:
-		// 1.  We failed to allocate on a backend and fell through to the
-		//     end of the function.  In this case, we don't need to roll
-		//     anything back.
-		// 2.  We failed to add the volume to etcd.  In this case, we need
-		//     to remove the volume from the backend.
-		if backend != nil && vol != nil {
-			// We succeeded in adding the volume to the backend; now
-			// delete it.
-			cleanupErr = backend.RemoveVolume(vol)
-			if cleanupErr != nil {
-				cleanupErr = fmt.Errorf("unable to delete volume "+
-					"from backend during cleanup:  %v", cleanupErr)
-			}
-		}
+	if err := o.lockBackend("reconcileVolumes", backend); err != nil {
+		return nil
 	}
-	if cleanupErr == nil {
-		// Only clean up the volume transaction if we've succeeded at
-		// cleaning up on the backend or if we didn't need to do so in the
-		// first place.
-		txErr = o.deleteVolumeTransaction(volTxn)
-		if txErr != nil {
-			txErr = fmt.Errorf("unable to clean up transaction:  %v", txErr)
+
+	changed := make(map[string]string)
+	for volName, internalName := range internalNames {
+		if volumeExists := backend.Driver.Get(internalName) == nil; volumeExists == orphaned[volName] {
+			changed[volName] = internalName
 		}
 	}
-	if cleanupErr != nil || txErr != nil {
-		// Remove the volume from memory, if it's there, so that the user
-		// can try to re-add.  This will trigger recovery code.
-		delete(o.volumes, volumeConfig.Name)
 
-		// Report on all errors we encountered.
-		errList := make([]string, 0, 3)
-		for _, e := range []error{err, cleanupErr, txErr} {
-			if e != nil {
-				errList = append(errList, e.Error())
-			}
+	listed := true
+	backendVolumes := make([]string, 0)
+	channel := make(chan *storage.VolumeExternalWrapper)
+	go backend.Driver.GetVolumeExternalWrappers(channel)
+	for wrapper := range channel {
+		if wrapper.Error != nil {
+			log.WithFields(log.Fields{
+				"backend": backend.Name,
+				"error":   wrapper.Error,
+			}).Debug("Could not list volumes on backend.")
+			listed = false
+			continue
 		}
-		err = fmt.Errorf(strings.Join(errList, ", "))
-		log.Warnf("Unable to clean up artifacts of volume creation: %v. "+
-			"Repeat creating the volume or restart %v.",
-			err, config.OrchestratorName)
+		backendVolumes = append(backendVolumes, wrapper.Volume.Config.InternalName)
 	}
-	return err
-}
 
-func (o *TridentOrchestrator) importVolumeCleanup(
-	err error, volumeConfig *storage.VolumeConfig, originalName string, backend *storage.Backend, notManaged bool, volTxn *persistentstore.VolumeTransaction) error {
+	o.unlockBackend("reconcileVolumes", backend)
 
-	var (
-		cleanupErr, txErr error
-	)
-	if err != nil {
-		// We failed somewhere. Most likely we failed to rename the volume or retrieve its size.
-		if !notManaged {
-			cleanupErr = backend.Driver.Rename(volumeConfig.InternalName, originalName)
-			log.WithFields(log.Fields{
-				"InternalName": volumeConfig.InternalName,
-				"originalName": originalName,
-			}).Warn("importVolumeCleanup: renamed volume to originalName.")
-		}
+	// If the backend couldn't be listed, failures to find volumes may be due to the
+	// same problem, so don't risk marking healthy volumes as orphaned.
+	if !listed {
+		return nil
 	}
-	txErr = o.deleteVolumeTransaction(volTxn)
-	if txErr != nil {
-		txErr = fmt.Errorf("unable to clean up transaction:  %v", txErr)
+
+	for volName, internalName := range changed {
+		o.reconcileVolume(backend, volName, internalName)
 	}
 
-	if cleanupErr != nil || txErr != nil {
-		if !notManaged {
-			// Remove the volume from memory since import failed.
-			delete(o.volumes, volumeConfig.Name)
-			log.Debug("importVolumeCleanup: removed volume from memory")
-		}
+	// Save any events once the mutex lock has been released
+	defer o.flushEvents()
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 
-		// Report on all errors we encountered.
-		errList := make([]string, 0, 3)
-		for _, e := range []error{err, cleanupErr, txErr} {
-			if e != nil {
-				errList = append(errList, e.Error())
-			}
+	// The backend may have been updated or deleted since we released its lock
+	if current, ok := o.backends[backend.Name]; !ok || current != backend {
+		return nil
+	}
+
+	managed := make(map[string]bool, len(backend.Volumes))
+	for _, vol := range backend.Volumes {
+		managed[vol.Config.InternalName] = true
+	}
+
+	unmanaged := make([]string, 0)
+	prefix := backend.StoragePrefix()
+	for _, internalName := range backendVolumes {
+		if managed[internalName] || prefix == "" || !strings.HasPrefix(internalName, prefix) {
+			continue
 		}
-		err = fmt.Errorf(strings.Join(errList, ", "))
-		log.Warnf("Unable to clean up artifacts of volume import: %v. "+
-			"Repeat importing the volume %v.",
-			err, originalName)
+		unmanaged = append(unmanaged, internalName)
+		log.WithFields(log.Fields{
+			"internalName": internalName,
+			"backend":      backend.Name,
+		}).Warning("Found a volume on the backend that isn't managed by Trident.")
+		o.recordEvent(storage.NewEvent(eventVolumeUnmanaged, storage.EventObjectVolume, internalName,
+			eventSourceReconciler, time.Now(), nil))
 	}
 
-	return err
+	return unmanaged
 }
 
-func (o *TridentOrchestrator) GetVolume(volume string) (*storage.VolumeExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+// reconcileVolume checks again whether a volume exists on its backend, holding the
+// volume's lock so that no other operation can create, delete or rename it meanwhile,
+// and marks it orphaned or no longer orphaned accordingly.  The caller must not hold
+// the volume, backend or mutex locks.
+func (o *TridentOrchestrator) reconcileVolume(backend *storage.Backend, volName, internalName string) {
+
+	utils.Lock("reconcileVolumes", volumeLockID(volName))
+	defer utils.Unlock("reconcileVolumes", volumeLockID(volName))
+
+	if err := o.lockBackend("reconcileVolumes", backend); err != nil {
+		return
 	}
+	volumeExists := backend.Driver.Get(internalName) == nil
+	o.unlockBackend("reconcileVolumes", backend)
 
+	// Save any events once the mutex lock has been released
+	defer o.flushEvents()
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	vol, found := o.volumes[volume]
-	if !found {
-		return nil, notFoundError(fmt.Sprintf("volume %v was not found", volume))
+	if current, ok := o.backends[backend.Name]; !ok || current != backend {
+		return
+	}
+	vol, ok := backend.Volumes[volName]
+	if !ok || vol.Config.InternalName != internalName || volumeExists != vol.Orphaned {
+		return
 	}
-	return vol.ConstructExternal(), nil
-}
 
-func (o *TridentOrchestrator) GetDriverTypeForVolume(vol *storage.VolumeExternal) (string, error) {
-	if o.bootstrapError != nil {
-		return config.UnknownDriver, o.bootstrapError
+	start := time.Now()
+	vol.Orphaned = !volumeExists
+	fields := log.Fields{
+		"volume":       volName,
+		"internalName": vol.Config.InternalName,
+		"backend":      backend.Name,
+	}
+	event := eventVolumeRecovered
+	if vol.Orphaned {
+		event = eventVolumeOrphaned
+		log.WithFields(fields).Warning("Volume no longer exists on its backend; marked as orphaned.")
+	} else {
+		log.WithFields(fields).Info("Volume exists on its backend again; no longer orphaned.")
+	}
+	err := o.updateVolumeOnPersistentStore(vol)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volume": volName,
+			"error":  err,
+		}).Error("Could not persist the orphaned state of a volume.")
 	}
+	o.recordEvent(storage.NewEvent(event, storage.EventObjectVolume, volName, eventSourceReconciler, start, err))
+}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+// backendHealth tracks the results of checking a backend's storage system.
+type backendHealth struct {
+	failures  int       // consecutive failed probes or recovery attempts
+	nextCheck time.Time // the backend isn't checked again before this time
+}
 
-	return o.getDriverTypeForVolume(vol.Backend)
+// backendRetryInterval returns how long to wait before checking a backend again after
+// the specified number of consecutive failures.  The normal interval is doubled after
+// each failure, up to a limit.
+func backendRetryInterval(failures int) time.Duration {
+	interval := config.BackendHealthCheckInterval
+	for i := 1; i < failures && interval < config.BackendHealthCheckMaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > config.BackendHealthCheckMaxInterval {
+		interval = config.BackendHealthCheckMaxInterval
+	}
+	return interval
 }
 
-// getDriverTypeForVolume does the necessary work to get the driver type.  It does
-// not construct a transaction, nor does it take locks; it assumes that the
-// caller will take care of both of these.  It also assumes that the backend
-// exists in memory.
-func (o *TridentOrchestrator) getDriverTypeForVolume(backendName string) (string, error) {
-	if b, ok := o.backends[backendName]; ok {
-		return b.Driver.Name(), nil
+// monitorBackendHealth checks the health of all backends at a fixed interval, until
+// the stop channel is closed.
+func (o *TridentOrchestrator) monitorBackendHealth(stop <-chan struct{}) {
+	ticker := time.NewTicker(config.BackendHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			// Only the leader fails and recovers backends
+			if !o.isStandby() {
+				o.checkBackendHealth(now)
+			}
+		}
 	}
-	return config.UnknownDriver, nil
 }
 
-func (o *TridentOrchestrator) GetVolumeType(vol *storage.VolumeExternal) (
-	volumeType config.VolumeType, err error,
-) {
-	if o.bootstrapError != nil {
-		return config.UnknownVolumeType, o.bootstrapError
+// checkBackendHealth probes each online backend that is due for a check, and tries
+// to reinitialize each failed backend that is due for a retry.  The caller must not
+// hold the mutex lock.
+func (o *TridentOrchestrator) checkBackendHealth(now time.Time) {
+
+	o.mutex.RLock()
+	states := make(map[*storage.Backend]storage.BackendState, len(o.backends))
+	monitorFailed := make(map[*storage.Backend]bool, len(o.backends))
+	for _, backend := range o.backends {
+		states[backend] = backend.State
+		monitorFailed[backend] = backend.MonitorFailed
 	}
+	o.mutex.RUnlock()
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	checked := make(map[string]bool, len(states))
+	for backend, state := range states {
+		checked[backend.Name] = true
 
-	// Since the caller has a valid VolumeExternal and we're disallowing
-	// backend deletion, we can assume that this will not hit a nil pointer.
-	driver := o.backends[vol.Backend].GetDriverName()
-	switch {
-	case driver == drivers.OntapNASStorageDriverName:
-		volumeType = config.OntapNFS
-	case driver == drivers.OntapNASQtreeStorageDriverName:
-		volumeType = config.OntapNFS
-	case driver == drivers.OntapNASFlexGroupStorageDriverName:
-		volumeType = config.OntapNFS
-	case driver == drivers.OntapSANStorageDriverName:
-		volumeType = config.OntapISCSI
-	case driver == drivers.SolidfireSANStorageDriverName:
-		volumeType = config.SolidFireISCSI
-	case driver == drivers.EseriesIscsiStorageDriverName:
-		volumeType = config.ESeriesISCSI
-	default:
-		volumeType = config.UnknownVolumeType
+		health, ok := o.backendHealth[backend.Name]
+		if !ok {
+			health = &backendHealth{}
+			o.backendHealth[backend.Name] = health
+		}
+		if now.Before(health.nextCheck) {
+			continue
+		}
+
+		if state.IsOnline() {
+			o.probeBackend(backend, health, now)
+		} else if state.IsFailed() && monitorFailed[backend] {
+			// Only recover backends the monitor failed; an administrator's decision
+			// or a failed initialization stays in place until the backend is updated.
+			o.recoverBackend(backend, health, now)
+		}
 	}
 
-	return
+	// Forget any backends that were deleted
+	for backendName := range o.backendHealth {
+		if !checked[backendName] {
+			delete(o.backendHealth, backendName)
+		}
+	}
 }
 
-func (o *TridentOrchestrator) ListVolumes() ([]*storage.VolumeExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+// probeBackend checks that an online backend can still reach its storage system, and
+// fails the backend once too many consecutive probes have failed.  The probe holds the
+// backend lock shared, like a volume operation, and the lock is only taken exclusively
+// to fail the backend.  The caller must not hold the mutex lock.
+func (o *TridentOrchestrator) probeBackend(backend *storage.Backend, health *backendHealth, now time.Time) {
+
+	if err := o.lockBackend("probeBackend", backend); err != nil {
+		return
 	}
+	err := backend.CheckHealth()
+	o.unlockBackend("probeBackend", backend)
 
+	if err == nil {
+		health.failures = 0
+		health.nextCheck = now.Add(config.BackendHealthCheckInterval)
+		return
+	}
+
+	health.failures++
+	health.nextCheck = now.Add(backendRetryInterval(health.failures))
+	log.WithFields(log.Fields{
+		"backend":  backend.Name,
+		"failures": health.failures,
+		"error":    err,
+	}).Warning("Backend health check failed.")
+
+	if health.failures < config.BackendHealthCheckFailureThreshold {
+		return
+	}
+
+	if lockErr := o.lockBackendExclusive("probeBackend", backend); lockErr != nil {
+		return
+	}
+	defer o.unlockBackendExclusive("probeBackend", backend)
+
+	// Save the event once the mutex lock has been released
+	defer o.flushEvents()
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	volumes := make([]*storage.VolumeExternal, 0, len(o.volumes))
-	for _, v := range o.volumes {
-		volumes = append(volumes, v.ConstructExternal())
+	if !backend.State.IsOnline() {
+		return
 	}
-	return volumes, nil
+	start := time.Now()
+	if _, err = o.updateBackendState(backend.Name, string(storage.Failed), true); err != nil {
+		log.WithFields(log.Fields{
+			"backend": backend.Name,
+			"error":   err,
+		}).Error("Could not persist the failed state of a backend.")
+	}
+	log.WithField("backend", backend.Name).Warning(
+		"Backend failed its health checks; no volumes will be placed on it until it recovers.")
+	o.recordEvent(storage.NewEvent(eventBackendFailed, storage.EventObjectBackend, backend.Name,
+		eventSourceHealthMonitor, start, err))
 }
 
-// deleteVolume does the necessary work to delete a volume entirely.  It does
-// not construct a transaction, nor does it take locks; it assumes that the
-// caller will take care of both of these.  It also assumes that the volume
-// exists in memory.
-func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
-	volume := o.volumes[volumeName]
-	volumeBackend := o.backends[volume.Backend]
+// recoverBackend tries to reinitialize a failed backend from its persisted config,
+// replacing the failed backend if that succeeds.  The caller must not hold the mutex
+// lock.
+func (o *TridentOrchestrator) recoverBackend(backend *storage.Backend, health *backendHealth, now time.Time) {
 
-	// Note that this call will only return an error if the backend actually
-	// fails to delete the volume.  If the volume does not exist on the backend,
-	// the driver will not return an error.  Thus, we're fine.
-	if err := volumeBackend.RemoveVolume(volume); err != nil {
-		log.WithFields(log.Fields{
-			"volume":  volumeName,
-			"backend": volume.Backend,
-			"error":   err,
-		}).Error("Unable to delete volume from backend.")
+	start := time.Now()
+	err := func() error {
+		o.mutex.RLock()
+		persistentBackend, err := o.storeClient.GetBackend(backend.Name)
+		o.mutex.RUnlock()
+		if err != nil {
+			return err
+		}
+		configJSON, err := persistentBackend.MarshalConfig()
+		if err != nil {
+			return err
+		}
+
+		// Initializing the backend contacts the storage system, so do that before
+		// taking any locks.
+		newBackend, err := factory.NewStorageBackendForConfig(configJSON)
+		if err != nil {
+			if newBackend != nil {
+				newBackend.Terminate()
+			}
+			return err
+		}
+
+		if err = o.lockBackendExclusive("recoverBackend", backend); err != nil {
+			newBackend.Terminate()
+			return err
+		}
+		defer o.unlockBackendExclusive("recoverBackend", backend)
+
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		if !backend.State.IsFailed() || !backend.MonitorFailed {
+			newBackend.Terminate()
+			return fmt.Errorf("backend %s is no longer failed by the health monitor", backend.Name)
+		}
+		_, err = o.replaceBackend(backend.Name, newBackend)
 		return err
-	}
-	// Ignore failures to find the volume being deleted, as this may be called
-	// during recovery of a volume that has already been deleted from etcd.
-	// During normal operation, checks on whether the volume is present in the
-	// volume map should suffice to prevent deletion of non-existent volumes.
-	if err := o.storeClient.DeleteVolumeIgnoreNotFound(volume); err != nil {
+	}()
+
+	if err != nil {
+		health.failures++
+		health.nextCheck = now.Add(backendRetryInterval(health.failures))
 		log.WithFields(log.Fields{
-			"volume": volumeName,
-		}).Error("Unable to delete volume from persistent store.")
-		return err
+			"backend":  backend.Name,
+			"failures": health.failures,
+			"error":    err,
+		}).Debug("Failed backend could not be reinitialized.")
+		return
 	}
-	if volumeBackend.State.IsDeleting() && !volumeBackend.HasVolumes() {
-		if err := o.storeClient.DeleteBackend(volumeBackend); err != nil {
-			log.WithFields(log.Fields{
-				"backend": volume.Backend,
-				"volume":  volumeName,
-			}).Error("Unable to delete offline backend from the backing store" +
-				" after its last volume was deleted.  Delete the volume again" +
-				" to remove the backend.")
-			return err
-		}
-		volumeBackend.Terminate()
-		delete(o.backends, volume.Backend)
+
+	health.failures = 0
+	health.nextCheck = now.Add(config.BackendHealthCheckInterval)
+	log.WithField("backend", backend.Name).Info("Failed backend recovered.")
+	o.RecordEvent(storage.NewEvent(eventBackendRecovered, storage.EventObjectBackend, backend.Name,
+		eventSourceHealthMonitor, start, nil))
+}
+
+// addVolumeToStoreAndCache records a volume that was just created on a
+// backend.  It takes the mutex lock, so the caller must not hold it.
+func (o *TridentOrchestrator) addVolumeToStoreAndCache(backend *storage.Backend, vol *storage.Volume) error {
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err := o.storeClient.AddVolume(vol); err != nil {
+		return err
 	}
-	delete(o.volumes, volumeName)
+	o.volumes[vol.Config.Name] = vol
+	backend.Volumes[vol.Config.Name] = vol
 	return nil
 }
 
-// DeleteVolume does the necessary set up to delete a volume during the course
-// of normal operation, verifying that the volume is present in Trident and
-// creating a transaction to ensure that the delete eventually completes.
-func (o *TridentOrchestrator) DeleteVolume(volumeName string) (err error) {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+// This func is used by volume import so it doesn't check core's o.volumes to see if the
+// volume exists or not. Instead it asks the driver if the volume exists before requesting
+// the volume size. Returns the VolumeExternal representation of the volume.
+func (o *TridentOrchestrator) GetVolumeExternal(volumeName string, backendName string) (*storage.VolumeExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	log.WithFields(log.Fields{
+		"originalName": volumeName,
+		"backendName":  backendName,
+	}).Debug("Orchestrator#GetVolumeExternal")
 
-	volume, ok := o.volumes[volumeName]
+	o.mutex.RLock()
+	backend, ok := o.backends[backendName]
+	o.mutex.RUnlock()
 	if !ok {
-		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		return nil, notFoundError(fmt.Sprintf("backend %s not found", backendName))
 	}
-	if volume.Orphaned {
-		log.WithFields(log.Fields{
-			"volume":  volumeName,
-			"backend": volume.Backend,
-		}).Warnf("Delete operation is likely to fail with an orphaned volume!")
+
+	if err := o.lockBackend("GetVolumeExternal", backend); err != nil {
+		return nil, err
 	}
+	defer o.unlockBackend("GetVolumeExternal", backend)
 
-	volTxn := &persistentstore.VolumeTransaction{
-		Config: volume.Config,
-		Op:     persistentstore.DeleteVolume,
+	volExternal, err := backend.GetVolumeExternal(volumeName)
+	if err != nil {
+		return nil, err
 	}
-	if err := o.addVolumeTransaction(volTxn); err != nil {
-		return err
+
+	return volExternal, nil
+}
+
+// ListUnmanagedVolumes returns the names of the volumes on a backend that Trident does not
+// manage and whose names match the pattern, which uses shell file name pattern syntax.  An
+// empty pattern matches every volume.  The volumes are candidates for import.
+func (o *TridentOrchestrator) ListUnmanagedVolumes(backendName, pattern string) ([]string, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
 
-	defer func() {
-		errTxn := o.deleteVolumeTransaction(volTxn)
-		if errTxn != nil {
-			log.WithFields(log.Fields{
-				"volume":    volume,
-				"error":     errTxn,
-				"operation": volTxn.Op,
-			}).Warnf("Unable to delete volume transaction. Repeat deletion using %s or restart %v.",
-				config.OrchestratorClientName, config.OrchestratorName)
-		}
-		if err != nil || errTxn != nil {
-			errList := make([]string, 0, 2)
-			for _, e := range []error{err, errTxn} {
-				if e != nil {
-					errList = append(errList, e.Error())
-				}
-			}
-			err = fmt.Errorf(strings.Join(errList, ", "))
-		}
-	}()
+	if pattern == "" {
+		pattern = "*"
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid volume name pattern %s: %v", pattern, err)
+	}
 
-	// Delete the volume
-	return o.deleteVolume(volumeName)
-}
+	o.mutex.RLock()
+	backend, ok := o.backends[backendName]
+	o.mutex.RUnlock()
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("backend %s not found", backendName))
+	}
 
-func (o *TridentOrchestrator) ListVolumesByPlugin(pluginName string) ([]*storage.VolumeExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+	if err := o.lockBackend("ListUnmanagedVolumes", backend); err != nil {
+		return nil, err
 	}
+	defer o.unlockBackend("ListUnmanagedVolumes", backend)
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	names, err := backend.ListVolumes()
+	if err != nil {
+		return nil, err
+	}
 
-	volumes := make([]*storage.VolumeExternal, 0)
-	for _, backend := range o.backends {
-		if backendName := backend.GetDriverName(); pluginName != backendName {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	managed := make(map[string]bool)
+	for _, volume := range o.volumes {
+		if volume.Backend == backendName {
+			managed[volume.Config.InternalName] = true
+		}
+	}
+
+	unmanaged := make([]string, 0)
+	for _, name := range names {
+		if managed[name] {
 			continue
 		}
-		for _, vol := range backend.Volumes {
-			volumes = append(volumes, vol.ConstructExternal())
+		if matched, _ := path.Match(pattern, name); matched {
+			unmanaged = append(unmanaged, name)
 		}
 	}
-	return volumes, nil
+	sort.Strings(unmanaged)
+	return unmanaged, nil
 }
 
-func (o *TridentOrchestrator) PublishVolume(
-	volumeName string, publishInfo *utils.VolumePublishInfo,
-) error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+func (o *TridentOrchestrator) ImportVolume(
+	volumeConfig *storage.VolumeConfig, originalName string, backendName string, notManaged bool, createPVandPVC Operation,
+) (externalVol *storage.VolumeExternal, err error) {
+
+	if err := o.writeError(); err != nil {
+		return nil, err
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	log.WithFields(log.Fields{
+		"volumeConfig": volumeConfig,
+		"originalName": originalName,
+		"backendName":  backendName,
+	}).Debug("Orchestrator#ImportVolume")
 
-	volume, ok := o.volumes[volumeName]
+	utils.Lock("ImportVolume", volumeLockID(volumeConfig.Name))
+	defer utils.Unlock("ImportVolume", volumeLockID(volumeConfig.Name))
+	defer o.releaseQuota(volumeConfig.Name)
+
+	o.mutex.RLock()
+	backend, ok := o.backends[backendName]
+	o.mutex.RUnlock()
 	if !ok {
-		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		return nil, notFoundError(fmt.Sprintf("backend %s not found", backendName))
 	}
 
-	return o.backends[volume.Backend].Driver.Publish(volume.Config.InternalName, publishInfo)
-}
+	if err = o.lockBackend("ImportVolume", backend); err != nil {
+		return nil, err
+	}
+	defer o.unlockBackend("ImportVolume", backend)
 
-// AttachVolume mounts a volume to the local host.  This method is currently only used by Docker,
-// and it should be able to accomplish its task using only the data passed in; it should not need to
-// use the storage controller API.  It may be assumed that this method always runs on the host to
-// which the volume will be attached.
-func (o *TridentOrchestrator) AttachVolume(
-	volumeName, mountpoint string, publishInfo *utils.VolumePublishInfo,
-) error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+	// Validate the request against the cached state
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		for volumeName, volume := range o.volumes {
+			if volume.Config.InternalName == originalName && volume.Backend == backendName {
+				return foundError(fmt.Sprintf("PV %s already exists for volume %s",
+					originalName, volumeName))
+			}
+		}
+
+		sc, ok := o.storageClasses[volumeConfig.StorageClass]
+		if !ok {
+			return fmt.Errorf("unknown storage class: %s", volumeConfig.StorageClass)
+		}
+
+		if !sc.IsAddedToBackend(backend, volumeConfig.StorageClass) {
+			return fmt.Errorf("storageClass %s is not added to backend %s", volumeConfig.StorageClass, backendName)
+		}
+
+		// Unmanaged volumes aren't tracked, so they don't count against any quota
+		if !notManaged {
+			return o.reserveQuota(volumeConfig)
+		}
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	if backend.Driver.Get(originalName) != nil {
+		return nil, notFoundError(fmt.Sprintf("volume %s was not found", originalName))
+	}
+
+	// Check that the specified protocol and access mode are compatible
+	_, err = o.getProtocol(volumeConfig.AccessMode, volumeConfig.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the protocol
+	if len(volumeConfig.Protocol) == 0 {
+		volumeConfig.Protocol = backend.GetProtocol()
+	} else {
+		backendProtocol := backend.GetProtocol()
+		if volumeConfig.Protocol != backendProtocol {
+			return nil, fmt.Errorf("requested protocol %s does not match backend protocol %s", volumeConfig.Protocol, backendProtocol)
+		}
 	}
 
+	// Add transaction in case operation must be rolled back
+	volTxn := &persistentstore.VolumeTransaction{
+		Config: volumeConfig,
+		Op:     persistentstore.ImportVolume,
+	}
 	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	err = o.addVolumeTransaction(volTxn)
+	o.mutex.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add volume transaction: %v", err)
+	}
 
-	if _, ok := o.volumes[volumeName]; !ok {
-		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	// Recover function in case or error
+	defer func() {
+		err = o.importVolumeCleanup(err, volumeConfig, originalName, backend, notManaged, volTxn)
+	}()
+
+	volume, err := backend.ImportVolume(volumeConfig, originalName, notManaged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import volume %s on backend %s: %v", originalName, backendName, err)
 	}
 
-	log.WithFields(log.Fields{"volume": volumeName, "mountpoint": mountpoint}).Debug("Mounting volume.")
+	if !notManaged {
+		// The volume's size is only known once the backend has found it, so check
+		// the quotas again before persisting it.
+		o.mutex.Lock()
+		err = o.reserveQuota(volume.Config)
+		o.mutex.Unlock()
+		if err != nil {
+			return nil, err
+		}
 
-	// Ensure mount point exists and is a directory
-	fileInfo, err := os.Lstat(mountpoint)
-	if os.IsNotExist(err) {
-		// Make mount point if it doesn't exist
-		if err := os.MkdirAll(mountpoint, 0755); err != nil {
-			return err
+		// The volume is managed and is persisted.
+		if err = o.addVolumeToStoreAndCache(backend, volume); err != nil {
+			return nil, fmt.Errorf("failed to persist imported volume data: %v", err)
 		}
-	} else if err != nil {
-		return err
-	} else if !fileInfo.IsDir() {
-		return fmt.Errorf("%v already exists and it's not a directory", mountpoint)
 	}
 
-	// Check if volume is already mounted
-	dfOutput, dfOuputErr := utils.GetDFOutput()
-	if dfOuputErr != nil {
+	volExternal := volume.ConstructExternal()
+	driverType := backend.Driver.Name()
+
+	log.WithFields(log.Fields{
+		"backend":        volExternal.Backend,
+		"name":           volExternal.Config.Name,
+		"internalName":   volExternal.Config.InternalName,
+		"size":           volExternal.Config.Size,
+		"protocol":       volExternal.Config.Protocol,
+		"fileSystem":     volExternal.Config.FileSystem,
+		"accessInfo":     volExternal.Config.AccessInfo,
+		"accessMode":     volExternal.Config.AccessMode,
+		"encryption":     volExternal.Config.Encryption,
+		"exportPolicy":   volExternal.Config.ExportPolicy,
+		"snapshotPolicy": volExternal.Config.SnapshotPolicy,
+		"driverType":     driverType,
+	}).Debug("Driver processed volume import.")
+
+	// This function includes cleanup logic. Thus it must be called at the end of the ImportVolume func.
+	err = createPVandPVC(volExternal, driverType)
+	if err != nil {
+		log.Errorf("error occurred while creating PV and PVC %s: %v", volExternal.Config.Name, err)
+		return nil, err
+	}
+
+	return volExternal, nil
+}
+
+// addVolumeTransaction is called from the volume create, clone, and resize
+// methods to save a record of the operation in case it fails and must be
+// cleaned up later.
+func (o *TridentOrchestrator) addVolumeTransaction(volTxn *persistentstore.VolumeTransaction) error {
+
+	// Check if a transaction already exists for this volume. This condition
+	// can occur if we failed to clean up the transaction object during the
+	// last operation on the volume. The check for a preexisting transaction
+	// allows recovery from a failure by repeating the operation and without
+	// having to restart Trident. It's important to note that repeating a
+	// failed transaction may have side-effects on the new transaction (e.g.,
+	// repeating a failed volume delete before a volume resize or clone).
+	// Therefore, it's important that the implementation can take care of such
+	// scenarios. The current implementation allows only one outstanding
+	// transaction per volume.
+	// If a transaction is found, we failed in cleaning up the transaction
+	// earlier and we need to call the bootstrap cleanup code. If this fails,
+	// return an error. If no transaction existed or the operation succeeds,
+	// log a new transaction in the persistent store and proceed.
+	oldTxn, err := o.storeClient.GetExistingVolumeTransaction(volTxn)
+	if err != nil {
+		log.Warningf("Unable to check for a preexisting volume transaction: %v", err)
+		return err
+	}
+	if oldTxn != nil {
+		err = o.handleFailedTransaction(oldTxn)
+		if err != nil {
+			return fmt.Errorf("unable to process the preexisting transaction "+
+				"for %s:  %v", volTxn.Name(), err)
+		}
+		if oldTxn.Op == persistentstore.DeleteVolume || oldTxn.Op == persistentstore.DeleteSnapshot {
+			return fmt.Errorf("rejecting the %v transaction after successful completion of a preexisting %v transaction",
+				volTxn.Op, oldTxn.Op)
+		}
+	}
+
+	return o.storeClient.AddVolumeTransaction(volTxn)
+}
+
+// deleteVolumeTransaction deletes a volume transaction created by
+// addVolumeTransaction.
+func (o *TridentOrchestrator) deleteVolumeTransaction(volTxn *persistentstore.VolumeTransaction) error {
+	return o.storeClient.DeleteVolumeTransaction(volTxn)
+}
+
+// addVolumeCleanup is used as a deferred method from the volume create/clone methods
+// to clean up in case anything goes wrong during the operation.  It takes the mutex
+// lock as needed, so the caller must hold the backend lock but not the mutex.
+func (o *TridentOrchestrator) addVolumeCleanup(
+	err error, backend *storage.Backend, vol *storage.Volume,
+	volTxn *persistentstore.VolumeTransaction, volumeConfig *storage.VolumeConfig) error {
+
+	var (
+		cleanupErr, txErr error
+	)
+	if err != nil {
+		// We failed somewhere.  There are two possible cases:
+		// 1.  We failed to allocate on a backend and fell through to the
+		//     end of the function.  In this case, we don't need to roll
+		//     anything back.
+		// 2.  We failed to add the volume to etcd.  In this case, we need
+		//     to remove the volume from the backend.
+		if backend != nil && vol != nil {
+			// We succeeded in adding the volume to the backend; now
+			// delete it.
+			cleanupErr = backend.RemoveVolume(vol)
+			if cleanupErr != nil {
+				cleanupErr = fmt.Errorf("unable to delete volume "+
+					"from backend during cleanup:  %v", cleanupErr)
+			}
+		}
+	}
+	if cleanupErr == nil {
+		// Only clean up the volume transaction if we've succeeded at
+		// cleaning up on the backend or if we didn't need to do so in the
+		// first place.
+		txErr = o.deleteVolumeTransaction(volTxn)
+		if txErr != nil {
+			txErr = fmt.Errorf("unable to clean up transaction:  %v", txErr)
+		}
+	}
+	if cleanupErr != nil || txErr != nil {
+		// Remove the volume from memory, if it's there, so that the user
+		// can try to re-add.  This will trigger recovery code.
+		o.mutex.Lock()
+		delete(o.volumes, volumeConfig.Name)
+		if backend != nil {
+			delete(backend.Volumes, volumeConfig.Name)
+		}
+		o.mutex.Unlock()
+
+		// Report on all errors we encountered.
+		errList := make([]string, 0, 3)
+		for _, e := range []error{err, cleanupErr, txErr} {
+			if e != nil {
+				errList = append(errList, e.Error())
+			}
+		}
+		err = fmt.Errorf(strings.Join(errList, ", "))
+		log.Warnf("Unable to clean up artifacts of volume creation: %v. "+
+			"Repeat creating the volume or restart %v.",
+			err, config.OrchestratorName)
+	}
+	return err
+}
+
+func (o *TridentOrchestrator) importVolumeCleanup(
+	err error, volumeConfig *storage.VolumeConfig, originalName string, backend *storage.Backend, notManaged bool, volTxn *persistentstore.VolumeTransaction) error {
+
+	var (
+		cleanupErr, txErr error
+	)
+	if err != nil {
+		// We failed somewhere. Most likely we failed to rename the volume or retrieve its size.
+		if !notManaged {
+			cleanupErr = backend.Driver.Rename(volumeConfig.InternalName, originalName)
+			log.WithFields(log.Fields{
+				"InternalName": volumeConfig.InternalName,
+				"originalName": originalName,
+			}).Warn("importVolumeCleanup: renamed volume to originalName.")
+		}
+	}
+	txErr = o.deleteVolumeTransaction(volTxn)
+	if txErr != nil {
+		txErr = fmt.Errorf("unable to clean up transaction:  %v", txErr)
+	}
+
+	if cleanupErr != nil || txErr != nil {
+		if !notManaged {
+			// Remove the volume from memory since import failed.
+			o.mutex.Lock()
+			delete(o.volumes, volumeConfig.Name)
+			delete(backend.Volumes, volumeConfig.Name)
+			o.mutex.Unlock()
+			log.Debug("importVolumeCleanup: removed volume from memory")
+		}
+
+		// Report on all errors we encountered.
+		errList := make([]string, 0, 3)
+		for _, e := range []error{err, cleanupErr, txErr} {
+			if e != nil {
+				errList = append(errList, e.Error())
+			}
+		}
+		err = fmt.Errorf(strings.Join(errList, ", "))
+		log.Warnf("Unable to clean up artifacts of volume import: %v. "+
+			"Repeat importing the volume %v.",
+			err, originalName)
+	}
+
+	return err
+}
+
+func (o *TridentOrchestrator) GetVolume(volume string) (*storage.VolumeExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	vol, found := o.volumes[volume]
+	if !found {
+		return nil, notFoundError(fmt.Sprintf("volume %v was not found", volume))
+	}
+	return vol.ConstructExternal(), nil
+}
+
+func (o *TridentOrchestrator) GetDriverTypeForVolume(vol *storage.VolumeExternal) (string, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return config.UnknownDriver, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	return o.getDriverTypeForVolume(vol.Backend)
+}
+
+// getDriverTypeForVolume does the necessary work to get the driver type.  It does
+// not construct a transaction, nor does it take locks; it assumes that the
+// caller will take care of both of these.  It also assumes that the backend
+// exists in memory.
+func (o *TridentOrchestrator) getDriverTypeForVolume(backendName string) (string, error) {
+	if b, ok := o.backends[backendName]; ok {
+		return b.Driver.Name(), nil
+	}
+	return config.UnknownDriver, nil
+}
+
+func (o *TridentOrchestrator) GetVolumeType(vol *storage.VolumeExternal) (
+	volumeType config.VolumeType, err error,
+) {
+	if err := o.getBootstrapError(); err != nil {
+		return config.UnknownVolumeType, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	// Since the caller has a valid VolumeExternal and we're disallowing
+	// backend deletion, we can assume that this will not hit a nil pointer.
+	driver := o.backends[vol.Backend].GetDriverName()
+	switch {
+	case driver == drivers.OntapNASStorageDriverName:
+		volumeType = config.OntapNFS
+	case driver == drivers.OntapNASQtreeStorageDriverName:
+		volumeType = config.OntapNFS
+	case driver == drivers.OntapNASFlexGroupStorageDriverName:
+		volumeType = config.OntapNFS
+	case driver == drivers.OntapSANStorageDriverName:
+		volumeType = config.OntapISCSI
+	case driver == drivers.SolidfireSANStorageDriverName:
+		volumeType = config.SolidFireISCSI
+	case driver == drivers.EseriesIscsiStorageDriverName:
+		volumeType = config.ESeriesISCSI
+	default:
+		volumeType = config.UnknownVolumeType
+	}
+
+	return
+}
+
+func (o *TridentOrchestrator) ListVolumes() ([]*storage.VolumeExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	volumes := make([]*storage.VolumeExternal, 0, len(o.volumes))
+	for _, v := range o.volumes {
+		volumes = append(volumes, v.ConstructExternal())
+	}
+	return volumes, nil
+}
+
+// deleteVolume does the necessary work to delete a volume entirely.  It does
+// not construct a transaction, nor does it take locks; it assumes that the
+// caller will take care of both of these.  It also assumes that the volume
+// exists in memory.
+func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
+	volume := o.volumes[volumeName]
+	volumeBackend := o.backends[volume.Backend]
+
+	if err := o.deleteVolumeFromBackend(volumeBackend, volume); err != nil {
+		return err
+	}
+	return o.deleteVolumeFromStoreAndCache(volumeBackend, volume)
+}
+
+// deleteVolumeFromBackend removes a volume from its backend.  It assumes that
+// the caller holds either the backend lock or the mutex lock.
+func (o *TridentOrchestrator) deleteVolumeFromBackend(volumeBackend *storage.Backend, volume *storage.Volume) error {
+
+	// Note that this call will only return an error if the backend actually
+	// fails to delete the volume.  If the volume does not exist on the backend,
+	// the driver will not return an error.  Thus, we're fine.
+	if err := volumeBackend.RemoveVolume(volume); err != nil {
+		log.WithFields(log.Fields{
+			"volume":  volume.Config.Name,
+			"backend": volume.Backend,
+			"error":   err,
+		}).Error("Unable to delete volume from backend.")
+		return err
+	}
+	return nil
+}
+
+// deleteVolumeFromStoreAndCache removes all record of a volume once it has
+// been deleted from its backend.  It assumes that the mutex lock is held.
+func (o *TridentOrchestrator) deleteVolumeFromStoreAndCache(volumeBackend *storage.Backend, volume *storage.Volume) error {
+
+	volumeName := volume.Config.Name
+
+	// Ignore failures to find the volume being deleted, as this may be called
+	// during recovery of a volume that has already been deleted from etcd.
+	// During normal operation, checks on whether the volume is present in the
+	// volume map should suffice to prevent deletion of non-existent volumes.
+	// Deleting a volume on the backend also deletes its snapshots, so remove
+	// any snapshot records for the volume before removing the volume itself.
+	for _, snapshot := range o.snapshots {
+		if snapshot.Config.VolumeName != volumeName {
+			continue
+		}
+		if err := o.storeClient.DeleteSnapshotIgnoreNotFound(snapshot); err != nil {
+			log.WithFields(log.Fields{
+				"volume":   volumeName,
+				"snapshot": snapshot.Config.Name,
+			}).Error("Unable to delete snapshot from persistent store.")
+			return err
+		}
+		delete(o.snapshots, snapshot.ID())
+	}
+	for _, publication := range o.publications {
+		if publication.VolumeName != volumeName {
+			continue
+		}
+		err := o.storeClient.DeleteVolumePublication(publication)
+		if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+			log.WithFields(log.Fields{
+				"volume": volumeName,
+				"node":   publication.NodeName,
+			}).Error("Unable to delete volume publication from persistent store.")
+			return err
+		}
+		delete(o.publications, publication.ID())
+	}
+	if err := o.storeClient.DeleteVolumeIgnoreNotFound(volume); err != nil {
+		log.WithFields(log.Fields{
+			"volume": volumeName,
+		}).Error("Unable to delete volume from persistent store.")
+		return err
+	}
+	delete(volumeBackend.Volumes, volumeName)
+	if volumeBackend.State.IsDeleting() && !volumeBackend.HasVolumes() {
+		if err := o.storeClient.DeleteBackend(volumeBackend); err != nil {
+			log.WithFields(log.Fields{
+				"backend": volume.Backend,
+				"volume":  volumeName,
+			}).Error("Unable to delete offline backend from the backing store" +
+				" after its last volume was deleted.  Delete the volume again" +
+				" to remove the backend.")
+			return err
+		}
+		volumeBackend.Terminate()
+		delete(o.backends, volume.Backend)
+	}
+	delete(o.volumes, volumeName)
+	delete(o.volumeMigrations, volumeName)
+	return nil
+}
+
+// DeleteVolume does the necessary set up to delete a volume during the course
+// of normal operation, verifying that the volume is present in Trident and
+// creating a transaction to ensure that the delete eventually completes.
+func (o *TridentOrchestrator) DeleteVolume(volumeName string) (err error) {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	var (
+		volume *storage.Volume
+		volTxn *persistentstore.VolumeTransaction
+	)
+
+	utils.Lock("DeleteVolume", volumeLockID(volumeName))
+	defer utils.Unlock("DeleteVolume", volumeLockID(volumeName))
+
+	backend, err := o.lockVolumeBackend("DeleteVolume", volumeName)
+	if err != nil {
+		return err
+	}
+	if backend == nil {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	defer o.unlockBackend("DeleteVolume", backend)
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		var ok bool
+		if volume, ok = o.volumes[volumeName]; !ok {
+			return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		}
+		if volume.Orphaned {
+			log.WithFields(log.Fields{
+				"volume":  volumeName,
+				"backend": volume.Backend,
+			}).Warnf("Delete operation is likely to fail with an orphaned volume!")
+		}
+
+		volTxn = &persistentstore.VolumeTransaction{
+			Config: volume.Config,
+			Op:     persistentstore.DeleteVolume,
+		}
+		return o.addVolumeTransaction(volTxn)
+	}()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		errTxn := o.deleteVolumeTransaction(volTxn)
+		if errTxn != nil {
+			log.WithFields(log.Fields{
+				"volume":    volume,
+				"error":     errTxn,
+				"operation": volTxn.Op,
+			}).Warnf("Unable to delete volume transaction. Repeat deletion using %s or restart %v.",
+				config.OrchestratorClientName, config.OrchestratorName)
+		}
+		if err != nil || errTxn != nil {
+			errList := make([]string, 0, 2)
+			for _, e := range []error{err, errTxn} {
+				if e != nil {
+					errList = append(errList, e.Error())
+				}
+			}
+			err = fmt.Errorf(strings.Join(errList, ", "))
+		}
+	}()
+
+	// Delete the volume
+	if err = o.deleteVolumeFromBackend(backend, volume); err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.deleteVolumeFromStoreAndCache(backend, volume)
+}
+
+// UnmanageVolume removes all record of a volume from Trident without deleting
+// it from its backend.  If newName is specified, the backend volume is first
+// renamed to that name so that it no longer carries a Trident-generated name.
+func (o *TridentOrchestrator) UnmanageVolume(volumeName, newName string) (err error) {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	var (
+		volume *storage.Volume
+		volTxn *persistentstore.VolumeTransaction
+	)
+
+	utils.Lock("UnmanageVolume", volumeLockID(volumeName))
+	defer utils.Unlock("UnmanageVolume", volumeLockID(volumeName))
+
+	backend, err := o.lockVolumeBackend("UnmanageVolume", volumeName)
+	if err != nil {
+		return err
+	}
+	if backend == nil {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	defer o.unlockBackend("UnmanageVolume", backend)
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		var ok bool
+		if volume, ok = o.volumes[volumeName]; !ok {
+			return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		}
+
+		// A volume that is still mounted somewhere must remain under Trident's control
+		nodeNames := make([]string, 0)
+		for _, p := range o.publications {
+			if p.VolumeName == volumeName {
+				nodeNames = append(nodeNames, p.NodeName)
+			}
+		}
+		if len(nodeNames) > 0 {
+			sort.Strings(nodeNames)
+			return conflictError(fmt.Sprintf("volume %s is published to nodes %s; unpublish it before "+
+				"unmanaging it", volumeName, strings.Join(nodeNames, ", ")))
+		}
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	internalName := volume.Config.InternalName
+	renamed := newName != "" && newName != internalName
+	if renamed {
+		if volume.Orphaned {
+			return fmt.Errorf("volume %s is orphaned and cannot be renamed on backend %s", volumeName,
+				backend.Name)
+		}
+		if backend.Driver.Get(newName) == nil {
+			return conflictError(fmt.Sprintf("volume %s already exists on backend %s", newName, backend.Name))
+		}
+	}
+
+	// Add a transaction so that an interrupted unmanage is finished during bootstrapping
+	volTxn = &persistentstore.VolumeTransaction{
+		Config:  volume.Config,
+		Op:      persistentstore.UnmanageVolume,
+		NewName: newName,
+	}
+	o.mutex.Lock()
+	err = o.addVolumeTransaction(volTxn)
+	o.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to add volume transaction: %v", err)
+	}
+
+	if renamed {
+		if err = backend.Driver.Rename(internalName, newName); err != nil {
+			if txErr := o.deleteVolumeTransaction(volTxn); txErr != nil {
+				log.WithFields(log.Fields{
+					"volume": volumeName,
+					"error":  txErr,
+				}).Warning("Unable to clean up volume unmanage transaction.")
+			}
+			return fmt.Errorf("failed to rename volume %s to %s on backend %s: %v", internalName, newName,
+				backend.Name, err)
+		}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err = o.deleteVolumeFromStoreAndCache(backend, volume); err != nil {
+		if renamed {
+			// Put the backend volume back under the name Trident still knows it by.  If that
+			// fails, the transaction is left in place so that the unmanage is finished later.
+			if renameErr := backend.Driver.Rename(newName, internalName); renameErr != nil {
+				log.WithFields(log.Fields{
+					"volume":       volumeName,
+					"internalName": internalName,
+					"newName":      newName,
+					"error":        renameErr,
+				}).Error("Unable to restore the name of a volume that could not be unmanaged.")
+				return fmt.Errorf("failed to unmanage volume %s: %v", volumeName, err)
+			}
+		}
+		if txErr := o.deleteVolumeTransaction(volTxn); txErr != nil {
+			log.WithFields(log.Fields{
+				"volume": volumeName,
+				"error":  txErr,
+			}).Warning("Unable to clean up volume unmanage transaction.")
+		}
+		return fmt.Errorf("failed to unmanage volume %s: %v", volumeName, err)
+	}
+	if err = o.deleteVolumeTransaction(volTxn); err != nil {
+		return fmt.Errorf("failed to clean up volume unmanage transaction: %v", err)
+	}
+
+	log.WithFields(log.Fields{
+		"volume":       volumeName,
+		"backend":      backend.Name,
+		"internalName": internalName,
+		"newName":      newName,
+	}).Info("Volume is no longer managed.")
+
+	return nil
+}
+
+// unmanageVolume finishes an interrupted UnmanageVolume operation, renaming the volume on
+// its backend unless that was already done and then removing all record of it.  It
+// assumes the mutex lock is already held.
+func (o *TridentOrchestrator) unmanageVolume(volume *storage.Volume, newName string) error {
+
+	backend, ok := o.backends[volume.Backend]
+	if !ok {
+		return notFoundError(fmt.Sprintf("backend %s for volume %s not found", volume.Backend,
+			volume.Config.Name))
+	}
+
+	internalName := volume.Config.InternalName
+	if newName != "" && newName != internalName && backend.Driver.Get(newName) != nil {
+		if err := backend.Driver.Rename(internalName, newName); err != nil {
+			return fmt.Errorf("failed to rename volume %s to %s on backend %s: %v", internalName, newName,
+				backend.Name, err)
+		}
+	}
+	return o.deleteVolumeFromStoreAndCache(backend, volume)
+}
+
+func (o *TridentOrchestrator) ListVolumesByPlugin(pluginName string) ([]*storage.VolumeExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	volumes := make([]*storage.VolumeExternal, 0)
+	for _, backend := range o.backends {
+		if backendName := backend.GetDriverName(); pluginName != backendName {
+			continue
+		}
+		for _, vol := range backend.Volumes {
+			volumes = append(volumes, vol.ConstructExternal())
+		}
+	}
+	return volumes, nil
+}
+
+// ListVolumesBySelector returns the volumes whose labels match a label selector,
+// which uses the same syntax as Kubernetes label selectors.
+func (o *TridentOrchestrator) ListVolumesBySelector(selector string) ([]*storage.VolumeExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %s: %v", selector, err)
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	volumes := make([]*storage.VolumeExternal, 0)
+	for _, v := range o.volumes {
+		if labelSelector.Matches(labels.Set(v.Config.Labels)) {
+			volumes = append(volumes, v.ConstructExternal())
+		}
+	}
+	return volumes, nil
+}
+
+func (o *TridentOrchestrator) PublishVolume(
+	volumeName string, publishInfo *utils.VolumePublishInfo,
+) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	backend, err := o.lockVolumeBackend("PublishVolume", volumeName)
+	if err != nil {
+		return err
+	}
+	if backend == nil {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	defer o.unlockBackend("PublishVolume", backend)
+
+	o.mutex.RLock()
+	volume, ok := o.volumes[volumeName]
+	migrationErr := o.checkVolumeNotMigrating(volumeName)
+	o.mutex.RUnlock()
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if migrationErr != nil {
+		return migrationErr
+	}
+
+	return backend.Driver.Publish(volume.Config.InternalName, publishInfo)
+}
+
+// checkVolumeNotMigrating returns a conflict error if a volume is being migrated, since
+// nothing may use the volume until its data has been copied and it has been cut over.
+// A migration holds the volume's lock until it finishes, so this is checked before
+// taking the volume's lock, rather than waiting however long the migration takes, and
+// again once the lock is held, since a migration may have started in between.  It
+// assumes the mutex lock is already held.
+func (o *TridentOrchestrator) checkVolumeNotMigrating(volumeName string) error {
+	if migration, ok := o.volumeMigrations[volumeName]; ok && !migration.Done() {
+		return conflictError(fmt.Sprintf("volume %s is being migrated to backend %s", volumeName,
+			migration.TargetBackend))
+	}
+	return nil
+}
+
+// AttachVolume mounts a volume to the local host.  This method is currently only used by Docker,
+// and it should be able to accomplish its task using only the data passed in; it should not need to
+// use the storage controller API.  It may be assumed that this method always runs on the host to
+// which the volume will be attached.  The attachment is recorded as a publication to the local
+// host, so that operations that require the volume to be detached can tell.
+func (o *TridentOrchestrator) AttachVolume(
+	volumeName, mountpoint string, publishInfo *utils.VolumePublishInfo,
+) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	o.mutex.RLock()
+	err := o.checkVolumeNotMigrating(volumeName)
+	o.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	utils.Lock("AttachVolume", volumeLockID(volumeName))
+	defer utils.Unlock("AttachVolume", volumeLockID(volumeName))
+
+	o.mutex.RLock()
+	_, ok := o.volumes[volumeName]
+	err = o.checkVolumeNotMigrating(volumeName)
+	o.mutex.RUnlock()
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"volume": volumeName, "mountpoint": mountpoint}).Debug("Mounting volume.")
+
+	// Ensure mount point exists and is a directory
+	fileInfo, err := os.Lstat(mountpoint)
+	if os.IsNotExist(err) {
+		// Make mount point if it doesn't exist
+		if err := os.MkdirAll(mountpoint, 0755); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if !fileInfo.IsDir() {
+		return fmt.Errorf("%v already exists and it's not a directory", mountpoint)
+	}
+
+	// Check if volume is already mounted
+	dfOutput, dfOuputErr := utils.GetDFOutput()
+	if dfOuputErr != nil {
 		err = fmt.Errorf("error checking if %v is already mounted: %v", mountpoint, dfOuputErr)
 		return err
 	}
-	for _, e := range dfOutput {
-		if e.Target == mountpoint {
-			log.Debugf("%v is already mounted", mountpoint)
-			return nil
-		}
-	}
+	for _, e := range dfOutput {
+		if e.Target == mountpoint {
+			log.Debugf("%v is already mounted", mountpoint)
+			return o.updateLocalPublication(volumeName, true)
+		}
+	}
+
+	if publishInfo.FilesystemType == "nfs" {
+		err = utils.AttachNFSVolume(volumeName, mountpoint, publishInfo)
+	} else {
+		err = utils.AttachISCSIVolume(volumeName, mountpoint, publishInfo)
+	}
+	if err != nil {
+		return err
+	}
+	return o.updateLocalPublication(volumeName, true)
+}
+
+// DetachVolume unmounts a volume from the local host.  This method is currently only used by Docker,
+// and it should be able to accomplish its task using only the data passed in; it should not need to
+// use the storage controller API.  It may be assumed that this method always runs on the host to
+// which the volume will be attached.  It ensures the volume is already mounted, and it attempts to
+// delete the mount point.  It removes the publication recorded by AttachVolume.
+func (o *TridentOrchestrator) DetachVolume(volumeName, mountpoint string) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	utils.Lock("DetachVolume", volumeLockID(volumeName))
+	defer utils.Unlock("DetachVolume", volumeLockID(volumeName))
+
+	o.mutex.RLock()
+	_, ok := o.volumes[volumeName]
+	o.mutex.RUnlock()
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+
+	log.WithFields(log.Fields{"volume": volumeName, "mountpoint": mountpoint}).Debug("Unmounting volume.")
+
+	// Check if the mount point exists, so we know that it's attached and must be cleaned up
+	_, err := os.Stat(mountpoint)
+	if err != nil {
+		// Not attached, so nothing to do but forget any stale publication
+		return o.updateLocalPublication(volumeName, false)
+	}
+
+	// Unmount the volume
+	if err := utils.Umount(mountpoint); err != nil {
+		return err
+	}
+
+	// Best effort removal of the mount point
+	os.Remove(mountpoint)
+	return o.updateLocalPublication(volumeName, false)
+}
+
+// localNodeName returns the node name under which attachments to the local host are
+// recorded.
+func localNodeName() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "localhost"
+}
+
+// updateLocalPublication records or removes the publication of a volume to the local
+// host by AttachVolume.  The caller must hold the volume's lock but not the mutex.
+func (o *TridentOrchestrator) updateLocalPublication(volumeName string, attached bool) error {
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	volume, ok := o.volumes[volumeName]
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	publication := &storage.VolumePublication{
+		VolumeName: volumeName,
+		NodeName:   localNodeName(),
+		AccessMode: volume.Config.AccessMode,
+	}
+	existing, published := o.publications[publication.ID()]
+
+	if !attached {
+		if !published {
+			return nil
+		}
+		if err := o.storeClient.DeleteVolumePublication(existing); err != nil &&
+			!persistentstore.MatchKeyNotFoundErr(err) {
+			return err
+		}
+		delete(o.publications, existing.ID())
+		return nil
+	}
+
+	if published {
+		return nil
+	}
+	if err := o.storeClient.AddVolumePublication(publication); err != nil {
+		return err
+	}
+	o.publications[publication.ID()] = publication
+	return nil
+}
+
+func (o *TridentOrchestrator) ListVolumeSnapshots(volumeName string) ([]*storage.SnapshotExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	backend, err := o.lockVolumeBackend("ListVolumeSnapshots", volumeName)
+	if err != nil {
+		return nil, err
+	}
+	if backend == nil {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	defer o.unlockBackend("ListVolumeSnapshots", backend)
+
+	o.mutex.RLock()
+	volume, ok := o.volumes[volumeName]
+	o.mutex.RUnlock()
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+
+	snapshots, err := backend.GetSnapshots(volume.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	externalSnapshots := make([]*storage.SnapshotExternal, 0)
+	for _, snapshot := range snapshots {
+		externalSnapshots = append(externalSnapshots, snapshot.ConstructExternal())
+	}
+	return externalSnapshots, nil
+}
+
+// CreateSnapshot creates a snapshot of the given volume
+func (o *TridentOrchestrator) CreateSnapshot(snapshotConfig *storage.SnapshotConfig) (
+	externalSnapshot *storage.SnapshotExternal, err error) {
+
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	var (
+		backend  *storage.Backend
+		snapshot *storage.Snapshot
+		txn      *persistentstore.VolumeTransaction
+	)
+
+	utils.Lock("CreateSnapshot", volumeLockID(snapshotConfig.VolumeName))
+	defer utils.Unlock("CreateSnapshot", volumeLockID(snapshotConfig.VolumeName))
+
+	lockedBackend, err := o.lockVolumeBackend("CreateSnapshot", snapshotConfig.VolumeName)
+	if err != nil {
+		return nil, err
+	}
+	if lockedBackend != nil {
+		defer o.unlockBackend("CreateSnapshot", lockedBackend)
+	}
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		// Check if the snapshot already exists
+		if _, ok := o.snapshots[snapshotConfig.ID()]; ok {
+			return foundError(fmt.Sprintf("snapshot %s already exists on volume %s",
+				snapshotConfig.Name, snapshotConfig.VolumeName))
+		}
+
+		// Get the volume
+		volume, ok := o.volumes[snapshotConfig.VolumeName]
+		if !ok {
+			return notFoundError(fmt.Sprintf("source volume %s not found", snapshotConfig.VolumeName))
+		}
+
+		// Get the backend
+		if _, ok = o.backends[volume.Backend]; !ok || lockedBackend == nil {
+			// Should never get here but just to be safe
+			return notFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
+				volume.Backend, snapshotConfig.VolumeName))
+		}
+
+		// Complete the snapshot config
+		snapshotConfig.Version = config.OrchestratorAPIVersion
+		snapshotConfig.InternalName = snapshotConfig.Name
+		snapshotConfig.VolumeInternalName = volume.Config.InternalName
+
+		// Add transaction in case the operation must be rolled back later
+		txn = &persistentstore.VolumeTransaction{
+			SnapshotConfig: snapshotConfig,
+			Op:             persistentstore.AddSnapshot,
+		}
+		return o.addVolumeTransaction(txn)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	// Recovery function in case of error
+	defer func() {
+		err = o.addSnapshotCleanup(err, backend, snapshot, txn, snapshotConfig)
+	}()
+
+	// Create the snapshot
+	backend = lockedBackend
+	snapshot, err = backend.CreateSnapshot(snapshotConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot %s for volume %s on backend %s: %v",
+			snapshotConfig.Name, snapshotConfig.VolumeName, backend.Name, err)
+	}
+
+	// Save references to new snapshot
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err = o.storeClient.AddSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	o.snapshots[snapshotConfig.ID()] = snapshot
+
+	return snapshot.ConstructExternal(), nil
+}
+
+// addSnapshotCleanup is used as a deferred method from the snapshot create method
+// to clean up in case anything goes wrong during the operation.  It takes the mutex
+// lock as needed, so the caller must hold the backend lock but not the mutex.
+func (o *TridentOrchestrator) addSnapshotCleanup(
+	err error, backend *storage.Backend, snapshot *storage.Snapshot,
+	volTxn *persistentstore.VolumeTransaction, snapConfig *storage.SnapshotConfig) error {
+
+	var (
+		cleanupErr, txErr error
+	)
+	if err != nil {
+		// We failed somewhere.  There are two possible cases:
+		// 1.  We failed to create a snapshot and fell through to the
+		//     end of the function.  In this case, we don't need to roll
+		//     anything back.
+		// 2.  We failed to save the snapshot to the persistent store.
+		//     In this case, we need to remove the snapshot from the backend.
+		if backend != nil && snapshot != nil {
+			// We succeeded in adding the snapshot to the backend; now
+			// delete it.
+			cleanupErr = backend.DeleteSnapshot(snapshot.Config)
+			if cleanupErr != nil {
+				cleanupErr = fmt.Errorf("unable to delete snapshot from backend during cleanup:  %v",
+					cleanupErr)
+			}
+		}
+	}
+	if cleanupErr == nil {
+		// Only clean up the snapshot transaction if we've succeeded at
+		// cleaning up on the backend or if we didn't need to do so in the
+		// first place.
+		txErr = o.deleteVolumeTransaction(volTxn)
+		if txErr != nil {
+			txErr = fmt.Errorf("unable to clean up snapshot transaction:  %v", txErr)
+		}
+	}
+	if cleanupErr != nil || txErr != nil {
+		// Remove the snapshot from memory, if it's there, so that the user
+		// can try to re-add.  This will trigger recovery code.
+		o.mutex.Lock()
+		delete(o.snapshots, snapConfig.ID())
+		o.mutex.Unlock()
+
+		// Report on all errors we encountered.
+		errList := make([]string, 0, 3)
+		for _, e := range []error{err, cleanupErr, txErr} {
+			if e != nil {
+				errList = append(errList, e.Error())
+			}
+		}
+		err = fmt.Errorf(strings.Join(errList, ", "))
+		log.Warnf("Unable to clean up artifacts of snapshot creation: %v. Repeat creating the snapshot or "+
+			"restart %v.", err, config.OrchestratorName)
+	}
+	return err
+}
+
+func (o *TridentOrchestrator) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	snapshotID := storage.MakeSnapshotID(volumeName, snapshotName)
+	snapshot, found := o.snapshots[snapshotID]
+	if !found {
+		return nil, notFoundError(fmt.Sprintf("snapshot %v was not found", snapshotName))
+	}
+	return snapshot.ConstructExternal(), nil
+}
+
+func (o *TridentOrchestrator) ListSnapshots() ([]*storage.SnapshotExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	snapshots := make([]*storage.SnapshotExternal, 0, len(o.snapshots))
+	for _, s := range o.snapshots {
+		snapshots = append(snapshots, s.ConstructExternal())
+	}
+	return snapshots, nil
+}
+
+func (o *TridentOrchestrator) ListSnapshotsForVolume(volumeName string) ([]*storage.SnapshotExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	if _, ok := o.volumes[volumeName]; !ok {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+
+	snapshots := make([]*storage.SnapshotExternal, 0, len(o.snapshots))
+	for _, s := range o.snapshots {
+		if s.Config.VolumeName == volumeName {
+			snapshots = append(snapshots, s.ConstructExternal())
+		}
+	}
+	return snapshots, nil
+}
+
+// deleteSnapshot does the necessary work to delete a snapshot entirely.  It does
+// not construct a transaction, nor does it take locks; it assumes that the caller
+// will take care of both of these.
+func (o *TridentOrchestrator) deleteSnapshot(snapshotConfig *storage.SnapshotConfig) error {
+
+	snapshotID := snapshotConfig.ID()
+	snapshot, ok := o.snapshots[snapshotID]
+	if !ok {
+		return notFoundError(fmt.Sprintf("snapshot %s not found on volume %s",
+			snapshotConfig.Name, snapshotConfig.VolumeName))
+	}
+
+	volume, ok := o.volumes[snapshotConfig.VolumeName]
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", snapshotConfig.VolumeName))
+	}
+
+	backend, ok := o.backends[volume.Backend]
+	if !ok {
+		return notFoundError(fmt.Sprintf("backend %s not found", volume.Backend))
+	}
+
+	if err := o.deleteSnapshotFromBackend(backend, snapshot); err != nil {
+		return err
+	}
+	return o.deleteSnapshotFromStoreAndCache(snapshot)
+}
+
+// deleteSnapshotFromBackend removes a snapshot from its backend.  It assumes
+// that the caller holds either the backend lock or the mutex lock.
+func (o *TridentOrchestrator) deleteSnapshotFromBackend(backend *storage.Backend, snapshot *storage.Snapshot) error {
+
+	// Note that this call will only return an error if the backend actually
+	// fails to delete the snapshot.  If the snapshot does not exist on the backend,
+	// the driver will not return an error.  Thus, we're fine.
+	if err := backend.DeleteSnapshot(snapshot.Config); err != nil {
+		log.WithFields(log.Fields{
+			"volume":   snapshot.Config.VolumeName,
+			"snapshot": snapshot.Config.Name,
+			"backend":  backend.Name,
+			"error":    err,
+		}).Error("Unable to delete snapshot from backend.")
+		return err
+	}
+	return nil
+}
+
+// deleteSnapshotFromStoreAndCache removes all record of a snapshot.  It
+// assumes that the mutex lock is held.
+func (o *TridentOrchestrator) deleteSnapshotFromStoreAndCache(snapshot *storage.Snapshot) error {
+
+	if err := o.storeClient.DeleteSnapshotIgnoreNotFound(snapshot); err != nil {
+		return err
+	}
+
+	delete(o.snapshots, snapshot.ID())
+
+	return nil
+}
+
+// DeleteSnapshot deletes a snapshot of the given volume
+func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (err error) {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	var (
+		snapshot *storage.Snapshot
+		txn      *persistentstore.VolumeTransaction
+		done     bool
+	)
+
+	utils.Lock("DeleteSnapshot", volumeLockID(volumeName))
+	defer utils.Unlock("DeleteSnapshot", volumeLockID(volumeName))
+
+	backend, err := o.lockVolumeBackend("DeleteSnapshot", volumeName)
+	if err != nil {
+		return err
+	}
+	if backend != nil {
+		defer o.unlockBackend("DeleteSnapshot", backend)
+	}
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		snapshotID := storage.MakeSnapshotID(volumeName, snapshotName)
+		var ok bool
+		if snapshot, ok = o.snapshots[snapshotID]; !ok {
+			return notFoundError(fmt.Sprintf("snapshot %s not found on volume %s", snapshotName, volumeName))
+		}
+
+		// Snapshots whose volume is gone can only be removed from the persistent store
+		if _, ok = o.volumes[volumeName]; !ok {
+			if snapshot.State.IsMissingVolume() {
+				done = true
+				return o.deleteSnapshotFromStoreAndCache(snapshot)
+			}
+			return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		}
+		if backend == nil {
+			return notFoundError(fmt.Sprintf("backend for volume %s not found", volumeName))
+		}
+
+		// Add transaction in case the operation must be rolled back later
+		txn = &persistentstore.VolumeTransaction{
+			SnapshotConfig: snapshot.Config,
+			Op:             persistentstore.DeleteSnapshot,
+		}
+		return o.addVolumeTransaction(txn)
+	}()
+	if err != nil || done {
+		return err
+	}
+
+	defer func() {
+		errTxn := o.deleteVolumeTransaction(txn)
+		if errTxn != nil {
+			log.WithFields(log.Fields{
+				"volume":    volumeName,
+				"snapshot":  snapshotName,
+				"error":     errTxn,
+				"operation": txn.Op,
+			}).Warnf("Unable to delete snapshot transaction. Repeat deletion using %s or restart %v.",
+				config.OrchestratorClientName, config.OrchestratorName)
+		}
+		if err != nil || errTxn != nil {
+			errList := make([]string, 0, 2)
+			for _, e := range []error{err, errTxn} {
+				if e != nil {
+					errList = append(errList, e.Error())
+				}
+			}
+			err = fmt.Errorf(strings.Join(errList, ", "))
+		}
+	}()
+
+	// Delete the snapshot
+	if err = o.deleteSnapshotFromBackend(backend, snapshot); err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.deleteSnapshotFromStoreAndCache(snapshot)
+}
+
+// RestoreSnapshot reverts a volume to one of its snapshots, discarding any changes made
+// to the volume since the snapshot was created.  The volume must not be published to any
+// node, since the data would change underneath the node's filesystem.  Publications are
+// recorded by the CSI controller and by AttachVolume.
+func (o *TridentOrchestrator) RestoreSnapshot(volumeName, snapshotName string) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	var snapshot *storage.Snapshot
+
+	utils.Lock("RestoreSnapshot", volumeLockID(volumeName))
+	defer utils.Unlock("RestoreSnapshot", volumeLockID(volumeName))
+
+	backend, err := o.lockVolumeBackend("RestoreSnapshot", volumeName)
+	if err != nil {
+		return err
+	}
+	if backend == nil {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	defer o.unlockBackend("RestoreSnapshot", backend)
+
+	err = func() error {
+		o.mutex.RLock()
+		defer o.mutex.RUnlock()
+
+		if _, ok := o.volumes[volumeName]; !ok {
+			return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		}
+		var ok bool
+		if snapshot, ok = o.snapshots[storage.MakeSnapshotID(volumeName, snapshotName)]; !ok {
+			return notFoundError(fmt.Sprintf("snapshot %s not found on volume %s", snapshotName, volumeName))
+		}
+
+		// Reverting a volume that a node has mounted would corrupt the node's view of its data
+		nodeNames := make([]string, 0)
+		for _, p := range o.publications {
+			if p.VolumeName == volumeName {
+				nodeNames = append(nodeNames, p.NodeName)
+			}
+		}
+		if len(nodeNames) > 0 {
+			sort.Strings(nodeNames)
+			return conflictError(fmt.Sprintf("volume %s is published to nodes %s; unpublish it before "+
+				"restoring a snapshot", volumeName, strings.Join(nodeNames, ", ")))
+		}
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	if err = backend.RestoreSnapshot(snapshot.Config); err != nil {
+		return fmt.Errorf("failed to restore snapshot %s of volume %s on backend %s: %v",
+			snapshotName, volumeName, backend.Name, err)
+	}
+
+	log.WithFields(log.Fields{
+		"volume":   volumeName,
+		"snapshot": snapshotName,
+		"backend":  backend.Name,
+	}).Info("Restored snapshot.")
+
+	// Some storage systems delete the snapshots newer than the one restored, so forget any
+	// of the volume's snapshots that no longer exist.  The volume lock keeps its snapshots
+	// from changing, so only take the mutex to read and update the cache.
+	o.mutex.RLock()
+	otherSnapshots := make([]*storage.Snapshot, 0)
+	for _, s := range o.snapshots {
+		if s.Config.VolumeName == volumeName && s != snapshot {
+			otherSnapshots = append(otherSnapshots, s)
+		}
+	}
+	o.mutex.RUnlock()
+
+	removed := make([]*storage.Snapshot, 0)
+	for _, s := range otherSnapshots {
+		if existing, err := backend.GetSnapshot(s.Config); err != nil {
+			log.WithFields(log.Fields{
+				"volume":   volumeName,
+				"snapshot": s.Config.Name,
+				"error":    err,
+			}).Warning("Could not check whether a snapshot survived a restore.")
+		} else if existing == nil {
+			log.WithFields(log.Fields{
+				"volume":   volumeName,
+				"snapshot": s.Config.Name,
+			}).Info("Snapshot was removed by restoring an earlier snapshot.")
+			removed = append(removed, s)
+		}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, s := range removed {
+		if err = o.deleteSnapshotFromStoreAndCache(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateGroupSnapshot snapshots several volumes at the same point in time.  Where a
+// backend can snapshot its volumes together, it does; otherwise, and only if the group's
+// config allows it, the volumes are snapshotted one after another while no other
+// orchestrator operation may run on any of them.  Each volume's snapshot is named after
+// the group.
+func (o *TridentOrchestrator) CreateGroupSnapshot(groupConfig *storage.GroupSnapshotConfig) (
+	externalGroup *storage.GroupSnapshotExternal, err error) {
+
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	if err = groupConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		snapshots []*storage.Snapshot
+		txns      []*persistentstore.VolumeTransaction
+	)
+
+	// Lock the volumes and then their backends, each in a fixed order so that concurrent
+	// group operations can't deadlock
+	volumeNames := make([]string, len(groupConfig.VolumeNames))
+	copy(volumeNames, groupConfig.VolumeNames)
+	sort.Strings(volumeNames)
+	for _, volumeName := range volumeNames {
+		utils.Lock("CreateGroupSnapshot", volumeLockID(volumeName))
+		defer utils.Unlock("CreateGroupSnapshot", volumeLockID(volumeName))
+	}
+
+	o.mutex.RLock()
+	backendsByName := make(map[string]*storage.Backend)
+	for _, volumeName := range volumeNames {
+		if volume, ok := o.volumes[volumeName]; ok {
+			if backend, ok := o.backends[volume.Backend]; ok {
+				backendsByName[backend.Name] = backend
+			}
+		}
+	}
+	o.mutex.RUnlock()
+
+	backendNames := make([]string, 0, len(backendsByName))
+	for backendName := range backendsByName {
+		backendNames = append(backendNames, backendName)
+	}
+	sort.Strings(backendNames)
+	for _, backendName := range backendNames {
+		if err = o.lockBackend("CreateGroupSnapshot", backendsByName[backendName]); err != nil {
+			return nil, err
+		}
+		defer o.unlockBackend("CreateGroupSnapshot", backendsByName[backendName])
+	}
+
+	// The snapshot configs of each backend's volumes, in the order the volumes were listed
+	snapConfigsByBackend := make(map[string][]*storage.SnapshotConfig)
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		if _, ok := o.groupSnapshots[groupConfig.Name]; ok {
+			return foundError(fmt.Sprintf("group snapshot %s already exists", groupConfig.Name))
+		}
+
+		for _, volumeName := range groupConfig.VolumeNames {
+			snapshotConfig := groupConfig.SnapshotConfig(volumeName)
+			if _, ok := o.snapshots[snapshotConfig.ID()]; ok {
+				return foundError(fmt.Sprintf("snapshot %s already exists on volume %s",
+					snapshotConfig.Name, volumeName))
+			}
+			volume, ok := o.volumes[volumeName]
+			if !ok {
+				return notFoundError(fmt.Sprintf("source volume %s not found", volumeName))
+			}
+			if backend, ok := o.backends[volume.Backend]; !ok || backend != backendsByName[volume.Backend] {
+				return fmt.Errorf("backend %s for the source volume %s changed during the operation; "+
+					"repeat the operation", volume.Backend, volumeName)
+			}
+
+			// Complete the snapshot config
+			snapshotConfig.Version = config.OrchestratorAPIVersion
+			snapshotConfig.InternalName = snapshotConfig.Name
+			snapshotConfig.VolumeInternalName = volume.Config.InternalName
+			snapConfigsByBackend[volume.Backend] = append(snapConfigsByBackend[volume.Backend], snapshotConfig)
+		}
+		groupConfig.Version = config.OrchestratorAPIVersion
+
+		// Snapshots taken one after another aren't crash-consistent, so they must be asked for
+		if !groupConfig.AllowSequential &&
+			(len(backendNames) != 1 || !backendsByName[backendNames[0]].CanGroupSnapshot()) {
+			return unsupportedError(fmt.Sprintf("the volumes of group snapshot %s can't be snapshotted "+
+				"at the same point in time, since they aren't all on one backend that supports group "+
+				"snapshots; allow sequential snapshots to take them one after another", groupConfig.Name))
+		}
+
+		// Add a transaction for each snapshot in case the operation must be rolled back later
+		for _, backendName := range backendNames {
+			for _, snapshotConfig := range snapConfigsByBackend[backendName] {
+				txn := &persistentstore.VolumeTransaction{
+					SnapshotConfig: snapshotConfig,
+					Op:             persistentstore.AddSnapshot,
+				}
+				if err := o.addVolumeTransaction(txn); err != nil {
+					return err
+				}
+				txns = append(txns, txn)
+			}
+		}
+		return nil
+	}()
+
+	// Recovery function in case of error
+	defer func() {
+		err = o.addGroupSnapshotCleanup(err, backendsByName, snapshots, txns, groupConfig)
+	}()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the snapshots.  The group is only taken at a single point in time if it is
+	// confined to one backend that can snapshot its volumes together.
+	native := len(backendNames) == 1
+	for _, backendName := range backendNames {
+		backend := backendsByName[backendName]
+		backendSnapshots, backendNative, err := backend.CreateGroupSnapshot(snapConfigsByBackend[backendName])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create group snapshot %s on backend %s: %v",
+				groupConfig.Name, backendName, err)
+		}
+		snapshots = append(snapshots, backendSnapshots...)
+		native = native && backendNative
+	}
+
+	// Save references to the new snapshots and the group
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	groupSnapshot := storage.NewGroupSnapshot(groupConfig, time.Now().UTC().Format(time.RFC3339), native)
+	for _, snapshot := range snapshots {
+		if err = o.storeClient.AddSnapshot(snapshot); err != nil {
+			return nil, err
+		}
+		o.snapshots[snapshot.ID()] = snapshot
+	}
+	if err = o.storeClient.AddGroupSnapshot(groupSnapshot); err != nil {
+		return nil, err
+	}
+	o.groupSnapshots[groupConfig.Name] = groupSnapshot
+
+	log.WithFields(log.Fields{
+		"groupSnapshot": groupConfig.Name,
+		"volumes":       groupConfig.VolumeNames,
+		"native":        native,
+	}).Info("Created group snapshot.")
+
+	return o.constructGroupSnapshotExternal(groupSnapshot), nil
+}
+
+// addGroupSnapshotCleanup is used as a deferred method from the group snapshot create
+// method to clean up in case anything goes wrong during the operation.  It takes the
+// mutex lock as needed, so the caller must hold the volume and backend locks but not
+// the mutex.
+func (o *TridentOrchestrator) addGroupSnapshotCleanup(
+	err error, backends map[string]*storage.Backend, snapshots []*storage.Snapshot,
+	volTxns []*persistentstore.VolumeTransaction, groupConfig *storage.GroupSnapshotConfig) error {
+
+	var (
+		cleanupErr, txErr error
+	)
+	if err != nil {
+		// Remove any snapshots that were created, from the backends as well as from
+		// the persistent store, so that the group is all or nothing.  The volume locks
+		// keep the volumes on their backends, so the backends may be called without
+		// holding the mutex.
+		o.mutex.RLock()
+		snapshotBackends := make(map[*storage.Snapshot]*storage.Backend, len(snapshots))
+		for _, snapshot := range snapshots {
+			if volume, ok := o.volumes[snapshot.Config.VolumeName]; ok {
+				snapshotBackends[snapshot] = backends[volume.Backend]
+			}
+		}
+		o.mutex.RUnlock()
+
+		deleted := make([]*storage.Snapshot, 0, len(snapshotBackends))
+		for _, snapshot := range snapshots {
+			backend, ok := snapshotBackends[snapshot]
+			if !ok {
+				continue
+			}
+			if deleteErr := backend.DeleteSnapshot(snapshot.Config); deleteErr != nil {
+				cleanupErr = fmt.Errorf("unable to delete snapshot %s from backend during cleanup:  %v",
+					snapshot.ID(), deleteErr)
+				continue
+			}
+			deleted = append(deleted, snapshot)
+		}
+
+		o.mutex.Lock()
+		for _, snapshot := range deleted {
+			if deleteErr := o.deleteSnapshotFromStoreAndCache(snapshot); deleteErr != nil {
+				cleanupErr = fmt.Errorf("unable to delete snapshot %s during cleanup:  %v",
+					snapshot.ID(), deleteErr)
+			}
+		}
+		o.mutex.Unlock()
+	}
+	if cleanupErr == nil {
+		// Only clean up the snapshot transactions if we've succeeded at
+		// cleaning up on the backends or if we didn't need to do so in the
+		// first place.
+		for _, volTxn := range volTxns {
+			if deleteErr := o.deleteVolumeTransaction(volTxn); deleteErr != nil {
+				txErr = fmt.Errorf("unable to clean up snapshot transaction:  %v", deleteErr)
+			}
+		}
+	}
+	if cleanupErr != nil || txErr != nil {
+		// Report on all errors we encountered.
+		errList := make([]string, 0, 3)
+		for _, e := range []error{err, cleanupErr, txErr} {
+			if e != nil {
+				errList = append(errList, e.Error())
+			}
+		}
+		err = fmt.Errorf(strings.Join(errList, ", "))
+		log.Warnf("Unable to clean up artifacts of group snapshot %s creation: %v. Repeat creating the "+
+			"group snapshot or restart %v.", groupConfig.Name, err, config.OrchestratorName)
+	}
+	return err
+}
+
+// constructGroupSnapshotExternal returns a group snapshot with its member snapshots.
+// It assumes the mutex lock is already held.
+func (o *TridentOrchestrator) constructGroupSnapshotExternal(
+	groupSnapshot *storage.GroupSnapshot,
+) *storage.GroupSnapshotExternal {
+
+	external := &storage.GroupSnapshotExternal{
+		GroupSnapshot: *groupSnapshot,
+		Snapshots:     make([]*storage.SnapshotExternal, 0, len(groupSnapshot.Config.VolumeNames)),
+	}
+	for _, snapshotID := range groupSnapshot.Config.SnapshotIDs() {
+		if snapshot, ok := o.snapshots[snapshotID]; ok {
+			external.Snapshots = append(external.Snapshots, snapshot.ConstructExternal())
+		}
+	}
+	return external
+}
+
+func (o *TridentOrchestrator) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshotExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	groupSnapshot, found := o.groupSnapshots[groupSnapshotName]
+	if !found {
+		return nil, notFoundError(fmt.Sprintf("group snapshot %v was not found", groupSnapshotName))
+	}
+	return o.constructGroupSnapshotExternal(groupSnapshot), nil
+}
+
+func (o *TridentOrchestrator) ListGroupSnapshots() ([]*storage.GroupSnapshotExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	groupSnapshots := make([]*storage.GroupSnapshotExternal, 0, len(o.groupSnapshots))
+	for _, g := range o.groupSnapshots {
+		groupSnapshots = append(groupSnapshots, o.constructGroupSnapshotExternal(g))
+	}
+	return groupSnapshots, nil
+}
+
+// DeleteGroupSnapshot deletes each of a group's snapshots that still exists, and then
+// the record of the group.
+func (o *TridentOrchestrator) DeleteGroupSnapshot(groupSnapshotName string) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	o.mutex.RLock()
+	groupSnapshot, found := o.groupSnapshots[groupSnapshotName]
+	o.mutex.RUnlock()
+	if !found {
+		return notFoundError(fmt.Sprintf("group snapshot %v was not found", groupSnapshotName))
+	}
+
+	for _, volumeName := range groupSnapshot.Config.VolumeNames {
+		if err := o.DeleteSnapshot(volumeName, groupSnapshot.Config.Name); err != nil && !IsNotFoundError(err) {
+			return fmt.Errorf("could not delete snapshot of volume %s in group snapshot %s: %v",
+				volumeName, groupSnapshotName, err)
+		}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err := o.storeClient.DeleteGroupSnapshot(groupSnapshot); err != nil &&
+		!persistentstore.MatchKeyNotFoundErr(err) {
+		return err
+	}
+	delete(o.groupSnapshots, groupSnapshotName)
+	return nil
+}
+
+// CloneGroupSnapshot creates a new volume from each of a group's snapshots.  Each clone
+// is named by adding the prefix to the name of its source volume.  If any clone can't be
+// created, those that were are deleted.
+func (o *TridentOrchestrator) CloneGroupSnapshot(groupSnapshotName, clonePrefix string) (
+	[]*storage.VolumeExternal, error) {
+
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	if clonePrefix == "" {
+		return nil, fmt.Errorf("a prefix for the names of the clones is required")
+	}
+
+	// Each clone belongs to the same namespace as its source volume, so that it
+	// counts against the same quotas
+	o.mutex.RLock()
+	groupSnapshot, found := o.groupSnapshots[groupSnapshotName]
+	namespaces := make(map[string]string)
+	if found {
+		for _, volumeName := range groupSnapshot.Config.VolumeNames {
+			if volume, ok := o.volumes[volumeName]; ok {
+				namespaces[volumeName] = volume.Config.Namespace
+			}
+		}
+	}
+	o.mutex.RUnlock()
+	if !found {
+		return nil, notFoundError(fmt.Sprintf("group snapshot %v was not found", groupSnapshotName))
+	}
+
+	clones := make([]*storage.VolumeExternal, 0, len(groupSnapshot.Config.VolumeNames))
+	for _, volumeName := range groupSnapshot.Config.VolumeNames {
+		clone, err := o.CloneVolume(&storage.VolumeConfig{
+			Name:                clonePrefix + volumeName,
+			CloneSourceVolume:   volumeName,
+			CloneSourceSnapshot: groupSnapshot.Config.Name,
+			Namespace:           namespaces[volumeName],
+		})
+		if err != nil {
+			for _, created := range clones {
+				if deleteErr := o.DeleteVolume(created.Config.Name); deleteErr != nil {
+					log.WithFields(log.Fields{
+						"volume":        created.Config.Name,
+						"groupSnapshot": groupSnapshotName,
+						"error":         deleteErr,
+					}).Warning("Could not delete a clone of a failed group snapshot clone.")
+				}
+			}
+			return nil, fmt.Errorf("could not clone volume %s from group snapshot %s: %v",
+				volumeName, groupSnapshotName, err)
+		}
+		clones = append(clones, clone)
+	}
+	return clones, nil
+}
+
+func (o *TridentOrchestrator) ReloadVolumes() error {
+	if err := o.getBootstrapError(); err != nil {
+		return err
+	}
+
+	// Lock out all other workflows while we reload the volumes
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	// Make a temporary copy of backends in case anything goes wrong
+	tempBackends := make(map[string]*storage.Backend)
+	for k, v := range o.backends {
+		tempBackends[k] = v
+	}
+	// Make a temporary copy of volumes in case anything goes wrong
+	tempVolumes := make(map[string]*storage.Volume)
+	for k, v := range o.volumes {
+		tempVolumes[k] = v
+	}
+
+	// Clear out cached volumes in the backends
+	for _, backend := range o.backends {
+		backend.Volumes = make(map[string]*storage.Volume)
+	}
+
+	// Re-run the volume bootstrapping code
+	o.volumes = make(map[string]*storage.Volume)
+	err := o.bootstrapVolumes()
+
+	// If anything went wrong, reinstate the original volumes
+	if err != nil {
+		log.Errorf("Volume reload failed, restoring original volume list: %v", err)
+		o.backends = tempBackends
+		o.volumes = tempVolumes
+	}
+
+	return err
+}
+
+// ResizeVolume resizes a volume to the new size.
+func (o *TridentOrchestrator) ResizeVolume(volumeName, newSize string) (err error) {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	var (
+		vol    *storage.Volume
+		volTxn *persistentstore.VolumeTransaction
+	)
+
+	utils.Lock("ResizeVolume", volumeLockID(volumeName))
+	defer utils.Unlock("ResizeVolume", volumeLockID(volumeName))
+	defer o.releaseQuota(volumeName)
+
+	backend, err := o.lockVolumeBackend("ResizeVolume", volumeName)
+	if err != nil {
+		return err
+	}
+	if backend != nil {
+		defer o.unlockBackend("ResizeVolume", backend)
+	}
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		var found bool
+		if vol, found = o.volumes[volumeName]; !found {
+			return fmt.Errorf("volume %s wasn't found", volumeName)
+		}
+		if vol.Orphaned {
+			log.WithFields(log.Fields{
+				"volume":  volumeName,
+				"backend": vol.Backend,
+			}).Warnf("Resize operation is likely to fail with an orphaned volume!")
+		}
+		if backend == nil {
+			return fmt.Errorf("unable to find backend %v during volume resize", vol.Backend)
+		}
+		if sc, ok := o.storageClasses[vol.Config.StorageClass]; ok {
+			if err := sc.CheckVolumeSize(newSize); err != nil {
+				return err
+			}
+		}
+
+		// Create a new config to capture the volume size change.
+		cloneConfig := vol.Config.ConstructClone()
+		cloneConfig.Size = newSize
+
+		if err := o.reserveQuota(cloneConfig); err != nil {
+			return err
+		}
+
+		// Add a transaction in case the operation must be retried during bootstraping.
+		volTxn = &persistentstore.VolumeTransaction{
+			Config: cloneConfig,
+			Op:     persistentstore.ResizeVolume,
+		}
+		return o.addVolumeTransaction(volTxn)
+	}()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err == nil {
+			log.WithFields(log.Fields{
+				"volume":      volumeName,
+				"volume_size": newSize,
+			}).Info("Orchestrator resized the volume on the storage backend.")
+		}
+		err = o.resizeVolumeCleanup(err, vol, volTxn)
+	}()
+
+	// Resize the volume.
+	if err = o.resizeVolumeOnBackend(backend, vol, newSize); err != nil {
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.updateVolumeSize(vol, newSize)
+}
+
+// UpdateVolumeStorageClass reassigns a volume to another storage class.  If the volume's
+// storage pool isn't in the new class, the volume is moved to one of its backend's pools
+// that is, provided the backend's driver can move volumes between its pools.  A move may
+// take many minutes, so it is recorded in a volume transaction, and the backend lock is
+// only held shared meanwhile.
+func (o *TridentOrchestrator) UpdateVolumeStorageClass(
+	volumeName, storageClassName string,
+) (volExternal *storage.VolumeExternal, err error) {
+
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	var (
+		volume      *storage.Volume
+		newConfig   *storage.VolumeConfig
+		targetPools []*storage.Pool
+		volTxn      *persistentstore.VolumeTransaction
+	)
+
+	utils.Lock("UpdateVolumeStorageClass", volumeLockID(volumeName))
+	defer utils.Unlock("UpdateVolumeStorageClass", volumeLockID(volumeName))
+	defer o.releaseQuota(volumeName)
+
+	backend, err := o.lockVolumeBackend("UpdateVolumeStorageClass", volumeName)
+	if err != nil {
+		return nil, err
+	}
+	if backend == nil {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	defer o.unlockBackend("UpdateVolumeStorageClass", backend)
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		var ok bool
+		if volume, ok = o.volumes[volumeName]; !ok {
+			return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		}
+		if volume.Config.StorageClass == storageClassName {
+			return nil
+		}
+		sc, ok := o.storageClasses[storageClassName]
+		if !ok {
+			return notFoundError(fmt.Sprintf("storage class %s not found", storageClassName))
+		}
+		if err := sc.CheckVolumeSize(volume.Config.Size); err != nil {
+			return err
+		}
+
+		newConfig = volume.Config.ConstructClone()
+		newConfig.StorageClass = storageClassName
+		if err := o.reserveQuota(newConfig); err != nil {
+			return err
+		}
+
+		// The volume stays put if its pool is already in the new class
+		for _, pool := range sc.Pools() {
+			if pool.Backend == backend && pool.Name == volume.Pool {
+				return nil
+			}
+		}
+
+		candidates := make([]*storage.Pool, 0)
+		for _, pool := range sc.Pools() {
+			if pool.Backend == backend {
+				candidates = append(candidates, pool)
+			}
+		}
+		if len(candidates) == 0 {
+			return unsupportedError(fmt.Sprintf("storage class %s has no storage pools on backend %s, "+
+				"so volume %s can't be moved to it", storageClassName, backend.Name, volumeName))
+		}
+		if !backend.CanMoveVolumes() {
+			return unsupportedError(fmt.Sprintf("storage class %s doesn't include storage pool %s of "+
+				"volume %s, and backend %s can't move volumes between its storage pools", storageClassName,
+				volume.Pool, volumeName, backend.Name))
+		}
+		if sizeBytes, err := volumeSizeBytes(volume.Config); err == nil && sizeBytes > 0 {
+			if candidates = poolsWithFreeSpace(candidates, sizeBytes); len(candidates) == 0 {
+				return fmt.Errorf("no storage pool for storage class %s on backend %s has %d bytes "+
+					"of free space", storageClassName, backend.Name, sizeBytes)
+			}
+		}
+		targetPools = sc.RankPools(candidates, o.getPoolUsage())
+
+		// Add a transaction in case the move is interrupted by a restart
+		volTxn = &persistentstore.VolumeTransaction{
+			Config: newConfig,
+			Op:     persistentstore.MoveVolume,
+		}
+		return o.addVolumeTransaction(volTxn)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	if newConfig == nil {
+		// The volume is already in the storage class
+		o.mutex.RLock()
+		defer o.mutex.RUnlock()
+		return volume.ConstructExternal(), nil
+	}
+
+	newPool := volume.Pool
+	if len(targetPools) > 0 {
+		errorMessages := make([]string, 0)
+		for _, pool := range targetPools {
+			// Record the pool before the volume starts moving to it
+			o.mutex.Lock()
+			volTxn.Pool = pool.Name
+			err = o.storeClient.AddVolumeTransaction(volTxn)
+			o.mutex.Unlock()
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("[%s: failed to update volume "+
+					"move transaction: %v]", pool.Name, err))
+				break
+			}
+
+			if err = backend.MoveVolume(volume.Config, pool); err != nil {
+				log.WithFields(log.Fields{
+					"volume":  volumeName,
+					"backend": backend.Name,
+					"pool":    pool.Name,
+					"error":   err,
+				}).Warn("Failed to move the volume to this storage pool.")
+				errorMessages = append(errorMessages, fmt.Sprintf("[%s: %v]", pool.Name, err))
+				continue
+			}
+			newPool = pool.Name
+			break
+		}
+		if newPool == volume.Pool {
+			o.mutex.Lock()
+			if txnErr := o.deleteVolumeTransaction(volTxn); txnErr != nil {
+				log.WithFields(log.Fields{
+					"volume": volumeName,
+					"error":  txnErr,
+				}).Error("Unable to clean up the volume move transaction.")
+			}
+			o.mutex.Unlock()
+			return nil, fmt.Errorf("could not move volume %s to a storage pool of storage class %s: %s",
+				volumeName, storageClassName, strings.Join(errorMessages, ", "))
+		}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	oldStorageClass, oldPool := volume.Config.StorageClass, volume.Pool
+	volume.Config.StorageClass = storageClassName
+	volume.Pool = newPool
+	if err = o.updateVolumeOnPersistentStore(volume); err != nil {
+		log.WithFields(log.Fields{
+			"volume": volumeName,
+		}).Error("Unable to update the volume's storage class in persistent store.")
+		volume.Config.StorageClass = oldStorageClass
+		if newPool == oldPool {
+			return nil, err
+		}
+		// The volume did move, so its cached pool stays accurate, and the transaction is
+		// kept so that the storage class is updated when the move is finished
+		return nil, fmt.Errorf("volume %s was moved to storage pool %s but its storage class "+
+			"could not be updated: %v", volumeName, newPool, err)
+	}
+	if volTxn != nil {
+		if txnErr := o.deleteVolumeTransaction(volTxn); txnErr != nil {
+			log.WithFields(log.Fields{
+				"volume": volumeName,
+				"error":  txnErr,
+			}).Warning("Unable to clean up the volume move transaction.")
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"volume":       volumeName,
+		"storageClass": storageClassName,
+		"backend":      backend.Name,
+		"pool":         newPool,
+		"moved":        newPool != oldPool,
+	}).Info("Updated the volume's storage class.")
+
+	return volume.ConstructExternal(), nil
+}
+
+// finishVolumeMove moves a volume to one of its backend's storage pools, unless it is
+// already there, and records the volume's new storage class and pool.  It is used to
+// recover a move interrupted by a restart, so the caller must hold the mutex lock.
+func (o *TridentOrchestrator) finishVolumeMove(volume *storage.Volume, storageClassName, poolName string) error {
+
+	backend, ok := o.backends[volume.Backend]
+	if !ok {
+		return fmt.Errorf("backend %s not found", volume.Backend)
+	}
+	pool, ok := backend.Storage[poolName]
+	if !ok {
+		return fmt.Errorf("storage pool %s not found on backend %s", poolName, backend.Name)
+	}
+	if volume.Pool != poolName {
+		if err := backend.MoveVolume(volume.Config, pool); err != nil {
+			return err
+		}
+		volume.Pool = poolName
+	}
+	volume.Config.StorageClass = storageClassName
+	return o.updateVolumeOnPersistentStore(volume)
+}
+
+// MigrateVolume moves a volume to another backend without changing its name.  A target
+// volume is created in one of the backend's pools that belongs to the volume's storage
+// class, and the volume's data is copied to it, either by the storage systems themselves
+// if the backend can replicate volumes from the volume's current backend, or otherwise by
+// a frontend that can copy volumes.  The volume is then cut over to the target volume,
+// and its original is destroyed.  Copying may take a long time, so only the target volume
+// is created before this method returns; the rest of the migration continues in the
+// background, and GetVolumeMigration reports its progress.  The volume stays locked until
+// the migration finishes.  Each step is recorded in a volume transaction, so a migration
+// interrupted by a restart is rolled back, or finished if its cutover was complete.
+func (o *TridentOrchestrator) MigrateVolume(
+	volumeName, backendName string,
+) (migrationExternal *storage.VolumeMigration, err error) {
+
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+	if config.UsingPassthroughStore {
+		return nil, unsupportedError("volumes cannot be migrated when using the passthrough store")
+	}
+
+	var (
+		source, target *storage.Backend
+		pools          []*storage.Pool
+		volAttributes  map[string]sa.Request
+		copier         frontend.VolumeCopier
+		migration      *storage.VolumeMigration
+		volTxn         *persistentstore.VolumeTransaction
+	)
+
+	// The volume stays locked until the background migration releases it
+	utils.Lock("MigrateVolume", volumeLockID(volumeName))
+	migrationStarted := false
+	defer func() {
+		if !migrationStarted {
+			utils.Unlock("MigrateVolume", volumeLockID(volumeName))
+		}
+	}()
+
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		volume, ok := o.volumes[volumeName]
+		if !ok {
+			return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+		}
+		if volume.Orphaned {
+			return fmt.Errorf("volume %s is orphaned and cannot be migrated", volumeName)
+		}
+		if source, ok = o.backends[volume.Backend]; !ok {
+			return notFoundError(fmt.Sprintf("backend %s of volume %s not found", volume.Backend, volumeName))
+		}
+		if target, ok = o.backends[backendName]; !ok {
+			return notFoundError(fmt.Sprintf("backend %s not found", backendName))
+		}
+		if target == source {
+			return fmt.Errorf("volume %s is already on backend %s", volumeName, backendName)
+		}
+		if !source.State.IsOnline() {
+			return fmt.Errorf("backend %s of volume %s is not online", source.Name, volumeName)
+		}
+		if !target.State.IsOnline() {
+			return fmt.Errorf("backend %s is not online", backendName)
+		}
+		if target.GetProtocol() != source.GetProtocol() {
+			return unsupportedError(fmt.Sprintf("backend %s provides %s volumes, but volume %s is a %s volume",
+				backendName, target.GetProtocol(), volumeName, source.GetProtocol()))
+		}
+
+		// Nothing may use the volume or depend on it while its data is copied
+		for _, publication := range o.publications {
+			if publication.VolumeName == volumeName {
+				return conflictError(fmt.Sprintf("volume %s is published to node %s and cannot be migrated",
+					volumeName, publication.NodeName))
+			}
+		}
+		for _, snapshot := range o.snapshots {
+			if snapshot.Config.VolumeName == volumeName {
+				return conflictError(fmt.Sprintf("volume %s has snapshots, which cannot be migrated; "+
+					"delete them first", volumeName))
+			}
+		}
+		for _, clone := range o.volumes {
+			if clone.Config.CloneSourceVolume == volumeName && clone.Backend == source.Name {
+				return conflictError(fmt.Sprintf("volume %s is the source of clone %s and cannot be migrated",
+					volumeName, clone.Config.Name))
+			}
+		}
+
+		sc, ok := o.storageClasses[volume.Config.StorageClass]
+		if !ok {
+			return notFoundError(fmt.Sprintf("storage class %s of volume %s not found",
+				volume.Config.StorageClass, volumeName))
+		}
+		for _, pool := range sc.Pools() {
+			if pool.Backend == target {
+				pools = append(pools, pool)
+			}
+		}
+		if len(pools) == 0 {
+			return unsupportedError(fmt.Sprintf("storage class %s has no storage pools on backend %s, "+
+				"so volume %s can't be migrated to it", sc.GetName(), backendName, volumeName))
+		}
+		if sizeBytes, err := volumeSizeBytes(volume.Config); err == nil && sizeBytes > 0 {
+			if pools = poolsWithFreeSpace(pools, sizeBytes); len(pools) == 0 {
+				return fmt.Errorf("no storage pool for storage class %s on backend %s has %d bytes "+
+					"of free space", sc.GetName(), backendName, sizeBytes)
+			}
+		}
+		pools = sc.RankPools(pools, o.getPoolUsage())
+		volAttributes = sc.GetAttributes()
+
+		native := target.CanReplicateVolumesFrom(source)
+		copier = o.getVolumeCopier(source.GetProtocol())
+		if !native && copier == nil {
+			return unsupportedError(fmt.Sprintf("backend %s cannot replicate volumes from backend %s, "+
+				"and no frontend can copy %s volumes", backendName, source.Name, source.GetProtocol()))
+		}
+
+		targetConfig := volume.Config.ConstructClone()
+		targetConfig.InternalName = target.Driver.GetInternalVolumeName(volumeName)
+		targetConfig.AccessInfo = utils.VolumeAccessInfo{}
+		targetConfig.CloneSourceVolume = ""
+		targetConfig.CloneSourceVolumeInternal = ""
+		targetConfig.CloneSourceSnapshot = ""
+
+		migration = &storage.VolumeMigration{
+			VolumeName:    volumeName,
+			SourceBackend: source.Name,
+			SourcePool:    volume.Pool,
+			SourceConfig:  volume.Config.ConstructClone(),
+			TargetBackend: target.Name,
+			TargetConfig:  targetConfig,
+			Native:        native,
+			State:         storage.MigrationCopying,
+			Started:       time.Now().UTC().Format(time.RFC3339),
+		}
+		volTxn = &persistentstore.VolumeTransaction{
+			Config:    volume.Config,
+			Op:        persistentstore.MigrateVolume,
+			Migration: migration,
+		}
+		return o.addVolumeTransaction(volTxn)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	// If the target volume can't be destroyed, the transaction is kept so that it is
+	// destroyed during recovery
+	keepTxn := false
+	if err = o.lockBackends("MigrateVolume", source, target); err == nil {
+		err = o.createMigrationTarget(volTxn, source, target, pools, volAttributes, copier != nil)
+		if err == nil {
+			// Record which pool and copy method were chosen
+			o.mutex.Lock()
+			err = o.storeClient.AddVolumeTransaction(volTxn)
+			o.mutex.Unlock()
+			if err != nil {
+				log.WithFields(log.Fields{
+					"volume": volumeName,
+					"error":  err,
+				}).Error("Unable to update the migration transaction; destroying the target volume.")
+				if cleanupErr := o.destroyMigrationTarget(migration, source, target); cleanupErr != nil {
+					err = fmt.Errorf("failed to update volume migration transaction: %v; "+
+						"the target volume could not be destroyed: %v", err, cleanupErr)
+					keepTxn = true
+				}
+			}
+		}
+		o.unlockBackends("MigrateVolume", source, target)
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if keepTxn {
+		return nil, err
+	}
+	if err != nil {
+		if txnErr := o.deleteVolumeTransaction(volTxn); txnErr != nil {
+			log.WithFields(log.Fields{
+				"volume": volumeName,
+				"error":  txnErr,
+			}).Error("Unable to clean up the migration transaction.")
+		}
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"volume":        volumeName,
+		"sourceBackend": source.Name,
+		"targetBackend": target.Name,
+		"targetPool":    migration.TargetPool,
+		"native":        migration.Native,
+	}).Info("Started migrating the volume.")
+
+	o.volumeMigrations[volumeName] = migration
+	migrationStarted = true
+	go o.runVolumeMigration(volTxn, source, target, copier)
+
+	return migration.ConstructExternal(), nil
+}
+
+// GetVolumeMigration returns the progress of a volume's most recent migration.
+func (o *TridentOrchestrator) GetVolumeMigration(volumeName string) (*storage.VolumeMigration, error) {
+
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	migration, ok := o.volumeMigrations[volumeName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("no migration of volume %s found", volumeName))
+	}
+	return migration.ConstructExternal(), nil
+}
+
+// getVolumeCopier returns a frontend that can copy volumes of the specified protocol, or
+// nil if there is none.  It assumes that the mutex lock is held.
+func (o *TridentOrchestrator) getVolumeCopier(protocol config.Protocol) frontend.VolumeCopier {
+	for _, f := range o.frontends {
+		if copier, ok := f.(frontend.VolumeCopier); ok && copier.CanCopyVolume(protocol) {
+			return copier
+		}
+	}
+	return nil
+}
+
+// lockBackends acquires the shared locks of several backends in name order, so that
+// callers locking overlapping sets of backends cannot deadlock.  The caller must call
+// unlockBackends if no error is returned.
+func (o *TridentOrchestrator) lockBackends(ctx string, backends ...*storage.Backend) error {
+
+	sorted := append([]*storage.Backend(nil), backends...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for i, backend := range sorted {
+		if err := o.lockBackend(ctx, backend); err != nil {
+			o.unlockBackends(ctx, sorted[:i]...)
+			return err
+		}
+	}
+	return nil
+}
+
+// unlockBackends releases backend locks acquired by lockBackends.
+func (o *TridentOrchestrator) unlockBackends(ctx string, backends ...*storage.Backend) {
+	for _, backend := range backends {
+		o.unlockBackend(ctx, backend)
+	}
+}
+
+// createMigrationTarget creates the volume that a migrating volume's data will be copied
+// to, trying the target backend's pools in order.  Native replication is preferred, but
+// if no pool can hold a replica, an empty volume is created for a frontend to copy to.
+// The caller must hold the locks of both backends, but not the mutex.
+func (o *TridentOrchestrator) createMigrationTarget(
+	volTxn *persistentstore.VolumeTransaction, source, target *storage.Backend, pools []*storage.Pool,
+	volAttributes map[string]sa.Request, canCopy bool,
+) error {
+
+	migration := volTxn.Migration
+
+	// A volume that merely shares the target's name must never be destroyed by a rollback
+	if target.Driver.Get(migration.TargetConfig.InternalName) == nil {
+		return conflictError(fmt.Sprintf("volume %s already exists on backend %s",
+			migration.TargetConfig.InternalName, target.Name))
+	}
+
+	// Record that any volume with the target's name is now ours, so that an interrupted
+	// migration only destroys the target if it may have been created
+	o.mutex.Lock()
+	migration.TargetCreated = true
+	err := o.storeClient.AddVolumeTransaction(volTxn)
+	o.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to update volume migration transaction: %v", err)
+	}
+
+	methods := make([]bool, 0, 2)
+	if migration.Native {
+		methods = append(methods, true)
+	}
+	if canCopy {
+		methods = append(methods, false)
+	}
+
+	sizeBytes, err := volumeSizeBytes(migration.SourceConfig)
+	if err != nil {
+		sizeBytes = 0
+	}
+
+	errorMessages := make([]string, 0)
+	for _, native := range methods {
+		for _, pool := range pools {
+			// Other requests may have claimed the pool's free space since it was checked
+			if !o.reservePoolSpace(pool, sizeBytes) {
+				errorMessages = append(errorMessages, fmt.Sprintf("[%s: no longer has %d bytes of "+
+					"free space]", pool.Name, sizeBytes))
+				continue
+			}
+
+			targetConfig := migration.TargetConfig.ConstructClone()
+			var err error
+			if native {
+				if err = target.ReplicateVolume(source, migration.SourceConfig, targetConfig, pool); err != nil {
+					// Don't leave a partial replica behind for the next attempt to trip over
+					partial := *migration
+					partial.TargetConfig = targetConfig
+					if cleanupErr := o.destroyMigrationTarget(&partial, source, target); cleanupErr != nil {
+						return fmt.Errorf("failed to replicate volume %s: %v; the partial replica "+
+							"could not be destroyed: %v", migration.VolumeName, err, cleanupErr)
+					}
+				}
+			} else {
+				_, err = target.AddVolume(targetConfig, pool, volAttributes)
+			}
+			if err != nil {
+				log.WithFields(log.Fields{
+					"volume":  migration.VolumeName,
+					"backend": target.Name,
+					"pool":    pool.Name,
+					"native":  native,
+					"error":   err,
+				}).Warn("Failed to create the migration target in this storage pool.")
+				errorMessages = append(errorMessages, fmt.Sprintf("[%s: %v]", pool.Name, err))
+				o.releasePoolSpace(pool, sizeBytes)
+				continue
+			}
+			migration.TargetConfig = targetConfig
+			migration.TargetPool = pool.Name
+			migration.Native = native
+			return nil
+		}
+	}
+
+	return fmt.Errorf("could not create volume %s on backend %s: %s", migration.VolumeName,
+		target.Name, strings.Join(errorMessages, ", "))
+}
+
+// destroyMigrationTarget stops any replication to a migration's target volume, and then
+// destroys the target volume.  Either backend may be nil if it no longer exists.
+func (o *TridentOrchestrator) destroyMigrationTarget(
+	migration *storage.VolumeMigration, source, target *storage.Backend,
+) error {
+
+	if target == nil {
+		return nil
+	}
+	if source != nil {
+		if err := target.AbortVolumeReplication(source, migration.SourceConfig, migration.TargetConfig); err != nil {
+			return err
+		}
+	}
+	return target.Driver.Destroy(migration.TargetConfig.InternalName)
+}
+
+// runVolumeMigration copies a migrating volume's data to its target volume, cuts the
+// volume over to the target, and destroys the original.  If any step before the cutover
+// fails, the target volume is destroyed instead.  It runs in the background, and releases
+// the volume lock acquired by MigrateVolume when it finishes.
+func (o *TridentOrchestrator) runVolumeMigration(
+	volTxn *persistentstore.VolumeTransaction, source, target *storage.Backend, copier frontend.VolumeCopier,
+) {
+
+	migration := volTxn.Migration
+	volumeName := migration.VolumeName
+	start := time.Now()
+
+	defer utils.Unlock("MigrateVolume", volumeLockID(volumeName))
+
+	err := o.copyVolumeMigrationData(migration, source, target, copier)
+	if err == nil {
+		err = o.cutOverVolumeMigration(volTxn, source, target)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volume":        volumeName,
+			"targetBackend": target.Name,
+			"error":         err,
+		}).Error("Volume migration failed; rolling it back.")
+		o.rollBackVolumeMigration(volTxn, source, target, err)
+	} else {
+		o.finishVolumeMigration(volTxn, source)
+	}
+
+	o.RecordEvent(storage.NewEvent(eventVolumeMigrated, storage.EventObjectVolume, volumeName,
+		eventSourceMigration, start, err))
+}
+
+// copyVolumeMigrationData waits for native replication to copy a migrating volume, or has
+// a frontend copy it.  It holds no locks besides the volume's, so that the backends remain
+// usable however long the copy takes.
+func (o *TridentOrchestrator) copyVolumeMigrationData(
+	migration *storage.VolumeMigration, source, target *storage.Backend, copier frontend.VolumeCopier,
+) error {
+
+	if migration.Native {
+		return target.WaitForVolumeReplication(source, migration.SourceConfig, migration.TargetConfig)
+	}
+
+	sourceVolume := storage.NewVolume(migration.SourceConfig, source.Name, migration.SourcePool, false)
+	targetVolume := storage.NewVolume(migration.TargetConfig, target.Name, migration.TargetPool, false)
+	return copier.CopyVolumeData(sourceVolume.ConstructExternal(), targetVolume.ConstructExternal())
+}
+
+// cutOverVolumeMigration makes a migrating volume's target volume writable if it is a
+// replica, and then points the volume's record at the target backend.
+func (o *TridentOrchestrator) cutOverVolumeMigration(
+	volTxn *persistentstore.VolumeTransaction, source, target *storage.Backend,
+) error {
+
+	migration := volTxn.Migration
+
+	if err := o.lockBackends("MigrateVolume", source, target); err != nil {
+		return err
+	}
+	defer o.unlockBackends("MigrateVolume", source, target)
+
+	if err := o.setVolumeMigrationState(volTxn, storage.MigrationCutover, ""); err != nil {
+		return err
+	}
+
+	targetConfig := migration.TargetConfig.ConstructClone()
+	if migration.Native {
+		if err := target.CompleteVolumeReplication(source, migration.SourceConfig, targetConfig); err != nil {
+			return err
+		}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	migration.TargetConfig = targetConfig
+	volume := storage.NewVolume(targetConfig.ConstructClone(), target.Name, migration.TargetPool, false)
+	if err := o.updateVolumeOnPersistentStore(volume); err != nil {
+		return err
+	}
+	delete(source.Volumes, volume.Config.Name)
+	target.Volumes[volume.Config.Name] = volume
+	o.volumes[volume.Config.Name] = volume
+
+	log.WithFields(log.Fields{
+		"volume":  volume.Config.Name,
+		"backend": target.Name,
+		"pool":    volume.Pool,
+	}).Info("Cut the volume over to its new backend.")
+
+	return nil
+}
+
+// finishVolumeMigration destroys a migrated volume's original.  If that fails, the
+// migration's transaction is kept so that the original is destroyed during recovery.
+func (o *TridentOrchestrator) finishVolumeMigration(volTxn *persistentstore.VolumeTransaction, source *storage.Backend) {
+
+	migration := volTxn.Migration
+
+	err := o.lockBackend("MigrateVolume", source)
+	if err == nil {
+		err = source.Driver.Destroy(migration.SourceConfig.InternalName)
+		o.unlockBackend("MigrateVolume", source)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volume":  migration.VolumeName,
+			"backend": source.Name,
+			"error":   err,
+		}).Warn("Volume migrated, but its original could not be destroyed; it will be destroyed " +
+			"when the migration transaction is recovered.")
+		message := fmt.Sprintf("the original volume on backend %s could not be destroyed: %v", source.Name, err)
+		if stateErr := o.setVolumeMigrationState(volTxn, storage.MigrationComplete, message); stateErr != nil {
+			log.WithField("error", stateErr).Error("Unable to update the migration transaction.")
+		}
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	migration.State = storage.MigrationComplete
+	if err := o.deleteVolumeTransaction(volTxn); err != nil {
+		log.WithFields(log.Fields{
+			"volume": migration.VolumeName,
+			"error":  err,
+		}).Error("Unable to clean up the migration transaction.")
+	}
+
+	log.WithFields(log.Fields{
+		"volume":  migration.VolumeName,
+		"backend": migration.TargetBackend,
+	}).Info("Migrated the volume.")
+}
+
+// rollBackVolumeMigration destroys the target volume of a migration that failed before its
+// cutover.  If that fails, the migration's transaction is kept so that the target volume is
+// destroyed during recovery.
+func (o *TridentOrchestrator) rollBackVolumeMigration(
+	volTxn *persistentstore.VolumeTransaction, source, target *storage.Backend, cause error,
+) {
+
+	migration := volTxn.Migration
+
+	err := o.lockBackends("MigrateVolume", source, target)
+	if err == nil {
+		err = o.destroyMigrationTarget(migration, source, target)
+		o.unlockBackends("MigrateVolume", source, target)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volume":  migration.VolumeName,
+			"backend": target.Name,
+			"error":   err,
+		}).Warn("Could not destroy the migration target; it will be destroyed when the migration " +
+			"transaction is recovered.")
+		message := fmt.Sprintf("%v; the target volume on backend %s could not be destroyed: %v",
+			cause, target.Name, err)
+		if stateErr := o.setVolumeMigrationState(volTxn, storage.MigrationFailed, message); stateErr != nil {
+			log.WithField("error", stateErr).Error("Unable to update the migration transaction.")
+		}
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	migration.State = storage.MigrationFailed
+	migration.Error = cause.Error()
+	if err := o.deleteVolumeTransaction(volTxn); err != nil {
+		log.WithFields(log.Fields{
+			"volume": migration.VolumeName,
+			"error":  err,
+		}).Error("Unable to clean up the migration transaction.")
+	}
+}
+
+// setVolumeMigrationState records a migration's progress in its transaction.
+func (o *TridentOrchestrator) setVolumeMigrationState(
+	volTxn *persistentstore.VolumeTransaction, state storage.VolumeMigrationState, message string,
+) error {
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	volTxn.Migration.State = state
+	volTxn.Migration.Error = message
+	return o.storeClient.AddVolumeTransaction(volTxn)
+}
+
+// recoverVolumeMigration finishes or rolls back a migration that was interrupted.  If the
+// volume's record already names the target backend, the cutover was complete and only
+// the original volume remains to be destroyed; otherwise the target volume is destroyed,
+// if the migration got as far as creating it.  It assumes that the mutex lock is held.
+func (o *TridentOrchestrator) recoverVolumeMigration(migration *storage.VolumeMigration) error {
+
+	source := o.backends[migration.SourceBackend]
+	target := o.backends[migration.TargetBackend]
+
+	if volume, ok := o.volumes[migration.VolumeName]; ok && volume.Backend == migration.TargetBackend {
+		if source == nil || !source.State.IsOnline() {
+			log.WithFields(log.Fields{
+				"volume":  migration.SourceConfig.InternalName,
+				"backend": migration.SourceBackend,
+			}).Warn("Backend of migrated volume's original is unavailable; the original must be " +
+				"deleted manually.")
+		} else if err := source.Driver.Destroy(migration.SourceConfig.InternalName); err != nil {
+			return fmt.Errorf("failed to destroy original of migrated volume %s on backend %s: %v",
+				migration.VolumeName, migration.SourceBackend, err)
+		}
+		migration.State = storage.MigrationComplete
+		migration.Error = ""
+	} else {
+		if !migration.TargetCreated {
+			log.WithFields(log.Fields{
+				"volume":  migration.TargetConfig.InternalName,
+				"backend": migration.TargetBackend,
+			}).Debug("Interrupted migration hadn't created its target.")
+		} else if target != nil && !target.State.IsOnline() {
+			log.WithFields(log.Fields{
+				"volume":  migration.TargetConfig.InternalName,
+				"backend": migration.TargetBackend,
+			}).Warn("Backend of interrupted migration's target is unavailable; the target must be " +
+				"deleted manually.")
+		} else if err := o.destroyMigrationTarget(migration, source, target); err != nil {
+			return fmt.Errorf("failed to destroy target of interrupted migration of volume %s on "+
+				"backend %s: %v", migration.VolumeName, migration.TargetBackend, err)
+		}
+		migration.State = storage.MigrationFailed
+		if migration.Error == "" {
+			migration.Error = "the migration was interrupted before its cutover"
+		}
+	}
+
+	o.volumeMigrations[migration.VolumeName] = migration
+	return nil
+}
+
+// resizeVolume does the necessary work to resize a volume. It doesn't
+// construct a transaction, nor does it take locks; it assumes that the
+// caller will take care of both of these. It also assumes that the volume
+// exists in memory.
+func (o *TridentOrchestrator) resizeVolume(volume *storage.Volume, newSize string) error {
+	volumeBackend, found := o.backends[volume.Backend]
+	if !found {
+		log.WithFields(log.Fields{
+			"volume":  volume.Config.Name,
+			"backend": volume.Backend,
+		}).Error("Unable to find backend during volume resize.")
+		return fmt.Errorf("unable to find backend %v during volume resize",
+			volume.Backend)
+	}
+
+	if err := o.resizeVolumeOnBackend(volumeBackend, volume, newSize); err != nil {
+		return err
+	}
+	return o.updateVolumeSize(volume, newSize)
+}
+
+// resizeVolumeOnBackend resizes a volume on its backend, if its size is
+// changing.  It assumes that the caller holds either the backend lock or the
+// mutex lock.
+func (o *TridentOrchestrator) resizeVolumeOnBackend(
+	volumeBackend *storage.Backend, volume *storage.Volume, newSize string,
+) error {
+
+	if volume.Config.Size != newSize {
+		if err := volumeBackend.ResizeVolume(volume.Config.InternalName,
+			newSize); err != nil {
+			log.WithFields(log.Fields{
+				"volume":          volume.Config.Name,
+				"volume_internal": volume.Config.InternalName,
+				"backend":         volume.Backend,
+				"current_size":    volume.Config.Size,
+				"new_size":        newSize,
+				"error":           err,
+			}).Error("Unable to resize the volume.")
+			return fmt.Errorf("unable to resize the volume: %v", err)
+		}
+	}
+	return nil
+}
+
+// updateVolumeSize records the new size of a resized volume.  It assumes
+// that the mutex lock is held.
+func (o *TridentOrchestrator) updateVolumeSize(volume *storage.Volume, newSize string) error {
+
+	volume.Config.Size = newSize
+	if err := o.updateVolumeOnPersistentStore(volume); err != nil {
+		// It's ok not to revert volume size as we don't clean up the
+		// transaction object in this situation.
+		log.WithFields(log.Fields{
+			"volume": volume.Config.Name,
+		}).Error("Unable to update the volume's size in persistent store.")
+		return err
+	}
+	return nil
+}
+
+// resizeVolumeCleanup is used to clean up artifacts of volume resize in case
+// anything goes wrong during the operation.
+func (o *TridentOrchestrator) resizeVolumeCleanup(
+	err error, vol *storage.Volume, volTxn *persistentstore.VolumeTransaction) error {
+
+	if vol == nil || err == nil ||
+		(err != nil && vol.Config.Size != volTxn.Config.Size) {
+		// We either succeeded or failed to resize the volume on the
+		// backend. There are two possible failure cases:
+		// 1.  We failed to resize the volume. In this case, we just need to
+		//     remove the volume transaction.
+		// 2.  We failed to update the volume on persistent store. In this
+		//     case, we leave the volume transaction around so that we can
+		//     update the persistent store later.
+		txErr := o.deleteVolumeTransaction(volTxn)
+		if txErr != nil {
+			txErr = fmt.Errorf("unable to clean up transaction:  %v", txErr)
+		}
+		errList := make([]string, 0, 2)
+		for _, e := range []error{err, txErr} {
+			if e != nil {
+				errList = append(errList, e.Error())
+			}
+		}
+		if len(errList) > 0 {
+			err = fmt.Errorf(strings.Join(errList, ", "))
+			log.Warnf("Unable to clean up artifacts of volume resize: %v. "+
+				"Repeat resizing the volume or restart %v.",
+				err, config.OrchestratorName)
+		}
+	} else {
+		// We get here only when we fail to update the volume size in
+		// persistent store after successfully updating the volume on the
+		// backend. We leave the transaction object around so that the
+		// persistent store can be updated in the future through retries.
+	}
+	return err
+}
+
+// getProtocol returns the appropriate protocol based on a specified volume access mode and protocol, or
+// an error if the two settings are incompatible.
+//
+// Generally, the access mode maps to a protocol as follows:
+//
+//  ReadWriteOnce -> Any (File + Block)
+//  ReadOnlyMany  -> Any (File + Block)
+//  ReadWriteMany -> File
+//
+// But if the protocol is explicitly set to File or Block, then it may override ProtocolAny or generate a conflict.
+// The truth table below yields two special cases (RWX/Block) and (RWX/Any); all other rows simply echo the protocol.
+//
+//   AccessMode     Protocol     Result
+//      RWO          File        File
+//      RWO          Block       Block
+//      RWO          Any         Any
+//      ROX          File        File
+//      ROX          Block       Block
+//      ROX          Any         Any
+//      RWX          File        File
+//      RWX          Block       *ERROR*
+//      RWX          Any         *File*
+//      Any          File        File
+//      Any          Block       Block
+//      Any          Any         Any
+//
+func (o *TridentOrchestrator) getProtocol(
+	accessMode config.AccessMode, protocol config.Protocol,
+) (config.Protocol, error) {
+
+	if accessMode == config.ReadWriteMany {
+		if protocol == config.Block {
+			return config.ProtocolAny, fmt.Errorf("incompatible access mode (%s) and protocol (%s)",
+				accessMode, protocol)
+		} else if protocol == config.ProtocolAny {
+			return config.File, nil
+		}
+	}
+
+	return protocol, nil
+}
+
+func (o *TridentOrchestrator) AddStorageClass(scConfig *storageclass.Config) (*storageclass.External, error) {
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	if _, err := storageclass.NewPlacementPolicy(scConfig); err != nil {
+		return nil, err
+	}
+	if err := scConfig.ValidateVolumeDefaults(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	sc := storageclass.New(scConfig)
+	if _, ok := o.storageClasses[sc.GetName()]; ok {
+		return nil, fmt.Errorf("storage class %s already exists", sc.GetName())
+	}
+	err := o.storeClient.AddStorageClass(sc)
+	if err != nil {
+		return nil, err
+	}
+	o.storageClasses[sc.GetName()] = sc
+	added := 0
+	for _, backend := range o.backends {
+		added += sc.CheckAndAddBackend(backend)
+	}
+	if added == 0 {
+		log.WithFields(log.Fields{
+			"storageClass": scConfig.Name,
+		}).Info("No backends currently satisfy provided storage class.")
+	} else {
+		log.WithFields(log.Fields{
+			"storageClass": sc.GetName(),
+		}).Infof("Storage class satisfied by %d storage pools.", added)
+	}
+	return sc.ConstructExternal(), nil
+}
+
+func (o *TridentOrchestrator) GetStorageClass(scName string) (*storageclass.External, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	sc, found := o.storageClasses[scName]
+	if !found {
+		return nil, notFoundError(fmt.Sprintf("storage class %v was not found", scName))
+	}
+	// Storage classes aren't threadsafe (we modify them during runtime),
+	// so return a copy, rather than the original
+	return sc.ConstructExternal(), nil
+}
+
+func (o *TridentOrchestrator) ListStorageClasses() ([]*storageclass.External, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	storageClasses := make([]*storageclass.External, 0, len(o.storageClasses))
+	for _, sc := range o.storageClasses {
+		storageClasses = append(storageClasses, sc.ConstructExternal())
+	}
+	return storageClasses, nil
+}
+
+func (o *TridentOrchestrator) DeleteStorageClass(scName string) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	sc, found := o.storageClasses[scName]
+	if !found {
+		return notFoundError(fmt.Sprintf("storage class %s not found", scName))
+	}
+
+	// Note that we don't need a tranasaction here.  If this crashes prior
+	// to successful deletion, the storage class will be reloaded upon reboot
+	// automatically, which is consistent with the method never having returned
+	// successfully.
+	if err := o.storeClient.DeleteStorageClass(sc); err != nil {
+		return err
+	}
+	delete(o.storageClasses, scName)
+	for _, storagePool := range sc.GetStoragePoolsForProtocol(config.ProtocolAny) {
+		storagePool.RemoveStorageClass(scName)
+	}
+	return nil
+}
+
+func (o *TridentOrchestrator) AddNode(node *utils.Node) error {
+	if err := o.getBootstrapError(); err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if err := o.storeClient.AddOrUpdateNode(node); err != nil {
+		return err
+	}
+	o.nodes[node.Name] = node
+	return nil
+}
+
+func (o *TridentOrchestrator) GetNode(nName string) (*utils.Node, error) {
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	node, found := o.nodes[nName]
+	if !found {
+		return nil, notFoundError(fmt.Sprintf("node %v was not found", nName))
+	}
+	return node, nil
+}
+
+func (o *TridentOrchestrator) ListNodes() ([]*utils.Node, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	nodes := make([]*utils.Node, 0, len(o.nodes))
+	for _, node := range o.nodes {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (o *TridentOrchestrator) DeleteNode(nName string) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
 
-	if publishInfo.FilesystemType == "nfs" {
-		return utils.AttachNFSVolume(volumeName, mountpoint, publishInfo)
-	} else {
-		return utils.AttachISCSIVolume(volumeName, mountpoint, publishInfo)
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	node, found := o.nodes[nName]
+	if !found {
+		return notFoundError(fmt.Sprintf("node %s not found", nName))
+	}
+	if err := o.storeClient.DeleteNode(node); err != nil {
+		return err
 	}
+	delete(o.nodes, nName)
+	return nil
 }
 
-// DetachVolume unmounts a volume from the local host.  This method is currently only used by Docker,
-// and it should be able to accomplish its task using only the data passed in; it should not need to
-// use the storage controller API.  It may be assumed that this method always runs on the host to
-// which the volume will be attached.  It ensures the volume is already mounted, and it attempts to
-// delete the mount point.
-func (o *TridentOrchestrator) DetachVolume(volumeName, mountpoint string) error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+// AddVolumePublication records that a volume has been published to a node, after
+// checking that the volume's access mode permits the publication.  Recording a
+// publication that already exists has no effect.
+func (o *TridentOrchestrator) AddVolumePublication(publication *storage.VolumePublication) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+	if err := publication.Validate(); err != nil {
+		return err
 	}
 
-	if _, ok := o.volumes[volumeName]; !ok {
-		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	o.mutex.RLock()
+	err := o.checkVolumeNotMigrating(publication.VolumeName)
+	o.mutex.RUnlock()
+	if err != nil {
+		return err
 	}
 
-	log.WithFields(log.Fields{"volume": volumeName, "mountpoint": mountpoint}).Debug("Unmounting volume.")
+	utils.Lock("AddVolumePublication", volumeLockID(publication.VolumeName))
+	defer utils.Unlock("AddVolumePublication", volumeLockID(publication.VolumeName))
 
-	// Check if the mount point exists, so we know that it's attached and must be cleaned up
-	_, err := os.Stat(mountpoint)
-	if err != nil {
-		// Not attached, so nothing to do
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	volume, ok := o.volumes[publication.VolumeName]
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", publication.VolumeName))
+	}
+	if err := o.checkVolumeNotMigrating(publication.VolumeName); err != nil {
+		return err
+	}
+	if existing, ok := o.publications[publication.ID()]; ok && *existing == *publication {
 		return nil
 	}
-
-	// Unmount the volume
-	if err := utils.Umount(mountpoint); err != nil {
+	if err := o.validateVolumePublication(volume, publication); err != nil {
 		return err
 	}
 
-	// Best effort removal of the mount point
-	os.Remove(mountpoint)
+	if err := o.storeClient.AddVolumePublication(publication); err != nil {
+		return err
+	}
+	o.publications[publication.ID()] = publication
 	return nil
 }
 
-func (o *TridentOrchestrator) ListVolumeSnapshots(volumeName string) ([]*storage.SnapshotExternal, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
-	}
+// validateVolumePublication checks a new publication against the volume's access
+// mode and the volume's other publications.  It assumes the mutex lock is already held.
+func (o *TridentOrchestrator) validateVolumePublication(
+	volume *storage.Volume, publication *storage.VolumePublication,
+) error {
 
-	volume, ok := o.volumes[volumeName]
-	if !ok {
-		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
-	}
+	volumeMode := volume.Config.AccessMode
 
-	snapshots, err := o.backends[volume.Backend].Driver.SnapshotList(volume.Config.InternalName)
-	if err != nil {
-		return nil, err
+	if publication.AccessMode == config.ReadWriteMany &&
+		volumeMode != config.ReadWriteMany && volumeMode != config.ModeAny {
+		return conflictError(fmt.Sprintf("volume %s has access mode %s and cannot be published with "+
+			"access mode %s", volume.Config.Name, volumeMode, publication.AccessMode))
 	}
 
-	externalSnapshots := make([]*storage.SnapshotExternal, 0)
-	for _, snapshot := range snapshots {
-		externalSnapshots = append(externalSnapshots, snapshot.ConstructExternal())
+	switch volumeMode {
+	case config.ReadWriteOnce:
+		for _, existing := range o.publications {
+			if existing.VolumeName == publication.VolumeName && existing.NodeName != publication.NodeName {
+				return conflictError(fmt.Sprintf("volume %s has access mode %s and is already published "+
+					"to node %s", volume.Config.Name, volumeMode, existing.NodeName))
+			}
+		}
+	case config.ReadOnlyMany:
+		if !publication.ReadOnly {
+			return conflictError(fmt.Sprintf("volume %s has access mode %s and may only be published "+
+				"read-only", volume.Config.Name, volumeMode))
+		}
 	}
-	return externalSnapshots, nil
+	return nil
 }
 
-func (o *TridentOrchestrator) ReloadVolumes() error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+// DeleteVolumePublication removes the record of a volume's publication to a node.
+func (o *TridentOrchestrator) DeleteVolumePublication(volumeName, nodeName string) error {
+	if err := o.writeError(); err != nil {
+		return err
 	}
 
-	// Lock out all other workflows while we reload the volumes
+	utils.Lock("DeleteVolumePublication", volumeLockID(volumeName))
+	defer utils.Unlock("DeleteVolumePublication", volumeLockID(volumeName))
+
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	// Make a temporary copy of backends in case anything goes wrong
-	tempBackends := make(map[string]*storage.Backend)
-	for k, v := range o.backends {
-		tempBackends[k] = v
+	publication, ok := o.publications[storage.MakeVolumePublicationID(volumeName, nodeName)]
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s is not published to node %s", volumeName, nodeName))
 	}
-	// Make a temporary copy of volumes in case anything goes wrong
-	tempVolumes := make(map[string]*storage.Volume)
-	for k, v := range o.volumes {
-		tempVolumes[k] = v
+	err := o.storeClient.DeleteVolumePublication(publication)
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		return err
 	}
+	delete(o.publications, publication.ID())
+	return nil
+}
 
-	// Clear out cached volumes in the backends
-	for _, backend := range o.backends {
-		backend.Volumes = make(map[string]*storage.Volume)
+func (o *TridentOrchestrator) ListVolumePublications() ([]*storage.VolumePublication, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
 
-	// Re-run the volume bootstrapping code
-	o.volumes = make(map[string]*storage.Volume)
-	err := o.bootstrapVolumes()
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
 
-	// If anything went wrong, reinstate the original volumes
-	if err != nil {
-		log.Errorf("Volume reload failed, restoring original volume list: %v", err)
-		o.backends = tempBackends
-		o.volumes = tempVolumes
+	publications := make([]*storage.VolumePublication, 0, len(o.publications))
+	for _, p := range o.publications {
+		publications = append(publications, p)
 	}
-
-	return err
+	return publications, nil
 }
 
-// ResizeVolume resizes a volume to the new size.
-func (o *TridentOrchestrator) ResizeVolume(volumeName, newSize string) (err error) {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+func (o *TridentOrchestrator) ListVolumePublicationsForVolume(volumeName string) (
+	[]*storage.VolumePublication, error,
+) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
 
-	vol, found := o.volumes[volumeName]
-	if !found {
-		return fmt.Errorf("volume %s wasn't found", volumeName)
-	}
-	if vol.Orphaned {
-		log.WithFields(log.Fields{
-			"volume":  volumeName,
-			"backend": vol.Backend,
-		}).Warnf("Resize operation is likely to fail with an orphaned volume!")
+	if _, ok := o.volumes[volumeName]; !ok {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
 	}
 
-	// Create a new config to capture the volume size change.
-	cloneConfig := vol.Config.ConstructClone()
-	cloneConfig.Size = newSize
-
-	// Add a transaction in case the operation must be retried during bootstraping.
-	volTxn := &persistentstore.VolumeTransaction{
-		Config: cloneConfig,
-		Op:     persistentstore.ResizeVolume,
-	}
-	if err = o.addVolumeTransaction(volTxn); err != nil {
-		return
+	publications := make([]*storage.VolumePublication, 0)
+	for _, p := range o.publications {
+		if p.VolumeName == volumeName {
+			publications = append(publications, p)
+		}
 	}
+	return publications, nil
+}
 
-	defer func() {
-		if err == nil {
-			log.WithFields(log.Fields{
-				"volume":      volumeName,
-				"volume_size": newSize,
-			}).Info("Orchestrator resized the volume on the storage backend.")
-		}
-		err = o.resizeVolumeCleanup(err, vol, volTxn)
-	}()
+// reserveQuota checks that a volume being created or resized fits within each quota
+// that applies to it, and if so counts the volume's new config against the quotas
+// until releaseQuota is called.  The caller must hold the mutex lock and the volume's
+// shared lock.
+func (o *TridentOrchestrator) reserveQuota(volConfig *storage.VolumeConfig) error {
 
-	// Resize the volume.
-	return o.resizeVolume(vol, newSize)
+	if err := o.checkQuota(volConfig); err != nil {
+		return err
+	}
+	o.quotaReservations[volConfig.Name] = volConfig
+	return nil
 }
 
-// resizeVolume does the necessary work to resize a volume. It doesn't
-// construct a transaction, nor does it take locks; it assumes that the
-// caller will take care of both of these. It also assumes that the volume
-// exists in memory.
-func (o *TridentOrchestrator) resizeVolume(volume *storage.Volume, newSize string) error {
-	volumeBackend, found := o.backends[volume.Backend]
-	if !found {
-		log.WithFields(log.Fields{
-			"volume":  volume.Config.Name,
-			"backend": volume.Backend,
-		}).Error("Unable to find backend during volume resize.")
-		return fmt.Errorf("unable to find backend %v during volume resize",
-			volume.Backend)
+// checkQuota returns an error if a volume being created or resized would exceed any
+// quota that applies to it.  It assumes the mutex lock is already held.
+func (o *TridentOrchestrator) checkQuota(volConfig *storage.VolumeConfig) error {
+
+	var current *storage.VolumeConfig
+	if reserved, ok := o.quotaReservations[volConfig.Name]; ok {
+		current = reserved
+	} else if vol, ok := o.volumes[volConfig.Name]; ok {
+		current = vol.Config
 	}
 
-	if volume.Config.Size != newSize {
-		if err := volumeBackend.ResizeVolume(volume.Config.InternalName,
-			newSize); err != nil {
-			log.WithFields(log.Fields{
-				"volume":          volume.Config.Name,
-				"volume_internal": volume.Config.InternalName,
-				"backend":         volume.Backend,
-				"current_size":    volume.Config.Size,
-				"new_size":        newSize,
-				"error":           err,
-			}).Error("Unable to resize the volume.")
-			return fmt.Errorf("unable to resize the volume: %v", err)
+	for _, quota := range o.quotas {
+		if !quota.Applies(volConfig) {
+			continue
+		}
+		usedBytes, usedVolumes := o.quotaUsage(quota)
+		newBytes, newVolumes := usedBytes+quotaSizeBytes(volConfig), usedVolumes+1
+		if current != nil && quota.Applies(current) {
+			newBytes -= quotaSizeBytes(current)
+			newVolumes--
 		}
-	}
 
-	volume.Config.Size = newSize
-	if err := o.updateVolumeOnPersistentStore(volume); err != nil {
-		// It's ok not to revert volume size as we don't clean up the
-		// transaction object in this situation.
-		log.WithFields(log.Fields{
-			"volume": volume.Config.Name,
-		}).Error("Unable to update the volume's size in persistent store.")
-		return err
+		// Only reject requests that add to the usage, so that volumes may be shrunk
+		// or deleted while their owner is over a lowered quota
+		if quota.MaxVolumes > 0 && newVolumes > quota.MaxVolumes && newVolumes > usedVolumes {
+			return fmt.Errorf("volume %s would exceed quota %s of %d volumes for %s %s",
+				volConfig.Name, quota.Name, quota.MaxVolumes, quota.Scope, quota.Owner)
+		}
+		if maxBytes, err := quota.MaxBytes(); err == nil && maxBytes > 0 && newBytes > maxBytes &&
+			newBytes > usedBytes {
+			return fmt.Errorf("volume %s would exceed quota %s of %s for %s %s; %d bytes are in use",
+				volConfig.Name, quota.Name, quota.MaxSize, quota.Scope, quota.Owner, usedBytes)
+		}
 	}
 	return nil
 }
 
-// resizeVolumeCleanup is used to clean up artifacts of volume resize in case
-// anything goes wrong during the operation.
-func (o *TridentOrchestrator) resizeVolumeCleanup(
-	err error, vol *storage.Volume, volTxn *persistentstore.VolumeTransaction) error {
+// releaseQuota stops counting a volume's reserved config against the quotas, once the
+// volume has been created or resized or the operation has failed.  The caller must hold
+// the volume's shared lock.
+func (o *TridentOrchestrator) releaseQuota(volumeName string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 
-	if vol == nil || err == nil ||
-		(err != nil && vol.Config.Size != volTxn.Config.Size) {
-		// We either succeeded or failed to resize the volume on the
-		// backend. There are two possible failure cases:
-		// 1.  We failed to resize the volume. In this case, we just need to
-		//     remove the volume transaction.
-		// 2.  We failed to update the volume on persistent store. In this
-		//     case, we leave the volume transaction around so that we can
-		//     update the persistent store later.
-		txErr := o.deleteVolumeTransaction(volTxn)
-		if txErr != nil {
-			txErr = fmt.Errorf("unable to clean up transaction:  %v", txErr)
+	delete(o.quotaReservations, volumeName)
+}
+
+// quotaSizeBytes returns the size of a volume in bytes, or zero if the size is unknown.
+func quotaSizeBytes(volConfig *storage.VolumeConfig) uint64 {
+	size, err := volumeSizeBytes(volConfig)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// quotaUsage totals the size and number of the volumes that count against a quota,
+// including volumes that are being created or resized.  It assumes the mutex lock
+// is already held.
+func (o *TridentOrchestrator) quotaUsage(quota *storage.Quota) (uint64, int) {
+
+	var usedBytes uint64
+	usedVolumes := 0
+	count := func(volConfig *storage.VolumeConfig) {
+		if quota.Applies(volConfig) {
+			usedBytes += quotaSizeBytes(volConfig)
+			usedVolumes++
 		}
-		errList := make([]string, 0, 2)
-		for _, e := range []error{err, txErr} {
-			if e != nil {
-				errList = append(errList, e.Error())
-			}
+	}
+
+	for name, vol := range o.volumes {
+		if reserved, ok := o.quotaReservations[name]; ok {
+			count(reserved)
+		} else {
+			count(vol.Config)
 		}
-		if len(errList) > 0 {
-			err = fmt.Errorf(strings.Join(errList, ", "))
-			log.Warnf("Unable to clean up artifacts of volume resize: %v. "+
-				"Repeat resizing the volume or restart %v.",
-				err, config.OrchestratorName)
+	}
+	for name, reserved := range o.quotaReservations {
+		if _, ok := o.volumes[name]; !ok {
+			count(reserved)
 		}
-	} else {
-		// We get here only when we fail to update the volume size in
-		// persistent store after successfully updating the volume on the
-		// backend. We leave the transaction object around so that the
-		// persistent store can be updated in the future through retries.
 	}
-	return err
+	return usedBytes, usedVolumes
 }
 
-// getProtocol returns the appropriate protocol based on a specified volume access mode and protocol, or
-// an error if the two settings are incompatible.
-//
-// Generally, the access mode maps to a protocol as follows:
-//
-//  ReadWriteOnce -> Any (File + Block)
-//  ReadOnlyMany  -> Any (File + Block)
-//  ReadWriteMany -> File
-//
-// But if the protocol is explicitly set to File or Block, then it may override ProtocolAny or generate a conflict.
-// The truth table below yields two special cases (RWX/Block) and (RWX/Any); all other rows simply echo the protocol.
-//
-//   AccessMode     Protocol     Result
-//      RWO          File        File
-//      RWO          Block       Block
-//      RWO          Any         Any
-//      ROX          File        File
-//      ROX          Block       Block
-//      ROX          Any         Any
-//      RWX          File        File
-//      RWX          Block       *ERROR*
-//      RWX          Any         *File*
-//      Any          File        File
-//      Any          Block       Block
-//      Any          Any         Any
-//
-func (o *TridentOrchestrator) getProtocol(
-	accessMode config.AccessMode, protocol config.Protocol,
-) (config.Protocol, error) {
+// constructQuotaExternal returns a quota with its current usage.  It assumes the
+// mutex lock is already held.
+func (o *TridentOrchestrator) constructQuotaExternal(quota *storage.Quota) *storage.QuotaExternal {
+	usedBytes, usedVolumes := o.quotaUsage(quota)
+	return &storage.QuotaExternal{
+		Quota:       *quota,
+		UsedBytes:   usedBytes,
+		UsedVolumes: usedVolumes,
+	}
+}
 
-	if accessMode == config.ReadWriteMany {
-		if protocol == config.Block {
-			return config.ProtocolAny, fmt.Errorf("incompatible access mode (%s) and protocol (%s)",
-				accessMode, protocol)
-		} else if protocol == config.ProtocolAny {
-			return config.File, nil
-		}
+func (o *TridentOrchestrator) AddQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+	if err := quota.Validate(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if _, ok := o.quotas[quota.Name]; ok {
+		return nil, fmt.Errorf("quota %s already exists", quota.Name)
 	}
+	if err := o.storeClient.AddOrUpdateQuota(quota); err != nil {
+		return nil, err
+	}
+	o.quotas[quota.Name] = quota
 
-	return protocol, nil
+	return o.constructQuotaExternal(quota), nil
 }
 
-func (o *TridentOrchestrator) AddStorageClass(scConfig *storageclass.Config) (*storageclass.External, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+// UpdateQuota replaces a quota's owner and limits.  Existing volumes are never
+// affected, even if the new limits leave their owner over quota.
+func (o *TridentOrchestrator) UpdateQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+	if err := quota.Validate(); err != nil {
+		return nil, err
 	}
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
-	sc := storageclass.New(scConfig)
-	if _, ok := o.storageClasses[sc.GetName()]; ok {
-		return nil, fmt.Errorf("storage class %s already exists", sc.GetName())
+
+	if _, ok := o.quotas[quota.Name]; !ok {
+		return nil, notFoundError(fmt.Sprintf("quota %s not found", quota.Name))
 	}
-	err := o.storeClient.AddStorageClass(sc)
-	if err != nil {
+	if err := o.storeClient.AddOrUpdateQuota(quota); err != nil {
 		return nil, err
 	}
-	o.storageClasses[sc.GetName()] = sc
-	added := 0
-	for _, backend := range o.backends {
-		added += sc.CheckAndAddBackend(backend)
+	o.quotas[quota.Name] = quota
+
+	return o.constructQuotaExternal(quota), nil
+}
+
+func (o *TridentOrchestrator) GetQuota(quotaName string) (*storage.QuotaExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
-	if added == 0 {
-		log.WithFields(log.Fields{
-			"storageClass": scConfig.Name,
-		}).Info("No backends currently satisfy provided storage class.")
-	} else {
-		log.WithFields(log.Fields{
-			"storageClass": sc.GetName(),
-		}).Infof("Storage class satisfied by %d storage pools.", added)
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	quota, ok := o.quotas[quotaName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("quota %s not found", quotaName))
 	}
-	return sc.ConstructExternal(), nil
+	return o.constructQuotaExternal(quota), nil
 }
 
-func (o *TridentOrchestrator) GetStorageClass(scName string) (*storageclass.External, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+func (o *TridentOrchestrator) ListQuotas() ([]*storage.QuotaExternal, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
 
-	sc, found := o.storageClasses[scName]
-	if !found {
-		return nil, notFoundError(fmt.Sprintf("storage class %v was not found", scName))
+	quotas := make([]*storage.QuotaExternal, 0, len(o.quotas))
+	for _, quota := range o.quotas {
+		quotas = append(quotas, o.constructQuotaExternal(quota))
 	}
-	// Storage classes aren't threadsafe (we modify them during runtime),
-	// so return a copy, rather than the original
-	return sc.ConstructExternal(), nil
+	return quotas, nil
 }
 
-func (o *TridentOrchestrator) ListStorageClasses() ([]*storageclass.External, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+func (o *TridentOrchestrator) DeleteQuota(quotaName string) error {
+	if err := o.writeError(); err != nil {
+		return err
 	}
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	storageClasses := make([]*storageclass.External, 0, len(o.storageClasses))
-	for _, sc := range o.storageClasses {
-		storageClasses = append(storageClasses, sc.ConstructExternal())
+	quota, ok := o.quotas[quotaName]
+	if !ok {
+		return notFoundError(fmt.Sprintf("quota %s not found", quotaName))
 	}
-	return storageClasses, nil
+	if err := o.storeClient.DeleteQuota(quota); err != nil {
+		return err
+	}
+	delete(o.quotas, quotaName)
+	return nil
 }
 
-func (o *TridentOrchestrator) DeleteStorageClass(scName string) error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
-	}
+// RecordEvent adds an event to the event log, and saves it to the persistent store if
+// events are persisted.
+func (o *TridentOrchestrator) RecordEvent(event *storage.Event) {
+	o.mutex.RLock()
+	o.recordEvent(event)
+	o.mutex.RUnlock()
 
-	sc, found := o.storageClasses[scName]
-	if !found {
-		return notFoundError(fmt.Sprintf("storage class %s not found", scName))
+	o.flushEvents()
+}
+
+// recordEvent adds an event to the event log, and queues it to be saved to the
+// persistent store by flushEvents if events are persisted.  The caller must hold the
+// mutex lock, and must call flushEvents once it has released the lock.
+func (o *TridentOrchestrator) recordEvent(event *storage.Event) {
+
+	evicted := o.events.add(event)
+
+	log.WithFields(log.Fields{
+		"id":         event.ID,
+		"operation":  event.Operation,
+		"objectType": event.ObjectType,
+		"objectName": event.ObjectName,
+		"source":     event.Source,
+		"outcome":    event.Outcome,
+	}).Debug("Recorded event.")
+
+	// A standby's events aren't saved, as only the leader may change the store
+	if !o.persistEvents || o.isStandby() {
+		return
 	}
+	o.events.queue(event, evicted)
+}
 
-	// Note that we don't need a tranasaction here.  If this crashes prior
-	// to successful deletion, the storage class will be reloaded upon reboot
-	// automatically, which is consistent with the method never having returned
-	// successfully.
-	if err := o.storeClient.DeleteStorageClass(sc); err != nil {
-		return err
+// flushEvents saves the events recorded since the last flush to the persistent store,
+// so that the store isn't written while the mutex lock is held.  The caller must not
+// hold the mutex lock.
+func (o *TridentOrchestrator) flushEvents() {
+
+	o.events.flushMutex.Lock()
+	defer o.events.flushMutex.Unlock()
+
+	for _, change := range o.events.takePending() {
+		if change.deleted {
+			if err := o.storeClient.DeleteEvent(change.event); err != nil {
+				log.WithFields(log.Fields{
+					"id":    change.event.ID,
+					"error": err,
+				}).Warning("Could not delete event from the persistent store.")
+			}
+		} else if err := o.storeClient.AddEvent(change.event); err != nil {
+			log.WithFields(log.Fields{
+				"id":    change.event.ID,
+				"error": err,
+			}).Warning("Could not save event to the persistent store.")
+		}
 	}
-	delete(o.storageClasses, scName)
-	for _, storagePool := range sc.GetStoragePoolsForProtocol(config.ProtocolAny) {
-		storagePool.RemoveStorageClass(scName)
+}
+
+// ListEvents returns the recorded events, oldest first.
+func (o *TridentOrchestrator) ListEvents() ([]*storage.Event, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
 	}
-	return nil
+	return o.events.list(), nil
 }
 
-func (o *TridentOrchestrator) AddNode(node *utils.Node) error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+// BackupState returns an archive of the orchestrator state held in the persistent
+// store.  The backend credentials never leave the orchestrator in plaintext: they are
+// encrypted with the given key, or redacted if there is none.
+func (o *TridentOrchestrator) BackupState(encryptionKey []byte) (*persistentstore.Backup, error) {
+	if err := o.getBootstrapError(); err != nil {
+		return nil, err
+	}
+	if config.UsingPassthroughStore {
+		return nil, unsupportedError("state cannot be backed up when using the passthrough store")
 	}
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
-	if err := o.storeClient.AddOrUpdateNode(node); err != nil {
+
+	// A store that has never held a given kind of object may report it missing
+	ignoreNotFound := func(err error) error {
+		if persistentstore.MatchKeyNotFoundErr(err) {
+			return nil
+		}
 		return err
 	}
-	o.nodes[node.Name] = node
-	return nil
+
+	backup := &persistentstore.Backup{
+		FormatVersion:  persistentstore.BackupFormatVersion,
+		TridentVersion: config.OrchestratorVersion.String(),
+		Created:        time.Now().UTC().Format(time.RFC3339),
+		Secrets:        persistentstore.BackupSecretsPlaintext,
+	}
+
+	var err error
+	if backup.StateVersion, err = o.storeClient.GetVersion(); err != nil {
+		return nil, fmt.Errorf("couldn't read the persistent state version; %v", err)
+	}
+	if backup.Backends, err = o.storeClient.GetBackends(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the backends; %v", err)
+	}
+	if backup.StorageClasses, err = o.storeClient.GetStorageClasses(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the storage classes; %v", err)
+	}
+	if backup.Volumes, err = o.storeClient.GetVolumes(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the volumes; %v", err)
+	}
+	if backup.Snapshots, err = o.storeClient.GetSnapshots(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the snapshots; %v", err)
+	}
+	if backup.GroupSnapshots, err = o.storeClient.GetGroupSnapshots(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the group snapshots; %v", err)
+	}
+	if backup.Nodes, err = o.storeClient.GetNodes(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the nodes; %v", err)
+	}
+	if backup.Publications, err = o.storeClient.GetVolumePublications(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the volume publications; %v", err)
+	}
+	if backup.Quotas, err = o.storeClient.GetQuotas(); ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the quotas; %v", err)
+	}
+	transactions, err := o.storeClient.GetVolumeTransactions()
+	if ignoreNotFound(err) != nil {
+		return nil, fmt.Errorf("couldn't read the volume transactions; %v", err)
+	}
+	backup.Transactions = make([]*persistentstore.VolumeTransaction, 0, len(transactions))
+	for _, t := range transactions {
+		if restorableTransaction(t) {
+			backup.Transactions = append(backup.Transactions, t)
+		}
+	}
+
+	if len(encryptionKey) == 0 {
+		err = backup.RedactSecrets()
+	} else {
+		err = backup.EncryptSecrets(encryptionKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"secrets":        backup.Secrets,
+		"backends":       len(backup.Backends),
+		"storageClasses": len(backup.StorageClasses),
+		"volumes":        len(backup.Volumes),
+		"snapshots":      len(backup.Snapshots),
+		"nodes":          len(backup.Nodes),
+		"transactions":   len(backup.Transactions),
+	}).Info("Backed up the orchestrator state.")
+
+	return backup, nil
 }
 
-func (o *TridentOrchestrator) GetNode(nName string) (*utils.Node, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+// RestoreState loads an archive made by BackupState into the persistent store and
+// bootstraps the orchestrator from it.  The orchestrator must not yet have any
+// backends, storage classes or volumes, and it isn't ready while the restore runs.
+func (o *TridentOrchestrator) RestoreState(backup *persistentstore.Backup) error {
+	if err := o.writeError(); err != nil {
+		return err
+	}
+	if config.UsingPassthroughStore {
+		return unsupportedError("state cannot be restored when using the passthrough store")
+	}
+	if backup == nil {
+		return fmt.Errorf("no backup was provided")
+	}
+	if err := backup.Validate(); err != nil {
+		return fmt.Errorf("invalid backup; %v", err)
 	}
 
 	o.mutex.Lock()
-	defer o.mutex.Unlock()
 
-	node, found := o.nodes[nName]
-	if !found {
-		return nil, notFoundError(fmt.Sprintf("node %v was not found", nName))
+	if len(o.backends) > 0 || len(o.storageClasses) > 0 || len(o.volumes) > 0 {
+		o.mutex.Unlock()
+		return conflictError("state may only be restored when there are no backends, " +
+			"storage classes or volumes")
 	}
-	return node, nil
+
+	// Turn away all other workflows, including other restores, until the restored
+	// state is bootstrapped
+	o.statusMutex.Lock()
+	if !o.bootstrapped {
+		o.statusMutex.Unlock()
+		o.mutex.Unlock()
+		return notReadyError()
+	}
+	o.bootstrapped = false
+	o.bootstrapError = notReadyError()
+	o.statusMutex.Unlock()
+
+	// Nodes and quotas may already exist, so remember them in case the restore fails
+	previousNodes := make(map[string]*utils.Node, len(o.nodes))
+	for name, node := range o.nodes {
+		previousNodes[name] = node
+	}
+	previousQuotas := make(map[string]*storage.Quota, len(o.quotas))
+	for name, quota := range o.quotas {
+		previousQuotas[name] = quota
+	}
+	o.mutex.Unlock()
+
+	err := o.restoreState(backup, previousNodes, previousQuotas)
+
+	o.setBootstrapped(true, nil)
+
+	if err != nil {
+		log.WithField("error", err).Error("Failed to restore the orchestrator state.")
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"backends":       len(backup.Backends),
+		"storageClasses": len(backup.StorageClasses),
+		"volumes":        len(backup.Volumes),
+		"created":        backup.Created,
+		"tridentVersion": backup.TridentVersion,
+	}).Info("Restored the orchestrator state.")
+
+	return nil
 }
 
-func (o *TridentOrchestrator) ListNodes() ([]*utils.Node, error) {
-	if o.bootstrapError != nil {
-		return nil, o.bootstrapError
+// restoreState writes the objects in a backup to the persistent store and adds them to
+// the orchestrator.  The backends are initialized and the store is written without
+// holding the mutex lock, which is only taken to add the objects to the orchestrator.
+// If anything fails, including finishing the restored volume transactions, the objects
+// written so far are removed from the store again and any nodes and quotas they
+// replaced are put back, so that the restore may be repeated.  The caller must not
+// hold the mutex lock.
+func (o *TridentOrchestrator) restoreState(
+	backup *persistentstore.Backup, previousNodes map[string]*utils.Node,
+	previousQuotas map[string]*storage.Quota,
+) (err error) {
+
+	// A private orchestrator initializes the backends, so that the storage systems
+	// aren't contacted while holding the mutex
+	staging := NewTridentOrchestrator(o.storeClient)
+	backends := make([]*storage.Backend, 0, len(backup.Backends))
+	for _, b := range backup.Backends {
+		backend, err := staging.bootstrapBackend(b)
+		if err != nil {
+			for _, staged := range staging.backends {
+				staged.Terminate()
+			}
+			return fmt.Errorf("couldn't restore backend %s; %v", b.Name, err)
+		}
+		backends = append(backends, backend)
 	}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+	// Remember how to undo each write, and undo them all if the restore fails
+	undo := make([]func() error, 0)
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, backend := range backends {
+			backend.Terminate()
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			if undoErr := undo[i](); undoErr != nil && !persistentstore.MatchKeyNotFoundErr(undoErr) {
+				log.WithField("error", undoErr).Error("Could not remove a restored object from the " +
+					"persistent store.")
+			}
+		}
+	}()
 
-	nodes := make([]*utils.Node, 0, len(o.nodes))
-	for _, node := range o.nodes {
-		nodes = append(nodes, node)
+	for _, backend := range backends {
+		if err = o.storeClient.AddBackend(backend); err != nil {
+			return fmt.Errorf("couldn't store backend %s; %v", backend.Name, err)
+		}
+		backend := backend
+		undo = append(undo, func() error { return o.storeClient.DeleteBackend(backend) })
 	}
-	return nodes, nil
+	for _, psc := range backup.StorageClasses {
+		sc := storageclass.NewFromPersistent(psc)
+		if err = o.storeClient.AddStorageClass(sc); err != nil {
+			return fmt.Errorf("couldn't store storage class %s; %v", sc.GetName(), err)
+		}
+		undo = append(undo, func() error { return o.storeClient.DeleteStorageClass(sc) })
+	}
+	for _, v := range backup.Volumes {
+		vol := storage.NewVolume(v.Config, v.Backend, v.Pool, v.Orphaned)
+		if err = o.storeClient.AddVolume(vol); err != nil {
+			return fmt.Errorf("couldn't store volume %s; %v", v.Config.Name, err)
+		}
+		undo = append(undo, func() error { return o.storeClient.DeleteVolume(vol) })
+	}
+	for _, s := range backup.Snapshots {
+		snapshot := storage.NewSnapshot(s.Config, s.Created, s.SizeBytes, s.State)
+		if err = o.storeClient.AddSnapshot(snapshot); err != nil {
+			return fmt.Errorf("couldn't store snapshot %s; %v", s.Config.Name, err)
+		}
+		undo = append(undo, func() error { return o.storeClient.DeleteSnapshot(snapshot) })
+	}
+	for _, g := range backup.GroupSnapshots {
+		if err = o.storeClient.AddGroupSnapshot(g); err != nil {
+			return fmt.Errorf("couldn't store group snapshot %s; %v", g.Config.Name, err)
+		}
+		g := g
+		undo = append(undo, func() error { return o.storeClient.DeleteGroupSnapshot(g) })
+	}
+	for _, n := range backup.Nodes {
+		if err = o.storeClient.AddOrUpdateNode(n); err != nil {
+			return fmt.Errorf("couldn't store node %s; %v", n.Name, err)
+		}
+		if previous, ok := previousNodes[n.Name]; ok {
+			undo = append(undo, func() error { return o.storeClient.AddOrUpdateNode(previous) })
+		} else {
+			n := n
+			undo = append(undo, func() error { return o.storeClient.DeleteNode(n) })
+		}
+	}
+	for _, p := range backup.Publications {
+		if err = o.storeClient.AddVolumePublication(p); err != nil {
+			return fmt.Errorf("couldn't store publication of volume %s to node %s; %v",
+				p.VolumeName, p.NodeName, err)
+		}
+		p := p
+		undo = append(undo, func() error { return o.storeClient.DeleteVolumePublication(p) })
+	}
+	for _, q := range backup.Quotas {
+		if err = o.storeClient.AddOrUpdateQuota(q); err != nil {
+			return fmt.Errorf("couldn't store quota %s; %v", q.Name, err)
+		}
+		if previous, ok := previousQuotas[q.Name]; ok {
+			undo = append(undo, func() error { return o.storeClient.AddOrUpdateQuota(previous) })
+		} else {
+			q := q
+			undo = append(undo, func() error { return o.storeClient.DeleteQuota(q) })
+		}
+	}
+	for _, t := range backup.Transactions {
+		if !restorableTransaction(t) {
+			log.WithField("op", t.Op).Warning("Skipped restoring the transaction of an unfinished creation.")
+			continue
+		}
+		if err = o.storeClient.AddVolumeTransaction(t); err != nil {
+			return fmt.Errorf("couldn't store volume transaction; %v", err)
+		}
+		t := t
+		undo = append(undo, func() error { return o.storeClient.DeleteVolumeTransaction(t) })
+	}
+
+	// Add the backends, and bootstrap everything else from the store
+	err = func() error {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+
+		for _, backend := range backends {
+			o.backends[backend.Name] = backend
+		}
+		type bootstrapFunc func() error
+		for _, f := range []bootstrapFunc{o.bootstrapStorageClasses, o.bootstrapVolumes,
+			o.bootstrapSnapshots, o.bootstrapGroupSnapshots, o.bootstrapNodes,
+			o.bootstrapVolumePublications, o.bootstrapQuotas} {
+			if err := f(); err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+				return err
+			}
+		}
+		return nil
+	}()
+
+	// Volume transactions are handled last, as they take the mutex themselves
+	if err == nil {
+		err = o.bootstrapVolTxns()
+	}
+	if err != nil {
+		o.mutex.Lock()
+		o.resetState()
+		o.nodes = previousNodes
+		o.quotas = previousQuotas
+		o.mutex.Unlock()
+	}
+	return err
 }
 
-func (o *TridentOrchestrator) DeleteNode(nName string) error {
-	if o.bootstrapError != nil {
-		return o.bootstrapError
+// restorableTransaction returns whether a volume transaction may be restored from a
+// backup.  Unfinished volume and snapshot creations are rolled back by deleting what
+// they created, which may well be in use by the time a backup is restored, so they are
+// left out.
+func restorableTransaction(t *persistentstore.VolumeTransaction) bool {
+	switch t.Op {
+	case persistentstore.AddVolume, persistentstore.AddSnapshot:
+		return false
+	default:
+		return true
 	}
+}
 
-	o.mutex.Lock()
-	defer o.mutex.Unlock()
+// ReencryptBackends reloads the keyring with which backend credentials are encrypted
+// in the persistent store, and writes the credentials of each backend again, so that
+// they are encrypted with its active key.  Once this succeeds, keys that were rotated
+// out may be removed from the keyring.  It returns the names of the backends written.
+func (o *TridentOrchestrator) ReencryptBackends() ([]string, error) {
+	if err := o.writeError(); err != nil {
+		return nil, err
+	}
+	encryptingClient, ok := o.storeClient.(*persistentstore.EncryptingClient)
+	if !ok {
+		return nil, unsupportedError("backend credentials are not encrypted in the persistent store; " +
+			"start Trident with an encryption keyring to encrypt them")
+	}
+	if err := encryptingClient.ReloadKeyring(); err != nil {
+		return nil, err
+	}
 
-	node, found := o.nodes[nName]
-	if !found {
-		return notFoundError(fmt.Sprintf("node %s not found", nName))
+	o.mutex.RLock()
+	backendNames := make([]string, 0, len(o.backends))
+	for backendName := range o.backends {
+		backendNames = append(backendNames, backendName)
 	}
-	if err := o.storeClient.DeleteNode(node); err != nil {
-		return err
+	o.mutex.RUnlock()
+	sort.Strings(backendNames)
+
+	reencrypted := make([]string, 0, len(backendNames))
+	for _, backendName := range backendNames {
+		written, err := o.reencryptBackend(backendName)
+		if err != nil {
+			return reencrypted, fmt.Errorf("could not re-encrypt the credentials of backend %s; %v",
+				backendName, err)
+		}
+		if written {
+			reencrypted = append(reencrypted, backendName)
+		}
 	}
-	delete(o.nodes, nName)
-	return nil
+
+	log.WithFields(log.Fields{
+		"activeKeyID": encryptingClient.ActiveKeyID(),
+		"backends":    len(reencrypted),
+	}).Info("Re-encrypted the backend credentials.")
+
+	return reencrypted, nil
+}
+
+// reencryptBackend writes a backend to the persistent store again, returning false if
+// it has been deleted in the meantime.
+func (o *TridentOrchestrator) reencryptBackend(backendName string) (bool, error) {
+	utils.Lock("ReencryptBackends", backendLockID(backendName))
+	defer utils.Unlock("ReencryptBackends", backendLockID(backendName))
+
+	o.mutex.RLock()
+	backend, ok := o.backends[backendName]
+	o.mutex.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	return true, o.storeClient.UpdateBackend(backend)
 }
 
 func (o *TridentOrchestrator) updateBackendOnPersistentStore(
 	backend *storage.Backend, newBackend bool,
 ) error {
 	// Update the persistent store with the backend information
-	if o.bootstrapped || config.UsingPassthroughStore {
+	if o.isBootstrapped() || config.UsingPassthroughStore {
 		var err error
 		if newBackend {
 			err = o.storeClient.AddBackend(backend)
@@ -2023,6 +6352,16 @@ func notReadyError() error {
 	}
 }
 
+func notLeaderError(leader string) error {
+	if leader == "" {
+		leader = "unknown"
+	}
+	return &NotReadyError{
+		fmt.Sprintf("this %s replica is a standby and can't change any state; the leader is %s",
+			strings.Title(config.OrchestratorName), leader),
+	}
+}
+
 func IsNotReadyError(err error) bool {
 	if err == nil {
 		return false
@@ -2065,3 +6404,23 @@ func foundError(message string) error {
 func unsupportedError(message string) error {
 	return &UnsupportedError{message}
 }
+
+func IsUnsupportedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*UnsupportedError)
+	return ok
+}
+
+func conflictError(message string) error {
+	return &ConflictError{message}
+}
+
+func IsConflictError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*ConflictError)
+	return ok
+}
@@ -3,12 +3,16 @@
 package core
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -18,6 +22,7 @@ import (
 	"github.com/netapp/trident/storage/fake"
 	sa "github.com/netapp/trident/storage_attribute"
 	"github.com/netapp/trident/storage_class"
+	drivers "github.com/netapp/trident/storage_drivers"
 	fakedriver "github.com/netapp/trident/storage_drivers/fake"
 	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
 	"github.com/netapp/trident/utils"
@@ -74,6 +79,26 @@ func cleanup(t *testing.T, o *TridentOrchestrator) {
 	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
 		t.Fatal("Unable to clean up volumes:  ", err)
 	}
+	err = o.storeClient.DeleteSnapshots()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Fatal("Unable to clean up snapshots:  ", err)
+	}
+	err = o.storeClient.DeleteVolumePublications()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Fatal("Unable to clean up volume publications:  ", err)
+	}
+	err = o.storeClient.DeleteEvents()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Fatal("Unable to clean up events:  ", err)
+	}
+	err = o.storeClient.DeleteQuotas()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Fatal("Unable to clean up quotas:  ", err)
+	}
+	err = o.storeClient.DeleteGroupSnapshots()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Fatal("Unable to clean up group snapshots:  ", err)
+	}
 	if *etcdV2 == "" && *etcdV3 == "" {
 		// Clear the InMemoryClient state so that it looks like we're
 		// bootstrapping afresh next time.
@@ -1747,390 +1772,2660 @@ func TestDeleteVolumeRecovery(t *testing.T) {
 	cleanup(t, orchestrator)
 }
 
-func TestBadBootstrapEtcdV2(t *testing.T) {
-	if *etcdV2 == "" {
-		t.SkipNow()
-	}
-	_, err := persistentstore.NewEtcdClientV2("invalidIPAddress")
-	if err != nil && persistentstore.MatchUnavailableClusterErr(err) {
-	} else {
-		panic(fmt.Errorf("didn't catch invalid etcdv2 client"))
-	}
-}
-
-func TestBadBootstrapEtcdV3(t *testing.T) {
-	if *etcdV3 == "" {
-		t.SkipNow()
-	}
-	_, err := persistentstore.NewEtcdClientV3("invalidIPAddress")
-	if err != nil && persistentstore.MatchUnavailableClusterErr(err) {
-	} else {
-		panic(fmt.Errorf("didn't catch invalid etcdv3 client"))
-	}
-}
-
-func TestBootstrapEtcdV2ToEtcdV3Migration(t *testing.T) {
+func TestSnapshots(t *testing.T) {
 	const (
-		backendName = "bootstrapV2toV3_backend"
-		scName      = "bootstrapV2toV3_class"
-		volumeName  = "bootstrapV2toV3_vol"
+		backendName  = "snapshotBackend"
+		scName       = "snapshotBackendSC"
+		volumeName   = "snapshotVolume"
+		snapshotName = "snapshot"
 	)
-	if *etcdV3 == "" {
-		t.SkipNow()
-	}
-	cleanupStoreVersion(t, *etcdV3)
-
-	// Populate the etcdv2 cluster with one backend, one storage class, and one volume
-	etcdV2Orig := etcdV2
-	etcdV2 = etcdV3
-	orchestratorV2 := getOrchestrator()
-	addBackendStorageClass(t, orchestratorV2, backendName, scName)
-
-	orchestratorV2.mutex.Lock()
-	if _, ok := orchestratorV2.storageClasses[scName]; !ok {
-		t.Fatal("Storage class not found in the orchestrator map!")
-	}
-	orchestratorV2.mutex.Unlock()
-
-	v2Volume, err := orchestratorV2.AddVolume(generateVolumeConfig(volumeName, 50, scName,
-		config.File))
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	_, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 50, scName, config.File))
 	if err != nil {
-		t.Fatal("Unable to create volume: ", err)
-	}
-	orchestratorV2.mutex.Lock()
-	if _, ok := orchestratorV2.volumes[volumeName]; !ok {
-		t.Fatal("Volume name found in orchestrator map!")
+		t.Fatal("Unable to add volume: ", err)
 	}
-	orchestratorV2.mutex.Unlock()
 
-	// Bootstrap etcdv3 orchestrator with etcdv2 data
-	etcdV2 = etcdV2Orig
-	orchestratorV3 := getOrchestrator()
-
-	// Verify etcdv2 to etcdv3 transformation
-	if v3Backend, _ := orchestratorV3.GetBackend(backendName); v3Backend == nil {
-		t.Fatalf("Failed to find backend %s after bootstrapping!", backendName)
+	// Create a snapshot
+	snapshotConfig := &storage.SnapshotConfig{Name: snapshotName, VolumeName: volumeName}
+	snapshot, err := orchestrator.CreateSnapshot(snapshotConfig)
+	if err != nil {
+		t.Fatal("Unable to create snapshot: ", err)
 	}
-	//TODO: Optionally we can diff etcdv2 and etcdv3 backends here
-	v3StorageClass, err := orchestratorV3.GetStorageClass(scName)
-	if v3StorageClass == nil || err != nil {
-		t.Fatalf("Failed to find storage class %s after bootstrapping!", scName)
+	if snapshot.Config.VolumeInternalName == "" || !snapshot.State.IsOnline() {
+		t.Errorf("Unexpected snapshot returned from CreateSnapshot: %+v", snapshot)
 	}
-	//TODO: Optionally we can diff etcdv2 and etcdv3 storage classes here
-	v3Volume, err := orchestratorV3.GetVolume(volumeName)
-	if v3Volume == nil || err != nil {
-		t.Fatalf("Failed to find storage class %s after bootstrapping!", volumeName)
+	if _, err = orchestrator.CreateSnapshot(
+		&storage.SnapshotConfig{Name: snapshotName, VolumeName: volumeName}); err == nil {
+		t.Error("Expected creating a duplicate snapshot to fail.")
 	}
-	// Verifying the same volume exists on both clusters
-	if !reflect.DeepEqual(v2Volume, v3Volume) {
-		t.Fatalf("etcdv2 volume (%v) doesn't match the etcdv3 volume (%v)!",
-			v2Volume, v3Volume)
+	if _, err = orchestrator.CreateSnapshot(
+		&storage.SnapshotConfig{Name: snapshotName, VolumeName: "missingVolume"}); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
 	}
-	etcdv3Client, err := persistentstore.NewEtcdClientV3(*etcdV3)
-	if err != nil {
-		t.Fatalf("Creating etcdv3 client failed: %v", err)
+
+	// Read the snapshot back, from the cache and after bootstrapping from the store
+	for _, o := range []*TridentOrchestrator{orchestrator, getOrchestrator()} {
+		if _, err = o.GetSnapshot(volumeName, snapshotName); err != nil {
+			t.Errorf("Unable to get snapshot: %v", err)
+		}
+		if snapshots, err := o.ListSnapshots(); err != nil {
+			t.Errorf("Unable to list snapshots: %v", err)
+		} else if len(snapshots) != 1 {
+			t.Errorf("Expected 1 snapshot, got %d", len(snapshots))
+		}
+		if snapshots, err := o.ListSnapshotsForVolume(volumeName); err != nil {
+			t.Errorf("Unable to list snapshots for volume: %v", err)
+		} else if len(snapshots) != 1 {
+			t.Errorf("Expected 1 snapshot for volume %s, got %d", volumeName, len(snapshots))
+		}
 	}
-	versionJSON, err := etcdv3Client.Read(config.StoreURL)
-	if err != nil {
-		t.Fatalf("Couldn't determine the orchestrator persistent state version: %v",
-			err)
+
+	// Delete the snapshot
+	if err = orchestrator.DeleteSnapshot(volumeName, snapshotName); err != nil {
+		t.Fatal("Unable to delete snapshot: ", err)
 	}
-	version := &persistentstore.PersistentStateVersion{}
-	err = json.Unmarshal([]byte(versionJSON), version)
-	if err != nil {
-		t.Fatalf("Couldn't unmarshal the orchestrator persistent state version: %v", err)
+	if _, err = orchestrator.GetSnapshot(volumeName, snapshotName); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a deleted snapshot, got %v", err)
 	}
-	if config.OrchestratorAPIVersion != version.OrchestratorAPIVersion ||
-		string(orchestratorV3.storeClient.GetType()) != version.PersistentStoreVersion {
-		t.Fatalf("Failed to set the orchestrator persistent state version after bootsrapping: %v",
-			err)
+	if _, err = orchestrator.storeClient.GetSnapshot(volumeName, snapshotName); !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected deleted snapshot to be removed from the store, got %v", err)
 	}
 
-	// cleanup
-	if err = etcdv3Client.Stop(); err != nil {
-		t.Fatalf("Couldn't shut down etcdv3 client: %v", err)
+	// Deleting a volume removes the records of its snapshots
+	if _, err = orchestrator.CreateSnapshot(snapshotConfig); err != nil {
+		t.Fatal("Unable to create snapshot: ", err)
 	}
-	cleanup(t, orchestratorV2)
-	cleanup(t, orchestratorV3)
-	cleanupStoreVersion(t, *etcdV3)
-}
-
-// The next series of tests test that bootstrap doesn't exit early if it
-// encounters a key error for one of the main types of entries.
-func TestStorageClassOnlyBootstrap(t *testing.T) {
-	const scName = "storageclass-only"
-
-	orchestrator := getOrchestrator()
-	originalSC, err := orchestrator.AddStorageClass(
-		&storageclass.Config{
-			Name: scName,
-			Attributes: map[string]sa.Request{
-				sa.Media:            sa.NewStringRequest("hdd"),
-				sa.ProvisioningType: sa.NewStringRequest("thick"),
-				sa.RecoveryTest:     sa.NewBoolRequest(true),
-			},
-		},
-	)
-	if err != nil {
-		t.Fatal("Unable to add storage class: ", err)
+	if err = orchestrator.DeleteVolume(volumeName); err != nil {
+		t.Fatal("Unable to delete volume: ", err)
 	}
-	newOrchestrator := getOrchestrator()
-	bootstrappedSC, err := newOrchestrator.GetStorageClass(scName)
-	if bootstrappedSC == nil || err != nil {
-		t.Error("Unable to find storage class after bootstrapping.")
-	} else if !reflect.DeepEqual(bootstrappedSC, originalSC) {
-		t.Errorf("External storage classs differ:\n\tOriginal:  %v\n\t."+
-			"Bootstrapped:  %v", originalSC, bootstrappedSC)
+	if snapshots, err := orchestrator.storeClient.GetSnapshots(); err != nil {
+		t.Errorf("Unable to read snapshots from the store: %v", err)
+	} else if len(snapshots) != 0 {
+		t.Errorf("Expected snapshots to be removed with their volume, found %d", len(snapshots))
 	}
 	cleanup(t, orchestrator)
 }
 
-func TestFirstVolumeRecovery(t *testing.T) {
+func TestAddSnapshotRecovery(t *testing.T) {
 	const (
-		backendName      = "firstRecoveryBackend"
-		scName           = "firstRecoveryBackendSC"
-		txOnlyVolumeName = "firstRecoveryVolumeTxOnly"
+		backendName        = "addSnapshotRecoveryBackend"
+		scName             = "addSnapshotRecoveryBackendSC"
+		volumeName         = "addSnapshotRecoveryVolume"
+		fullSnapshotName   = "addSnapshotRecoverySnapshotFull"
+		txOnlySnapshotName = "addSnapshotRecoverySnapshotTxOnly"
 	)
 	orchestrator := getOrchestrator()
 	prepRecoveryTest(t, orchestrator, backendName, scName)
-	txOnlyVolumeConfig := generateVolumeConfig(txOnlyVolumeName, 50, scName,
-		config.File)
+	_, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 50, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	// It's easier to add the snapshot and then reinject the transaction begin
+	// afterwards
+	fullSnapshotConfig := &storage.SnapshotConfig{Name: fullSnapshotName, VolumeName: volumeName}
+	if _, err = orchestrator.CreateSnapshot(fullSnapshotConfig); err != nil {
+		t.Fatal("Unable to create snapshot: ", err)
+	}
+	txOnlySnapshotConfig := &storage.SnapshotConfig{Name: txOnlySnapshotName, VolumeName: volumeName}
+
 	// BEGIN actual test
-	runRecoveryTests(t, orchestrator, backendName, persistentstore.AddVolume,
-		[]recoveryTest{
-			{name: "firstTXOnly", volumeConfig: txOnlyVolumeConfig,
-				expectDestroy: true},
-		})
+	for _, snapshotConfig := range []*storage.SnapshotConfig{fullSnapshotConfig, txOnlySnapshotConfig} {
+		txn := &persistentstore.VolumeTransaction{
+			SnapshotConfig: snapshotConfig,
+			Op:             persistentstore.AddSnapshot,
+		}
+		if err = orchestrator.storeClient.AddVolumeTransaction(txn); err != nil {
+			t.Fatalf("%s: Unable to create snapshot transaction: %v", snapshotConfig.Name, err)
+		}
+		newOrchestrator := getOrchestrator()
+		if _, ok := newOrchestrator.snapshots[snapshotConfig.ID()]; ok {
+			t.Errorf("%s: snapshot still present in orchestrator.", snapshotConfig.Name)
+		}
+		if _, err = newOrchestrator.storeClient.GetSnapshot(volumeName, snapshotConfig.Name); err == nil {
+			t.Errorf("%s: Found snapshot still stored in the backing store.", snapshotConfig.Name)
+		} else if !persistentstore.MatchKeyNotFoundErr(err) {
+			t.Errorf("%s: unable to communicate with backing store: %v", snapshotConfig.Name, err)
+		}
+		if txns, err := newOrchestrator.storeClient.GetVolumeTransactions(); err != nil {
+			t.Errorf("%s: Unable to retrieve transactions from backing store: %v", snapshotConfig.Name, err)
+		} else if len(txns) > 0 {
+			t.Errorf("%s: Transaction not cleared from the backing store", snapshotConfig.Name)
+		}
+	}
 	cleanup(t, orchestrator)
 }
 
-func TestOrchestratorNotReady(t *testing.T) {
-
-	var (
-		err            error
-		backend        *storage.BackendExternal
-		backends       []*storage.BackendExternal
-		volume         *storage.VolumeExternal
-		volumes        []*storage.VolumeExternal
-		snapshots      []*storage.SnapshotExternal
-		storageClass   *storageclass.External
-		storageClasses []*storageclass.External
+func TestRestoreSnapshot(t *testing.T) {
+	const (
+		backendName = "restoreSnapshotBackend"
+		scName      = "restoreSnapshotBackendSC"
+		volumeName  = "restoreSnapshotVolume"
+		oldSnapshot = "oldSnapshot"
+		newSnapshot = "newSnapshot"
 	)
-
 	orchestrator := getOrchestrator()
-	orchestrator.bootstrapped = false
-	orchestrator.bootstrapError = notReadyError()
-
-	backend, err = orchestrator.AddBackend("")
-	if backend != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected AddBackend to return an error.")
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 50, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
 	}
-
-	backend, err = orchestrator.GetBackend("")
-	if backend != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected GetBackend to return an error.")
+	for _, snapshotName := range []string{oldSnapshot, newSnapshot} {
+		snapshotConfig := &storage.SnapshotConfig{Name: snapshotName, VolumeName: volumeName}
+		if _, err := orchestrator.CreateSnapshot(snapshotConfig); err != nil {
+			t.Fatal("Unable to create snapshot: ", err)
+		}
 	}
 
-	backends, err = orchestrator.ListBackends()
-	if backends != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected ListBackends to return an error.")
+	if err := orchestrator.RestoreSnapshot(volumeName, "missingSnapshot"); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing snapshot, got %v", err)
 	}
-
-	err = orchestrator.DeleteBackend("")
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected DeleteBackend to return an error.")
+	if err := orchestrator.RestoreSnapshot("missingVolume", oldSnapshot); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
 	}
 
-	volume, err = orchestrator.AddVolume(nil)
-	if volume != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected AddVolume to return an error.")
+	// A published volume can't be reverted
+	publication := &storage.VolumePublication{VolumeName: volumeName, NodeName: "node1"}
+	if err := orchestrator.AddVolumePublication(publication); err != nil {
+		t.Fatal("Unable to add volume publication: ", err)
 	}
-
-	volume, err = orchestrator.CloneVolume(nil)
-	if volume != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected CloneVolume to return an error.")
+	if err := orchestrator.RestoreSnapshot(volumeName, oldSnapshot); !IsConflictError(err) {
+		t.Errorf("Expected a conflict restoring a snapshot of a published volume, got %v", err)
 	}
-
-	volume, err = orchestrator.GetVolume("")
-	if volume != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected GetVolume to return an error.")
+	if err := orchestrator.DeleteVolumePublication(volumeName, "node1"); err != nil {
+		t.Fatal("Unable to delete volume publication: ", err)
 	}
 
-	_, err = orchestrator.GetDriverTypeForVolume(nil)
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected GetDriverTypeForVolume to return an error.")
+	// Simulate a storage system that deletes the snapshots newer than the one restored
+	volume, err := orchestrator.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get volume: ", err)
 	}
+	fakeDriver := orchestrator.backends[volume.Backend].Driver.(*fakedriver.StorageDriver)
+	delete(fakeDriver.Snapshots[volume.Config.InternalName], newSnapshot)
 
-	_, err = orchestrator.GetVolumeType(nil)
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected GetVolumeType to return an error.")
+	if err = orchestrator.RestoreSnapshot(volumeName, oldSnapshot); err != nil {
+		t.Fatal("Unable to restore snapshot: ", err)
 	}
-
-	volumes, err = orchestrator.ListVolumes()
-	if volumes != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected ListVolumes to return an error.")
+	if _, err = orchestrator.GetSnapshot(volumeName, oldSnapshot); err != nil {
+		t.Errorf("Expected the restored snapshot to remain, got %v", err)
 	}
-
-	err = orchestrator.DeleteVolume("")
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected DeleteVolume to return an error.")
+	if _, err = orchestrator.GetSnapshot(volumeName, newSnapshot); !IsNotFoundError(err) {
+		t.Errorf("Expected the snapshot removed by the restore to be forgotten, got %v", err)
 	}
-
-	volumes, err = orchestrator.ListVolumesByPlugin("")
-	if volumes != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected ListVolumesByPlugin to return an error.")
+	if _, err = orchestrator.storeClient.GetSnapshot(volumeName, newSnapshot); !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected the snapshot removed by the restore to be removed from the store, got %v", err)
 	}
+	cleanup(t, orchestrator)
+}
 
-	err = orchestrator.AttachVolume("", "", nil)
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected AttachVolume to return an error.")
+func TestUnmanageVolume(t *testing.T) {
+	const (
+		backendName  = "unmanageBackend"
+		scName       = "unmanageBackendSC"
+		volumeName   = "unmanageVolume"
+		otherName    = "unmanageOtherVolume"
+		snapshotName = "unmanageSnapshot"
+		newName      = "unmanaged_volume"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	for _, name := range []string{volumeName, otherName} {
+		if _, err := orchestrator.AddVolume(generateVolumeConfig(name, 50, scName, config.File)); err != nil {
+			t.Fatal("Unable to add volume: ", err)
+		}
 	}
-
-	err = orchestrator.DetachVolume("", "")
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected DetachVolume to return an error.")
+	snapshotConfig := &storage.SnapshotConfig{Name: snapshotName, VolumeName: volumeName}
+	if _, err := orchestrator.CreateSnapshot(snapshotConfig); err != nil {
+		t.Fatal("Unable to create snapshot: ", err)
 	}
-
-	snapshots, err = orchestrator.ListVolumeSnapshots("")
-	if snapshots != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected ListVolumeSnapshots to return an error.")
+	volume, err := orchestrator.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get volume: ", err)
 	}
-
-	err = orchestrator.ReloadVolumes()
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected ReloadVolumes to return an error.")
+	other, err := orchestrator.GetVolume(otherName)
+	if err != nil {
+		t.Fatal("Unable to get volume: ", err)
 	}
+	fakeDriver := orchestrator.backends[volume.Backend].Driver.(*fakedriver.StorageDriver)
 
-	storageClass, err = orchestrator.AddStorageClass(nil)
-	if storageClass != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected AddStorageClass to return an error.")
+	if err = orchestrator.UnmanageVolume("missingVolume", ""); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
 	}
 
-	storageClass, err = orchestrator.GetStorageClass("")
-	if storageClass != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected GetStorageClass to return an error.")
+	// A published volume can't be unmanaged
+	publication := &storage.VolumePublication{VolumeName: volumeName, NodeName: "node1"}
+	if err = orchestrator.AddVolumePublication(publication); err != nil {
+		t.Fatal("Unable to add volume publication: ", err)
+	}
+	if err = orchestrator.UnmanageVolume(volumeName, newName); !IsConflictError(err) {
+		t.Errorf("Expected a conflict unmanaging a published volume, got %v", err)
+	}
+	if err = orchestrator.DeleteVolumePublication(volumeName, "node1"); err != nil {
+		t.Fatal("Unable to delete volume publication: ", err)
 	}
 
-	storageClasses, err = orchestrator.ListStorageClasses()
-	if storageClasses != nil || !IsNotReadyError(err) {
-		t.Errorf("Expected ListStorageClasses to return an error.")
+	// The new name must not already be in use on the backend
+	if err = orchestrator.UnmanageVolume(volumeName, other.Config.InternalName); !IsConflictError(err) {
+		t.Errorf("Expected a conflict renaming over an existing volume, got %v", err)
+	}
+	if _, err = orchestrator.GetVolume(volumeName); err != nil {
+		t.Errorf("Expected the volume to remain managed after a failed unmanage, got %v", err)
 	}
 
-	err = orchestrator.DeleteStorageClass("")
-	if !IsNotReadyError(err) {
-		t.Errorf("Expected DeleteStorageClass to return an error.")
+	if err = orchestrator.UnmanageVolume(volumeName, newName); err != nil {
+		t.Fatal("Unable to unmanage volume: ", err)
+	}
+	if _, err = orchestrator.GetVolume(volumeName); !IsNotFoundError(err) {
+		t.Errorf("Expected the unmanaged volume to be forgotten, got %v", err)
+	}
+	if _, err = orchestrator.storeClient.GetVolume(volumeName); !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected the unmanaged volume to be removed from the store, got %v", err)
+	}
+	if _, err = orchestrator.GetSnapshot(volumeName, snapshotName); !IsNotFoundError(err) {
+		t.Errorf("Expected the unmanaged volume's snapshot to be forgotten, got %v", err)
+	}
+	if _, ok := fakeDriver.Volumes[newName]; !ok {
+		t.Errorf("Expected the unmanaged volume to remain on the backend as %s", newName)
+	}
+	if _, ok := fakeDriver.Volumes[volume.Config.InternalName]; ok {
+		t.Errorf("Expected the unmanaged volume to be renamed on the backend")
+	}
+	if fakeDriver.DestroyedVolumes[volume.Config.InternalName] || fakeDriver.DestroyedVolumes[newName] {
+		t.Errorf("Expected the unmanaged volume not to be destroyed")
 	}
-}
 
-func TestAddNode(t *testing.T) {
-	node := &utils.Node{
-		Name: "testNode",
-		IQN:  "myIQN",
-		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+	// An interrupted unmanage is finished during bootstrapping
+	txn := &persistentstore.VolumeTransaction{Config: other.Config, Op: persistentstore.UnmanageVolume}
+	if err = orchestrator.storeClient.AddVolumeTransaction(txn); err != nil {
+		t.Fatal("Unable to create volume transaction: ", err)
 	}
-	orchestrator := getOrchestrator()
-	if err := orchestrator.AddNode(node); err != nil {
-		t.Errorf("adding node failed; %v", err)
+	restarted := getOrchestrator()
+	if _, err = restarted.GetVolume(otherName); !IsNotFoundError(err) {
+		t.Errorf("Expected the interrupted unmanage to be finished, got %v", err)
 	}
+	if txns, err := restarted.storeClient.GetVolumeTransactions(); err != nil {
+		t.Error("Unable to retrieve transactions from backing store: ", err)
+	} else if len(txns) > 0 {
+		t.Error("Transaction not cleared from the backing store")
+	}
+	cleanup(t, orchestrator)
 }
 
-func TestGetNode(t *testing.T) {
+func TestListUnmanagedVolumes(t *testing.T) {
+	const (
+		backendName = "listUnmanagedBackend"
+		scName      = "listUnmanagedBackendSC"
+		volumeName  = "listUnmanagedVolume"
+	)
 	orchestrator := getOrchestrator()
-	expectedNode := &utils.Node{
-		Name: "testNode",
-		IQN:  "myIQN",
-		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	volume, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 50, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to add volume: ", err)
 	}
-	unexpectedNode := &utils.Node{
-		Name: "testNode2",
-		IQN:  "myOtherIQN",
-		IPs:  []string{"3.3.3.3", "4.4.4.4"},
+	driver := orchestrator.backends[backendName].Driver.(*fakedriver.StorageDriver)
+	driver.Volumes["legacy_b"] = fake.Volume{Name: "legacy_b", SizeBytes: 1024}
+	driver.Volumes["legacy_a"] = fake.Volume{Name: "legacy_a", SizeBytes: 1024}
+	driver.Volumes["other"] = fake.Volume{Name: "other", SizeBytes: 1024}
+
+	// Volumes managed by Trident are never candidates for import
+	unmanaged, err := orchestrator.ListUnmanagedVolumes(backendName, "")
+	if err != nil {
+		t.Fatal("Unable to list unmanaged volumes: ", err)
+	}
+	if !reflect.DeepEqual(unmanaged, []string{"legacy_a", "legacy_b", "other"}) {
+		t.Errorf("Expected all unmanaged volumes, got %v", unmanaged)
+	}
+	for _, name := range unmanaged {
+		if name == volume.Config.InternalName {
+			t.Errorf("Managed volume %s was listed as unmanaged", name)
+		}
 	}
-	initialNodes := map[string]*utils.Node{}
-	initialNodes[expectedNode.Name] = expectedNode
-	initialNodes[unexpectedNode.Name] = unexpectedNode
-	orchestrator.nodes = initialNodes
 
-	actualNode, err := orchestrator.GetNode(expectedNode.Name)
+	unmanaged, err = orchestrator.ListUnmanagedVolumes(backendName, "legacy_*")
 	if err != nil {
-		t.Errorf("error getting node; %v", err)
+		t.Fatal("Unable to list unmanaged volumes: ", err)
+	}
+	if !reflect.DeepEqual(unmanaged, []string{"legacy_a", "legacy_b"}) {
+		t.Errorf("Expected only the volumes matching the pattern, got %v", unmanaged)
 	}
 
-	if actualNode != expectedNode {
-		t.Errorf("Did not get expected node back; expected %+v, got %+v", expectedNode, actualNode)
+	if _, err = orchestrator.ListUnmanagedVolumes(backendName, "legacy_["); err == nil {
+		t.Error("Expected an error for an invalid pattern")
 	}
+	if _, err = orchestrator.ListUnmanagedVolumes("missingBackend", ""); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing backend, got %v", err)
+	}
+
+	for _, name := range []string{"legacy_a", "legacy_b", "other"} {
+		delete(driver.Volumes, name)
+	}
+	cleanup(t, orchestrator)
 }
 
-func TestListNodes(t *testing.T) {
+func TestUpdateVolumeStorageClass(t *testing.T) {
+	const (
+		backendName = "reclassBackend"
+		volumeName  = "reclassVolume"
+		firstSC     = "reclassFirstSC"
+		anySC       = "reclassAnySC"
+		secondSC    = "reclassSecondSC"
+		elsewhereSC = "reclassElsewhereSC"
+	)
 	orchestrator := getOrchestrator()
-	expectedNode1 := &utils.Node{
-		Name: "testNode",
-		IQN:  "myIQN",
-		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+
+	pools := make(map[string]*fake.StoragePool)
+	for _, poolName := range []string{"first", "second"} {
+		pools[poolName] = &fake.StoragePool{
+			Attrs: map[string]sa.Offer{sa.Media: sa.NewStringOffer("hdd")},
+			Bytes: 100 * 1024 * 1024 * 1024,
+		}
 	}
-	expectedNode2 := &utils.Node{
-		Name: "testNode2",
-		IQN:  "myOtherIQN",
-		IPs:  []string{"3.3.3.3", "4.4.4.4"},
+	configJSON, err := fakedriver.NewFakeStorageDriverConfigJSON(backendName, config.File, pools)
+	if err != nil {
+		t.Fatal("Unable to generate config JSON: ", err)
 	}
-	initialNodes := map[string]*utils.Node{}
-	initialNodes[expectedNode1.Name] = expectedNode1
-	initialNodes[expectedNode2.Name] = expectedNode2
-	orchestrator.nodes = initialNodes
-	expectedNodes := []*utils.Node{expectedNode1, expectedNode2}
+	if _, err = orchestrator.AddBackend(configJSON); err != nil {
+		t.Fatal("Unable to add backend: ", err)
+	}
+	for scName, scPools := range map[string]map[string][]string{
+		firstSC:     {backendName: {"first"}},
+		anySC:       {backendName: {"first", "second"}},
+		secondSC:    {backendName: {"second"}},
+		elsewhereSC: {"reclassMissingBackend": {"first"}},
+	} {
+		if _, err = orchestrator.AddStorageClass(&storageclass.Config{Name: scName, Pools: scPools}); err != nil {
+			t.Fatalf("Unable to add storage class %s: %v", scName, err)
+		}
+	}
+	volume, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, firstSC, config.File))
+	if err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if volume.Pool != "first" {
+		t.Fatalf("Expected the volume in pool first, got %s", volume.Pool)
+	}
+	fakeDriver := orchestrator.backends[volume.Backend].Driver.(*fakedriver.StorageDriver)
 
-	actualNodes, err := orchestrator.ListNodes()
+	if _, err = orchestrator.UpdateVolumeStorageClass("missingVolume", anySC); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
+	}
+	if _, err = orchestrator.UpdateVolumeStorageClass(volumeName, "missingSC"); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing storage class, got %v", err)
+	}
+	if _, err = orchestrator.UpdateVolumeStorageClass(volumeName, elsewhereSC); !IsUnsupportedError(err) {
+		t.Errorf("Expected an unsupported error for a storage class on another backend, got %v", err)
+	}
+
+	// The current pool satisfies the new class, so only the class changes
+	volume, err = orchestrator.UpdateVolumeStorageClass(volumeName, anySC)
 	if err != nil {
-		t.Errorf("error listing nodes; %v", err)
+		t.Fatal("Unable to update the volume's storage class: ", err)
+	}
+	if volume.Config.StorageClass != anySC || volume.Pool != "first" {
+		t.Errorf("Expected the volume in class %s and pool first, got %s and %s",
+			anySC, volume.Config.StorageClass, volume.Pool)
+	}
+	if fakeDriver.Volumes[volume.Config.InternalName].PhysicalPool != "first" {
+		t.Error("Expected the volume to stay in pool first on the backend")
+	}
+	stored, err := orchestrator.storeClient.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get the volume from the store: ", err)
+	}
+	if stored.Config.StorageClass != anySC {
+		t.Errorf("Expected the stored volume in class %s, got %s", anySC, stored.Config.StorageClass)
 	}
 
-	if !unorderedNodeSlicesEqual(actualNodes, expectedNodes) {
-		t.Errorf("node list values do not match; expected %v, found %v", expectedNodes, actualNodes)
+	// The new class needs the other pool, so the volume is moved
+	volume, err = orchestrator.UpdateVolumeStorageClass(volumeName, secondSC)
+	if err != nil {
+		t.Fatal("Unable to update the volume's storage class: ", err)
+	}
+	if volume.Config.StorageClass != secondSC || volume.Pool != "second" {
+		t.Errorf("Expected the volume in class %s and pool second, got %s and %s",
+			secondSC, volume.Config.StorageClass, volume.Pool)
+	}
+	if fakeDriver.Volumes[volume.Config.InternalName].PhysicalPool != "second" {
+		t.Error("Expected the volume to be moved to pool second on the backend")
+	}
+	stored, err = orchestrator.storeClient.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get the volume from the store: ", err)
+	}
+	if stored.Config.StorageClass != secondSC || stored.Pool != "second" {
+		t.Errorf("Expected the stored volume in class %s and pool second, got %s and %s",
+			secondSC, stored.Config.StorageClass, stored.Pool)
+	}
+	checkNoVolumeTransaction(t, orchestrator, volumeName)
+
+	// A move interrupted by a restart is finished when its transaction is recovered
+	movedConfig := volume.Config.ConstructClone()
+	movedConfig.StorageClass = firstSC
+	volTxn := &persistentstore.VolumeTransaction{Config: movedConfig, Op: persistentstore.MoveVolume, Pool: "first"}
+	if err = orchestrator.storeClient.AddVolumeTransaction(volTxn); err != nil {
+		t.Fatal("Unable to add volume transaction: ", err)
+	}
+	orchestrator.mutex.Lock()
+	err = orchestrator.handleFailedTransaction(volTxn)
+	orchestrator.mutex.Unlock()
+	if err != nil {
+		t.Fatal("Unable to recover the volume move: ", err)
+	}
+	if fakeDriver.Volumes[volume.Config.InternalName].PhysicalPool != "first" {
+		t.Error("Expected the volume to be moved to pool first on the backend")
+	}
+	stored, err = orchestrator.storeClient.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get the volume from the store: ", err)
+	}
+	if stored.Config.StorageClass != firstSC || stored.Pool != "first" {
+		t.Errorf("Expected the stored volume in class %s and pool first, got %s and %s",
+			firstSC, stored.Config.StorageClass, stored.Pool)
 	}
+	checkNoVolumeTransaction(t, orchestrator, volumeName)
+	cleanup(t, orchestrator)
 }
 
-func unorderedNodeSlicesEqual(x, y []*utils.Node) bool {
-	if len(x) != len(y) {
-		return false
+// volumeCopierFrontend is a frontend that pretends to copy volumes between backends.
+type volumeCopierFrontend struct {
+	copied  []string
+	err     error
+	started chan struct{} // If set, closed when a copy starts
+	release chan struct{} // If set, the copy waits for it to be closed
+}
+
+func (f *volumeCopierFrontend) Activate() error   { return nil }
+func (f *volumeCopierFrontend) Deactivate() error { return nil }
+func (f *volumeCopierFrontend) GetName() string   { return "volumeCopier" }
+func (f *volumeCopierFrontend) Version() string   { return "1" }
+
+func (f *volumeCopierFrontend) CanCopyVolume(protocol config.Protocol) bool {
+	return protocol == config.File
+}
+
+func (f *volumeCopierFrontend) CopyVolumeData(source, target *storage.VolumeExternal) error {
+	if f.started != nil {
+		close(f.started)
 	}
-	// create a map of node pointers -> int
-	diff := make(map[*utils.Node]int, len(x))
-	for _, _x := range x {
-		// 0 value for int is 0, so just increment a counter for the string
-		diff[_x]++
+	if f.release != nil {
+		<-f.release
 	}
-	for _, _y := range y {
-		// If the node _y is not in diff bail out early
-		if _, ok := diff[_y]; !ok {
-			return false
+	f.copied = append(f.copied, source.Backend+"->"+target.Backend)
+	return f.err
+}
+
+// addRegionalBackend adds a fake backend with one storage pool in the specified region.
+func addRegionalBackend(t *testing.T, o *TridentOrchestrator, backendName, region string) {
+	pools := map[string]*fake.StoragePool{
+		"pool": {
+			Attrs: map[string]sa.Offer{sa.Media: sa.NewStringOffer("hdd")},
+			Bytes: 100 * 1024 * 1024 * 1024,
+		},
+	}
+	configJSON, err := fakedriver.NewFakeStorageDriverConfigJSONWithVirtualPools(backendName, config.File,
+		pools, drivers.FakeStorageDriverPool{Region: region}, nil)
+	if err != nil {
+		t.Fatal("Unable to generate config JSON: ", err)
+	}
+	if _, err = o.AddBackend(configJSON); err != nil {
+		t.Fatalf("Unable to add backend %s: %v", backendName, err)
+	}
+}
+
+// waitForVolumeMigration waits for the background part of a volume migration to finish.
+func waitForVolumeMigration(t *testing.T, o *TridentOrchestrator, volumeName string) *storage.VolumeMigration {
+	for i := 0; i < 100; i++ {
+		migration, err := o.GetVolumeMigration(volumeName)
+		if err != nil {
+			t.Fatal("Unable to get the volume migration: ", err)
 		}
-		diff[_y] -= 1
-		if diff[_y] == 0 {
-			delete(diff, _y)
+		if migration.Done() {
+			return migration
 		}
+		time.Sleep(50 * time.Millisecond)
 	}
-	if len(diff) == 0 {
-		return true
+	t.Fatalf("Migration of volume %s did not finish", volumeName)
+	return nil
+}
+
+// checkNoVolumeTransaction fails the test if a transaction remains for the volume.
+func checkNoVolumeTransaction(t *testing.T, o *TridentOrchestrator, volumeName string) {
+	volTxns, err := o.storeClient.GetVolumeTransactions()
+	if err != nil {
+		t.Fatal("Unable to get volume transactions: ", err)
+	}
+	for _, volTxn := range volTxns {
+		if volTxn.Name() == volumeName {
+			t.Errorf("Expected no transaction for volume %s, found a %s transaction", volumeName, volTxn.Op)
+		}
 	}
-	return false
 }
 
-func TestDeleteNode(t *testing.T) {
+func TestMigrateVolume(t *testing.T) {
+	const (
+		firstBackend  = "migrateFirstBackend"
+		secondBackend = "migrateSecondBackend"
+		thirdBackend  = "migrateThirdBackend"
+		firstOnlySC   = "migrateFirstOnlySC"
+		anySC         = "migrateAnySC"
+		volumeName    = "migrateVolume"
+		pinnedName    = "migratePinnedVolume"
+		snapshotName  = "migrateSnapshot"
+	)
 	orchestrator := getOrchestrator()
-	initialNode := &utils.Node{
-		Name: "testNode",
-		IQN:  "myIQN",
-		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+
+	// The first two backends can replicate to each other, but not to the third
+	addRegionalBackend(t, orchestrator, firstBackend, "east")
+	addRegionalBackend(t, orchestrator, secondBackend, "east")
+	addRegionalBackend(t, orchestrator, thirdBackend, "west")
+	for scName, scPools := range map[string]map[string][]string{
+		firstOnlySC: {firstBackend: {"pool"}},
+		anySC:       {firstBackend: {"pool"}, secondBackend: {"pool"}, thirdBackend: {"pool"}},
+	} {
+		if _, err := orchestrator.AddStorageClass(&storageclass.Config{Name: scName, Pools: scPools}); err != nil {
+			t.Fatalf("Unable to add storage class %s: %v", scName, err)
+		}
+	}
+	fakeDrivers := make(map[string]*fakedriver.StorageDriver)
+	for _, backendName := range []string{firstBackend, secondBackend, thirdBackend} {
+		fakeDrivers[backendName] = orchestrator.backends[backendName].Driver.(*fakedriver.StorageDriver)
 	}
-	initialNodes := map[string]*utils.Node{}
-	initialNodes[initialNode.Name] = initialNode
-	orchestrator.nodes = initialNodes
 
-	if err := orchestrator.DeleteNode(initialNode.Name); err != nil {
-		t.Errorf("error deleting node; %v", err)
+	// Create the volume on the first backend, then let it go anywhere
+	for _, name := range []string{volumeName, pinnedName} {
+		if _, err := orchestrator.AddVolume(generateVolumeConfig(name, 1, firstOnlySC, config.File)); err != nil {
+			t.Fatal("Unable to add volume: ", err)
+		}
 	}
+	volume, err := orchestrator.UpdateVolumeStorageClass(volumeName, anySC)
+	if err != nil {
+		t.Fatal("Unable to update the volume's storage class: ", err)
+	}
+	if volume.Backend != firstBackend {
+		t.Fatalf("Expected the volume on backend %s, got %s", firstBackend, volume.Backend)
+	}
+	internalName := volume.Config.InternalName
 
-	if _, ok := orchestrator.nodes[initialNode.Name]; ok {
-		t.Errorf("node was not properly deleted")
+	if _, err = orchestrator.MigrateVolume("missingVolume", secondBackend); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
+	}
+	if _, err = orchestrator.MigrateVolume(volumeName, "missingBackend"); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing backend, got %v", err)
+	}
+	if _, err = orchestrator.MigrateVolume(volumeName, firstBackend); err == nil {
+		t.Error("Expected an error migrating a volume to its own backend")
+	}
+	if _, err = orchestrator.MigrateVolume(pinnedName, secondBackend); !IsUnsupportedError(err) {
+		t.Errorf("Expected an unsupported error for a storage class without pools on the backend, got %v", err)
+	}
+	if _, err = orchestrator.MigrateVolume(volumeName, thirdBackend); !IsUnsupportedError(err) {
+		t.Errorf("Expected an unsupported error without replication or a copier, got %v", err)
+	}
+	if _, err = orchestrator.CreateSnapshot(
+		&storage.SnapshotConfig{Name: snapshotName, VolumeName: volumeName}); err != nil {
+		t.Fatal("Unable to create snapshot: ", err)
+	}
+	if _, err = orchestrator.MigrateVolume(volumeName, secondBackend); !IsConflictError(err) {
+		t.Errorf("Expected a conflict error for a volume with snapshots, got %v", err)
+	}
+	if err = orchestrator.DeleteSnapshot(volumeName, snapshotName); err != nil {
+		t.Fatal("Unable to delete snapshot: ", err)
+	}
+
+	// The second backend replicates the volume natively
+	migration, err := orchestrator.MigrateVolume(volumeName, secondBackend)
+	if err != nil {
+		t.Fatal("Unable to migrate the volume: ", err)
+	}
+	if !migration.Native {
+		t.Error("Expected the volume to be replicated natively")
+	}
+	if migration = waitForVolumeMigration(t, orchestrator, volumeName); migration.State != storage.MigrationComplete {
+		t.Fatalf("Expected the migration to complete, got %s: %s", migration.State, migration.Error)
+	}
+	if volume, err = orchestrator.GetVolume(volumeName); err != nil {
+		t.Fatal("Unable to get the volume: ", err)
+	}
+	if volume.Backend != secondBackend || volume.Pool != "pool" {
+		t.Errorf("Expected the volume in pool pool of backend %s, got %s and %s",
+			secondBackend, volume.Pool, volume.Backend)
+	}
+	if !fakeDrivers[firstBackend].DestroyedVolumes[internalName] {
+		t.Error("Expected the original volume to be destroyed")
+	}
+	if _, ok := fakeDrivers[secondBackend].Volumes[volume.Config.InternalName]; !ok {
+		t.Error("Expected the volume to exist on the second backend")
+	}
+	stored, err := orchestrator.storeClient.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get the volume from the store: ", err)
+	}
+	if stored.Backend != secondBackend {
+		t.Errorf("Expected the stored volume on backend %s, got %s", secondBackend, stored.Backend)
 	}
+	checkNoVolumeTransaction(t, orchestrator, volumeName)
+
+	// The third backend is in another region, so a frontend copies the volume
+	copier := &volumeCopierFrontend{started: make(chan struct{}), release: make(chan struct{})}
+	orchestrator.AddFrontend(copier)
+	migration, err = orchestrator.MigrateVolume(volumeName, thirdBackend)
+	if err != nil {
+		t.Fatal("Unable to migrate the volume: ", err)
+	}
+	if migration.Native {
+		t.Error("Expected the volume to be copied by the frontend")
+	}
+
+	// The volume can't be published while its data is being copied
+	<-copier.started
+	publication := &storage.VolumePublication{VolumeName: volumeName, NodeName: "node1"}
+	if err = orchestrator.AddVolumePublication(publication); !IsConflictError(err) {
+		t.Errorf("Expected a conflict publishing a migrating volume, got %v", err)
+	}
+	if err = orchestrator.PublishVolume(volumeName, &utils.VolumePublishInfo{}); !IsConflictError(err) {
+		t.Errorf("Expected a conflict publishing a migrating volume, got %v", err)
+	}
+	close(copier.release)
+	if migration = waitForVolumeMigration(t, orchestrator, volumeName); migration.State != storage.MigrationComplete {
+		t.Fatalf("Expected the migration to complete, got %s: %s", migration.State, migration.Error)
+	}
+	copier.started, copier.release = nil, nil
+	if expected := []string{secondBackend + "->" + thirdBackend}; !reflect.DeepEqual(copier.copied, expected) {
+		t.Errorf("Expected the copies %v, got %v", expected, copier.copied)
+	}
+	if volume, err = orchestrator.GetVolume(volumeName); err != nil {
+		t.Fatal("Unable to get the volume: ", err)
+	}
+	if volume.Backend != thirdBackend {
+		t.Errorf("Expected the volume on backend %s, got %s", thirdBackend, volume.Backend)
+	}
+	checkNoVolumeTransaction(t, orchestrator, volumeName)
+
+	// A failed copy leaves the volume where it was
+	copier.err = fmt.Errorf("copy failed")
+	if _, err = orchestrator.MigrateVolume(volumeName, firstBackend); err != nil {
+		t.Fatal("Unable to migrate the volume: ", err)
+	}
+	if migration = waitForVolumeMigration(t, orchestrator, volumeName); migration.State != storage.MigrationFailed {
+		t.Fatalf("Expected the migration to fail, got %s", migration.State)
+	}
+	if !strings.Contains(migration.Error, "copy failed") {
+		t.Errorf("Expected the migration to report the copy failure, got %s", migration.Error)
+	}
+	if volume, err = orchestrator.GetVolume(volumeName); err != nil {
+		t.Fatal("Unable to get the volume: ", err)
+	}
+	if volume.Backend != thirdBackend {
+		t.Errorf("Expected the volume to stay on backend %s, got %s", thirdBackend, volume.Backend)
+	}
+	if _, ok := fakeDrivers[firstBackend].Volumes[internalName]; ok {
+		t.Error("Expected the target volume to be destroyed")
+	}
+	if _, ok := fakeDrivers[thirdBackend].Volumes[volume.Config.InternalName]; !ok {
+		t.Error("Expected the volume to remain on the third backend")
+	}
+	checkNoVolumeTransaction(t, orchestrator, volumeName)
+
+	cleanup(t, orchestrator)
+}
+
+func TestMigrateVolumeRecovery(t *testing.T) {
+	const (
+		sourceBackend = "migrateRecoverySourceBackend"
+		targetBackend = "migrateRecoveryTargetBackend"
+		scName        = "migrateRecoverySC"
+		volumeName    = "migrateRecoveryVolume"
+	)
+	orchestrator := getOrchestrator()
+	addRegionalBackend(t, orchestrator, sourceBackend, "")
+	addRegionalBackend(t, orchestrator, targetBackend, "")
+	scPools := map[string][]string{sourceBackend: {"pool"}, targetBackend: {"pool"}}
+	if _, err := orchestrator.AddStorageClass(&storageclass.Config{Name: scName, Pools: scPools}); err != nil {
+		t.Fatal("Unable to add storage class: ", err)
+	}
+	volume, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	source, target := volume.Backend, sourceBackend
+	if source == sourceBackend {
+		target = targetBackend
+	}
+
+	migration := &storage.VolumeMigration{
+		VolumeName:    volumeName,
+		SourceBackend: source,
+		SourcePool:    volume.Pool,
+		SourceConfig:  volume.Config,
+		TargetBackend: target,
+		TargetPool:    "pool",
+		TargetConfig:  volume.Config.ConstructClone(),
+		Native:        true,
+		State:         storage.MigrationCopying,
+	}
+
+	for _, c := range []struct {
+		name          string
+		targetCreated bool
+		cutOver       bool
+		expectedState storage.VolumeMigrationState
+	}{
+		{name: "notCreated", targetCreated: false, cutOver: false, expectedState: storage.MigrationFailed},
+		{name: "copying", targetCreated: true, cutOver: false, expectedState: storage.MigrationFailed},
+		{name: "cutOver", targetCreated: true, cutOver: true, expectedState: storage.MigrationComplete},
+	} {
+		migration.TargetCreated = c.targetCreated
+		expectedBackend, destroyedBackend := source, target
+		if !c.targetCreated {
+			// A volume the migration didn't create is never destroyed
+			destroyedBackend = ""
+		}
+		if c.cutOver {
+			// Simulate a restart just after the volume record was updated
+			cutOverVolume := storage.NewVolume(migration.TargetConfig, target, "pool", false)
+			if err = orchestrator.storeClient.UpdateVolume(cutOverVolume); err != nil {
+				t.Fatalf("%s: Unable to update volume: %v", c.name, err)
+			}
+			expectedBackend, destroyedBackend = target, source
+		}
+		volTxn := &persistentstore.VolumeTransaction{
+			Config:    volume.Config,
+			Op:        persistentstore.MigrateVolume,
+			Migration: migration,
+		}
+		if err = orchestrator.storeClient.AddVolumeTransaction(volTxn); err != nil {
+			t.Fatalf("%s: Unable to create volume transaction: %v", c.name, err)
+		}
+
+		newOrchestrator := getOrchestrator()
+		recovered, err := newOrchestrator.GetVolume(volumeName)
+		if err != nil {
+			t.Fatalf("%s: Unable to get the volume: %v", c.name, err)
+		}
+		if recovered.Backend != expectedBackend {
+			t.Errorf("%s: Expected the volume on backend %s, got %s", c.name, expectedBackend, recovered.Backend)
+		}
+		for backendName, backend := range newOrchestrator.backends {
+			if backendName != source && backendName != target {
+				continue
+			}
+			destroyed := backend.Driver.(*fakedriver.StorageDriver).DestroyedVolumes[volume.Config.InternalName]
+			if destroyed != (backendName == destroyedBackend) {
+				t.Errorf("%s: Expected destroyed %t on backend %s, got %t", c.name,
+					backendName == destroyedBackend, backendName, destroyed)
+			}
+		}
+		recoveredMigration, err := newOrchestrator.GetVolumeMigration(volumeName)
+		if err != nil {
+			t.Fatalf("%s: Unable to get the volume migration: %v", c.name, err)
+		}
+		if recoveredMigration.State != c.expectedState {
+			t.Errorf("%s: Expected the migration %s, got %s", c.name, c.expectedState, recoveredMigration.State)
+		}
+		checkNoVolumeTransaction(t, newOrchestrator, volumeName)
+	}
+
+	cleanup(t, orchestrator)
+}
+
+func TestGroupSnapshots(t *testing.T) {
+	const (
+		backendName = "groupSnapshotBackend"
+		scName      = "groupSnapshotBackendSC"
+		groupName   = "groupSnapshot"
+		clonePrefix = "clone-"
+	)
+	volumeNames := []string{"groupSnapshotVolume1", "groupSnapshotVolume2"}
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	for _, volumeName := range volumeNames {
+		if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 50, scName, config.File)); err != nil {
+			t.Fatal("Unable to add volume: ", err)
+		}
+	}
+
+	// The fake driver can't snapshot volumes together, so the snapshots may only be
+	// taken one after another if that is allowed
+	_, err := orchestrator.CreateGroupSnapshot(&storage.GroupSnapshotConfig{Name: groupName, VolumeNames: volumeNames})
+	if !IsUnsupportedError(err) {
+		t.Errorf("Expected an unsupported error for a sequential group snapshot, got %v", err)
+	}
+	for _, volumeName := range volumeNames {
+		if _, err = orchestrator.GetSnapshot(volumeName, groupName); !IsNotFoundError(err) {
+			t.Errorf("Expected no snapshot of volume %s from a rejected group snapshot, got %v", volumeName, err)
+		}
+	}
+
+	// Create a group snapshot, taking the snapshots one after another
+	groupConfig := &storage.GroupSnapshotConfig{Name: groupName, VolumeNames: volumeNames, AllowSequential: true}
+	groupSnapshot, err := orchestrator.CreateGroupSnapshot(groupConfig)
+	if err != nil {
+		t.Fatal("Unable to create group snapshot: ", err)
+	}
+	if groupSnapshot.Native || len(groupSnapshot.Snapshots) != len(volumeNames) {
+		t.Errorf("Unexpected group snapshot returned from CreateGroupSnapshot: %+v", groupSnapshot)
+	}
+	for _, volumeName := range volumeNames {
+		if _, err = orchestrator.GetSnapshot(volumeName, groupName); err != nil {
+			t.Errorf("Unable to get snapshot of volume %s: %v", volumeName, err)
+		}
+	}
+	if _, err = orchestrator.CreateGroupSnapshot(
+		&storage.GroupSnapshotConfig{Name: groupName, VolumeNames: volumeNames}); err == nil {
+		t.Error("Expected creating a duplicate group snapshot to fail.")
+	}
+	if _, err = orchestrator.CreateGroupSnapshot(&storage.GroupSnapshotConfig{
+		Name: "missingVolumeGroup", VolumeNames: []string{volumeNames[0], "missingVolume"}}); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
+	}
+	if _, err = orchestrator.GetSnapshot(volumeNames[0], "missingVolumeGroup"); !IsNotFoundError(err) {
+		t.Errorf("Expected no snapshot to be left from a failed group snapshot, got %v", err)
+	}
+	if _, err = orchestrator.CreateGroupSnapshot(&storage.GroupSnapshotConfig{
+		Name: "duplicateVolumeGroup", VolumeNames: []string{volumeNames[0], volumeNames[0]}}); err == nil {
+		t.Error("Expected a group snapshot listing a volume twice to fail.")
+	}
+
+	// Read the group snapshot back, from the cache and after bootstrapping from the store
+	for _, o := range []*TridentOrchestrator{orchestrator, getOrchestrator()} {
+		if groupSnapshot, err := o.GetGroupSnapshot(groupName); err != nil {
+			t.Errorf("Unable to get group snapshot: %v", err)
+		} else if len(groupSnapshot.Snapshots) != len(volumeNames) {
+			t.Errorf("Expected %d snapshots in the group, got %d", len(volumeNames), len(groupSnapshot.Snapshots))
+		}
+		if groupSnapshots, err := o.ListGroupSnapshots(); err != nil {
+			t.Errorf("Unable to list group snapshots: %v", err)
+		} else if len(groupSnapshots) != 1 {
+			t.Errorf("Expected 1 group snapshot, got %d", len(groupSnapshots))
+		}
+	}
+
+	// Clone the group
+	if _, err = orchestrator.CloneGroupSnapshot(groupName, ""); err == nil {
+		t.Error("Expected cloning a group snapshot without a prefix to fail.")
+	}
+	clones, err := orchestrator.CloneGroupSnapshot(groupName, clonePrefix)
+	if err != nil {
+		t.Fatal("Unable to clone group snapshot: ", err)
+	}
+	if len(clones) != len(volumeNames) {
+		t.Errorf("Expected %d clones, got %d", len(volumeNames), len(clones))
+	}
+	for _, volumeName := range volumeNames {
+		if clone, err := orchestrator.GetVolume(clonePrefix + volumeName); err != nil {
+			t.Errorf("Unable to get clone of volume %s: %v", volumeName, err)
+		} else if clone.Config.CloneSourceVolume != volumeName || clone.Config.CloneSourceSnapshot != groupName {
+			t.Errorf("Unexpected clone of volume %s: %+v", volumeName, clone.Config)
+		}
+	}
+
+	// Delete the group, which deletes its snapshots
+	if err = orchestrator.DeleteGroupSnapshot(groupName); err != nil {
+		t.Fatal("Unable to delete group snapshot: ", err)
+	}
+	if _, err = orchestrator.GetGroupSnapshot(groupName); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a deleted group snapshot, got %v", err)
+	}
+	if _, err = orchestrator.storeClient.GetGroupSnapshot(groupName); !persistentstore.MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected deleted group snapshot to be removed from the store, got %v", err)
+	}
+	for _, volumeName := range volumeNames {
+		if _, err = orchestrator.GetSnapshot(volumeName, groupName); !IsNotFoundError(err) {
+			t.Errorf("Expected the snapshot of volume %s to be deleted with its group, got %v", volumeName, err)
+		}
+	}
+	cleanup(t, orchestrator)
+}
+
+// blockingCreateDriver is a driver whose volume creations wait until the test releases
+// them, to simulate a slow storage system.
+type blockingCreateDriver struct {
+	storage.Driver
+	started chan string
+	release chan struct{}
+}
+
+func (d *blockingCreateDriver) Create(
+	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+) error {
+	d.started <- volConfig.Name
+	<-d.release
+	return d.Driver.Create(volConfig, storagePool, volAttributes)
+}
+
+func TestReadsDuringVolumeCreation(t *testing.T) {
+	const (
+		backendName = "readsDuringVolumeCreationBackend"
+		scName      = "readsDuringVolumeCreationBackendSC"
+		volumeName  = "readsDuringVolumeCreationVolume"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+
+	orchestrator.mutex.Lock()
+	backend := orchestrator.backends[backendName]
+	driver := &blockingCreateDriver{
+		Driver:  backend.Driver,
+		started: make(chan string),
+		release: make(chan struct{}),
+	}
+	backend.Driver = driver
+	orchestrator.mutex.Unlock()
+
+	volumeNames := []string{volumeName + "1", volumeName + "2"}
+	results := make(chan error, len(volumeNames))
+	for _, name := range volumeNames {
+		go func(name string) {
+			_, err := orchestrator.AddVolume(generateVolumeConfig(name, 1, scName, config.File))
+			results <- err
+		}(name)
+	}
+
+	// Creations on the same backend only share its lock, so both reach the storage system
+	for range volumeNames {
+		select {
+		case <-driver.started:
+		case <-time.After(5 * time.Second):
+			close(driver.release)
+			t.Fatal("Volume creations on the same backend didn't run at once.")
+		}
+	}
+
+	// Reads from the cache must not wait for the volume creations
+	done := make(chan bool)
+	go func() {
+		orchestrator.ListVolumes()
+		orchestrator.GetBackend(backendName)
+		orchestrator.ListStorageClasses()
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		close(driver.release)
+		t.Fatal("Reads were blocked by a volume creation.")
+	}
+
+	close(driver.release)
+	for range volumeNames {
+		if err := <-results; err != nil {
+			t.Fatal("Unable to add volume: ", err)
+		}
+	}
+	for _, name := range volumeNames {
+		if _, err := orchestrator.GetVolume(name); err != nil {
+			t.Error("Volume not found after creation: ", err)
+		}
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestBadBootstrapEtcdV2(t *testing.T) {
+	if *etcdV2 == "" {
+		t.SkipNow()
+	}
+	_, err := persistentstore.NewEtcdClientV2("invalidIPAddress")
+	if err != nil && persistentstore.MatchUnavailableClusterErr(err) {
+	} else {
+		panic(fmt.Errorf("didn't catch invalid etcdv2 client"))
+	}
+}
+
+func TestBadBootstrapEtcdV3(t *testing.T) {
+	if *etcdV3 == "" {
+		t.SkipNow()
+	}
+	_, err := persistentstore.NewEtcdClientV3("invalidIPAddress")
+	if err != nil && persistentstore.MatchUnavailableClusterErr(err) {
+	} else {
+		panic(fmt.Errorf("didn't catch invalid etcdv3 client"))
+	}
+}
+
+func TestBootstrapEtcdV2ToEtcdV3Migration(t *testing.T) {
+	const (
+		backendName = "bootstrapV2toV3_backend"
+		scName      = "bootstrapV2toV3_class"
+		volumeName  = "bootstrapV2toV3_vol"
+	)
+	if *etcdV3 == "" {
+		t.SkipNow()
+	}
+	cleanupStoreVersion(t, *etcdV3)
+
+	// Populate the etcdv2 cluster with one backend, one storage class, and one volume
+	etcdV2Orig := etcdV2
+	etcdV2 = etcdV3
+	orchestratorV2 := getOrchestrator()
+	addBackendStorageClass(t, orchestratorV2, backendName, scName)
+
+	orchestratorV2.mutex.Lock()
+	if _, ok := orchestratorV2.storageClasses[scName]; !ok {
+		t.Fatal("Storage class not found in the orchestrator map!")
+	}
+	orchestratorV2.mutex.Unlock()
+
+	v2Volume, err := orchestratorV2.AddVolume(generateVolumeConfig(volumeName, 50, scName,
+		config.File))
+	if err != nil {
+		t.Fatal("Unable to create volume: ", err)
+	}
+	orchestratorV2.mutex.Lock()
+	if _, ok := orchestratorV2.volumes[volumeName]; !ok {
+		t.Fatal("Volume name found in orchestrator map!")
+	}
+	orchestratorV2.mutex.Unlock()
+
+	// Bootstrap etcdv3 orchestrator with etcdv2 data
+	etcdV2 = etcdV2Orig
+	orchestratorV3 := getOrchestrator()
+
+	// Verify etcdv2 to etcdv3 transformation
+	if v3Backend, _ := orchestratorV3.GetBackend(backendName); v3Backend == nil {
+		t.Fatalf("Failed to find backend %s after bootstrapping!", backendName)
+	}
+	//TODO: Optionally we can diff etcdv2 and etcdv3 backends here
+	v3StorageClass, err := orchestratorV3.GetStorageClass(scName)
+	if v3StorageClass == nil || err != nil {
+		t.Fatalf("Failed to find storage class %s after bootstrapping!", scName)
+	}
+	//TODO: Optionally we can diff etcdv2 and etcdv3 storage classes here
+	v3Volume, err := orchestratorV3.GetVolume(volumeName)
+	if v3Volume == nil || err != nil {
+		t.Fatalf("Failed to find storage class %s after bootstrapping!", volumeName)
+	}
+	// Verifying the same volume exists on both clusters
+	if !reflect.DeepEqual(v2Volume, v3Volume) {
+		t.Fatalf("etcdv2 volume (%v) doesn't match the etcdv3 volume (%v)!",
+			v2Volume, v3Volume)
+	}
+	etcdv3Client, err := persistentstore.NewEtcdClientV3(*etcdV3)
+	if err != nil {
+		t.Fatalf("Creating etcdv3 client failed: %v", err)
+	}
+	versionJSON, err := etcdv3Client.Read(config.StoreURL)
+	if err != nil {
+		t.Fatalf("Couldn't determine the orchestrator persistent state version: %v",
+			err)
+	}
+	version := &persistentstore.PersistentStateVersion{}
+	err = json.Unmarshal([]byte(versionJSON), version)
+	if err != nil {
+		t.Fatalf("Couldn't unmarshal the orchestrator persistent state version: %v", err)
+	}
+	if config.OrchestratorAPIVersion != version.OrchestratorAPIVersion ||
+		string(orchestratorV3.storeClient.GetType()) != version.PersistentStoreVersion {
+		t.Fatalf("Failed to set the orchestrator persistent state version after bootsrapping: %v",
+			err)
+	}
+
+	// cleanup
+	if err = etcdv3Client.Stop(); err != nil {
+		t.Fatalf("Couldn't shut down etcdv3 client: %v", err)
+	}
+	cleanup(t, orchestratorV2)
+	cleanup(t, orchestratorV3)
+	cleanupStoreVersion(t, *etcdV3)
+}
+
+// The next series of tests test that bootstrap doesn't exit early if it
+// encounters a key error for one of the main types of entries.
+func TestStorageClassOnlyBootstrap(t *testing.T) {
+	const scName = "storageclass-only"
+
+	orchestrator := getOrchestrator()
+	originalSC, err := orchestrator.AddStorageClass(
+		&storageclass.Config{
+			Name: scName,
+			Attributes: map[string]sa.Request{
+				sa.Media:            sa.NewStringRequest("hdd"),
+				sa.ProvisioningType: sa.NewStringRequest("thick"),
+				sa.RecoveryTest:     sa.NewBoolRequest(true),
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("Unable to add storage class: ", err)
+	}
+	newOrchestrator := getOrchestrator()
+	bootstrappedSC, err := newOrchestrator.GetStorageClass(scName)
+	if bootstrappedSC == nil || err != nil {
+		t.Error("Unable to find storage class after bootstrapping.")
+	} else if !reflect.DeepEqual(bootstrappedSC, originalSC) {
+		t.Errorf("External storage classs differ:\n\tOriginal:  %v\n\t."+
+			"Bootstrapped:  %v", originalSC, bootstrappedSC)
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestFirstVolumeRecovery(t *testing.T) {
+	const (
+		backendName      = "firstRecoveryBackend"
+		scName           = "firstRecoveryBackendSC"
+		txOnlyVolumeName = "firstRecoveryVolumeTxOnly"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	txOnlyVolumeConfig := generateVolumeConfig(txOnlyVolumeName, 50, scName,
+		config.File)
+	// BEGIN actual test
+	runRecoveryTests(t, orchestrator, backendName, persistentstore.AddVolume,
+		[]recoveryTest{
+			{name: "firstTXOnly", volumeConfig: txOnlyVolumeConfig,
+				expectDestroy: true},
+		})
+	cleanup(t, orchestrator)
+}
+
+func TestOrchestratorNotReady(t *testing.T) {
+
+	var (
+		err            error
+		backend        *storage.BackendExternal
+		backends       []*storage.BackendExternal
+		volume         *storage.VolumeExternal
+		volumes        []*storage.VolumeExternal
+		snapshot       *storage.SnapshotExternal
+		snapshots      []*storage.SnapshotExternal
+		storageClass   *storageclass.External
+		storageClasses []*storageclass.External
+	)
+
+	orchestrator := getOrchestrator()
+	orchestrator.setBootstrapped(false, notReadyError())
+
+	backend, err = orchestrator.AddBackend("")
+	if backend != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected AddBackend to return an error.")
+	}
+
+	backend, err = orchestrator.GetBackend("")
+	if backend != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected GetBackend to return an error.")
+	}
+
+	backends, err = orchestrator.ListBackends()
+	if backends != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListBackends to return an error.")
+	}
+
+	err = orchestrator.DeleteBackend("")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected DeleteBackend to return an error.")
+	}
+
+	volume, err = orchestrator.AddVolume(nil)
+	if volume != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected AddVolume to return an error.")
+	}
+
+	volume, err = orchestrator.CloneVolume(nil)
+	if volume != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected CloneVolume to return an error.")
+	}
+
+	volume, err = orchestrator.GetVolume("")
+	if volume != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected GetVolume to return an error.")
+	}
+
+	_, err = orchestrator.GetDriverTypeForVolume(nil)
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected GetDriverTypeForVolume to return an error.")
+	}
+
+	_, err = orchestrator.GetVolumeType(nil)
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected GetVolumeType to return an error.")
+	}
+
+	volumes, err = orchestrator.ListVolumes()
+	if volumes != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListVolumes to return an error.")
+	}
+
+	err = orchestrator.DeleteVolume("")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected DeleteVolume to return an error.")
+	}
+
+	volumes, err = orchestrator.ListVolumesByPlugin("")
+	if volumes != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListVolumesByPlugin to return an error.")
+	}
+
+	err = orchestrator.AttachVolume("", "", nil)
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected AttachVolume to return an error.")
+	}
+
+	err = orchestrator.DetachVolume("", "")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected DetachVolume to return an error.")
+	}
+
+	snapshots, err = orchestrator.ListVolumeSnapshots("")
+	if snapshots != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListVolumeSnapshots to return an error.")
+	}
+
+	snapshot, err = orchestrator.CreateSnapshot(nil)
+	if snapshot != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected CreateSnapshot to return an error.")
+	}
+
+	snapshot, err = orchestrator.GetSnapshot("", "")
+	if snapshot != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected GetSnapshot to return an error.")
+	}
+
+	snapshots, err = orchestrator.ListSnapshots()
+	if snapshots != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListSnapshots to return an error.")
+	}
+
+	snapshots, err = orchestrator.ListSnapshotsForVolume("")
+	if snapshots != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListSnapshotsForVolume to return an error.")
+	}
+
+	err = orchestrator.DeleteSnapshot("", "")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected DeleteSnapshot to return an error.")
+	}
+
+	err = orchestrator.RestoreSnapshot("", "")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected RestoreSnapshot to return an error.")
+	}
+
+	if _, err = orchestrator.UpdateVolumeStorageClass("", ""); !IsNotReadyError(err) {
+		t.Errorf("Expected UpdateVolumeStorageClass to return an error.")
+	}
+
+	err = orchestrator.UnmanageVolume("", "")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected UnmanageVolume to return an error.")
+	}
+
+	if _, err = orchestrator.MigrateVolume("", ""); !IsNotReadyError(err) {
+		t.Errorf("Expected MigrateVolume to return an error.")
+	}
+
+	if _, err = orchestrator.GetVolumeMigration(""); !IsNotReadyError(err) {
+		t.Errorf("Expected GetVolumeMigration to return an error.")
+	}
+
+	if _, err = orchestrator.ListUnmanagedVolumes("", ""); !IsNotReadyError(err) {
+		t.Errorf("Expected ListUnmanagedVolumes to return an error.")
+	}
+
+	if groupSnapshot, err := orchestrator.CreateGroupSnapshot(nil); groupSnapshot != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected CreateGroupSnapshot to return an error.")
+	}
+
+	if groupSnapshots, err := orchestrator.ListGroupSnapshots(); groupSnapshots != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListGroupSnapshots to return an error.")
+	}
+
+	if clones, err := orchestrator.CloneGroupSnapshot("", ""); clones != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected CloneGroupSnapshot to return an error.")
+	}
+
+	err = orchestrator.DeleteGroupSnapshot("")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected DeleteGroupSnapshot to return an error.")
+	}
+
+	err = orchestrator.ReloadVolumes()
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected ReloadVolumes to return an error.")
+	}
+
+	if backup, err := orchestrator.BackupState(nil); backup != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected BackupState to return an error.")
+	}
+
+	err = orchestrator.RestoreState(nil)
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected RestoreState to return an error.")
+	}
+
+	if backendNames, err := orchestrator.ReencryptBackends(); backendNames != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ReencryptBackends to return an error.")
+	}
+
+	storageClass, err = orchestrator.AddStorageClass(nil)
+	if storageClass != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected AddStorageClass to return an error.")
+	}
+
+	storageClass, err = orchestrator.GetStorageClass("")
+	if storageClass != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected GetStorageClass to return an error.")
+	}
+
+	storageClasses, err = orchestrator.ListStorageClasses()
+	if storageClasses != nil || !IsNotReadyError(err) {
+		t.Errorf("Expected ListStorageClasses to return an error.")
+	}
+
+	err = orchestrator.DeleteStorageClass("")
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected DeleteStorageClass to return an error.")
+	}
+}
+
+func TestAddNode(t *testing.T) {
+	node := &utils.Node{
+		Name: "testNode",
+		IQN:  "myIQN",
+		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+	}
+	orchestrator := getOrchestrator()
+	if err := orchestrator.AddNode(node); err != nil {
+		t.Errorf("adding node failed; %v", err)
+	}
+}
+
+func TestGetNode(t *testing.T) {
+	orchestrator := getOrchestrator()
+	expectedNode := &utils.Node{
+		Name: "testNode",
+		IQN:  "myIQN",
+		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+	}
+	unexpectedNode := &utils.Node{
+		Name: "testNode2",
+		IQN:  "myOtherIQN",
+		IPs:  []string{"3.3.3.3", "4.4.4.4"},
+	}
+	initialNodes := map[string]*utils.Node{}
+	initialNodes[expectedNode.Name] = expectedNode
+	initialNodes[unexpectedNode.Name] = unexpectedNode
+	orchestrator.nodes = initialNodes
+
+	actualNode, err := orchestrator.GetNode(expectedNode.Name)
+	if err != nil {
+		t.Errorf("error getting node; %v", err)
+	}
+
+	if actualNode != expectedNode {
+		t.Errorf("Did not get expected node back; expected %+v, got %+v", expectedNode, actualNode)
+	}
+}
+
+func TestListNodes(t *testing.T) {
+	orchestrator := getOrchestrator()
+	expectedNode1 := &utils.Node{
+		Name: "testNode",
+		IQN:  "myIQN",
+		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+	}
+	expectedNode2 := &utils.Node{
+		Name: "testNode2",
+		IQN:  "myOtherIQN",
+		IPs:  []string{"3.3.3.3", "4.4.4.4"},
+	}
+	initialNodes := map[string]*utils.Node{}
+	initialNodes[expectedNode1.Name] = expectedNode1
+	initialNodes[expectedNode2.Name] = expectedNode2
+	orchestrator.nodes = initialNodes
+	expectedNodes := []*utils.Node{expectedNode1, expectedNode2}
+
+	actualNodes, err := orchestrator.ListNodes()
+	if err != nil {
+		t.Errorf("error listing nodes; %v", err)
+	}
+
+	if !unorderedNodeSlicesEqual(actualNodes, expectedNodes) {
+		t.Errorf("node list values do not match; expected %v, found %v", expectedNodes, actualNodes)
+	}
+}
+
+func unorderedNodeSlicesEqual(x, y []*utils.Node) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	// create a map of node pointers -> int
+	diff := make(map[*utils.Node]int, len(x))
+	for _, _x := range x {
+		// 0 value for int is 0, so just increment a counter for the string
+		diff[_x]++
+	}
+	for _, _y := range y {
+		// If the node _y is not in diff bail out early
+		if _, ok := diff[_y]; !ok {
+			return false
+		}
+		diff[_y] -= 1
+		if diff[_y] == 0 {
+			delete(diff, _y)
+		}
+	}
+	if len(diff) == 0 {
+		return true
+	}
+	return false
+}
+
+func TestDeleteNode(t *testing.T) {
+	orchestrator := getOrchestrator()
+	initialNode := &utils.Node{
+		Name: "testNode",
+		IQN:  "myIQN",
+		IPs:  []string{"1.1.1.1", "2.2.2.2"},
+	}
+	initialNodes := map[string]*utils.Node{}
+	initialNodes[initialNode.Name] = initialNode
+	orchestrator.nodes = initialNodes
+
+	if err := orchestrator.DeleteNode(initialNode.Name); err != nil {
+		t.Errorf("error deleting node; %v", err)
+	}
+
+	if _, ok := orchestrator.nodes[initialNode.Name]; ok {
+		t.Errorf("node was not properly deleted")
+	}
+}
+
+func TestPlacementPolicies(t *testing.T) {
+	for _, policy := range []string{
+		storageclass.PlacementPolicyRoundRobin,
+		storageclass.PlacementPolicyLeastAllocated,
+		storageclass.PlacementPolicyFewestVolumes,
+	} {
+		backendName := policy + "PlacementBackend"
+		scName := policy + "PlacementSC"
+		orchestrator := getOrchestrator()
+
+		pools := make(map[string]*fake.StoragePool)
+		for _, poolName := range []string{"first", "second"} {
+			pools[poolName] = &fake.StoragePool{
+				Attrs: map[string]sa.Offer{sa.Media: sa.NewStringOffer("hdd")},
+				Bytes: 100 * 1024 * 1024 * 1024,
+			}
+		}
+		configJSON, err := fakedriver.NewFakeStorageDriverConfigJSON(backendName, config.File, pools)
+		if err != nil {
+			t.Fatalf("%s: Unable to generate config JSON: %v", policy, err)
+		}
+		if _, err = orchestrator.AddBackend(configJSON); err != nil {
+			t.Fatalf("%s: Unable to add backend: %v", policy, err)
+		}
+		if _, err = orchestrator.AddStorageClass(&storageclass.Config{
+			Name:            scName,
+			PlacementPolicy: policy,
+			Pools:           map[string][]string{backendName: {"first", "second"}},
+		}); err != nil {
+			t.Fatalf("%s: Unable to add storage class: %v", policy, err)
+		}
+
+		// Equally sized volumes should be spread evenly across both pools, and explaining
+		// a placement shouldn't disturb the policy
+		volumesPerPool := make(map[string]int)
+		for i := 0; i < 4; i++ {
+			volumeName := fmt.Sprintf("%sPlacementVolume%d", policy, i)
+			if _, err := orchestrator.ExplainVolume(generateVolumeConfig(volumeName, 1, scName,
+				config.File)); err != nil {
+				t.Fatalf("%s: Unable to explain volume %s: %v", policy, volumeName, err)
+			}
+			vol, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File))
+			if err != nil {
+				t.Fatalf("%s: Unable to add volume %s: %v", policy, volumeName, err)
+			}
+			volumesPerPool[vol.Pool]++
+		}
+		for _, poolName := range []string{"first", "second"} {
+			if volumesPerPool[poolName] != 2 {
+				t.Errorf("%s: Expected 2 volumes in pool %s, found %d", policy, poolName,
+					volumesPerPool[poolName])
+			}
+		}
+		cleanup(t, orchestrator)
+	}
+
+	orchestrator := getOrchestrator()
+	if _, err := orchestrator.AddStorageClass(&storageclass.Config{
+		Name:            "invalidPlacementSC",
+		PlacementPolicy: storageclass.PlacementPolicyLabelWeighted,
+	}); err == nil {
+		t.Error("Expected a storage class with an incomplete placement policy to be rejected.")
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestPoolCapacity(t *testing.T) {
+	const (
+		backendName = "poolCapacityBackend"
+		scName      = "poolCapacityBackendSC"
+		volumeName  = "poolCapacityVolume"
+		gib         = 1024 * 1024 * 1024
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+
+	getPool := func() *storage.PoolExternal {
+		backend, err := orchestrator.GetBackend(backendName)
+		if err != nil {
+			t.Fatal("Unable to get backend: ", err)
+		}
+		pool, ok := backend.Storage["primary"].(*storage.PoolExternal)
+		if !ok {
+			t.Fatal("Backend does not have the expected storage pool.")
+		}
+		return pool
+	}
+
+	if pool := getPool(); pool.TotalBytes != 100*gib || pool.FreeBytes != 100*gib || pool.CommittedBytes != 0 {
+		t.Errorf("Unexpected capacity for new storage pool: %+v", pool)
+	}
+
+	// A volume larger than the pool must be rejected without a transaction left behind
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 150, scName, config.File)); err == nil {
+		t.Error("Expected a volume larger than its storage pool to be rejected.")
+	}
+	if txns, err := orchestrator.storeClient.GetVolumeTransactions(); err != nil {
+		t.Error("Unable to retrieve transactions: ", err)
+	} else if len(txns) > 0 {
+		t.Error("Transaction not cleared after rejecting a volume.")
+	}
+
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 40, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if pool := getPool(); pool.UsedBytes != 0 || pool.FreeBytes != 60*gib {
+		t.Errorf("Expected the new volume to be reserved until the next refresh: %+v", pool)
+	}
+	orchestrator.refreshPoolCapacity()
+	if pool := getPool(); pool.TotalBytes != 100*gib || pool.UsedBytes != 40*gib ||
+		pool.FreeBytes != 60*gib || pool.CommittedBytes != 40*gib {
+		t.Errorf("Unexpected capacity for storage pool after adding a volume: %+v", pool)
+	}
+
+	// The remaining free space is now too small for a volume that fit initially
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName+"2", 70, scName, config.File)); err == nil {
+		t.Error("Expected a volume larger than the free space in its storage pool to be rejected.")
+	}
+
+	// Volumes created since the last refresh count against the free space
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName+"3", 35, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName+"4", 35, scName, config.File)); err == nil {
+		t.Error("Expected a volume larger than the unreserved space in its storage pool to be rejected.")
+	}
+	orchestrator.refreshPoolCapacity()
+	if pool := getPool(); pool.UsedBytes != 75*gib || pool.FreeBytes != 25*gib {
+		t.Errorf("Unexpected capacity for storage pool after refreshing it: %+v", pool)
+	}
+	cleanup(t, orchestrator)
+}
+
+// waitForTask fails the test unless the task returns soon.
+func waitForTask(t *testing.T, name string, task func()) {
+	done := make(chan struct{})
+	go func() {
+		task()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("The %s task didn't stop.", name)
+	}
+}
+
+// backgroundTasksRunning returns whether an orchestrator's background tasks are running.
+func backgroundTasksRunning(o *TridentOrchestrator) bool {
+	o.statusMutex.RLock()
+	defer o.statusMutex.RUnlock()
+	return o.stopTasks != nil
+}
+
+func TestStopBackgroundTasks(t *testing.T) {
+	orchestrator := getOrchestrator()
+	if !backgroundTasksRunning(orchestrator) {
+		t.Fatal("Expected the background tasks to run once bootstrapped.")
+	}
+	orchestrator.statusMutex.RLock()
+	stop := orchestrator.stopTasks
+	orchestrator.statusMutex.RUnlock()
+
+	// Stopping waits for the tasks, and stopping twice is harmless
+	waitForTask(t, "background", orchestrator.Stop)
+	waitForTask(t, "background", orchestrator.Stop)
+
+	waitForTask(t, "pool capacity", func() {
+		orchestrator.refreshPoolCapacityPeriodically(stop)
+	})
+	waitForTask(t, "volume reconciler", func() {
+		orchestrator.reconcileVolumesPeriodically(stop)
+	})
+	waitForTask(t, "backend health monitor", func() {
+		orchestrator.monitorBackendHealth(stop)
+	})
+
+	// A stopped orchestrator doesn't start its tasks again
+	orchestrator.startBackgroundTasks()
+	if backgroundTasksRunning(orchestrator) {
+		t.Error("Expected a stopped orchestrator not to start its background tasks.")
+	}
+}
+
+func TestVolumePublications(t *testing.T) {
+	const (
+		backendName   = "publicationBackend"
+		scName        = "publicationBackendSC"
+		rwoVolumeName = "publicationVolumeRWO"
+		roxVolumeName = "publicationVolumeROX"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	for volumeName, accessMode := range map[string]config.AccessMode{
+		rwoVolumeName: config.ReadWriteOnce,
+		roxVolumeName: config.ReadOnlyMany,
+	} {
+		volumeConfig := generateVolumeConfig(volumeName, 1, scName, config.File)
+		volumeConfig.AccessMode = accessMode
+		if _, err := orchestrator.AddVolume(volumeConfig); err != nil {
+			t.Fatalf("Unable to add volume %s: %v", volumeName, err)
+		}
+	}
+
+	// A ReadWriteOnce volume may only be published to one node at a time
+	first := &storage.VolumePublication{VolumeName: rwoVolumeName, NodeName: "node1"}
+	if err := orchestrator.AddVolumePublication(first); err != nil {
+		t.Fatal("Unable to add volume publication: ", err)
+	}
+	if err := orchestrator.AddVolumePublication(
+		&storage.VolumePublication{VolumeName: rwoVolumeName, NodeName: "node1"}); err != nil {
+		t.Error("Expected repeating a publication to succeed: ", err)
+	}
+	second := &storage.VolumePublication{VolumeName: rwoVolumeName, NodeName: "node2"}
+	if err := orchestrator.AddVolumePublication(second); !IsConflictError(err) {
+		t.Errorf("Expected a conflict publishing a ReadWriteOnce volume to a second node, got %v", err)
+	}
+	if err := orchestrator.AddVolumePublication(&storage.VolumePublication{
+		VolumeName: rwoVolumeName, NodeName: "node1", AccessMode: config.ReadWriteMany,
+	}); !IsConflictError(err) {
+		t.Errorf("Expected a conflict publishing a ReadWriteOnce volume as ReadWriteMany, got %v", err)
+	}
+
+	// A ReadOnlyMany volume may be published to many nodes, but only read-only
+	if err := orchestrator.AddVolumePublication(
+		&storage.VolumePublication{VolumeName: roxVolumeName, NodeName: "node1"}); !IsConflictError(err) {
+		t.Errorf("Expected a conflict publishing a ReadOnlyMany volume read-write, got %v", err)
+	}
+	for _, nodeName := range []string{"node1", "node2"} {
+		if err := orchestrator.AddVolumePublication(&storage.VolumePublication{
+			VolumeName: roxVolumeName, NodeName: nodeName, ReadOnly: true,
+		}); err != nil {
+			t.Errorf("Unable to publish volume %s to node %s: %v", roxVolumeName, nodeName, err)
+		}
+	}
+	if err := orchestrator.AddVolumePublication(
+		&storage.VolumePublication{VolumeName: "missingVolume", NodeName: "node1"}); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error for a missing volume, got %v", err)
+	}
+
+	// Publications survive bootstrapping
+	newOrchestrator := getOrchestrator()
+	if publications, err := newOrchestrator.ListVolumePublications(); err != nil {
+		t.Error("Unable to list volume publications: ", err)
+	} else if len(publications) != 3 {
+		t.Errorf("Expected 3 volume publications after bootstrapping, got %d", len(publications))
+	}
+
+	// Once unpublished, a ReadWriteOnce volume may be published to another node
+	if err := orchestrator.DeleteVolumePublication(rwoVolumeName, "node1"); err != nil {
+		t.Error("Unable to delete volume publication: ", err)
+	}
+	if err := orchestrator.DeleteVolumePublication(rwoVolumeName, "node1"); !IsNotFoundError(err) {
+		t.Errorf("Expected a not found error deleting a missing publication, got %v", err)
+	}
+	if err := orchestrator.AddVolumePublication(second); err != nil {
+		t.Error("Unable to publish volume to a second node after unpublishing it: ", err)
+	}
+
+	// Deleting a volume removes its publications
+	if err := orchestrator.DeleteVolume(roxVolumeName); err != nil {
+		t.Fatal("Unable to delete volume: ", err)
+	}
+	if publications, err := orchestrator.storeClient.GetVolumePublications(); err != nil {
+		t.Error("Unable to read volume publications from the store: ", err)
+	} else if len(publications) != 1 || publications[0].ID() != second.ID() {
+		t.Errorf("Expected only publication %s to remain, found %d", second.ID(), len(publications))
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestReconcileVolumes(t *testing.T) {
+	const (
+		backendName = "reconcileBackend"
+		scName      = "reconcileBackendSC"
+		volumeName  = "reconcileVolume"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+
+	backend := orchestrator.backends[backendName]
+	driver, ok := backend.Driver.(*fakedriver.StorageDriver)
+	if !ok {
+		t.Fatal("Backend does not use the fake driver.")
+	}
+	internalName := orchestrator.volumes[volumeName].Config.InternalName
+
+	checkOrphaned := func(expected bool) {
+		if vol, err := orchestrator.GetVolume(volumeName); err != nil {
+			t.Fatal("Unable to get volume: ", err)
+		} else if vol.Orphaned != expected {
+			t.Errorf("Expected orphaned to be %t in the cache, got %t", expected, vol.Orphaned)
+		}
+		if vol, err := orchestrator.storeClient.GetVolume(volumeName); err != nil {
+			t.Fatal("Unable to get volume from the store: ", err)
+		} else if vol.Orphaned != expected {
+			t.Errorf("Expected orphaned to be %t in the store, got %t", expected, vol.Orphaned)
+		}
+	}
+
+	// A volume deleted behind Trident's back is marked as orphaned
+	fakeVolume := driver.Volumes[internalName]
+	delete(driver.Volumes, internalName)
+	orchestrator.reconcileVolumes()
+	checkOrphaned(true)
+
+	// and recovers once it reappears
+	driver.Volumes[internalName] = fakeVolume
+	orchestrator.reconcileVolumes()
+	checkOrphaned(false)
+
+	// Unmanaged volumes with the storage prefix are reported but left alone
+	prefix := "trident_"
+	driver.Config.StoragePrefix = &prefix
+	driver.Volumes["trident_stray"] = fake.Volume{Name: "trident_stray", SizeBytes: 1024}
+	driver.Volumes["stray"] = fake.Volume{Name: "stray", SizeBytes: 1024}
+	unmanaged := orchestrator.reconcileBackendVolumes(backend)
+	if !reflect.DeepEqual(unmanaged, []string{"trident_stray"}) {
+		t.Errorf("Expected only trident_stray to be reported as unmanaged, got %v", unmanaged)
+	}
+	if _, ok := driver.Volumes["trident_stray"]; !ok {
+		t.Error("The reconciler deleted an unmanaged volume.")
+	}
+	checkOrphaned(false)
+
+	delete(driver.Volumes, "trident_stray")
+	delete(driver.Volumes, "stray")
+	cleanup(t, orchestrator)
+}
+
+func TestBackendHealthMonitor(t *testing.T) {
+	const (
+		backendName = "healthBackend"
+		scName      = "healthBackendSC"
+		volumeName  = "healthVolume"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+
+	getState := func() storage.BackendState {
+		backend, err := orchestrator.GetBackend(backendName)
+		if err != nil {
+			t.Fatal("Unable to get backend: ", err)
+		}
+		return backend.State
+	}
+
+	driver, ok := orchestrator.backends[backendName].Driver.(*fakedriver.StorageDriver)
+	if !ok {
+		t.Fatal("Backend does not use the fake driver.")
+	}
+	driver.HealthError = fmt.Errorf("storage system unreachable")
+
+	// The backend stays online until enough consecutive probes have failed
+	now := time.Now()
+	for i := 1; i < config.BackendHealthCheckFailureThreshold; i++ {
+		orchestrator.checkBackendHealth(now)
+		if state := getState(); !state.IsOnline() {
+			t.Fatalf("Backend is %s after %d failed health checks.", state, i)
+		}
+		now = now.Add(config.BackendHealthCheckMaxInterval)
+	}
+	orchestrator.checkBackendHealth(now)
+	if state := getState(); !state.IsFailed() {
+		t.Fatalf("Expected backend to fail after %d failed health checks, but it is %s.",
+			config.BackendHealthCheckFailureThreshold, state)
+	}
+	if backend, err := orchestrator.storeClient.GetBackend(backendName); err != nil {
+		t.Error("Unable to get backend from the store: ", err)
+	} else if !backend.State.IsFailed() {
+		t.Errorf("Expected the failed state to be persisted, found %s.", backend.State)
+	}
+
+	// No volumes are placed on a failed backend
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File)); err == nil {
+		t.Error("Expected volume creation to fail with no online backends.")
+	}
+
+	// A failed backend isn't retried until its backoff interval has passed
+	orchestrator.checkBackendHealth(now.Add(time.Second))
+	if state := getState(); !state.IsFailed() {
+		t.Fatalf("Backend recovered before its retry interval elapsed; it is %s.", state)
+	}
+	orchestrator.checkBackendHealth(now.Add(config.BackendHealthCheckMaxInterval))
+	if state := getState(); !state.IsOnline() {
+		t.Fatalf("Expected backend to recover, but it is %s.", state)
+	}
+
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File)); err != nil {
+		t.Error("Unable to add volume after the backend recovered: ", err)
+	}
+
+	// A backend failed by an administrator is left alone
+	if _, err := orchestrator.UpdateBackendState(backendName, string(storage.Failed)); err != nil {
+		t.Fatal("Unable to fail backend: ", err)
+	}
+	orchestrator.checkBackendHealth(now.Add(2 * config.BackendHealthCheckMaxInterval))
+	if state := getState(); !state.IsFailed() {
+		t.Errorf("Health monitor recovered a backend failed by an administrator; it is %s.", state)
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestEventLog(t *testing.T) {
+	const (
+		backendName = "eventBackend"
+		scName      = "eventBackendSC"
+		volumeName  = "eventVolume"
+	)
+	newOrchestrator := func(storeClient persistentstore.Client) *TridentOrchestrator {
+		o := NewTridentOrchestrator(storeClient)
+		o.SetEventPersistence(true)
+		if err := o.Bootstrap(); err != nil {
+			t.Fatal("Unable to bootstrap orchestrator: ", err)
+		}
+		return o
+	}
+	orchestrator := newOrchestrator(getOrchestrator().storeClient)
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+
+	// Calls made through an auditing wrapper are attributed to its source
+	frontend := NewAuditingOrchestrator(orchestrator, "test")
+	if _, err := frontend.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if err := frontend.DeleteVolume("missingVolume"); err == nil {
+		t.Fatal("Expected deleting a nonexistent volume to fail.")
+	}
+	if _, err := orchestrator.GetVolume(volumeName); err != nil {
+		t.Fatal("Unable to get volume: ", err)
+	}
+
+	expected := []struct {
+		operation, objectName, outcome string
+	}{
+		{"AddVolume", volumeName, storage.EventOutcomeSuccess},
+		{"DeleteVolume", "missingVolume", storage.EventOutcomeFailure},
+	}
+	events, err := orchestrator.ListEvents()
+	if err != nil {
+		t.Fatal("Unable to list events: ", err)
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d.", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event.Operation != expected[i].operation || event.ObjectName != expected[i].objectName ||
+			event.Outcome != expected[i].outcome || event.Source != "test" {
+			t.Errorf("Unexpected event %d: %+v", i, event)
+		}
+	}
+	if events[1].Error == "" {
+		t.Error("Expected the failed event to record its error.")
+	}
+
+	// Persisted events are reloaded by the next orchestrator
+	restarted := newOrchestrator(orchestrator.storeClient)
+	reloaded, err := restarted.ListEvents()
+	if err != nil {
+		t.Fatal("Unable to list events: ", err)
+	}
+	if !reflect.DeepEqual(events, reloaded) {
+		t.Errorf("Events not reloaded after restart; expected %v, got %v.", events, reloaded)
+	}
+
+	// The log retains only the most recent events, and evicted events are removed from the store
+	restarted.events = newEventLog(2)
+	restarted.events.load(reloaded)
+	restarted.RecordEvent(storage.NewEvent("ReloadVolumes", storage.EventObjectVolume, "", "test", time.Now(), nil))
+	events, err = restarted.ListEvents()
+	if err != nil {
+		t.Fatal("Unable to list events: ", err)
+	}
+	if len(events) != 2 || events[0].ID != reloaded[1].ID || events[1].Operation != "ReloadVolumes" {
+		t.Errorf("Unexpected events after eviction: %v", events)
+	}
+	stored, err := restarted.storeClient.GetEvents()
+	if err != nil {
+		t.Fatal("Unable to get events from the store: ", err)
+	}
+	if len(stored) != 2 {
+		t.Errorf("Expected 2 events in the store, found %d.", len(stored))
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestListVolumesBySelector(t *testing.T) {
+	const (
+		backendName = "labelBackend"
+		scName      = "labelBackendSC"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+
+	volumeLabels := map[string]map[string]string{
+		"mysqlWeb":   {"app": "mysql", "team": "web"},
+		"mysqlBatch": {"app": "mysql", "team": "batch"},
+		"nginxWeb":   {"app": "nginx", "team": "web"},
+		"unlabeled":  nil,
+	}
+	for name, labels := range volumeLabels {
+		volumeConfig := generateVolumeConfig(name, 1, scName, config.File)
+		volumeConfig.Labels = labels
+		if _, err := orchestrator.AddVolume(volumeConfig); err != nil {
+			t.Fatalf("Unable to add volume %s: %v", name, err)
+		}
+	}
+
+	for _, c := range []struct {
+		selector string
+		expected []string
+	}{
+		{"app=mysql", []string{"mysqlBatch", "mysqlWeb"}},
+		{"app=mysql,team!=web", []string{"mysqlBatch"}},
+		{"team in (web)", []string{"mysqlWeb", "nginxWeb"}},
+		{"!app", []string{"unlabeled"}},
+		{"", []string{"mysqlBatch", "mysqlWeb", "nginxWeb", "unlabeled"}},
+	} {
+		volumes, err := orchestrator.ListVolumesBySelector(c.selector)
+		if err != nil {
+			t.Errorf("Unable to list volumes with selector %s: %v", c.selector, err)
+			continue
+		}
+		names := make([]string, 0, len(volumes))
+		for _, volume := range volumes {
+			names = append(names, volume.Config.Name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("Selector %s matched %v; expected %v.", c.selector, names, c.expected)
+		}
+	}
+
+	if _, err := orchestrator.ListVolumesBySelector("app in mysql"); err == nil {
+		t.Error("Expected an invalid selector to fail.")
+	}
+
+	// Labels are persisted with the volume
+	volume, err := orchestrator.storeClient.GetVolume("mysqlWeb")
+	if err != nil {
+		t.Fatal("Unable to get volume from the store: ", err)
+	}
+	if !reflect.DeepEqual(volume.Config.Labels, volumeLabels["mysqlWeb"]) {
+		t.Errorf("Expected persisted labels %v, found %v.", volumeLabels["mysqlWeb"], volume.Config.Labels)
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestStorageClassVolumeDefaults(t *testing.T) {
+	const (
+		backendName = "defaultsBackend"
+		scName      = "defaultsSC"
+	)
+	orchestrator := getOrchestrator()
+	addBackend(t, orchestrator, backendName)
+
+	scConfig := &storageclass.Config{
+		Name: scName,
+		Attributes: map[string]sa.Request{
+			sa.Media:            sa.NewStringRequest("hdd"),
+			sa.ProvisioningType: sa.NewStringRequest("thick"),
+			sa.TestingAttribute: sa.NewBoolRequest(true),
+		},
+		VolumeDefaults: map[string]string{"size": "2Gi", "snapshotPolicy": "hourly"},
+		MinVolumeSize:  "1Gi",
+		MaxVolumeSize:  "5Gi",
+	}
+
+	// Invalid defaults and limits are rejected
+	for _, modify := range []func(c *storageclass.Config){
+		func(c *storageclass.Config) { c.VolumeDefaults = map[string]string{"color": "blue"} },
+		func(c *storageclass.Config) { c.MinVolumeSize = "lots" },
+		func(c *storageclass.Config) { c.MinVolumeSize = "10Gi" },
+	} {
+		invalidConfig := *scConfig
+		modify(&invalidConfig)
+		if _, err := orchestrator.AddStorageClass(&invalidConfig); err == nil {
+			t.Errorf("Expected storage class %+v to be rejected.", invalidConfig)
+		}
+	}
+	if _, err := orchestrator.AddStorageClass(scConfig); err != nil {
+		t.Fatal("Unable to add storage class: ", err)
+	}
+
+	// Unspecified settings are taken from the storage class
+	volumeConfig := generateVolumeConfig("defaultedVolume", 0, scName, config.File)
+	volumeConfig.SnapshotPolicy = ""
+	volume, err := orchestrator.AddVolume(volumeConfig)
+	if err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if volume.Config.Size != "2147483648" || volume.Config.SnapshotPolicy != "hourly" {
+		t.Errorf("Expected the storage class defaults, got size %s and snapshot policy %s.",
+			volume.Config.Size, volume.Config.SnapshotPolicy)
+	}
+
+	// Sizes outside the storage class's limits are rejected on creation and resize
+	for _, gb := range []int{1, 6} {
+		name := fmt.Sprintf("volume%dGB", gb)
+		_, err := orchestrator.AddVolume(generateVolumeConfig(name, gb, scName, config.File))
+		if gb == 6 && err == nil {
+			t.Errorf("Expected a %d GiB volume to exceed the maximum size.", gb)
+		} else if gb == 1 && err != nil {
+			t.Errorf("Unable to add a %d GiB volume: %v", gb, err)
+		}
+	}
+	if volume, err := orchestrator.GetVolume("volume1GB"); err != nil {
+		t.Error("Unable to get volume: ", err)
+	} else if volume.Config.SnapshotPolicy != "none" {
+		t.Errorf("Expected the requested snapshot policy, got %s.", volume.Config.SnapshotPolicy)
+	}
+	if err := orchestrator.ResizeVolume("volume1GB", "512Mi"); err == nil {
+		t.Error("Expected resizing below the minimum size to fail.")
+	}
+	if err := orchestrator.ResizeVolume("volume1GB", "4Gi"); err != nil {
+		t.Error("Unable to resize volume within the storage class's limits: ", err)
+	}
+	if err := orchestrator.ResizeVolume("volume1GB", "8Gi"); err == nil {
+		t.Error("Expected resizing above the maximum size to fail.")
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestExplainVolume(t *testing.T) {
+	const (
+		fileBackendName  = "explainFileBackend"
+		blockBackendName = "explainBlockBackend"
+		scName           = "explainSC"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, fileBackendName, scName)
+
+	configJSON, err := fakedriver.NewFakeStorageDriverConfigJSON(
+		blockBackendName,
+		config.Block,
+		map[string]*fake.StoragePool{
+			"fast": {
+				Attrs: map[string]sa.Offer{
+					sa.Media:            sa.NewStringOffer("ssd"),
+					sa.ProvisioningType: sa.NewStringOffer("thick", "thin"),
+					sa.TestingAttribute: sa.NewBoolOffer(true),
+				},
+				Bytes: 100 * 1024 * 1024 * 1024,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("Unable to create mock driver config JSON: ", err)
+	}
+	if _, err = orchestrator.AddBackend(configJSON); err != nil {
+		t.Fatal("Unable to add backend: ", err)
+	}
+
+	findCheck := func(pool *storageclass.PoolExplanation, criterion string) *storageclass.Check {
+		for i := range pool.Checks {
+			if pool.Checks[i].Criterion == criterion {
+				return &pool.Checks[i]
+			}
+		}
+		t.Fatalf("Pool %s has no %s check.", pool.Pool, criterion)
+		return nil
+	}
+
+	// The matching pool is listed first, and the other pool's failed criteria are identified
+	volumeConfig := generateVolumeConfig("explainedVolume", 1, scName, config.File)
+	explanation, err := orchestrator.ExplainVolume(volumeConfig)
+	if err != nil {
+		t.Fatal("Unable to explain volume: ", err)
+	}
+	if !explanation.Provisionable || len(explanation.Pools) != 2 {
+		t.Fatalf("Expected a provisionable volume with two pools, got %+v.", explanation)
+	}
+	matched, unmatched := explanation.Pools[0], explanation.Pools[1]
+	if !matched.Matched || matched.Backend != fileBackendName || matched.Pool != "primary" {
+		t.Errorf("Expected pool primary to match, got %+v.", matched)
+	}
+	if unmatched.Matched || unmatched.Backend != blockBackendName {
+		t.Errorf("Expected pool fast not to match, got %+v.", unmatched)
+	}
+	if check := findCheck(unmatched, sa.Media); check.Passed || check.Offered == "" {
+		t.Errorf("Expected the media check to fail, got %+v.", check)
+	}
+	if check := findCheck(unmatched, "protocol"); check.Passed {
+		t.Errorf("Expected the protocol check to fail, got %+v.", check)
+	}
+	if check := findCheck(unmatched, sa.ProvisioningType); !check.Passed {
+		t.Errorf("Expected the provisioning type check to pass, got %+v.", check)
+	}
+
+	// Nothing is created, and the request isn't modified
+	if _, err := orchestrator.GetVolume(volumeConfig.Name); !IsNotFoundError(err) {
+		t.Errorf("Expected explaining a volume not to create it, got %v.", err)
+	}
+	if volumeConfig.InternalName != "" {
+		t.Errorf("Expected the request to be unchanged, got internal name %s.", volumeConfig.InternalName)
+	}
+
+	// A volume too large for any pool can't be provisioned
+	explanation, err = orchestrator.ExplainVolume(generateVolumeConfig("hugeVolume", 200, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to explain volume: ", err)
+	}
+	if explanation.Provisionable {
+		t.Error("Expected a volume larger than the pools not to be provisionable.")
+	}
+	for _, pool := range explanation.Pools {
+		if check := findCheck(pool, "freeSpace"); check.Passed {
+			t.Errorf("Expected the free space check of pool %s to fail, got %+v.", pool.Pool, check)
+		}
+	}
+
+	// Existing volumes can't be provisioned again
+	if _, err := orchestrator.AddVolume(generateVolumeConfig("existingVolume", 1, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	explanation, err = orchestrator.ExplainVolume(generateVolumeConfig("existingVolume", 1, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to explain volume: ", err)
+	}
+	if explanation.Provisionable || explanation.Checks[0].Passed {
+		t.Errorf("Expected an existing volume not to be provisionable, got %+v.", explanation.Checks)
+	}
+
+	_, err = orchestrator.ExplainVolume(generateVolumeConfig("lostVolume", 1, "unknownSC", config.File))
+	if !IsNotFoundError(err) {
+		t.Errorf("Expected an unknown storage class to be reported, got %v.", err)
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestQuotas(t *testing.T) {
+	const (
+		backendName = "quotaBackend"
+		scName      = "quotaBackendSC"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+
+	addVolume := func(name string, gb int, namespace string, labels map[string]string) error {
+		volumeConfig := generateVolumeConfig(name, gb, scName, config.File)
+		volumeConfig.Namespace = namespace
+		volumeConfig.Labels = labels
+		_, err := orchestrator.AddVolume(volumeConfig)
+		return err
+	}
+	checkUsage := func(quotaName string, expectedGB, expectedVolumes int) {
+		quota, err := orchestrator.GetQuota(quotaName)
+		if err != nil {
+			t.Fatalf("Unable to get quota %s: %v", quotaName, err)
+		}
+		if quota.UsedBytes != uint64(expectedGB)*1024*1024*1024 || quota.UsedVolumes != expectedVolumes {
+			t.Errorf("Quota %s reports %d bytes in %d volumes; expected %d GiB in %d volumes.",
+				quotaName, quota.UsedBytes, quota.UsedVolumes, expectedGB, expectedVolumes)
+		}
+	}
+
+	if _, err := orchestrator.AddQuota(&storage.Quota{Name: "bad", Scope: "tenant", Owner: "a"}); err == nil {
+		t.Error("Expected a quota with an unknown scope to be rejected.")
+	}
+	namespaceQuota := &storage.Quota{
+		Name: "teamA", Scope: storage.QuotaScopeNamespace, Owner: "teamA", MaxSize: "3Gi", MaxVolumes: 2,
+	}
+	if _, err := orchestrator.AddQuota(namespaceQuota); err != nil {
+		t.Fatal("Unable to add quota: ", err)
+	}
+	labelQuota := &storage.Quota{Name: "web", Scope: storage.QuotaScopeLabel, Owner: "team=web", MaxVolumes: 1}
+	if _, err := orchestrator.AddQuota(labelQuota); err != nil {
+		t.Fatal("Unable to add quota: ", err)
+	}
+
+	// Volumes are rejected once their owner's capacity or volume count would be exceeded
+	if err := addVolume("vol1", 1, "teamA", nil); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if err := addVolume("vol2", 3, "teamA", nil); err == nil {
+		t.Error("Expected a volume exceeding the namespace's capacity quota to be rejected.")
+	}
+	if err := addVolume("vol2", 1, "teamA", nil); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if err := addVolume("vol3", 1, "teamA", nil); err == nil {
+		t.Error("Expected a volume exceeding the namespace's volume quota to be rejected.")
+	}
+	if err := addVolume("vol3", 1, "teamB", nil); err != nil {
+		t.Error("Quota applied to the wrong namespace: ", err)
+	}
+	checkUsage("teamA", 2, 2)
+
+	if err := addVolume("web1", 1, "", map[string]string{"team": "web"}); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if err := addVolume("web2", 1, "", map[string]string{"team": "web", "app": "nginx"}); err == nil {
+		t.Error("Expected a volume exceeding the label quota to be rejected.")
+	}
+
+	// Clones and resizes count against the quota too
+	cloneConfig := generateVolumeConfig("vol1clone", 1, scName, config.File)
+	cloneConfig.CloneSourceVolume = "vol1"
+	cloneConfig.Namespace = "teamA"
+	if _, err := orchestrator.CloneVolume(cloneConfig); err == nil {
+		t.Error("Expected a clone exceeding the namespace's volume quota to be rejected.")
+	}
+	if err := orchestrator.ResizeVolume("vol1", "2Gi"); err != nil {
+		t.Error("Unable to resize volume within the quota: ", err)
+	}
+	if err := orchestrator.ResizeVolume("vol2", "2Gi"); err == nil {
+		t.Error("Expected a resize exceeding the namespace's capacity quota to be rejected.")
+	}
+	checkUsage("teamA", 3, 2)
+
+	// So do imports
+	driver := orchestrator.backends[backendName].Driver.(*fakedriver.StorageDriver)
+	driver.Volumes["legacy"] = fake.Volume{Name: "legacy", SizeBytes: 1024}
+	importConfig := generateVolumeConfig("imported", 1, scName, config.File)
+	importConfig.Namespace = "teamA"
+	noop := func(*storage.VolumeExternal, string) error { return nil }
+	if _, err := orchestrator.ImportVolume(importConfig, "legacy", backendName, false, noop); err == nil {
+		t.Error("Expected an import exceeding the namespace's volume quota to be rejected.")
+	}
+	delete(driver.Volumes, "legacy")
+
+	// Quotas survive a restart
+	restarted := NewTridentOrchestrator(orchestrator.storeClient)
+	if err := restarted.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	if quota, err := restarted.GetQuota("teamA"); err != nil {
+		t.Error("Quota not found after restart: ", err)
+	} else if !reflect.DeepEqual(quota.Quota, *namespaceQuota) {
+		t.Errorf("Expected quota %v after restart, got %v.", *namespaceQuota, quota.Quota)
+	}
+
+	// Raising a quota admits more volumes, and deleting it lifts the limits
+	raisedQuota := *namespaceQuota
+	raisedQuota.MaxVolumes = 3
+	raisedQuota.MaxSize = "4Gi"
+	if _, err := orchestrator.UpdateQuota(&raisedQuota); err != nil {
+		t.Fatal("Unable to update quota: ", err)
+	}
+	if err := addVolume("vol4", 1, "teamA", nil); err != nil {
+		t.Error("Unable to add volume after raising the quota: ", err)
+	}
+	if err := orchestrator.DeleteQuota("teamA"); err != nil {
+		t.Fatal("Unable to delete quota: ", err)
+	}
+	if err := addVolume("vol5", 1, "teamA", nil); err != nil {
+		t.Error("Unable to add volume after deleting the quota: ", err)
+	}
+	if quotas, err := orchestrator.ListQuotas(); err != nil || len(quotas) != 1 {
+		t.Errorf("Expected one remaining quota, got %v (%v).", quotas, err)
+	}
+	cleanup(t, orchestrator)
+}
+
+func TestBackupAndRestoreState(t *testing.T) {
+	const (
+		backendName = "backupBackend"
+		scName      = "backupBackendSC"
+		volumeName  = "backupVolume"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName)
+	if _, err := orchestrator.AddVolume(generateVolumeConfig(volumeName, 1, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	if err := orchestrator.AddNode(&utils.Node{Name: "node1"}); err != nil {
+		t.Fatal("Unable to add node: ", err)
+	}
+	// Rolling back an unfinished creation deletes the volume, so it isn't backed up
+	volume, err := orchestrator.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get volume: ", err)
+	}
+	createTxn := &persistentstore.VolumeTransaction{Config: volume.Config, Op: persistentstore.AddVolume}
+	if err = orchestrator.storeClient.AddVolumeTransaction(createTxn); err != nil {
+		t.Fatal("Unable to add volume transaction: ", err)
+	}
+
+	// The backend credentials are redacted unless a key is given to encrypt them
+	if backup, err := orchestrator.BackupState(nil); err != nil {
+		t.Fatal("Unable to back up state: ", err)
+	} else if backup.Secrets != persistentstore.BackupSecretsRedacted {
+		t.Errorf("Expected the backup secrets %s, got %s.", persistentstore.BackupSecretsRedacted, backup.Secrets)
+	}
+	key := []byte("0123456789abcdef0123456789abcdef")
+	backup, err := orchestrator.BackupState(key)
+	if err != nil {
+		t.Fatal("Unable to back up state: ", err)
+	}
+	if backup.Secrets != persistentstore.BackupSecretsEncrypted {
+		t.Errorf("Expected the backup secrets %s, got %s.", persistentstore.BackupSecretsEncrypted, backup.Secrets)
+	}
+	if err = backup.DecryptSecrets(key); err != nil {
+		t.Fatal("Unable to decrypt the backup secrets: ", err)
+	}
+	if err = backup.Validate(); err != nil {
+		t.Fatal("Backup is invalid: ", err)
+	}
+	if len(backup.Backends) != 1 || len(backup.StorageClasses) != 1 || len(backup.Volumes) != 1 {
+		t.Fatalf("Unexpected backup contents: %+v", backup)
+	}
+	if len(backup.Transactions) != 0 {
+		t.Fatalf("Expected no transactions in the backup, got %+v", backup.Transactions)
+	}
+	if err = orchestrator.storeClient.DeleteVolumeTransaction(createTxn); err != nil {
+		t.Fatal("Unable to delete volume transaction: ", err)
+	}
+
+	// A backup may only be restored into an orchestrator without any state
+	if err = orchestrator.RestoreState(backup); !IsConflictError(err) {
+		t.Errorf("Expected a conflict restoring into a non-empty orchestrator, got %v.", err)
+	}
+
+	restored := NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	if err = restored.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	// Nor is it replayed from an older backup
+	backup.Transactions = []*persistentstore.VolumeTransaction{createTxn}
+	if err = restored.RestoreState(backup); err != nil {
+		t.Fatal("Unable to restore state: ", err)
+	}
+	backup.Transactions = nil
+
+	backend, err := restored.GetBackend(backendName)
+	if err != nil {
+		t.Fatal("Backend not found after restore: ", err)
+	}
+	if !backend.Online {
+		t.Error("Expected the restored backend to be online.")
+	}
+	volume, err = restored.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Volume not found after restore: ", err)
+	}
+	if volume.Backend != backendName {
+		t.Errorf("Expected volume on backend %s, got %s.", backendName, volume.Backend)
+	}
+	if sc, err := restored.GetStorageClass(scName); err != nil {
+		t.Error("Storage class not found after restore: ", err)
+	} else if len(sc.StoragePools[backendName]) == 0 {
+		t.Error("Expected the restored storage class to match the restored backend's pools.")
+	}
+	if _, err = restored.GetNode("node1"); err != nil {
+		t.Error("Node not found after restore: ", err)
+	}
+
+	// The restored state is persisted, so it survives a restart
+	restarted := NewTridentOrchestrator(restored.storeClient)
+	if err = restarted.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	if _, err = restarted.GetVolume(volumeName); err != nil {
+		t.Error("Volume not found after restart: ", err)
+	}
+
+	// A restore that fails part way is rolled back, so that it may be repeated
+	failing := NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	if err = failing.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	if err = failing.AddNode(&utils.Node{Name: "node1", IQN: "previous"}); err != nil {
+		t.Fatal("Unable to add node: ", err)
+	}
+	snapshot := &storage.SnapshotPersistent{Snapshot: storage.Snapshot{
+		Config: &storage.SnapshotConfig{Name: "backupSnapshot", VolumeName: volumeName},
+		State:  storage.SnapshotStateOnline,
+	}}
+	backup.Snapshots = []*storage.SnapshotPersistent{snapshot, snapshot}
+	if err = failing.RestoreState(backup); err == nil {
+		t.Fatal("Expected a restore with a duplicate snapshot to fail.")
+	}
+	if backends, err := failing.storeClient.GetBackends(); err != nil || len(backends) != 0 {
+		t.Errorf("Expected no stored backends after a failed restore, got %v (%v).", backends, err)
+	}
+	if volumes, err := failing.storeClient.GetVolumes(); err != nil || len(volumes) != 0 {
+		t.Errorf("Expected no stored volumes after a failed restore, got %v (%v).", volumes, err)
+	}
+	if node, err := failing.storeClient.GetNode("node1"); err != nil || node.IQN != "previous" {
+		t.Errorf("Expected the previous node to be stored after a failed restore, got %v (%v).", node, err)
+	}
+	if node, err := failing.GetNode("node1"); err != nil || node.IQN != "previous" {
+		t.Errorf("Expected the previous node after a failed restore, got %v (%v).", node, err)
+	}
+	backup.Snapshots = []*storage.SnapshotPersistent{snapshot}
+	if err = failing.RestoreState(backup); err != nil {
+		t.Fatal("Unable to restore state after a failed restore: ", err)
+	}
+	if _, err = failing.GetVolume(volumeName); err != nil {
+		t.Error("Volume not found after restore: ", err)
+	}
+
+	// Archives that fail validation are rejected before anything is changed
+	empty := NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	if err = empty.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	backup.Volumes[0].Backend = "missingBackend"
+	if err = empty.RestoreState(backup); err == nil {
+		t.Error("Expected an invalid backup to be rejected.")
+	}
+	if backends, err := empty.ListBackends(); err != nil || len(backends) != 0 {
+		t.Errorf("Expected no backends after a rejected restore, got %v (%v).", backends, err)
+	}
+	cleanup(t, orchestrator)
+}
+
+// fakeLeaderElector is a LeaderElector whose election is decided by the test.
+type fakeLeaderElector struct {
+	elected chan struct{}
+	lost    chan struct{}
+	mutex   sync.Mutex
+}
+
+func newFakeLeaderElector() *fakeLeaderElector {
+	lost := make(chan struct{})
+	close(lost)
+	return &fakeLeaderElector{elected: make(chan struct{}), lost: lost}
+}
+
+func (e *fakeLeaderElector) Campaign() error {
+	<-e.elected
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.lost = make(chan struct{})
+	return nil
+}
+
+func (e *fakeLeaderElector) Lost() <-chan struct{} {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.lost
+}
+
+func (e *fakeLeaderElector) Resign() error {
+	e.lose()
+	return nil
+}
+
+func (e *fakeLeaderElector) Leader() (string, error) {
+	return "other", nil
+}
+
+func (e *fakeLeaderElector) elect() {
+	e.elected <- struct{}{}
+}
+
+func (e *fakeLeaderElector) lose() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	select {
+	case <-e.lost:
+	default:
+		close(e.lost)
+	}
+}
+
+func waitForStandby(t *testing.T, o *TridentOrchestrator, standby bool) {
+	for i := 0; i < 100; i++ {
+		if o.isStandby() == standby && o.getBootstrapError() == nil {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for standby to be %v.", standby)
+}
+
+func TestLeaderElection(t *testing.T) {
+	const (
+		backendName = "electionBackend"
+		scName      = "electionBackendSC"
+	)
+	leader := getOrchestrator()
+	addBackendStorageClass(t, leader, backendName, scName)
+	if _, err := leader.AddVolume(generateVolumeConfig("electionVolume1", 1, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+
+	elector := newFakeLeaderElector()
+	standby := NewTridentOrchestrator(leader.storeClient)
+	standby.SetLeaderElector(elector)
+	if err := standby.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap standby: ", err)
+	}
+
+	// A standby serves reads, but turns away workflows that change state
+	if backgroundTasksRunning(standby) {
+		t.Error("Expected a standby not to run the background tasks.")
+	}
+	if _, err := standby.GetVolume("electionVolume1"); err != nil {
+		t.Error("Volume not found on standby: ", err)
+	}
+	if _, err := standby.GetBackend(backendName); err != nil {
+		t.Error("Backend not found on standby: ", err)
+	}
+	_, err := standby.AddVolume(generateVolumeConfig("electionVolume2", 1, scName, config.File))
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected a standby to refuse adding a volume, got %v.", err)
+	}
+
+	// A refresh picks up the leader's changes, keeping the unchanged backends
+	if _, err = leader.AddVolume(generateVolumeConfig("electionVolume2", 1, scName, config.File)); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	backend := standby.backends[backendName]
+	if err = standby.loadStandbyState(); err != nil {
+		t.Fatal("Unable to refresh standby: ", err)
+	}
+	if _, err = standby.GetVolume("electionVolume2"); err != nil {
+		t.Error("Volume not found on standby after refresh: ", err)
+	}
+	if standby.backends[backendName] != backend {
+		t.Error("Expected the standby to keep its unchanged backend.")
+	}
+
+	// An elected standby takes over as the leader
+	elector.elect()
+	waitForStandby(t, standby, false)
+	if !backgroundTasksRunning(standby) {
+		t.Error("Expected a new leader to run the background tasks.")
+	}
+	if _, err = standby.GetVolume("electionVolume2"); err != nil {
+		t.Error("Volume not found on new leader: ", err)
+	}
+	if _, err = standby.AddVolume(generateVolumeConfig("electionVolume3", 1, scName, config.File)); err != nil {
+		t.Error("Unable to add volume on new leader: ", err)
+	}
+
+	// A leader that loses its lease becomes a standby again
+	elector.lose()
+	waitForStandby(t, standby, true)
+	if backgroundTasksRunning(standby) {
+		t.Error("Expected a demoted leader to stop the background tasks.")
+	}
+	_, err = standby.AddVolume(generateVolumeConfig("electionVolume4", 1, scName, config.File))
+	if !IsNotReadyError(err) {
+		t.Errorf("Expected a demoted leader to refuse adding a volume, got %v.", err)
+	}
+	if _, err = standby.GetVolume("electionVolume3"); err != nil {
+		t.Error("Volume not found after demotion: ", err)
+	}
+
+	cleanup(t, leader)
+}
+
+func TestReencryptBackends(t *testing.T) {
+	const backendName = "reencryptBackend"
+
+	// Credentials aren't encrypted unless the store is given a keyring
+	orchestrator := getOrchestrator()
+	if _, err := orchestrator.ReencryptBackends(); !IsUnsupportedError(err) {
+		t.Errorf("Expected re-encrypting without a keyring to be unsupported, got %v.", err)
+	}
+
+	keyringData := "key1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	loadKeyring := func() (*persistentstore.Keyring, error) {
+		return persistentstore.ParseKeyring([]byte(keyringData))
+	}
+	storeClient, err := persistentstore.NewEncryptingClient(persistentstore.NewInMemoryClient(), loadKeyring)
+	if err != nil {
+		t.Fatal("Unable to create the encrypting store client: ", err)
+	}
+	encrypted := NewTridentOrchestrator(storeClient)
+	if err = encrypted.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	addBackend(t, encrypted, backendName)
+
+	// The keyring is reloaded, so a rotated key takes effect
+	keyringData = "key2:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32))) + "\n" + keyringData
+	backendNames, err := encrypted.ReencryptBackends()
+	if err != nil {
+		t.Fatal("Unable to re-encrypt the backends: ", err)
+	}
+	if !reflect.DeepEqual(backendNames, []string{backendName}) {
+		t.Errorf("Expected backend %s to be re-encrypted, got %v.", backendName, backendNames)
+	}
+	if storeClient.ActiveKeyID() != "key2" {
+		t.Errorf("Expected the keyring to be reloaded, got active key %s.", storeClient.ActiveKeyID())
+	}
+
+	// A keyring that can't be loaded fails the re-encryption before anything is written
+	keyringData = ""
+	if _, err = encrypted.ReencryptBackends(); err == nil {
+		t.Error("Expected re-encrypting with an invalid keyring to fail.")
+	}
+
+	restarted := NewTridentOrchestrator(storeClient)
+	if err = restarted.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	if _, err = restarted.GetBackend(backendName); err != nil {
+		t.Error("Backend not found after restart: ", err)
+	}
+	cleanup(t, orchestrator)
 }
@@ -6,15 +6,18 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/persistent_store"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/storage_class"
 	drivers "github.com/netapp/trident/storage_drivers"
@@ -52,6 +55,12 @@ type MockOrchestrator struct {
 	storageClasses map[string]*storageclass.StorageClass
 	volumes        map[string]*storage.Volume
 	nodes          map[string]*utils.Node
+	snapshots      map[string]*storage.Snapshot
+	publications   map[string]*storage.VolumePublication
+	events         []*storage.Event
+	quotas         map[string]*storage.Quota
+	groupSnapshots map[string]*storage.GroupSnapshot
+	migrations     map[string]*storage.VolumeMigration
 	mutex          *sync.Mutex
 }
 
@@ -307,6 +316,11 @@ func (m *MockOrchestrator) GetVolumeType(vol *storage.VolumeExternal) (config.Vo
 	}
 }
 
+func (m *MockOrchestrator) ListUnmanagedVolumes(backendName, pattern string) ([]string, error) {
+	// The mock backends hold only managed volumes
+	return []string{}, nil
+}
+
 func (m *MockOrchestrator) ListVolumes() ([]*storage.VolumeExternal, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -333,12 +347,49 @@ func (m *MockOrchestrator) DeleteVolume(volumeName string) error {
 	return nil
 }
 
+func (m *MockOrchestrator) ExplainVolume(volumeConfig *storage.VolumeConfig) (
+	*storageclass.VolumeExplanation, error,
+) {
+	// Like AddVolume, don't bother matching pools; to test that logic, use an instance
+	// of the real orchestrator.
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.storageClasses[volumeConfig.StorageClass]; !ok {
+		return nil, notFoundError(fmt.Sprintf("unknown storage class: %s", volumeConfig.StorageClass))
+	}
+	return &storageclass.VolumeExplanation{
+		Volume:       volumeConfig.Name,
+		StorageClass: volumeConfig.StorageClass,
+		Protocol:     volumeConfig.Protocol,
+		Size:         volumeConfig.Size,
+		Checks:       make([]storageclass.Check, 0),
+		Pools:        make([]*storageclass.PoolExplanation, 0),
+	}, nil
+}
+
 func (m *MockOrchestrator) ListVolumesByPlugin(pluginName string) ([]*storage.VolumeExternal, error) {
 	// Currently returns nil, since this is backend agnostic.  Change this
 	// if we ever have non-apiserver functionality depend on this function.
 	return nil, nil
 }
 
+func (m *MockOrchestrator) ListVolumesBySelector(selector string) ([]*storage.VolumeExternal, error) {
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %s: %v", selector, err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	volumes := make([]*storage.VolumeExternal, 0)
+	for _, vol := range m.volumes {
+		if labelSelector.Matches(labels.Set(vol.Config.Labels)) {
+			volumes = append(volumes, vol.ConstructExternal())
+		}
+	}
+	return volumes, nil
+}
+
 func (m *MockOrchestrator) AttachVolume(volumeName, mountpoint string, publishInfo *utils.VolumePublishInfo) error {
 	return nil
 }
@@ -356,6 +407,343 @@ func (m *MockOrchestrator) ListVolumeSnapshots(volumeName string) ([]*storage.Sn
 	return make([]*storage.SnapshotExternal, 0), nil
 }
 
+func (m *MockOrchestrator) CreateSnapshot(snapshotConfig *storage.SnapshotConfig) (*storage.SnapshotExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.snapshots[snapshotConfig.ID()]; ok {
+		return nil, foundError("snapshot already exists")
+	}
+	volume, ok := m.volumes[snapshotConfig.VolumeName]
+	if !ok {
+		return nil, notFoundError("volume not found")
+	}
+
+	snapshotConfig.InternalName = snapshotConfig.Name
+	snapshotConfig.VolumeInternalName = volume.Config.InternalName
+	snapshot := storage.NewSnapshot(snapshotConfig, time.Now().UTC().Format(time.RFC3339), 0,
+		storage.SnapshotStateOnline)
+	m.snapshots[snapshotConfig.ID()] = snapshot
+	return snapshot.ConstructExternal(), nil
+}
+
+func (m *MockOrchestrator) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	snapshot, found := m.snapshots[storage.MakeSnapshotID(volumeName, snapshotName)]
+	if !found {
+		return nil, notFoundError("not found")
+	}
+	return snapshot.ConstructExternal(), nil
+}
+
+func (m *MockOrchestrator) ListSnapshots() ([]*storage.SnapshotExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	snapshots := make([]*storage.SnapshotExternal, 0, len(m.snapshots))
+	for _, s := range m.snapshots {
+		snapshots = append(snapshots, s.ConstructExternal())
+	}
+	return snapshots, nil
+}
+
+func (m *MockOrchestrator) ListSnapshotsForVolume(volumeName string) ([]*storage.SnapshotExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.volumes[volumeName]; !ok {
+		return nil, notFoundError("volume not found")
+	}
+
+	snapshots := make([]*storage.SnapshotExternal, 0)
+	for _, s := range m.snapshots {
+		if s.Config.VolumeName == volumeName {
+			snapshots = append(snapshots, s.ConstructExternal())
+		}
+	}
+	return snapshots, nil
+}
+
+func (m *MockOrchestrator) DeleteSnapshot(volumeName, snapshotName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	snapshotID := storage.MakeSnapshotID(volumeName, snapshotName)
+	if _, ok := m.snapshots[snapshotID]; !ok {
+		return notFoundError("not found")
+	}
+	delete(m.snapshots, snapshotID)
+	return nil
+}
+
+func (m *MockOrchestrator) RestoreSnapshot(volumeName, snapshotName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.snapshots[storage.MakeSnapshotID(volumeName, snapshotName)]; !ok {
+		return notFoundError("not found")
+	}
+	for _, p := range m.publications {
+		if p.VolumeName == volumeName {
+			return conflictError("volume is published")
+		}
+	}
+	return nil
+}
+
+func (m *MockOrchestrator) CreateGroupSnapshot(groupConfig *storage.GroupSnapshotConfig) (
+	*storage.GroupSnapshotExternal, error,
+) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if err := groupConfig.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := m.groupSnapshots[groupConfig.Name]; ok {
+		return nil, foundError("group snapshot already exists")
+	}
+	for _, volumeName := range groupConfig.VolumeNames {
+		if _, ok := m.volumes[volumeName]; !ok {
+			return nil, notFoundError("volume not found")
+		}
+		if _, ok := m.snapshots[storage.MakeSnapshotID(volumeName, groupConfig.Name)]; ok {
+			return nil, foundError("snapshot already exists")
+		}
+	}
+
+	created := time.Now().UTC().Format(time.RFC3339)
+	groupSnapshot := storage.NewGroupSnapshot(groupConfig, created, false)
+	external := &storage.GroupSnapshotExternal{GroupSnapshot: *groupSnapshot}
+	for _, volumeName := range groupConfig.VolumeNames {
+		snapshotConfig := groupConfig.SnapshotConfig(volumeName)
+		snapshotConfig.InternalName = snapshotConfig.Name
+		snapshotConfig.VolumeInternalName = m.volumes[volumeName].Config.InternalName
+		snapshot := storage.NewSnapshot(snapshotConfig, created, 0, storage.SnapshotStateOnline)
+		m.snapshots[snapshotConfig.ID()] = snapshot
+		external.Snapshots = append(external.Snapshots, snapshot.ConstructExternal())
+	}
+	m.groupSnapshots[groupConfig.Name] = groupSnapshot
+	return external, nil
+}
+
+func (m *MockOrchestrator) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshotExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	groupSnapshot, found := m.groupSnapshots[groupSnapshotName]
+	if !found {
+		return nil, notFoundError("not found")
+	}
+	return &storage.GroupSnapshotExternal{GroupSnapshot: *groupSnapshot}, nil
+}
+
+func (m *MockOrchestrator) ListGroupSnapshots() ([]*storage.GroupSnapshotExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	groupSnapshots := make([]*storage.GroupSnapshotExternal, 0, len(m.groupSnapshots))
+	for _, g := range m.groupSnapshots {
+		groupSnapshots = append(groupSnapshots, &storage.GroupSnapshotExternal{GroupSnapshot: *g})
+	}
+	return groupSnapshots, nil
+}
+
+func (m *MockOrchestrator) DeleteGroupSnapshot(groupSnapshotName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	groupSnapshot, found := m.groupSnapshots[groupSnapshotName]
+	if !found {
+		return notFoundError("not found")
+	}
+	for _, snapshotID := range groupSnapshot.Config.SnapshotIDs() {
+		delete(m.snapshots, snapshotID)
+	}
+	delete(m.groupSnapshots, groupSnapshotName)
+	return nil
+}
+
+func (m *MockOrchestrator) CloneGroupSnapshot(groupSnapshotName, clonePrefix string) (
+	[]*storage.VolumeExternal, error,
+) {
+	// TODO: write this method to enable CloneGroupSnapshot unit tests
+	return nil, nil
+}
+
+func (m *MockOrchestrator) AddVolumePublication(publication *storage.VolumePublication) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.volumes[publication.VolumeName]; !ok {
+		return notFoundError("volume not found")
+	}
+	m.publications[publication.ID()] = publication
+	return nil
+}
+
+func (m *MockOrchestrator) DeleteVolumePublication(volumeName, nodeName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	publicationID := storage.MakeVolumePublicationID(volumeName, nodeName)
+	if _, ok := m.publications[publicationID]; !ok {
+		return notFoundError("not found")
+	}
+	delete(m.publications, publicationID)
+	return nil
+}
+
+func (m *MockOrchestrator) ListVolumePublications() ([]*storage.VolumePublication, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	publications := make([]*storage.VolumePublication, 0, len(m.publications))
+	for _, p := range m.publications {
+		publications = append(publications, p)
+	}
+	return publications, nil
+}
+
+func (m *MockOrchestrator) ListVolumePublicationsForVolume(volumeName string) ([]*storage.VolumePublication, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.volumes[volumeName]; !ok {
+		return nil, notFoundError("volume not found")
+	}
+
+	publications := make([]*storage.VolumePublication, 0)
+	for _, p := range m.publications {
+		if p.VolumeName == volumeName {
+			publications = append(publications, p)
+		}
+	}
+	return publications, nil
+}
+
+func (m *MockOrchestrator) RecordEvent(event *storage.Event) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.events = append(m.events, event)
+}
+
+func (m *MockOrchestrator) ListEvents() ([]*storage.Event, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	events := make([]*storage.Event, len(m.events))
+	copy(events, m.events)
+	return events, nil
+}
+
+func (m *MockOrchestrator) BackupState(encryptionKey []byte) (*persistentstore.Backup, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	backup := &persistentstore.Backup{
+		FormatVersion:  persistentstore.BackupFormatVersion,
+		TridentVersion: config.OrchestratorVersion.String(),
+		Created:        time.Now().UTC().Format(time.RFC3339),
+		StateVersion: &persistentstore.PersistentStateVersion{
+			PersistentStoreVersion: string(persistentstore.MemoryStore),
+			OrchestratorAPIVersion: config.OrchestratorAPIVersion,
+		},
+		Secrets: persistentstore.BackupSecretsPlaintext,
+	}
+	for _, node := range m.nodes {
+		backup.Nodes = append(backup.Nodes, node)
+	}
+	if len(encryptionKey) == 0 {
+		return backup, backup.RedactSecrets()
+	}
+	return backup, backup.EncryptSecrets(encryptionKey)
+}
+
+func (m *MockOrchestrator) RestoreState(backup *persistentstore.Backup) error {
+	return backup.Validate()
+}
+
+func (m *MockOrchestrator) ReencryptBackends() ([]string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	backendNames := make([]string, 0, len(m.backends))
+	for backendName := range m.backends {
+		backendNames = append(backendNames, backendName)
+	}
+	sort.Strings(backendNames)
+	return backendNames, nil
+}
+
+// The mock orchestrator stores quotas but neither enforces them nor reports usage.
+
+func (m *MockOrchestrator) AddQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	if err := quota.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.quotas[quota.Name]; ok {
+		return nil, fmt.Errorf("quota %s already exists", quota.Name)
+	}
+	m.quotas[quota.Name] = quota
+	return &storage.QuotaExternal{Quota: *quota}, nil
+}
+
+func (m *MockOrchestrator) UpdateQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	if err := quota.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.quotas[quota.Name]; !ok {
+		return nil, notFoundError(fmt.Sprintf("quota %s not found", quota.Name))
+	}
+	m.quotas[quota.Name] = quota
+	return &storage.QuotaExternal{Quota: *quota}, nil
+}
+
+func (m *MockOrchestrator) GetQuota(quotaName string) (*storage.QuotaExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	quota, ok := m.quotas[quotaName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("quota %s not found", quotaName))
+	}
+	return &storage.QuotaExternal{Quota: *quota}, nil
+}
+
+func (m *MockOrchestrator) ListQuotas() ([]*storage.QuotaExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	quotas := make([]*storage.QuotaExternal, 0, len(m.quotas))
+	for _, quota := range m.quotas {
+		quotas = append(quotas, &storage.QuotaExternal{Quota: *quota})
+	}
+	return quotas, nil
+}
+
+func (m *MockOrchestrator) DeleteQuota(quotaName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.quotas[quotaName]; !ok {
+		return notFoundError(fmt.Sprintf("quota %s not found", quotaName))
+	}
+	delete(m.quotas, quotaName)
+	return nil
+}
+
 func (m *MockOrchestrator) ReloadVolumes() error {
 	return nil
 }
@@ -364,12 +752,95 @@ func (m *MockOrchestrator) ResizeVolume(volumeName, newSize string) error {
 	return nil
 }
 
+func (m *MockOrchestrator) UpdateVolumeStorageClass(
+	volumeName, storageClassName string,
+) (*storage.VolumeExternal, error) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	volume, ok := m.volumes[volumeName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if _, ok = m.storageClasses[storageClassName]; !ok {
+		return nil, notFoundError(fmt.Sprintf("storage class %s not found", storageClassName))
+	}
+	volume.Config.StorageClass = storageClassName
+	return volume.ConstructExternal(), nil
+}
+
+func (m *MockOrchestrator) MigrateVolume(volumeName, backendName string) (*storage.VolumeMigration, error) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	volume, ok := m.volumes[volumeName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	target, ok := m.mockBackends[backendName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("backend %s not found", backendName))
+	}
+
+	// Mock migrations finish immediately
+	migration := &storage.VolumeMigration{
+		VolumeName:    volumeName,
+		SourceBackend: volume.Backend,
+		SourcePool:    volume.Pool,
+		SourceConfig:  volume.Config.ConstructClone(),
+		TargetBackend: backendName,
+		TargetConfig:  volume.Config.ConstructClone(),
+		State:         storage.MigrationComplete,
+		Started:       time.Now().UTC().Format(time.RFC3339),
+	}
+	delete(m.mockBackends[volume.Backend].volumes, volume.Config.Name)
+	volume.Backend = backendName
+	target.volumes[volume.Config.Name] = volume
+	m.migrations[volumeName] = migration
+	return migration.ConstructExternal(), nil
+}
+
+func (m *MockOrchestrator) GetVolumeMigration(volumeName string) (*storage.VolumeMigration, error) {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	migration, ok := m.migrations[volumeName]
+	if !ok {
+		return nil, notFoundError(fmt.Sprintf("no migration of volume %s found", volumeName))
+	}
+	return migration.ConstructExternal(), nil
+}
+
+func (m *MockOrchestrator) UnmanageVolume(volumeName, newName string) error {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	volume, ok := m.volumes[volumeName]
+	if !ok {
+		return notFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+
+	delete(m.mockBackends[volume.Backend].volumes, volume.Config.Name)
+	delete(m.volumes, volume.Config.Name)
+	return nil
+}
+
 func NewMockOrchestrator() *MockOrchestrator {
 	return &MockOrchestrator{
 		backends:       make(map[string]*storage.Backend),
 		mockBackends:   make(map[string]*mockBackend),
 		storageClasses: make(map[string]*storageclass.StorageClass),
 		volumes:        make(map[string]*storage.Volume),
+		snapshots:      make(map[string]*storage.Snapshot),
+		publications:   make(map[string]*storage.VolumePublication),
+		events:         make([]*storage.Event, 0),
+		quotas:         make(map[string]*storage.Quota),
+		groupSnapshots: make(map[string]*storage.GroupSnapshot),
+		migrations:     make(map[string]*storage.VolumeMigration),
 		mutex:          &sync.Mutex{},
 	}
 }
@@ -5,6 +5,7 @@ package core
 import (
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/persistent_store"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/storage_class"
 	"github.com/netapp/trident/utils"
@@ -28,15 +29,40 @@ type Orchestrator interface {
 	CloneVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
 	DetachVolume(volumeName, mountpoint string) error
 	DeleteVolume(volume string) error
+	ExplainVolume(volumeConfig *storage.VolumeConfig) (*storageclass.VolumeExplanation, error)
 	GetVolume(volume string) (*storage.VolumeExternal, error)
 	GetVolumeExternal(volumeName string, backendName string) (*storage.VolumeExternal, error)
 	GetVolumeType(vol *storage.VolumeExternal) (config.VolumeType, error)
 	ImportVolume(volumeConfig *storage.VolumeConfig, originalVolName string, backendName string, notManaged bool, createPVandPVC Operation) (*storage.VolumeExternal, error)
+	ListUnmanagedVolumes(backendName, pattern string) ([]string, error)
 	ListVolumes() ([]*storage.VolumeExternal, error)
 	ListVolumesByPlugin(pluginName string) ([]*storage.VolumeExternal, error)
+	ListVolumesBySelector(selector string) ([]*storage.VolumeExternal, error)
 	ListVolumeSnapshots(volumeName string) ([]*storage.SnapshotExternal, error)
+	MigrateVolume(volumeName, backendName string) (*storage.VolumeMigration, error)
+	GetVolumeMigration(volumeName string) (*storage.VolumeMigration, error)
 	PublishVolume(volumeName string, publishInfo *utils.VolumePublishInfo) error
 	ResizeVolume(volumeName, newSize string) error
+	UnmanageVolume(volumeName, newName string) error
+	UpdateVolumeStorageClass(volumeName, storageClassName string) (*storage.VolumeExternal, error)
+
+	CreateSnapshot(snapshotConfig *storage.SnapshotConfig) (*storage.SnapshotExternal, error)
+	GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotExternal, error)
+	ListSnapshots() ([]*storage.SnapshotExternal, error)
+	ListSnapshotsForVolume(volumeName string) ([]*storage.SnapshotExternal, error)
+	DeleteSnapshot(volumeName, snapshotName string) error
+	RestoreSnapshot(volumeName, snapshotName string) error
+
+	CreateGroupSnapshot(groupConfig *storage.GroupSnapshotConfig) (*storage.GroupSnapshotExternal, error)
+	GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshotExternal, error)
+	ListGroupSnapshots() ([]*storage.GroupSnapshotExternal, error)
+	DeleteGroupSnapshot(groupSnapshotName string) error
+	CloneGroupSnapshot(groupSnapshotName, clonePrefix string) ([]*storage.VolumeExternal, error)
+
+	AddVolumePublication(publication *storage.VolumePublication) error
+	DeleteVolumePublication(volumeName, nodeName string) error
+	ListVolumePublications() ([]*storage.VolumePublication, error)
+	ListVolumePublicationsForVolume(volumeName string) ([]*storage.VolumePublication, error)
 
 	GetDriverTypeForVolume(vol *storage.VolumeExternal) (string, error)
 	ReloadVolumes() error
@@ -50,6 +76,19 @@ type Orchestrator interface {
 	GetNode(nName string) (*utils.Node, error)
 	ListNodes() ([]*utils.Node, error)
 	DeleteNode(nName string) error
+
+	AddQuota(quota *storage.Quota) (*storage.QuotaExternal, error)
+	UpdateQuota(quota *storage.Quota) (*storage.QuotaExternal, error)
+	GetQuota(quotaName string) (*storage.QuotaExternal, error)
+	ListQuotas() ([]*storage.QuotaExternal, error)
+	DeleteQuota(quotaName string) error
+
+	RecordEvent(event *storage.Event)
+	ListEvents() ([]*storage.Event, error)
+
+	BackupState(encryptionKey []byte) (*persistentstore.Backup, error)
+	RestoreState(backup *persistentstore.Backup) error
+	ReencryptBackends() ([]string, error)
 }
 
 type NotReadyError struct {
@@ -82,4 +121,10 @@ type UnsupportedError struct {
 
 func (e *UnsupportedError) Error() string { return e.message }
 
+type ConflictError struct {
+	message string
+}
+
+func (e *ConflictError) Error() string { return e.message }
+
 type Operation func(*storage.VolumeExternal, string) error
@@ -4,6 +4,7 @@ package common
 
 import (
 	"fmt"
+	"strings"
 
 	hash "github.com/mitchellh/hashstructure"
 	log "github.com/sirupsen/logrus"
@@ -18,6 +19,9 @@ import (
 
 const (
 	autoStorageClassPrefix = "auto_sc_%d"
+
+	// Volume creation options with this prefix set labels on the volume
+	volumeLabelPrefix = "label."
 )
 
 // getStorageClass accepts a list of volume creation options and returns a
@@ -80,6 +84,36 @@ func makeStorageClass(options map[string]string) (*storageclass.Config, error) {
 		}
 	}
 
+	if p, ok := options[sa.PlacementPolicy]; ok {
+		scConfig.PlacementPolicy = p
+		delete(options, sa.PlacementPolicy)
+	}
+
+	if p, ok := options[sa.PlacementLabel]; ok {
+		scConfig.PlacementLabel = p
+		delete(options, sa.PlacementLabel)
+	}
+
+	if p, ok := options[sa.MinVolumeSize]; ok {
+		scConfig.MinVolumeSize = p
+		delete(options, sa.MinVolumeSize)
+	}
+
+	if p, ok := options[sa.MaxVolumeSize]; ok {
+		scConfig.MaxVolumeSize = p
+		delete(options, sa.MaxVolumeSize)
+	}
+
+	for k, v := range options {
+		if strings.HasPrefix(k, sa.VolumeDefaultPrefix) {
+			if scConfig.VolumeDefaults == nil {
+				scConfig.VolumeDefaults = make(map[string]string)
+			}
+			scConfig.VolumeDefaults[strings.TrimPrefix(k, sa.VolumeDefaultPrefix)] = v
+			delete(options, k)
+		}
+	}
+
 	// Map options to storage class attributes
 	scConfig.Attributes = make(map[string]sa.Request)
 	for k, v := range options {
@@ -116,6 +150,11 @@ func GetVolumeConfig(
 	protocol config.Protocol, accessMode config.AccessMode,
 ) (*storage.VolumeConfig, error) {
 
+	labels := getVolumeLabels(opts)
+	if err := storage.ValidateVolumeLabels(labels); err != nil {
+		return nil, err
+	}
+
 	return &storage.VolumeConfig{
 		Name:                name,
 		Size:                fmt.Sprintf("%d", sizeBytes),
@@ -138,5 +177,23 @@ func GetVolumeConfig(
 		CloneSourceVolume:   utils.GetV(opts, "from", ""),
 		CloneSourceSnapshot: utils.GetV(opts, "fromSnapshot", ""),
 		ServiceLevel:        utils.GetV(opts, "serviceLevel", ""),
+		Labels:              labels,
 	}, nil
 }
+
+// getVolumeLabels returns the volume labels specified by a set of volume creation
+// options, such as label.app=mysql, or nil if there are none.
+func getVolumeLabels(opts map[string]string) map[string]string {
+
+	var labels map[string]string
+	for k, v := range opts {
+		if !strings.HasPrefix(k, volumeLabelPrefix) {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[strings.TrimPrefix(k, volumeLabelPrefix)] = v
+	}
+	return labels
+}
@@ -4,9 +4,12 @@ package csi
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -57,6 +60,7 @@ func (p *Plugin) CreateVolume(
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		csiVolume.ContentSource = req.GetVolumeContentSource()
 
 		return &csi.CreateVolumeResponse{Volume: csiVolume}, nil
 	}
@@ -114,8 +118,21 @@ func (p *Plugin) CreateVolume(
 		volConfig.FileSystem = fileSystem
 	}
 
-	// Invoke the orchestrator to create the new volume
-	newVolume, err := p.orchestrator.AddVolume(volConfig)
+	// Check if the volume should be cloned from a snapshot or another volume
+	contentSource := req.GetVolumeContentSource()
+	if contentSource != nil {
+		if err = p.setCloneSourceFromContentSource(volConfig, contentSource, sizeBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Invoke the orchestrator to create or clone the new volume
+	var newVolume *storage.VolumeExternal
+	if volConfig.CloneSourceVolume != "" {
+		newVolume, err = p.orchestrator.CloneVolume(volConfig)
+	} else {
+		newVolume, err = p.orchestrator.AddVolume(volConfig)
+	}
 	if err != nil {
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
@@ -124,10 +141,64 @@ func (p *Plugin) CreateVolume(
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	csiVolume.ContentSource = contentSource
 
 	return &csi.CreateVolumeResponse{Volume: csiVolume}, nil
 }
 
+// setCloneSourceFromContentSource updates a volume config so that the volume is cloned
+// from the snapshot or volume specified in a CSI volume content source.
+func (p *Plugin) setCloneSourceFromContentSource(
+	volConfig *storage.VolumeConfig, contentSource *csi.VolumeContentSource, sizeBytes int64,
+) error {
+
+	var sourceVolumeName string
+
+	if snapshotSource := contentSource.GetSnapshot(); snapshotSource != nil {
+
+		volumeName, snapshotName, err := storage.ParseSnapshotID(snapshotSource.GetSnapshotId())
+		if err != nil {
+			return status.Error(codes.NotFound, err.Error())
+		}
+
+		// Make sure the source snapshot exists
+		sourceSnapshot, err := p.orchestrator.GetSnapshot(volumeName, snapshotName)
+		if err != nil {
+			return p.getCSIErrorForOrchestratorError(err)
+		}
+
+		sourceVolumeName = volumeName
+		volConfig.CloneSourceSnapshot = sourceSnapshot.Config.InternalName
+
+	} else if volumeSource := contentSource.GetVolume(); volumeSource != nil {
+
+		sourceVolumeName = volumeSource.GetVolumeId()
+		if sourceVolumeName == "" {
+			return status.Error(codes.InvalidArgument, "no source volume ID provided")
+		}
+
+	} else {
+		return status.Error(codes.InvalidArgument, "unsupported volume content source")
+	}
+
+	// Make sure the source volume exists
+	sourceVolume, err := p.orchestrator.GetVolume(sourceVolumeName)
+	if err != nil {
+		return p.getCSIErrorForOrchestratorError(err)
+	}
+
+	// A clone is the same size as its source, so it can't satisfy a request for a larger volume
+	sourceSize, _ := strconv.ParseInt(sourceVolume.Config.Size, 10, 64)
+	if sizeBytes > sourceSize {
+		return status.Error(codes.OutOfRange, fmt.Sprintf(
+			"requested size %d is larger than the size %d of the clone source", sizeBytes, sourceSize))
+	}
+
+	volConfig.CloneSourceVolume = sourceVolumeName
+
+	return nil
+}
+
 func (p *Plugin) DeleteVolume(
 	ctx context.Context, req *csi.DeleteVolumeRequest,
 ) (*csi.DeleteVolumeResponse, error) {
@@ -210,9 +281,34 @@ func (p *Plugin) ControllerPublishVolume(
 		HostName:  nodeInfo.Name,
 	}
 
+	// Record the publication, which fails if the volume's access mode doesn't allow it
+	alreadyPublished, err := p.isVolumePublishedToNode(volume.Config.Name, nodeInfo.Name)
+	if err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+	publication := &storage.VolumePublication{
+		VolumeName: volume.Config.Name,
+		NodeName:   nodeInfo.Name,
+		ReadOnly:   req.GetReadonly(),
+		AccessMode: p.getAccessForCSIAccessMode(req.GetVolumeCapability().GetAccessMode().Mode),
+	}
+	if err = p.orchestrator.AddVolumePublication(publication); err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+
 	// Update NFS export rules (?), add node IQN to igroup, etc.
 	err = p.orchestrator.PublishVolume(volume.Config.Name, volumePublishInfo)
 	if err != nil {
+		if !alreadyPublished {
+			deleteErr := p.orchestrator.DeleteVolumePublication(volume.Config.Name, nodeInfo.Name)
+			if deleteErr != nil {
+				log.WithFields(log.Fields{
+					"volume": volume.Config.Name,
+					"node":   nodeInfo.Name,
+					"error":  deleteErr,
+				}).Error("Could not remove the publication record of a volume that failed to publish.")
+			}
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -259,10 +355,40 @@ func (p *Plugin) ControllerUnpublishVolume(
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
-	// Apart from validation, Trident has nothing to do for this entry point
+	// Remove the record of the volume's publication to the node, or to all nodes if
+	// no node was specified.  Apart from that, Trident has nothing to do here.
+	publications, err := p.orchestrator.ListVolumePublicationsForVolume(volumeID)
+	if err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+	for _, publication := range publications {
+		if req.GetNodeId() != "" && publication.NodeName != req.GetNodeId() {
+			continue
+		}
+		err = p.orchestrator.DeleteVolumePublication(publication.VolumeName, publication.NodeName)
+		if err != nil && !core.IsNotFoundError(err) {
+			return nil, p.getCSIErrorForOrchestratorError(err)
+		}
+	}
+
 	return &csi.ControllerUnpublishVolumeResponse{}, nil
 }
 
+// isVolumePublishedToNode returns whether Trident has a record of a volume's publication to a node.
+func (p *Plugin) isVolumePublishedToNode(volumeName, nodeName string) (bool, error) {
+
+	publications, err := p.orchestrator.ListVolumePublicationsForVolume(volumeName)
+	if err != nil {
+		return false, err
+	}
+	for _, publication := range publications {
+		if publication.NodeName == nodeName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (p *Plugin) ValidateVolumeCapabilities(
 	ctx context.Context, req *csi.ValidateVolumeCapabilitiesRequest,
 ) (*csi.ValidateVolumeCapabilitiesResponse, error) {
@@ -337,8 +463,59 @@ func (p *Plugin) GetCapacity(
 	ctx context.Context, req *csi.GetCapacityRequest,
 ) (*csi.GetCapacityResponse, error) {
 
-	// Trident doesn't report pool capacities
-	return nil, status.Error(codes.Unimplemented, "")
+	fields := log.Fields{"Method": "GetCapacity", "Type": "CSI_Controller"}
+	log.WithFields(fields).Debug(">>>> GetCapacity")
+	defer log.WithFields(fields).Debug("<<<< GetCapacity")
+
+	// Only count backends that could satisfy the requested access modes
+	protocol := tridentconfig.ProtocolAny
+	for _, capability := range req.GetVolumeCapabilities() {
+		required := p.getProtocolForCSIAccessMode(capability.GetAccessMode().Mode)
+		if required != tridentconfig.ProtocolAny {
+			protocol = required
+		}
+	}
+
+	// Find the storage class that a volume created with the same parameters would use.
+	// Copy the parameters, since they are consumed while building the storage class.
+	parameters := make(map[string]string)
+	for key, value := range req.GetParameters() {
+		parameters[key] = value
+	}
+	scConfig, err := frontendcommon.GetStorageClass(parameters, p.orchestrator)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "could not create a storage class from capacity request")
+	}
+	sc, err := p.orchestrator.GetStorageClass(scConfig.Name)
+	if err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+
+	// Total the free space of the storage class's pools.  Pools whose capacity isn't
+	// reported by their drivers don't contribute to the total.
+	var availableBytes uint64
+	for backendName, poolNames := range sc.StoragePools {
+		backend, err := p.orchestrator.GetBackend(backendName)
+		if core.IsNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return nil, p.getCSIErrorForOrchestratorError(err)
+		}
+		if !backend.Online || !backend.State.IsOnline() {
+			continue
+		}
+		if protocol != tridentconfig.ProtocolAny && backend.Protocol != tridentconfig.ProtocolAny &&
+			backend.Protocol != protocol {
+			continue
+		}
+		for _, poolName := range poolNames {
+			if pool, ok := backend.Storage[poolName].(*storage.PoolExternal); ok {
+				availableBytes += pool.FreeBytes
+			}
+		}
+	}
+
+	return &csi.GetCapacityResponse{AvailableCapacity: int64(availableBytes)}, nil
 }
 
 func (p *Plugin) ControllerGetCapabilities(
@@ -356,24 +533,193 @@ func (p *Plugin) CreateSnapshot(
 	ctx context.Context, req *csi.CreateSnapshotRequest,
 ) (*csi.CreateSnapshotResponse, error) {
 
-	// Trident doesn't support snapshots
-	return nil, status.Error(codes.Unimplemented, "")
+	fields := log.Fields{"Method": "CreateSnapshot", "Type": "CSI_Controller"}
+	log.WithFields(fields).Debug(">>>> CreateSnapshot")
+	defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+
+	volumeName := req.GetSourceVolumeId()
+	if volumeName == "" {
+		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
+	}
+
+	snapshotName := req.GetName()
+	if snapshotName == "" {
+		return nil, status.Error(codes.InvalidArgument, "no snapshot name provided")
+	}
+
+	// Check for pre-existing snapshot with the same name on the same volume
+	existingSnapshot, err := p.orchestrator.GetSnapshot(volumeName, snapshotName)
+	if err != nil && !core.IsNotFoundError(err) {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+
+	// If pre-existing snapshot found, just return it
+	if existingSnapshot != nil {
+		csiSnapshot, err := p.getCSISnapshotFromTridentSnapshot(existingSnapshot)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return &csi.CreateSnapshotResponse{Snapshot: csiSnapshot}, nil
+	}
+
+	// Snapshot names must be unique across all volumes
+	snapshots, err := p.orchestrator.ListSnapshots()
+	if err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Config.Name == snapshotName {
+			return nil, status.Error(codes.AlreadyExists,
+				fmt.Sprintf("snapshot %s already exists on a different volume", snapshotName))
+		}
+	}
+
+	// Check if the source volume exists
+	if _, err = p.orchestrator.GetVolume(volumeName); err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+
+	// Convert snapshot creation options into a Trident snapshot config
+	snapshotConfig := &storage.SnapshotConfig{
+		Version:    tridentconfig.OrchestratorAPIVersion,
+		Name:       snapshotName,
+		VolumeName: volumeName,
+	}
+
+	// Invoke the orchestrator to create the new snapshot
+	newSnapshot, err := p.orchestrator.CreateSnapshot(snapshotConfig)
+	if err != nil {
+		return nil, p.getCSIErrorForOrchestratorError(err)
+	}
+
+	csiSnapshot, err := p.getCSISnapshotFromTridentSnapshot(newSnapshot)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &csi.CreateSnapshotResponse{Snapshot: csiSnapshot}, nil
 }
 
 func (p *Plugin) DeleteSnapshot(
 	ctx context.Context, req *csi.DeleteSnapshotRequest,
 ) (*csi.DeleteSnapshotResponse, error) {
 
-	// Trident doesn't support snapshots
-	return nil, status.Error(codes.Unimplemented, "")
+	fields := log.Fields{"Method": "DeleteSnapshot", "Type": "CSI_Controller"}
+	log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+	defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+
+	snapshotID := req.GetSnapshotId()
+	if snapshotID == "" {
+		return nil, status.Error(codes.InvalidArgument, "no snapshot ID provided")
+	}
+
+	volumeName, snapshotName, err := storage.ParseSnapshotID(snapshotID)
+	if err != nil {
+		// An invalid ID is treated as a nonexistent snapshot, so we log the error and return success.
+		log.WithField("snapshotID", snapshotID).Warning("Could not parse snapshot ID.")
+		return &csi.DeleteSnapshotResponse{}, nil
+	}
+
+	err = p.orchestrator.DeleteSnapshot(volumeName, snapshotName)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volumeName":   volumeName,
+			"snapshotName": snapshotName,
+			"error":        err,
+		}).Debugf("Could not delete snapshot.")
+
+		// In CSI, delete is idempotent, so don't return an error if the snapshot doesn't exist
+		if !core.IsNotFoundError(err) {
+			return nil, p.getCSIErrorForOrchestratorError(err)
+		}
+	}
+
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
 func (p *Plugin) ListSnapshots(
 	ctx context.Context, req *csi.ListSnapshotsRequest,
 ) (*csi.ListSnapshotsResponse, error) {
 
-	// Trident doesn't support snapshots
-	return nil, status.Error(codes.Unimplemented, "")
+	fields := log.Fields{"Method": "ListSnapshots", "Type": "CSI_Controller"}
+	log.WithFields(fields).Debug(">>>> ListSnapshots")
+	defer log.WithFields(fields).Debug("<<<< ListSnapshots")
+
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max entries may not be negative")
+	}
+
+	var snapshots []*storage.SnapshotExternal
+
+	if snapshotID := req.GetSnapshotId(); snapshotID != "" {
+
+		// Filtering by snapshot ID yields either zero or one snapshot
+		snapshots = make([]*storage.SnapshotExternal, 0, 1)
+
+		volumeName, snapshotName, err := storage.ParseSnapshotID(snapshotID)
+		if err != nil {
+			return &csi.ListSnapshotsResponse{}, nil
+		}
+		if volumeID := req.GetSourceVolumeId(); volumeID != "" && volumeID != volumeName {
+			return &csi.ListSnapshotsResponse{}, nil
+		}
+
+		snapshot, err := p.orchestrator.GetSnapshot(volumeName, snapshotName)
+		if err != nil && !core.IsNotFoundError(err) {
+			return nil, p.getCSIErrorForOrchestratorError(err)
+		} else if snapshot != nil {
+			snapshots = append(snapshots, snapshot)
+		}
+
+	} else if volumeID := req.GetSourceVolumeId(); volumeID != "" {
+
+		var err error
+		snapshots, err = p.orchestrator.ListSnapshotsForVolume(volumeID)
+		if err != nil {
+			if core.IsNotFoundError(err) {
+				return &csi.ListSnapshotsResponse{}, nil
+			}
+			return nil, p.getCSIErrorForOrchestratorError(err)
+		}
+
+	} else {
+
+		var err error
+		snapshots, err = p.orchestrator.ListSnapshots()
+		if err != nil {
+			return nil, p.getCSIErrorForOrchestratorError(err)
+		}
+	}
+
+	// Sort the snapshots so that pagination yields consistent results
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID() < snapshots[j].ID() })
+
+	// The starting token is the index of the first entry to return
+	start := 0
+	if token := req.GetStartingToken(); token != "" {
+		var err error
+		start, err = strconv.Atoi(token)
+		if err != nil || start < 0 || start > len(snapshots) {
+			return nil, status.Error(codes.Aborted, fmt.Sprintf("invalid starting token %s", token))
+		}
+	}
+
+	end := len(snapshots)
+	nextToken := ""
+	if maxEntries := int(req.GetMaxEntries()); maxEntries > 0 && start+maxEntries < end {
+		end = start + maxEntries
+		nextToken = strconv.Itoa(end)
+	}
+
+	entries := make([]*csi.ListSnapshotsResponse_Entry, 0, end-start)
+
+	for _, snapshot := range snapshots[start:end] {
+		if csiSnapshot, err := p.getCSISnapshotFromTridentSnapshot(snapshot); err == nil {
+			entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: csiSnapshot})
+		}
+	}
+
+	return &csi.ListSnapshotsResponse{Entries: entries, NextToken: nextToken}, nil
 }
 
 func (p *Plugin) getCSIVolumeFromTridentVolume(volume *storage.VolumeExternal) (*csi.Volume, error) {
@@ -401,6 +747,31 @@ func (p *Plugin) getCSIVolumeFromTridentVolume(volume *storage.VolumeExternal) (
 	}, nil
 }
 
+func (p *Plugin) getCSISnapshotFromTridentSnapshot(snapshot *storage.SnapshotExternal) (*csi.Snapshot, error) {
+
+	createdTime, err := time.Parse(time.RFC3339, snapshot.Created)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"snapshot": snapshot.Config.Name,
+			"volume":   snapshot.Config.VolumeName,
+			"created":  snapshot.Created,
+		}).Warn("Could not parse snapshot creation time.")
+		createdTime = time.Now()
+	}
+	creationTime, err := ptypes.TimestampProto(createdTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csi.Snapshot{
+		SizeBytes:      snapshot.SizeBytes,
+		SnapshotId:     snapshot.ID(),
+		SourceVolumeId: snapshot.Config.VolumeName,
+		CreationTime:   creationTime,
+		ReadyToUse:     snapshot.State.IsOnline(),
+	}, nil
+}
+
 func (p *Plugin) getAccessForCSIAccessMode(accessMode csi.VolumeCapability_AccessMode_Mode) tridentconfig.AccessMode {
 	switch accessMode {
 	case csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER:
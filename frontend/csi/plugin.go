@@ -56,6 +56,10 @@ func NewControllerPlugin(nodeName, endpoint string, orchestrator core.Orchestrat
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+		csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+		csi.ControllerServiceCapability_RPC_GET_CAPACITY,
 	})
 
 	// Define volume capabilities
@@ -129,6 +133,10 @@ func NewAllInOnePlugin(nodeName, endpoint, caCert, clientCert, clientKey string,
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+		csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+		csi.ControllerServiceCapability_RPC_GET_CAPACITY,
 	})
 
 	p.addNodeServiceCapabilities([]csi.NodeServiceCapability_RPC_Type{
@@ -241,6 +249,8 @@ func (p *Plugin) getCSIErrorForOrchestratorError(err error) error {
 		return status.Error(codes.FailedPrecondition, err.Error())
 	} else if core.IsNotFoundError(err) {
 		return status.Error(codes.NotFound, err.Error())
+	} else if core.IsConflictError(err) {
+		return status.Error(codes.FailedPrecondition, err.Error())
 	} else {
 		return status.Error(codes.Unknown, err.Error())
 	}
@@ -2,9 +2,25 @@
 
 package frontend
 
+import (
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+)
+
 type Plugin interface {
 	Activate() error
 	Deactivate() error
 	GetName() string
 	Version() string
 }
+
+// VolumeCopier is implemented by frontends that can copy the data of one volume to another
+// by running a job on one of the nodes they manage.  The orchestrator uses it to migrate
+// volumes between backends that cannot replicate volumes natively.
+type VolumeCopier interface {
+	// CanCopyVolume reports whether the frontend can copy volumes of the given protocol.
+	CanCopyVolume(protocol config.Protocol) bool
+	// CopyVolumeData copies every file on the source volume to the target volume, returning
+	// once the copy is complete.  Neither volume may be in use while it is copied.
+	CopyVolumeData(source, target *storage.VolumeExternal) error
+}
@@ -12,6 +12,11 @@ const (
 	KubernetesSyncPeriod       = 60 * time.Second
 	KubernetesResizeSyncPeriod = 3 * time.Minute
 
+	// Jobs that copy volumes between backends need only a shell and cp
+	volumeCopyImage        = "alpine:3.9"
+	volumeCopyTimeout      = 24 * time.Hour
+	volumeCopyPollInterval = 10 * time.Second
+
 	// Kubernetes-defined storage class parameters
 	K8sFsType = "fsType"
 
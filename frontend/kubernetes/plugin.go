@@ -13,11 +13,14 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/core/v1"
 	k8sstoragev1 "k8s.io/api/storage/v1"
 	k8sstoragev1beta "k8s.io/api/storage/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,6 +48,7 @@ import (
 type KubernetesPlugin interface {
 	frontend.Plugin
 	ImportVolume(request *storage.ImportVolumeRequest) (*storage.VolumeExternal, error)
+	UpdateVolumeStorageClass(volumeName, storageClassName string) (*storage.VolumeExternal, error)
 }
 
 // StorageClassSummary captures relevant fields in the storage class that are needed during PV creation or PV resize.
@@ -670,6 +674,9 @@ func (p *Plugin) ImportVolume(request *storage.ImportVolumeRequest) (*storage.Vo
 	if err != nil {
 		return nil, fmt.Errorf("volume import failed to get size of volume: %v", err)
 	}
+	if claim.Spec.Resources.Requests == nil {
+		claim.Spec.Resources.Requests = v1.ResourceList{}
+	}
 	claim.Spec.Resources.Requests[v1.ResourceStorage] = resource.MustParse(volExternal.Config.Size)
 	log.WithFields(log.Fields{
 		"size":      volExternal.Config.Size,
@@ -692,7 +699,9 @@ func (p *Plugin) ImportVolume(request *storage.ImportVolumeRequest) (*storage.Vo
 	uniqueName := getUniqueClaimName(pvc)
 	storageClass := GetPersistentVolumeClaimClass(pvc)
 	annotations := p.processStorageClassAnnotations(pvc, storageClass)
-	volConfig := getVolumeConfig(accessModes, uniqueName, claim.Spec.Resources.Requests[v1.ResourceStorage], annotations)
+	volConfig := getVolumeConfig(accessModes, uniqueName, claim.Spec.Resources.Requests[v1.ResourceStorage],
+		annotations, pvc.Labels)
+	volConfig.Namespace = pvc.Namespace
 
 	// This func is passed to core when ImportVolume is called. The func is created with the locally scoped
 	// context but runs in orchestrator_core inside a volume transaction. This allows needed cleanup to be
@@ -1014,7 +1023,8 @@ func (p *Plugin) createVolumeAndPV(
 
 	size, _ := claim.Spec.Resources.Requests[v1.ResourceStorage]
 	accessModes := claim.Spec.AccessModes
-	volConfig := getVolumeConfig(accessModes, uniqueName, size, annotations)
+	volConfig := getVolumeConfig(accessModes, uniqueName, size, annotations, claim.Labels)
+	volConfig.Namespace = claim.Namespace
 	volExternal, err = p.createVolumeFromConfig(volConfig, storageClass, claim.Namespace, claim.Name)
 	if err != nil {
 		return nil, err
@@ -1445,7 +1455,32 @@ func (p *Plugin) processAddedClass(class *k8sstoragev1.StorageClass) {
 			}
 			scConfig.Pools = pools
 
+		case storageattribute.PlacementPolicy:
+			// format:  placementPolicy: "leastAllocated"
+			scConfig.PlacementPolicy = v
+
+		case storageattribute.PlacementLabel:
+			// format:  placementLabel: "weight"
+			scConfig.PlacementLabel = v
+
+		case storageattribute.MinVolumeSize:
+			// format:  minVolumeSize: "1Gi"
+			scConfig.MinVolumeSize = v
+
+		case storageattribute.MaxVolumeSize:
+			// format:  maxVolumeSize: "10Ti"
+			scConfig.MaxVolumeSize = v
+
 		default:
+			if strings.HasPrefix(k, storageattribute.VolumeDefaultPrefix) {
+				// format:  defaults.setting: "value"
+				if scConfig.VolumeDefaults == nil {
+					scConfig.VolumeDefaults = make(map[string]string)
+				}
+				scConfig.VolumeDefaults[strings.TrimPrefix(k, storageattribute.VolumeDefaultPrefix)] = v
+				continue
+			}
+
 			// format:  attribute: "value"
 			req, err := storageattribute.CreateAttributeRequestFromAttributeValue(k, v)
 			if err != nil {
@@ -1745,6 +1780,150 @@ func (p *Plugin) GetPVCForPV(pv *v1.PersistentVolume) (*v1.PersistentVolumeClaim
 		pv.Spec.ClaimRef.Namespace).Get(pv.Spec.ClaimRef.Name, metav1.GetOptions{})
 }
 
+// UpdateVolumeStorageClass reassigns a volume to another storage class and updates the
+// storage class of the volume's PV to match.  The PVC keeps its storage class, since
+// Kubernetes doesn't allow the storage class of a PVC to change.
+func (p *Plugin) UpdateVolumeStorageClass(volumeName, storageClassName string) (*storage.VolumeExternal, error) {
+
+	volume, err := p.orchestrator.UpdateVolumeStorageClass(volumeName, storageClassName)
+	if err != nil {
+		return nil, err
+	}
+
+	pv, err := p.kubeClient.CoreV1().PersistentVolumes().Get(volumeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// Not every volume has a PV
+		return volume, nil
+	} else if err != nil {
+		return volume, fmt.Errorf("volume %s was assigned to storage class %s, but its PV could not be read: %v",
+			volumeName, storageClassName, err)
+	}
+	if pv.Spec.StorageClassName == storageClassName {
+		return volume, nil
+	}
+
+	pvClone := pv.DeepCopy()
+	pvClone.Spec.StorageClassName = storageClassName
+	if _, err = PatchPV(p.kubeClient, pv, pvClone); err != nil {
+		return volume, fmt.Errorf("volume %s was assigned to storage class %s, but its PV could not be updated: %v",
+			volumeName, storageClassName, err)
+	}
+
+	log.WithFields(log.Fields{
+		"PV":              pv.Name,
+		"PV_old_class":    pv.Spec.StorageClassName,
+		"PV_storageClass": storageClassName,
+	}).Info("Kubernetes frontend updated the storage class of the PV.")
+
+	return volume, nil
+}
+
+// CanCopyVolume reports whether the plugin can copy the data of volumes with the specified
+// protocol.  Only NFS volumes can be copied, since a pod can mount them without a PV.
+func (p *Plugin) CanCopyVolume(protocol config.Protocol) bool {
+	return protocol == config.File
+}
+
+// CopyVolumeData copies the contents of one NFS volume to another using a job in Trident's
+// namespace, and waits for the job to finish.  The job is deleted whatever its outcome.
+func (p *Plugin) CopyVolumeData(source, target *storage.VolumeExternal) error {
+
+	if source.Config.AccessInfo.NfsServerIP == "" || target.Config.AccessInfo.NfsServerIP == "" {
+		return fmt.Errorf("only NFS volumes can be copied")
+	}
+
+	backoffLimit := int32(0)
+	activeDeadlineSeconds := int64(volumeCopyTimeout.Seconds())
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "trident-copy-",
+			Namespace:    p.tridentNamespace,
+			Labels:       map[string]string{"app": "copy.trident.netapp.io"},
+			Annotations:  map[string]string{AnnPrefix + "/volume": source.Config.Name},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					Containers: []v1.Container{
+						{
+							Name:    "copy",
+							Image:   volumeCopyImage,
+							Command: []string{"cp", "-a", "/source/.", "/target/"},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "source", MountPath: "/source", ReadOnly: true},
+								{Name: "target", MountPath: "/target"},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{Name: "source", VolumeSource: v1.VolumeSource{NFS: CreateNFSVolumeSource(source)}},
+						{Name: "target", VolumeSource: v1.VolumeSource{NFS: CreateNFSVolumeSource(target)}},
+					},
+				},
+			},
+		},
+	}
+
+	job, err := p.kubeClient.BatchV1().Jobs(p.tridentNamespace).Create(job)
+	if err != nil {
+		return fmt.Errorf("could not create volume copy job: %v", err)
+	}
+	jobName := job.Name
+	defer p.deleteVolumeCopyJob(jobName)
+
+	log.WithFields(log.Fields{
+		"job":    jobName,
+		"source": source.Config.Name,
+		"target": target.Config.InternalName,
+	}).Info("Copying volume data.")
+
+	for {
+		time.Sleep(volumeCopyPollInterval)
+
+		job, err = p.kubeClient.BatchV1().Jobs(p.tridentNamespace).Get(jobName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("volume copy job %s was deleted before it finished", jobName)
+		} else if err != nil {
+			log.WithFields(log.Fields{
+				"job":   jobName,
+				"error": err,
+			}).Warn("Could not read volume copy job; will retry.")
+			continue
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != v1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobComplete:
+				log.WithField("job", jobName).Info("Copied volume data.")
+				return nil
+			case batchv1.JobFailed:
+				return fmt.Errorf("volume copy job %s failed: %s", jobName, condition.Message)
+			}
+		}
+	}
+}
+
+// deleteVolumeCopyJob deletes a volume copy job along with its pod.
+func (p *Plugin) deleteVolumeCopyJob(jobName string) {
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	deleteOptions := &metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}
+
+	err := p.kubeClient.BatchV1().Jobs(p.tridentNamespace).Delete(jobName, deleteOptions)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.WithFields(log.Fields{
+			"job":   jobName,
+			"error": err,
+		}).Warn("Could not delete volume copy job.")
+	}
+}
+
 // resizeVolumeAndPV resizes the volume on the storage backend and updates the PV size.
 func (p *Plugin) resizeVolumeAndPV(pv *v1.PersistentVolume, newSize resource.Quantity) (*v1.PersistentVolume, error) {
 
@@ -174,7 +174,7 @@ func testVolumeConfig(
 	ret := getVolumeConfig(accessModes,
 		getUniqueClaimName(testClaim(name, pvcUID, size, accessModes,
 			v1.ClaimPending, annotations, kubeVersion)),
-		resource.MustParse(size), annotations)
+		resource.MustParse(size), annotations, nil)
 	ret.InternalName = core.GetFakeInternalName(ret.Name)
 	ret.AccessInfo.NfsServerIP = testNFSServer
 	ret.AccessInfo.NfsPath = fmt.Sprintf("/%s",
@@ -64,12 +64,13 @@ func getAnnotation(annotations map[string]string, key string) string {
 }
 
 // getVolumeConfig generates a NetApp DVP volume config from the specs pulled
-// from the PVC.
+// from the PVC.  The PVC's labels become the volume's labels.
 func getVolumeConfig(
 	accessModes []v1.PersistentVolumeAccessMode,
 	name string,
 	size resource.Quantity,
 	annotations map[string]string,
+	labels map[string]string,
 ) *storage.VolumeConfig {
 	var accessMode config.AccessMode
 
@@ -100,6 +101,7 @@ func getVolumeConfig(
 		CloneSourceVolume: getAnnotation(annotations, AnnCloneFromPVC),
 		SplitOnClone:      getAnnotation(annotations, AnnSplitOnClone),
 		AccessMode:        accessMode,
+		Labels:            labels,
 	}
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/core"
 	"github.com/netapp/trident/frontend/kubernetes"
+	"github.com/netapp/trident/persistent_store"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/storage_class"
 	"github.com/netapp/trident/utils"
@@ -486,7 +487,15 @@ func ListVolumes(w http.ResponseWriter, r *http.Request) {
 	response := &ListVolumesResponse{}
 	ListGeneric(w, r, response,
 		func() int {
-			volumes, err := orchestrator.ListVolumes()
+			var (
+				volumes []*storage.VolumeExternal
+				err     error
+			)
+			if selector := r.URL.Query().Get("selector"); selector != "" {
+				volumes, err = orchestrator.ListVolumesBySelector(selector)
+			} else {
+				volumes, err = orchestrator.ListVolumes()
+			}
 			volumeNames := make([]string, 0, len(volumes))
 			if err != nil {
 				response.Error = err.Error()
@@ -525,6 +534,284 @@ func DeleteVolume(w http.ResponseWriter, r *http.Request) {
 	DeleteGeneric(w, r, orchestrator.DeleteVolume, "volume")
 }
 
+type ListUnmanagedVolumesResponse struct {
+	Volumes []string `json:"volumes"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// ListUnmanagedVolumes lists the volumes on a backend that could be imported, optionally
+// limited to those whose names match the "pattern" query parameter.
+func ListUnmanagedVolumes(w http.ResponseWriter, r *http.Request) {
+	response := &ListUnmanagedVolumesResponse{}
+	GetGeneric(w, r, "backend", response,
+		func(backendName string) int {
+			volumes, err := orchestrator.ListUnmanagedVolumes(backendName, r.URL.Query().Get("pattern"))
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Volumes = volumes
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type ListVolumePublicationsResponse struct {
+	VolumePublications []*storage.VolumePublication `json:"volumePublications"`
+	Error              string                       `json:"error,omitempty"`
+}
+
+func ListVolumePublications(w http.ResponseWriter, r *http.Request) {
+	response := &ListVolumePublicationsResponse{}
+	GetGeneric(w, r, "volume", response,
+		func(volName string) int {
+			publications, err := orchestrator.ListVolumePublicationsForVolume(volName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.VolumePublications = publications
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type RestoreSnapshotResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+func RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	vars := mux.Vars(r)
+	volumeName := vars["volume"]
+	snapshotName := vars["snapshot"]
+
+	response := &RestoreSnapshotResponse{}
+	err := orchestrator.RestoreSnapshot(volumeName, snapshotName)
+	if err != nil {
+		response.Error = err.Error()
+		log.WithFields(log.Fields{
+			"handler":  "RestoreSnapshot",
+			"volume":   volumeName,
+			"snapshot": snapshotName,
+		}).Error(response.Error)
+	} else {
+		log.WithFields(log.Fields{
+			"handler":  "RestoreSnapshot",
+			"volume":   volumeName,
+			"snapshot": snapshotName,
+		}).Info("Restored a snapshot.")
+	}
+	writeHTTPResponse(w, response, httpStatusCodeForGetUpdateList(err))
+}
+
+// UpdateVolumeStorageClassRequest names the storage class to assign a volume to.
+type UpdateVolumeStorageClassRequest struct {
+	StorageClass string `json:"storageClass"`
+}
+
+type UpdateVolumeStorageClassResponse struct {
+	Volume *storage.VolumeExternal `json:"volume,omitempty"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+func (u *UpdateVolumeStorageClassResponse) setError(err error) {
+	u.Error = err.Error()
+}
+
+func (u *UpdateVolumeStorageClassResponse) isError() bool {
+	return u.Error != ""
+}
+
+func (u *UpdateVolumeStorageClassResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler":      "UpdateVolumeStorageClass",
+		"volume":       u.Volume.Config.Name,
+		"storageClass": u.Volume.Config.StorageClass,
+		"pool":         u.Volume.Pool,
+	}).Info("Updated the storage class of a volume.")
+}
+
+func (u *UpdateVolumeStorageClassResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "UpdateVolumeStorageClass",
+	}).Error(u.Error)
+}
+
+// UpdateVolumeStorageClass assigns a volume to another storage class.  The Kubernetes
+// frontend is used if it is running, so that the volume's PV is updated as well.  The
+// CSI frontend can't update PVs, so the request is refused when it is running instead.
+func UpdateVolumeStorageClass(w http.ResponseWriter, r *http.Request) {
+	response := &UpdateVolumeStorageClassResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			request := new(UpdateVolumeStorageClassRequest)
+			err := json.Unmarshal(body, request)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			if request.StorageClass == "" {
+				err = fmt.Errorf("the following fields are mandatory: storageClass")
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			if k8sFrontend, err := orchestrator.GetFrontend(string(config.ContextKubernetes)); err == nil {
+				if k8s, ok := k8sFrontend.(kubernetes.KubernetesPlugin); ok {
+					response.Volume, err = k8s.UpdateVolumeStorageClass(volumeName, request.StorageClass)
+					if err != nil {
+						response.setError(err)
+					}
+					return httpStatusCodeForGetUpdateList(err)
+				}
+			}
+			if _, err = orchestrator.GetFrontend(string(config.ContextCSI)); err == nil {
+				err = fmt.Errorf("the storage class of a CSI volume can't be changed, since its PV " +
+					"would keep the old storage class")
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.Volume, err = orchestrator.UpdateVolumeStorageClass(volumeName, request.StorageClass)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+// MigrateVolumeRequest names the backend to move a volume to.
+type MigrateVolumeRequest struct {
+	Backend string `json:"backend"`
+}
+
+type VolumeMigrationResponse struct {
+	Migration *storage.VolumeMigration `json:"migration,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+}
+
+func (v *VolumeMigrationResponse) setError(err error) {
+	v.Error = err.Error()
+}
+
+func (v *VolumeMigrationResponse) isError() bool {
+	return v.Error != ""
+}
+
+func (v *VolumeMigrationResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "MigrateVolume",
+		"volume":  v.Migration.VolumeName,
+		"backend": v.Migration.TargetBackend,
+		"native":  v.Migration.Native,
+	}).Info("Started migrating a volume.")
+}
+
+func (v *VolumeMigrationResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "MigrateVolume",
+	}).Error(v.Error)
+}
+
+// MigrateVolume starts moving a volume to another backend.  The migration continues in
+// the background, and its progress is available from GetVolumeMigration.
+func MigrateVolume(w http.ResponseWriter, r *http.Request) {
+	response := &VolumeMigrationResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			request := new(MigrateVolumeRequest)
+			err := json.Unmarshal(body, request)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			if request.Backend == "" {
+				err = fmt.Errorf("the following fields are mandatory: backend")
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.Migration, err = orchestrator.MigrateVolume(volumeName, request.Backend)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func GetVolumeMigration(w http.ResponseWriter, r *http.Request) {
+	response := &VolumeMigrationResponse{}
+	GetGeneric(w, r, "volume", response,
+		func(volumeName string) int {
+			migration, err := orchestrator.GetVolumeMigration(volumeName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Migration = migration
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+// UnmanageVolumeRequest optionally names the backend volume once Trident
+// no longer manages it.
+type UnmanageVolumeRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+type UnmanageVolumeResponse struct {
+	Volume string `json:"volume"`
+	Name   string `json:"name,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (u *UnmanageVolumeResponse) setError(err error) {
+	u.Error = err.Error()
+}
+
+func (u *UnmanageVolumeResponse) isError() bool {
+	return u.Error != ""
+}
+
+func (u *UnmanageVolumeResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "UnmanageVolume",
+		"volume":  u.Volume,
+		"name":    u.Name,
+	}).Info("Unmanaged a volume.")
+}
+
+func (u *UnmanageVolumeResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "UnmanageVolume",
+		"volume":  u.Volume,
+	}).Error(u.Error)
+}
+
+func UnmanageVolume(w http.ResponseWriter, r *http.Request) {
+	response := &UnmanageVolumeResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			request := new(UnmanageVolumeRequest)
+			if len(body) > 0 {
+				if err := json.Unmarshal(body, request); err != nil {
+					response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+					return httpStatusCodeForGetUpdateList(err)
+				}
+			}
+			response.Volume = volumeName
+			response.Name = request.Name
+			err := orchestrator.UnmanageVolume(volumeName, request.Name)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
 type ImportVolumeResponse struct {
 	Volume *storage.VolumeExternal `json:"volume"`
 	Error  string                  `json:"error,omitempty"`
@@ -589,6 +876,58 @@ func ImportVolume(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+type ExplainVolumeResponse struct {
+	Explanation *storageclass.VolumeExplanation `json:"explanation"`
+	Error       string                          `json:"error,omitempty"`
+}
+
+func (e *ExplainVolumeResponse) setError(err error) {
+	e.Error = err.Error()
+}
+
+func (e *ExplainVolumeResponse) isError() bool {
+	return e.Error != ""
+}
+
+func (e *ExplainVolumeResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler":       "ExplainVolume",
+		"volume":        e.Explanation.Volume,
+		"provisionable": e.Explanation.Provisionable,
+	}).Info("Explained a volume request.")
+}
+func (e *ExplainVolumeResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "ExplainVolume",
+	}).Error(e.Error)
+}
+
+// ExplainVolume reports where a volume could be provisioned without creating anything,
+// so it returns 200 rather than 201 on success.
+func ExplainVolume(w http.ResponseWriter, r *http.Request) {
+	response := &ExplainVolumeResponse{}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			volumeConfig := new(storage.VolumeConfig)
+			err := json.Unmarshal(body, volumeConfig)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			if err = volumeConfig.Validate(); err != nil {
+				response.setError(err)
+				return httpStatusCodeForAdd(err)
+			}
+			explanation, err := orchestrator.ExplainVolume(volumeConfig)
+			if err != nil {
+				response.setError(err)
+			}
+			response.Explanation = explanation
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
 type AddStorageClassResponse struct {
 	StorageClassID string `json:"storageClass"`
 	Error          string `json:"error,omitempty"`
@@ -793,3 +1132,453 @@ func ListNodes(w http.ResponseWriter, r *http.Request) {
 func DeleteNode(w http.ResponseWriter, r *http.Request) {
 	DeleteGeneric(w, r, orchestrator.DeleteNode, "node")
 }
+
+type QuotaResponse struct {
+	Name  string                 `json:"name"`
+	Quota *storage.QuotaExternal `json:"quota,omitempty"`
+	Error string                 `json:"error,omitempty"`
+}
+
+func (q *QuotaResponse) setError(err error) {
+	q.Error = err.Error()
+}
+
+func (q *QuotaResponse) isError() bool {
+	return q.Error != ""
+}
+
+func (q *QuotaResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "Quota",
+		"quota":   q.Name,
+	}).Info("Saved a quota.")
+}
+
+func (q *QuotaResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "Quota",
+		"quota":   q.Name,
+	}).Error(q.Error)
+}
+
+func AddQuota(w http.ResponseWriter, r *http.Request) {
+	response := &QuotaResponse{}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			quota := new(storage.Quota)
+			err := json.Unmarshal(body, quota)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			response.Name = quota.Name
+			response.Quota, err = orchestrator.AddQuota(quota)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForAdd(err)
+		},
+	)
+}
+
+func UpdateQuota(w http.ResponseWriter, r *http.Request) {
+	response := &QuotaResponse{}
+	UpdateGeneric(w, r, "quota", response,
+		func(quotaName string, body []byte) int {
+			quota := new(storage.Quota)
+			err := json.Unmarshal(body, quota)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.Name = quotaName
+			if quota.Name != quotaName {
+				err = fmt.Errorf("quota name %s doesn't match the URL", quota.Name)
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.Quota, err = orchestrator.UpdateQuota(quota)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type ListQuotasResponse struct {
+	Quotas []string `json:"quotas"`
+	Error  string   `json:"error,omitempty"`
+}
+
+func (l *ListQuotasResponse) setList(payload []string) {
+	l.Quotas = payload
+}
+
+func ListQuotas(w http.ResponseWriter, r *http.Request) {
+	response := &ListQuotasResponse{}
+	ListGeneric(w, r, response,
+		func() int {
+			quotas, err := orchestrator.ListQuotas()
+			quotaNames := make([]string, 0, len(quotas))
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				for _, quota := range quotas {
+					quotaNames = append(quotaNames, quota.Name)
+				}
+			}
+			response.setList(quotaNames)
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type GetQuotaResponse struct {
+	Quota *storage.QuotaExternal `json:"quota"`
+	Error string                 `json:"error,omitempty"`
+}
+
+func GetQuota(w http.ResponseWriter, r *http.Request) {
+	response := &GetQuotaResponse{}
+	GetGeneric(w, r, "quota", response,
+		func(quotaName string) int {
+			quota, err := orchestrator.GetQuota(quotaName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Quota = quota
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func DeleteQuota(w http.ResponseWriter, r *http.Request) {
+	DeleteGeneric(w, r, orchestrator.DeleteQuota, "quota")
+}
+
+type GroupSnapshotResponse struct {
+	Name          string                         `json:"name"`
+	GroupSnapshot *storage.GroupSnapshotExternal `json:"groupSnapshot,omitempty"`
+	Error         string                         `json:"error,omitempty"`
+}
+
+func (g *GroupSnapshotResponse) setError(err error) {
+	g.Error = err.Error()
+}
+
+func (g *GroupSnapshotResponse) isError() bool {
+	return g.Error != ""
+}
+
+func (g *GroupSnapshotResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler":       "GroupSnapshot",
+		"groupSnapshot": g.Name,
+	}).Info("Created a group snapshot.")
+}
+
+func (g *GroupSnapshotResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler":       "GroupSnapshot",
+		"groupSnapshot": g.Name,
+	}).Error(g.Error)
+}
+
+func CreateGroupSnapshot(w http.ResponseWriter, r *http.Request) {
+	response := &GroupSnapshotResponse{}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			groupConfig := new(storage.GroupSnapshotConfig)
+			err := json.Unmarshal(body, groupConfig)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			response.Name = groupConfig.Name
+			response.GroupSnapshot, err = orchestrator.CreateGroupSnapshot(groupConfig)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForAdd(err)
+		},
+	)
+}
+
+type ListGroupSnapshotsResponse struct {
+	GroupSnapshots []string `json:"groupSnapshots"`
+	Error          string   `json:"error,omitempty"`
+}
+
+func (l *ListGroupSnapshotsResponse) setList(payload []string) {
+	l.GroupSnapshots = payload
+}
+
+func ListGroupSnapshots(w http.ResponseWriter, r *http.Request) {
+	response := &ListGroupSnapshotsResponse{}
+	ListGeneric(w, r, response,
+		func() int {
+			groupSnapshots, err := orchestrator.ListGroupSnapshots()
+			groupSnapshotNames := make([]string, 0, len(groupSnapshots))
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				for _, groupSnapshot := range groupSnapshots {
+					groupSnapshotNames = append(groupSnapshotNames, groupSnapshot.Config.Name)
+				}
+			}
+			response.setList(groupSnapshotNames)
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type GetGroupSnapshotResponse struct {
+	GroupSnapshot *storage.GroupSnapshotExternal `json:"groupSnapshot"`
+	Error         string                         `json:"error,omitempty"`
+}
+
+func GetGroupSnapshot(w http.ResponseWriter, r *http.Request) {
+	response := &GetGroupSnapshotResponse{}
+	GetGeneric(w, r, "groupSnapshot", response,
+		func(groupSnapshotName string) int {
+			groupSnapshot, err := orchestrator.GetGroupSnapshot(groupSnapshotName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.GroupSnapshot = groupSnapshot
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func DeleteGroupSnapshot(w http.ResponseWriter, r *http.Request) {
+	DeleteGeneric(w, r, orchestrator.DeleteGroupSnapshot, "groupSnapshot")
+}
+
+// CloneGroupSnapshotRequest names the clones of a group's volumes by prefixing the
+// names of their source volumes.
+type CloneGroupSnapshotRequest struct {
+	Prefix string `json:"prefix"`
+}
+
+type CloneGroupSnapshotResponse struct {
+	Name    string                    `json:"name"`
+	Volumes []*storage.VolumeExternal `json:"volumes,omitempty"`
+	Error   string                    `json:"error,omitempty"`
+}
+
+func (c *CloneGroupSnapshotResponse) setError(err error) {
+	c.Error = err.Error()
+}
+
+func (c *CloneGroupSnapshotResponse) isError() bool {
+	return c.Error != ""
+}
+
+func (c *CloneGroupSnapshotResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler":       "CloneGroupSnapshot",
+		"groupSnapshot": c.Name,
+		"volumes":       len(c.Volumes),
+	}).Info("Cloned a group snapshot.")
+}
+
+func (c *CloneGroupSnapshotResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler":       "CloneGroupSnapshot",
+		"groupSnapshot": c.Name,
+	}).Error(c.Error)
+}
+
+func CloneGroupSnapshot(w http.ResponseWriter, r *http.Request) {
+	response := &CloneGroupSnapshotResponse{}
+	UpdateGeneric(w, r, "groupSnapshot", response,
+		func(groupSnapshotName string, body []byte) int {
+			request := new(CloneGroupSnapshotRequest)
+			err := json.Unmarshal(body, request)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			response.Name = groupSnapshotName
+			response.Volumes, err = orchestrator.CloneGroupSnapshot(groupSnapshotName, request.Prefix)
+			if err != nil {
+				response.setError(err)
+			}
+			return httpStatusCodeForAdd(err)
+		},
+	)
+}
+
+type ListEventsResponse struct {
+	Events []*storage.Event `json:"events"`
+	Error  string           `json:"error,omitempty"`
+}
+
+func ListEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	response := &ListEventsResponse{}
+	events, err := orchestrator.ListEvents()
+	if err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Events = events
+	}
+	writeHTTPResponse(w, response, httpStatusCodeForGetUpdateList(err))
+}
+
+// BackupRequest optionally carries a 16, 24 or 32-byte key, base64-encoded, with which
+// to encrypt the backend credentials in the backup.  Without one they are redacted.
+type BackupRequest struct {
+	EncryptionKey []byte `json:"encryptionKey,omitempty"`
+}
+
+type BackupResponse struct {
+	Backup *persistentstore.Backup `json:"backup"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+func (r *BackupResponse) setError(err error) {
+	r.Error = err.Error()
+}
+
+func (r *BackupResponse) isError() bool {
+	return r.Error != ""
+}
+
+func (r *BackupResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "Backup",
+		"secrets": r.Backup.Secrets,
+	}).Info("Backed up the orchestrator state.")
+}
+
+func (r *BackupResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "Backup",
+	}).Error(r.Error)
+}
+
+func Backup(w http.ResponseWriter, r *http.Request) {
+	response := &BackupResponse{}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			request := &BackupRequest{}
+			if len(body) > 0 {
+				if err := json.Unmarshal(body, request); err != nil {
+					response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+					return httpStatusCodeForAdd(err)
+				}
+			}
+			backup, err := orchestrator.BackupState(request.EncryptionKey)
+			if err != nil {
+				response.setError(err)
+			}
+			response.Backup = backup
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type RestoreResponse struct {
+	Backends int    `json:"backends"`
+	Volumes  int    `json:"volumes"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (r *RestoreResponse) setError(err error) {
+	r.Error = err.Error()
+}
+
+func (r *RestoreResponse) isError() bool {
+	return r.Error != ""
+}
+
+func (r *RestoreResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler":  "Restore",
+		"backends": r.Backends,
+		"volumes":  r.Volumes,
+	}).Info("Restored the orchestrator state.")
+}
+
+func (r *RestoreResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "Restore",
+	}).Error(r.Error)
+}
+
+// Restore is like AddGeneric, but it accepts a much larger request body, as a
+// backup archive holds every object in the persistent store.
+func Restore(w http.ResponseWriter, r *http.Request) {
+	var httpStatusCode int
+	response := &RestoreResponse{}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	defer func() {
+		if response.isError() {
+			response.logFailure()
+		} else {
+			response.logSuccess()
+		}
+		writeHTTPResponse(w, response, httpStatusCode)
+	}()
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, config.MaxRESTRestoreRequestSize))
+	if err != nil {
+		response.setError(err)
+		httpStatusCode = httpStatusCodeForAdd(err)
+		return
+	}
+	if err := r.Body.Close(); err != nil {
+		response.setError(err)
+		httpStatusCode = httpStatusCodeForAdd(err)
+		return
+	}
+
+	backup := new(persistentstore.Backup)
+	if err = json.Unmarshal(body, backup); err != nil {
+		response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+		httpStatusCode = httpStatusCodeForAdd(err)
+		return
+	}
+	if err = orchestrator.RestoreState(backup); err != nil {
+		response.setError(err)
+	} else {
+		response.Backends = len(backup.Backends)
+		response.Volumes = len(backup.Volumes)
+	}
+	httpStatusCode = httpStatusCodeForAdd(err)
+}
+
+type ReencryptBackendsResponse struct {
+	Backends []string `json:"backends"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// ReencryptBackends rewrites the backend credentials with the active encryption key.
+// It takes no request body.
+func ReencryptBackends(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+
+	response := &ReencryptBackendsResponse{}
+	backendNames, err := orchestrator.ReencryptBackends()
+	if err != nil {
+		response.Error = err.Error()
+		log.WithFields(log.Fields{
+			"handler": "ReencryptBackends",
+		}).Error(response.Error)
+	} else {
+		log.WithFields(log.Fields{
+			"handler":  "ReencryptBackends",
+			"backends": len(backendNames),
+		}).Info("Re-encrypted the backend credentials.")
+	}
+	response.Backends = backendNames
+	writeHTTPResponse(w, response, httpStatusCodeForGetUpdateList(err))
+}
@@ -49,6 +49,12 @@ var routes = Routes{
 		config.BackendURL + "/{backend}" + "/state",
 		UpdateBackendState,
 	},
+	Route{
+		"ListUnmanagedVolumes",
+		"GET",
+		config.BackendURL + "/{backend}" + "/unmanagedvolume",
+		ListUnmanagedVolumes,
+	},
 	Route{
 		"GetBackend",
 		"GET",
@@ -97,6 +103,48 @@ var routes = Routes{
 		config.VolumeURL + "/import",
 		ImportVolume,
 	},
+	Route{
+		"ExplainVolume",
+		"POST",
+		config.VolumeURL + "/explain",
+		ExplainVolume,
+	},
+	Route{
+		"RestoreSnapshot",
+		"POST",
+		config.VolumeURL + "/{volume}/snapshot/{snapshot}/restore",
+		RestoreSnapshot,
+	},
+	Route{
+		"UpdateVolumeStorageClass",
+		"POST",
+		config.VolumeURL + "/{volume}/storageclass",
+		UpdateVolumeStorageClass,
+	},
+	Route{
+		"MigrateVolume",
+		"POST",
+		config.VolumeURL + "/{volume}/migration",
+		MigrateVolume,
+	},
+	Route{
+		"GetVolumeMigration",
+		"GET",
+		config.VolumeURL + "/{volume}/migration",
+		GetVolumeMigration,
+	},
+	Route{
+		"UnmanageVolume",
+		"POST",
+		config.VolumeURL + "/{volume}/unmanage",
+		UnmanageVolume,
+	},
+	Route{
+		"ListVolumePublications",
+		"GET",
+		config.VolumeURL + "/{volume}/publication",
+		ListVolumePublications,
+	},
 	Route{
 		"AddStorageClass",
 		"POST",
@@ -145,4 +193,88 @@ var routes = Routes{
 		config.NodeURL + "/{node}",
 		DeleteNode,
 	},
+	Route{
+		"AddQuota",
+		"POST",
+		config.QuotaURL,
+		AddQuota,
+	},
+	Route{
+		"UpdateQuota",
+		"PUT",
+		config.QuotaURL + "/{quota}",
+		UpdateQuota,
+	},
+	Route{
+		"GetQuota",
+		"GET",
+		config.QuotaURL + "/{quota}",
+		GetQuota,
+	},
+	Route{
+		"ListQuotas",
+		"GET",
+		config.QuotaURL,
+		ListQuotas,
+	},
+	Route{
+		"DeleteQuota",
+		"DELETE",
+		config.QuotaURL + "/{quota}",
+		DeleteQuota,
+	},
+	Route{
+		"CreateGroupSnapshot",
+		"POST",
+		config.GroupSnapshotURL,
+		CreateGroupSnapshot,
+	},
+	Route{
+		"GetGroupSnapshot",
+		"GET",
+		config.GroupSnapshotURL + "/{groupSnapshot}",
+		GetGroupSnapshot,
+	},
+	Route{
+		"ListGroupSnapshots",
+		"GET",
+		config.GroupSnapshotURL,
+		ListGroupSnapshots,
+	},
+	Route{
+		"DeleteGroupSnapshot",
+		"DELETE",
+		config.GroupSnapshotURL + "/{groupSnapshot}",
+		DeleteGroupSnapshot,
+	},
+	Route{
+		"CloneGroupSnapshot",
+		"POST",
+		config.GroupSnapshotURL + "/{groupSnapshot}/clone",
+		CloneGroupSnapshot,
+	},
+	Route{
+		"ListEvents",
+		"GET",
+		config.EventURL,
+		ListEvents,
+	},
+	Route{
+		"Backup",
+		"POST",
+		config.BackupURL,
+		Backup,
+	},
+	Route{
+		"Restore",
+		"POST",
+		config.RestoreURL,
+		Restore,
+	},
+	Route{
+		"ReencryptBackends",
+		"POST",
+		config.ReencryptURL,
+		ReencryptBackends,
+	},
 }
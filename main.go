@@ -5,6 +5,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"runtime"
@@ -12,6 +13,8 @@ import (
 	"syscall"
 
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/core"
@@ -20,6 +23,7 @@ import (
 	"github.com/netapp/trident/frontend/docker"
 	"github.com/netapp/trident/frontend/kubernetes"
 	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/k8s_client"
 	"github.com/netapp/trident/logging"
 	"github.com/netapp/trident/persistent_store"
 )
@@ -64,6 +68,33 @@ var (
 		"any metadata.  WILL LOSE TRACK OF VOLUMES ON REBOOT/CRASH.")
 	usePassthrough = flag.Bool("passthrough", false, "Uses the storage backends "+
 		"as the source of truth.  No data is stored anywhere else.")
+	storePath = flag.String("store_path", "", "Path of a local file in which to "+
+		"persist orchestrator state (e.g., -store_path=/var/lib/trident/trident.db)")
+	useCRD = flag.Bool("crd_persistence", false, "Persists orchestrator state as "+
+		"Kubernetes custom resources.  If -etcd_v3 is also specified, existing state "+
+		"is migrated from that etcd server.")
+	crdNamespace = flag.String("crd_namespace", "", "Namespace of Trident's custom "+
+		"resources (defaults to Trident's namespace)")
+
+	// Encryption at rest
+	encryptionKeyFile = flag.String("encryption_key_file", "", "Path of a keyring file with "+
+		"which to encrypt backend credentials in the persistent store")
+	encryptionKeySecret = flag.String("encryption_key_secret", "", "Name of a Kubernetes secret "+
+		"in Trident's namespace holding a keyring with which to encrypt backend credentials "+
+		"in the persistent store")
+
+	// High availability
+	leaderElection = flag.Bool("leader_election", false, "Elect a leader among the Trident "+
+		"replicas sharing the -etcd_v3 store; the others serve reads as standbys until elected")
+	leaderElectionID = flag.String("leader_election_id", "", "Identity of this replica in the "+
+		"leader election (defaults to the host name, which is the pod name in Kubernetes)")
+
+	// Events
+	persistEvents = flag.Bool("persist_events", false, "Save the event log to the persistent store")
+
+	// Reconciliation
+	volumeReconcileInterval = flag.Duration("volume_reconcile_interval", config.DefaultVolumeReconcileInterval,
+		"How often to verify that volumes exist on their backends (0 to disable)")
 
 	// HTTP REST interface
 	address    = flag.String("address", "127.0.0.1", "Storage orchestrator HTTP API address")
@@ -81,6 +112,7 @@ var (
 	httpsClientCert = flag.String("https_client_cert", rest.ClientCertPath, "HTTPS client certificate")
 
 	storeClient      persistentstore.Client
+	leaderElector    persistentstore.LeaderElector
 	enableKubernetes bool
 	enableDocker     bool
 	enableCSI        bool
@@ -100,6 +132,67 @@ func shouldEnableTLS() bool {
 	return true
 }
 
+func newEtcdClientV3() *persistentstore.EtcdClientV3 {
+	var (
+		etcdClient *persistentstore.EtcdClientV3
+		err        error
+	)
+	if shouldEnableTLS() {
+		log.Debug("Trident is configured with an etcdv3 client with TLS.")
+		etcdClient, err = persistentstore.NewEtcdClientV3WithTLS(*etcdV3,
+			*etcdV3Cert, *etcdV3CACert, *etcdV3Key)
+	} else {
+		log.Debug("Trident is configured with an etcdv3 client without TLS.")
+		if !strings.Contains(*etcdV3, "127.0.0.1") {
+			log.Warn("Trident's etcdv3 client should be configured with TLS!")
+		}
+		etcdClient, err = persistentstore.NewEtcdClientV3(*etcdV3)
+	}
+	if err != nil {
+		log.Fatalf("Unable to create the etcd V3 client. %v", err)
+	}
+	return etcdClient
+}
+
+// newKeyringLoader returns a function that reads the encryption keyring from the file or
+// Kubernetes secret specified on the command line each time it is called.
+func newKeyringLoader() func() (*persistentstore.Keyring, error) {
+	if *encryptionKeyFile != "" {
+		return func() (*persistentstore.Keyring, error) {
+			data, err := ioutil.ReadFile(*encryptionKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			return persistentstore.ParseKeyring(data)
+		}
+	}
+
+	kubeConfig, err := clientcmd.BuildConfigFromFlags(*k8sAPIServer, *k8sConfigPath)
+	if err != nil {
+		log.Fatalf("Unable to build the Kubernetes client configuration. %v", err)
+	}
+	namespace, err := ioutil.ReadFile(config.TridentNamespaceFile)
+	if err != nil {
+		log.Fatalf("Unable to determine Trident's namespace. %v", err)
+	}
+	kubeClient, err := k8sclient.NewKubeClient(kubeConfig, strings.TrimSpace(string(namespace)))
+	if err != nil {
+		log.Fatalf("Unable to create the Kubernetes client. %v", err)
+	}
+	return func() (*persistentstore.Keyring, error) {
+		secret, err := kubeClient.GetSecret(*encryptionKeySecret, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data, ok := secret.Data[persistentstore.KeyringSecretKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no %s item", *encryptionKeySecret,
+				persistentstore.KeyringSecretKey)
+		}
+		return persistentstore.ParseKeyring(data)
+	}
+}
+
 func printFlag(f *flag.Flag) {
 	log.WithFields(log.Fields{
 		"name":  f.Name,
@@ -140,7 +233,13 @@ func processCmdLineArgs() {
 	if *etcdV2 != "" {
 		storeCount++
 	}
-	if *etcdV3 != "" {
+	if *etcdV3 != "" && !*useCRD {
+		storeCount++
+	}
+	if *useCRD {
+		storeCount++
+	}
+	if *storePath != "" {
 		storeCount++
 	}
 	if *useInMemory {
@@ -162,27 +261,44 @@ func processCmdLineArgs() {
 	// Don't bother validating the Kubernetes API server address; we'll know if
 	// it's invalid during start-up.  Given that users can specify DNS names,
 	// validation would be more trouble than it's worth.
-	if *etcdV3 != "" {
-		if shouldEnableTLS() {
-			log.Debug("Trident is configured with an etcdv3 client with TLS.")
-			storeClient, err = persistentstore.NewEtcdClientV3WithTLS(*etcdV3,
-				*etcdV3Cert, *etcdV3CACert, *etcdV3Key)
-		} else {
-			log.Debug("Trident is configured with an etcdv3 client without TLS.")
-			if !strings.Contains(*etcdV3, "127.0.0.1") {
-				log.Warn("Trident's etcdv3 client should be configured with TLS!")
-			}
-			storeClient, err = persistentstore.NewEtcdClientV3(*etcdV3)
-		}
+	if *useCRD {
+		log.Debug("Trident is configured with a CRD store client.")
+		crdClient, err := persistentstore.NewCRDClient(*k8sAPIServer, *k8sConfigPath, *crdNamespace)
 		if err != nil {
-			log.Fatalf("Unable to create the etcd V3 client. %v", err)
+			log.Fatalf("Unable to create the CRD store client. %v", err)
+		}
+		if *etcdV3 != "" {
+			// The etcd server is only used as the source of a one-time data migration
+			etcdClient := newEtcdClientV3()
+			crdClient.SetMigrationSource(etcdClient.GetConfig())
+			etcdClient.Stop()
 		}
+		storeClient = crdClient
+	} else if *etcdV3 != "" {
+		etcdClient := newEtcdClientV3()
+		if *leaderElection {
+			identity := *leaderElectionID
+			if identity == "" {
+				if identity, err = os.Hostname(); err != nil {
+					log.Fatalf("Unable to determine the leader election identity. %v", err)
+				}
+			}
+			log.WithField("identity", identity).Debug("Trident is configured with leader election.")
+			leaderElector = etcdClient.NewLeaderElector(identity, config.LeaderElectionTTL)
+		}
+		storeClient = etcdClient
 	} else if *etcdV2 != "" {
 		log.Debug("Trident is configured with an etcdv2 client.")
 		storeClient, err = persistentstore.NewEtcdClientV2(*etcdV2)
 		if err != nil {
 			log.Fatalf("Unable to create the etcd V2 client. %v", err)
 		}
+	} else if *storePath != "" {
+		log.Debug("Trident is configured with a file store client.")
+		storeClient, err = persistentstore.NewFileClient(*storePath)
+		if err != nil {
+			log.Fatalf("Unable to create the file store client. %v", err)
+		}
 	} else if *useInMemory {
 		log.Debug("Trident is configured with an in-memory store client.")
 		storeClient = persistentstore.NewInMemoryClient()
@@ -194,6 +310,21 @@ func processCmdLineArgs() {
 		}
 	}
 
+	if *leaderElection && leaderElector == nil {
+		log.Fatal("Leader election requires an etcd v3 store (-etcd_v3).")
+	}
+
+	if *encryptionKeyFile != "" || *encryptionKeySecret != "" {
+		if *encryptionKeyFile != "" && *encryptionKeySecret != "" {
+			log.Fatal("Specify either an encryption key file or an encryption key secret, not both.")
+		}
+		storeClient, err = persistentstore.NewEncryptingClient(storeClient, newKeyringLoader())
+		if err != nil {
+			log.Fatalf("Unable to encrypt backend credentials. %v", err)
+		}
+		log.Debug("Trident is configured to encrypt backend credentials.")
+	}
+
 	config.UsingPassthroughStore = storeClient.GetType() == persistentstore.PassthroughStore
 }
 
@@ -226,13 +357,18 @@ func main() {
 	processCmdLineArgs()
 
 	orchestrator := core.NewTridentOrchestrator(storeClient)
+	orchestrator.SetVolumeReconcileInterval(*volumeReconcileInterval)
+	orchestrator.SetEventPersistence(*persistEvents)
+	if leaderElector != nil {
+		orchestrator.SetLeaderElector(leaderElector)
+	}
 
 	// Create HTTP REST frontend
 	if *enableREST {
 		if *port == "" {
 			log.Warning("HTTP REST interface will not be available (port not specified).")
 		} else {
-			httpServer := rest.NewHTTPServer(orchestrator, *address, *port)
+			httpServer := rest.NewHTTPServer(core.NewAuditingOrchestrator(orchestrator, "rest"), *address, *port)
 			frontends = append(frontends, httpServer)
 			log.WithFields(log.Fields{"name": httpServer.GetName()}).Info("Added frontend.")
 		}
@@ -243,8 +379,8 @@ func main() {
 		if *httpsPort == "" {
 			log.Warning("HTTPS REST interface will not be available (httpsPort not specified).")
 		} else {
-			httpsServer, err := rest.NewHTTPSServer(
-				orchestrator, *httpsAddress, *httpsPort, *httpsCACert, *httpsServerCert, *httpsServerKey)
+			httpsServer, err := rest.NewHTTPSServer(core.NewAuditingOrchestrator(orchestrator, "https"),
+				*httpsAddress, *httpsPort, *httpsCACert, *httpsServerCert, *httpsServerKey)
 			if err != nil {
 				log.Fatalf("Unable to start the HTTPS REST frontend. %v", err)
 			}
@@ -258,11 +394,12 @@ func main() {
 
 		var kubernetesFrontend frontend.Plugin
 		config.CurrentDriverContext = config.ContextKubernetes
+		kubernetesOrchestrator := core.NewAuditingOrchestrator(orchestrator, string(config.ContextKubernetes))
 
 		if *k8sAPIServer != "" {
-			kubernetesFrontend, err = kubernetes.NewPlugin(orchestrator, *k8sAPIServer, *k8sConfigPath)
+			kubernetesFrontend, err = kubernetes.NewPlugin(kubernetesOrchestrator, *k8sAPIServer, *k8sConfigPath)
 		} else {
-			kubernetesFrontend, err = kubernetes.NewPluginInCluster(orchestrator)
+			kubernetesFrontend, err = kubernetes.NewPluginInCluster(kubernetesOrchestrator)
 		}
 		if err != nil {
 			log.Fatalf("Unable to start the Kubernetes frontend. %v", err)
@@ -281,7 +418,8 @@ func main() {
 			os.Exit(1)
 		}
 
-		dockerFrontend, err := docker.NewPlugin(*driverName, *driverPort, orchestrator)
+		dockerFrontend, err := docker.NewPlugin(*driverName, *driverPort,
+			core.NewAuditingOrchestrator(orchestrator, string(config.ContextDocker)))
 		if err != nil {
 			log.Fatalf("Unable to start the Docker frontend. %v", err)
 		}
@@ -308,15 +446,16 @@ func main() {
 		}).Info("Initializing CSI frontend.")
 
 		var csiFrontend *csi.Plugin
+		csiOrchestrator := core.NewAuditingOrchestrator(orchestrator, string(config.ContextCSI))
 		switch *csiRole {
 		case csi.CSIController:
-			csiFrontend, err = csi.NewControllerPlugin(*csiNodeName, *csiEndpoint, orchestrator)
+			csiFrontend, err = csi.NewControllerPlugin(*csiNodeName, *csiEndpoint, csiOrchestrator)
 		case csi.CSINode:
 			csiFrontend, err = csi.NewNodePlugin(*csiNodeName, *csiEndpoint, *httpsCACert, *httpsClientCert,
-				*httpsClientKey, orchestrator)
+				*httpsClientKey, csiOrchestrator)
 		case csi.CSIAllInOne:
 			csiFrontend, err = csi.NewAllInOnePlugin(*csiNodeName, *csiEndpoint, *httpsCACert, *httpsClientCert,
-				*httpsClientKey, orchestrator)
+				*httpsClientKey, csiOrchestrator)
 		}
 		if err != nil {
 			log.Fatalf("Unable to start the CSI frontend. %v", err)
@@ -341,5 +480,12 @@ func main() {
 	for _, f := range frontends {
 		f.Deactivate()
 	}
+	orchestrator.Stop()
+	if leaderElector != nil {
+		// Let a standby take over at once, rather than once the lease expires
+		if err = leaderElector.Resign(); err != nil {
+			log.WithField("error", err).Warning("Could not resign the leadership.")
+		}
+	}
 	storeClient.Stop()
 }
@@ -0,0 +1,230 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+// BackupFormatVersion is the version of the backup archive layout.  It changes
+// only when an archive written by one Trident release can't be read by another.
+const BackupFormatVersion = "1"
+
+// How the backend credentials in a backup archive are stored
+const (
+	BackupSecretsPlaintext = "plaintext"
+	BackupSecretsRedacted  = "redacted"
+	BackupSecretsEncrypted = "encrypted"
+)
+
+// Backup is an archive of the orchestrator state held in the persistent store.
+type Backup struct {
+	FormatVersion  string                        `json:"formatVersion"`
+	TridentVersion string                        `json:"tridentVersion"`
+	Created        string                        `json:"created"` // The UTC time that the backup was taken, in RFC3339 format
+	StateVersion   *PersistentStateVersion       `json:"stateVersion"`
+	Secrets        string                        `json:"secrets"`
+	Backends       []*storage.BackendPersistent  `json:"backends"`
+	StorageClasses []*storageclass.Persistent    `json:"storageClasses"`
+	Volumes        []*storage.VolumeExternal     `json:"volumes"`
+	Snapshots      []*storage.SnapshotPersistent `json:"snapshots"`
+	GroupSnapshots []*storage.GroupSnapshot      `json:"groupSnapshots"`
+	Nodes          []*utils.Node                 `json:"nodes"`
+	Publications   []*storage.VolumePublication  `json:"publications"`
+	Quotas         []*storage.Quota              `json:"quotas"`
+	Transactions   []*VolumeTransaction          `json:"transactions"`
+}
+
+// copyBackends replaces the backends with copies, so that changing their credentials
+// doesn't alter a store that keeps its objects in memory.
+func (b *Backup) copyBackends() error {
+	for i, backend := range b.Backends {
+		copied := &storage.BackendPersistent{}
+		if err := copyJSON(backend, copied); err != nil {
+			return fmt.Errorf("could not copy backend %s; %v", backend.Name, err)
+		}
+		b.Backends[i] = copied
+	}
+	return nil
+}
+
+// RedactSecrets blanks the backend credentials, so that the archive may be kept
+// where they shouldn't be.  They must be filled in again before restoring it.
+func (b *Backup) RedactSecrets() error {
+	if b.Secrets != BackupSecretsPlaintext {
+		return fmt.Errorf("backup secrets are %s, not %s", b.Secrets, BackupSecretsPlaintext)
+	}
+	if err := b.copyBackends(); err != nil {
+		return err
+	}
+	for _, backend := range b.Backends {
+		for _, secret := range backend.Config.Secrets() {
+			*secret = ""
+		}
+	}
+	b.Secrets = BackupSecretsRedacted
+	return nil
+}
+
+// EncryptSecrets encrypts each backend credential with AES-GCM using a 16, 24 or
+// 32-byte key.
+func (b *Backup) EncryptSecrets(key []byte) error {
+	if b.Secrets != BackupSecretsPlaintext {
+		return fmt.Errorf("backup secrets are %s, not %s", b.Secrets, BackupSecretsPlaintext)
+	}
+	if err := b.copyBackends(); err != nil {
+		return err
+	}
+	for _, backend := range b.Backends {
+		for _, secret := range backend.Config.Secrets() {
+			if *secret == "" {
+				continue
+			}
+			ciphertext, err := utils.EncryptAESGCM(key, []byte(*secret))
+			if err != nil {
+				return fmt.Errorf("could not encrypt the credentials of backend %s; %v", backend.Name, err)
+			}
+			*secret = base64.StdEncoding.EncodeToString(ciphertext)
+		}
+	}
+	b.Secrets = BackupSecretsEncrypted
+	return nil
+}
+
+// DecryptSecrets reverses EncryptSecrets.
+func (b *Backup) DecryptSecrets(key []byte) error {
+	if b.Secrets != BackupSecretsEncrypted {
+		return fmt.Errorf("backup secrets are %s, not %s", b.Secrets, BackupSecretsEncrypted)
+	}
+	for _, backend := range b.Backends {
+		for _, secret := range backend.Config.Secrets() {
+			if *secret == "" {
+				continue
+			}
+			ciphertext, err := base64.StdEncoding.DecodeString(*secret)
+			if err != nil {
+				return fmt.Errorf("could not decode the credentials of backend %s; %v", backend.Name, err)
+			}
+			plaintext, err := utils.DecryptAESGCM(key, ciphertext)
+			if err != nil {
+				return fmt.Errorf("could not decrypt the credentials of backend %s, "+
+					"check the encryption key; %v", backend.Name, err)
+			}
+			*secret = string(plaintext)
+		}
+	}
+	b.Secrets = BackupSecretsPlaintext
+	return nil
+}
+
+// Validate checks that the archive can be restored by this version of Trident
+// and that the objects in it are complete and refer to one another correctly.
+func (b *Backup) Validate() error {
+	if b.FormatVersion != BackupFormatVersion {
+		return fmt.Errorf("unsupported backup format version %s; expected %s",
+			b.FormatVersion, BackupFormatVersion)
+	}
+	if b.StateVersion == nil {
+		return fmt.Errorf("backup has no persistent state version")
+	}
+	if b.StateVersion.OrchestratorAPIVersion != config.OrchestratorAPIVersion {
+		return fmt.Errorf("backup has orchestrator API version %s; expected %s",
+			b.StateVersion.OrchestratorAPIVersion, config.OrchestratorAPIVersion)
+	}
+	switch StoreType(b.StateVersion.PersistentStoreVersion) {
+	case MemoryStore, EtcdV2Store, EtcdV3Store, CRDStore, FileStore:
+	default:
+		return fmt.Errorf("backup has unknown persistent store version %s",
+			b.StateVersion.PersistentStoreVersion)
+	}
+	switch b.Secrets {
+	case BackupSecretsPlaintext:
+	case BackupSecretsEncrypted:
+		return fmt.Errorf("backup secrets are encrypted and must be decrypted before restoring")
+	default:
+		return fmt.Errorf("backup secrets are %s; the backend credentials must be restored "+
+			"and secrets set to %s before restoring", b.Secrets, BackupSecretsPlaintext)
+	}
+
+	backends := make(map[string]bool)
+	for _, backend := range b.Backends {
+		if backend == nil || backend.Name == "" {
+			return fmt.Errorf("backup has a backend without a name")
+		}
+		if backends[backend.Name] {
+			return fmt.Errorf("backup has more than one backend named %s", backend.Name)
+		}
+		if _, err := backend.MarshalConfig(); err != nil {
+			return fmt.Errorf("backup has an invalid config for backend %s; %v", backend.Name, err)
+		}
+		backends[backend.Name] = true
+	}
+
+	storageClasses := make(map[string]bool)
+	for _, sc := range b.StorageClasses {
+		if sc == nil || sc.Config == nil || sc.GetName() == "" {
+			return fmt.Errorf("backup has a storage class without a name")
+		}
+		if storageClasses[sc.GetName()] {
+			return fmt.Errorf("backup has more than one storage class named %s", sc.GetName())
+		}
+		storageClasses[sc.GetName()] = true
+	}
+
+	volumes := make(map[string]bool)
+	for _, volume := range b.Volumes {
+		if volume == nil || volume.Config == nil || volume.Config.Name == "" {
+			return fmt.Errorf("backup has a volume without a name")
+		}
+		if volumes[volume.Config.Name] {
+			return fmt.Errorf("backup has more than one volume named %s", volume.Config.Name)
+		}
+		if !backends[volume.Backend] {
+			return fmt.Errorf("backup has volume %s on backend %s, which isn't in the backup",
+				volume.Config.Name, volume.Backend)
+		}
+		volumes[volume.Config.Name] = true
+	}
+
+	for _, snapshot := range b.Snapshots {
+		if snapshot == nil || snapshot.Config == nil || snapshot.Config.Name == "" {
+			return fmt.Errorf("backup has a snapshot without a name")
+		}
+		if !volumes[snapshot.Config.VolumeName] {
+			return fmt.Errorf("backup has snapshot %s of volume %s, which isn't in the backup",
+				snapshot.Config.Name, snapshot.Config.VolumeName)
+		}
+	}
+	for _, groupSnapshot := range b.GroupSnapshots {
+		if groupSnapshot == nil || groupSnapshot.Config == nil || groupSnapshot.Config.Name == "" {
+			return fmt.Errorf("backup has a group snapshot without a name")
+		}
+	}
+	for _, node := range b.Nodes {
+		if node == nil || node.Name == "" {
+			return fmt.Errorf("backup has a node without a name")
+		}
+	}
+	for _, publication := range b.Publications {
+		if publication == nil || publication.VolumeName == "" || publication.NodeName == "" {
+			return fmt.Errorf("backup has a volume publication without a volume or node")
+		}
+	}
+	for _, quota := range b.Quotas {
+		if quota == nil || quota.Name == "" {
+			return fmt.Errorf("backup has a quota without a name")
+		}
+	}
+	for _, txn := range b.Transactions {
+		if txn == nil || txn.Op == "" || (txn.Config == nil && txn.SnapshotConfig == nil) {
+			return fmt.Errorf("backup has an incomplete volume transaction")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+func newTestBackup() *Backup {
+	return &Backup{
+		FormatVersion:  BackupFormatVersion,
+		TridentVersion: config.OrchestratorVersion.String(),
+		StateVersion: &PersistentStateVersion{
+			PersistentStoreVersion: string(EtcdV3Store),
+			OrchestratorAPIVersion: config.OrchestratorAPIVersion,
+		},
+		Secrets: BackupSecretsPlaintext,
+		Backends: []*storage.BackendPersistent{
+			{
+				Version: config.OrchestratorAPIVersion,
+				Name:    "ontapnas_10.0.0.100",
+				Config: storage.PersistentStorageBackendConfig{
+					OntapConfig: &drivers.OntapStorageDriverConfig{
+						CommonStorageDriverConfig: &drivers.CommonStorageDriverConfig{
+							StorageDriverName: drivers.OntapNASStorageDriverName,
+						},
+						ManagementLIF: "10.0.0.4",
+						DataLIF:       "10.0.0.100",
+						SVM:           "svm1",
+						Username:      "admin",
+						Password:      "netapp",
+					},
+				},
+			},
+		},
+		Volumes: []*storage.VolumeExternal{
+			{
+				Config: &storage.VolumeConfig{
+					Version: config.OrchestratorAPIVersion,
+					Name:    "vol1",
+					Size:    "1GB",
+				},
+				Backend: "ontapnas_10.0.0.100",
+			},
+		},
+		Snapshots: []*storage.SnapshotPersistent{
+			{
+				Config: &storage.SnapshotConfig{
+					Name:       "snap1",
+					VolumeName: "vol1",
+				},
+			},
+		},
+	}
+}
+
+func TestBackupValidate(t *testing.T) {
+	if err := newTestBackup().Validate(); err != nil {
+		t.Fatalf("Expected a valid backup; %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Backup)
+		errMsg string
+	}{
+		{"format", func(b *Backup) { b.FormatVersion = "0" }, "format version"},
+		{"noStateVersion", func(b *Backup) { b.StateVersion = nil }, "persistent state version"},
+		{"apiVersion", func(b *Backup) { b.StateVersion.OrchestratorAPIVersion = "0" }, "API version"},
+		{"storeVersion", func(b *Backup) { b.StateVersion.PersistentStoreVersion = "bogus" }, "store version"},
+		{"redacted", func(b *Backup) { b.Secrets = BackupSecretsRedacted }, "credentials"},
+		{"encrypted", func(b *Backup) { b.Secrets = BackupSecretsEncrypted }, "decrypted"},
+		{"duplicateBackend", func(b *Backup) { b.Backends = append(b.Backends, b.Backends[0]) }, "more than one backend"},
+		{"noBackendConfig", func(b *Backup) { b.Backends[0].Config.OntapConfig = nil }, "invalid config"},
+		{"missingBackend", func(b *Backup) { b.Volumes[0].Backend = "other" }, "isn't in the backup"},
+		{"duplicateVolume", func(b *Backup) { b.Volumes = append(b.Volumes, b.Volumes[0]) }, "more than one volume"},
+		{"missingVolume", func(b *Backup) { b.Snapshots[0].Config.VolumeName = "vol2" }, "isn't in the backup"},
+	}
+	for _, test := range tests {
+		backup := newTestBackup()
+		test.modify(backup)
+		if err := backup.Validate(); err == nil || !strings.Contains(err.Error(), test.errMsg) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.errMsg, err)
+		}
+	}
+}
+
+func TestBackupRedactSecrets(t *testing.T) {
+	backup := newTestBackup()
+	original := backup.Backends[0]
+	if err := backup.RedactSecrets(); err != nil {
+		t.Fatal(err.Error())
+	}
+	ontapConfig := backup.Backends[0].Config.OntapConfig
+	if ontapConfig.Username != "" || ontapConfig.Password != "" {
+		t.Error("Expected the backend credentials to be redacted.")
+	}
+	if original.Config.OntapConfig.Password != "netapp" {
+		t.Error("Expected the backend the backup was made from to keep its credentials.")
+	}
+	if ontapConfig.ManagementLIF != "10.0.0.4" {
+		t.Error("Expected the rest of the backend config to be kept.")
+	}
+	if backup.Secrets != BackupSecretsRedacted {
+		t.Errorf("Expected secrets %s, got %s", BackupSecretsRedacted, backup.Secrets)
+	}
+	if err := backup.RedactSecrets(); err == nil {
+		t.Error("Expected an error redacting an already redacted backup.")
+	}
+}
+
+func TestBackupEncryptSecrets(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	backup := newTestBackup()
+	if err := backup.EncryptSecrets(key); err != nil {
+		t.Fatal(err.Error())
+	}
+	ontapConfig := backup.Backends[0].Config.OntapConfig
+	if ontapConfig.Username == "admin" || ontapConfig.Password == "netapp" {
+		t.Error("Expected the backend credentials to be encrypted.")
+	}
+	if backup.Secrets != BackupSecretsEncrypted {
+		t.Errorf("Expected secrets %s, got %s", BackupSecretsEncrypted, backup.Secrets)
+	}
+
+	encryptedPassword := ontapConfig.Password
+	if err := backup.DecryptSecrets([]byte("fedcba9876543210fedcba9876543210")); err == nil {
+		t.Error("Expected an error decrypting with the wrong key.")
+	}
+	if ontapConfig.Password != encryptedPassword || backup.Secrets != BackupSecretsEncrypted {
+		t.Error("Expected a failed decryption to leave the password encrypted.")
+	}
+
+	if err := backup.DecryptSecrets(key); err != nil {
+		t.Fatal(err.Error())
+	}
+	if ontapConfig.Username != "admin" || ontapConfig.Password != "netapp" {
+		t.Errorf("Expected the original credentials, got %s/%s", ontapConfig.Username, ontapConfig.Password)
+	}
+	if err := backup.Validate(); err != nil {
+		t.Errorf("Expected a valid backup after decryption; %v", err)
+	}
+}
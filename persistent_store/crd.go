@@ -0,0 +1,1188 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	CRDGroup   = "trident.netapp.io"
+	CRDVersion = "v1"
+
+	// maxCRDNameLength is the longest name allowed for a custom resource (a DNS-1123 subdomain)
+	maxCRDNameLength = 253
+)
+
+// crdKind maps one family of persistent store keys onto a Trident custom resource type.
+type crdKind struct {
+	keyPrefix string
+	kind      string
+	resource  string
+}
+
+func (k *crdKind) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: CRDGroup, Version: CRDVersion, Resource: k.resource}
+}
+
+// crdKinds lists the Trident custom resource types, one per object type kept in the store.
+var crdKinds = []*crdKind{
+	{keyPrefix: config.StoreURL, kind: "TridentVersion", resource: "tridentversions"},
+	{keyPrefix: config.BackendURL, kind: "TridentBackend", resource: "tridentbackends"},
+	{keyPrefix: config.VolumeURL, kind: "TridentVolume", resource: "tridentvolumes"},
+	{keyPrefix: config.TransactionURL, kind: "TridentTransaction", resource: "tridenttransactions"},
+	{keyPrefix: config.StorageClassURL, kind: "TridentStorageClass", resource: "tridentstorageclasses"},
+	{keyPrefix: config.NodeURL, kind: "TridentNode", resource: "tridentnodes"},
+	{keyPrefix: config.SnapshotURL, kind: "TridentSnapshot", resource: "tridentsnapshots"},
+	{keyPrefix: config.PublicationURL, kind: "TridentVolumePublication", resource: "tridentvolumepublications"},
+	{keyPrefix: config.EventURL, kind: "TridentEvent", resource: "tridentevents"},
+	{keyPrefix: config.QuotaURL, kind: "TridentQuota", resource: "tridentquotas"},
+	{keyPrefix: config.GroupSnapshotURL, kind: "TridentGroupSnapshot", resource: "tridentgroupsnapshots"},
+}
+
+var invalidCRDNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// CRDClient keeps Trident's state as custom resources in Trident's namespace, so that
+// no separate etcd deployment is needed when Trident runs in Kubernetes.  Each store key
+// becomes one custom resource whose spec holds the key and its JSON value.
+type CRDClient struct {
+	client    dynamic.Interface
+	namespace string
+
+	// migrationSource is the etcd server, if any, from which existing state should be copied
+	migrationSource *ClientConfig
+
+	// resourceVersions remembers the last resource version read or written for each key,
+	// so that updates fail rather than overwrite a change made by another client.
+	resourceVersions map[string]string
+	mutex            sync.Mutex
+}
+
+// NewCRDClient returns a CRD store client for the Kubernetes cluster described by the
+// API server address and/or kubeconfig file.  If both are empty, the in-cluster
+// configuration is used.  If no namespace is given, Trident's own namespace is used.
+func NewCRDClient(apiServerIP, kubeConfigPath, namespace string) (*CRDClient, error) {
+	kubeConfig, err := clientcmd.BuildConfigFromFlags(apiServerIP, kubeConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not build the Kubernetes client configuration; %v", err)
+	}
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the Kubernetes client; %v", err)
+	}
+	if namespace == "" {
+		bytes, err := ioutil.ReadFile(config.TridentNamespaceFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine Trident's namespace; %v", err)
+		}
+		namespace = strings.TrimSpace(string(bytes))
+	}
+
+	p := newCRDClient(client, namespace)
+
+	// Making sure the custom resource definitions are installed
+	if _, err = p.Read(config.StoreURL); err != nil && !MatchKeyNotFoundErr(err) {
+		return nil, fmt.Errorf("could not read Trident custom resources; %v", err)
+	}
+
+	log.WithField("namespace", namespace).Debug("Created CRD store client.")
+
+	return p, nil
+}
+
+func newCRDClient(client dynamic.Interface, namespace string) *CRDClient {
+	return &CRDClient{
+		client:           client,
+		namespace:        namespace,
+		resourceVersions: make(map[string]string),
+	}
+}
+
+// SetMigrationSource configures the etcd server from which existing state is migrated
+// the first time Trident starts with this store.
+func (p *CRDClient) SetMigrationSource(etcdConfig *ClientConfig) {
+	p.migrationSource = etcdConfig
+}
+
+// kindForKey returns the custom resource type that holds a key.
+func kindForKey(key string) (*crdKind, error) {
+	for _, k := range crdKinds {
+		if key == k.keyPrefix || strings.HasPrefix(key, k.keyPrefix+"/") {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("key %s cannot be stored as a Trident custom resource", key)
+}
+
+// crdObjectName converts the part of a key that follows its type prefix into a valid
+// custom resource name.  Names that had to be altered get a hash suffix so that
+// distinct keys never share a name; the key itself is kept in the resource's spec.
+func crdObjectName(k *crdKind, key string) string {
+	original := strings.TrimPrefix(strings.TrimPrefix(key, k.keyPrefix), "/")
+	if original == "" {
+		return config.OrchestratorName
+	}
+
+	name := strings.Trim(invalidCRDNameChars.ReplaceAllString(strings.ToLower(original), "-"), ".-")
+	if name == original && len(name) <= maxCRDNameLength {
+		return name
+	}
+
+	hash := sha256.Sum256([]byte(original))
+	suffix := hex.EncodeToString(hash[:])[:10]
+	if len(name) > maxCRDNameLength-len(suffix)-1 {
+		name = strings.Trim(name[:maxCRDNameLength-len(suffix)-1], ".-")
+	}
+	if name == "" {
+		return suffix
+	}
+	return name + "-" + suffix
+}
+
+func (p *CRDClient) resourceClient(k *crdKind) dynamic.ResourceInterface {
+	return p.client.Resource(k.gvr()).Namespace(p.namespace)
+}
+
+func (p *CRDClient) newObject(k *crdKind, key, value string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(CRDGroup + "/" + CRDVersion)
+	obj.SetKind(k.kind)
+	obj.SetNamespace(p.namespace)
+	obj.SetName(crdObjectName(k, key))
+	obj.Object["spec"] = map[string]interface{}{
+		"key":  key,
+		"data": value,
+	}
+	return obj
+}
+
+// objectKeyAndValue returns the key and value held in a custom resource.
+func objectKeyAndValue(obj *unstructured.Unstructured) (string, string, error) {
+	key, found, err := unstructured.NestedString(obj.Object, "spec", "key")
+	if err != nil || !found {
+		return "", "", fmt.Errorf("custom resource %s has no key", obj.GetName())
+	}
+	value, found, err := unstructured.NestedString(obj.Object, "spec", "data")
+	if err != nil || !found {
+		return "", "", fmt.Errorf("custom resource %s has no data", obj.GetName())
+	}
+	return key, value, nil
+}
+
+func (p *CRDClient) rememberResourceVersion(key string, obj *unstructured.Unstructured) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if obj == nil || obj.GetResourceVersion() == "" {
+		delete(p.resourceVersions, key)
+	} else {
+		p.resourceVersions[key] = obj.GetResourceVersion()
+	}
+}
+
+func (p *CRDClient) forgetResourceVersion(key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.resourceVersions, key)
+}
+
+func (p *CRDClient) lastResourceVersion(key string) string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.resourceVersions[key]
+}
+
+// Create is the abstract CRUD interface
+func (p *CRDClient) Create(key, value string) error {
+	k, err := kindForKey(key)
+	if err != nil {
+		return err
+	}
+	obj, err := p.resourceClient(k).Create(p.newObject(k, key, value), metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return NewPersistentStoreError(KeyExistsErr, key)
+		}
+		return err
+	}
+	p.rememberResourceVersion(key, obj)
+	return nil
+}
+
+func (p *CRDClient) Read(key string) (string, error) {
+	k, err := kindForKey(key)
+	if err != nil {
+		return "", err
+	}
+	obj, err := p.resourceClient(k).Get(crdObjectName(k, key), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", NewPersistentStoreError(KeyNotFoundErr, key)
+		}
+		return "", err
+	}
+	_, value, err := objectKeyAndValue(obj)
+	if err != nil {
+		return "", err
+	}
+	p.rememberResourceVersion(key, obj)
+	return value, nil
+}
+
+// ReadKeys returns all the keys with the designated prefix
+func (p *CRDClient) ReadKeys(keyPrefix string) ([]string, error) {
+	keys := make([]string, 0)
+	for _, k := range crdKinds {
+		if !strings.HasPrefix(k.keyPrefix, keyPrefix) && !strings.HasPrefix(keyPrefix, k.keyPrefix+"/") {
+			continue
+		}
+		list, err := p.resourceClient(k).List(metav1.ListOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The custom resource definition isn't installed, so there are no such keys
+				continue
+			}
+			return keys, err
+		}
+		for i := range list.Items {
+			key, _, err := objectKeyAndValue(&list.Items[i])
+			if err != nil {
+				return keys, err
+			}
+			if strings.HasPrefix(key, keyPrefix) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return keys, NewPersistentStoreError(KeyNotFoundErr, keyPrefix)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Update replaces the value of an existing key.  The update is conditional on the
+// resource version this client last saw for the key, so a change made by another
+// client in the meantime causes a KeyConflictErr instead of being overwritten.
+func (p *CRDClient) Update(key, value string) error {
+	k, err := kindForKey(key)
+	if err != nil {
+		return err
+	}
+	resourceVersion := p.lastResourceVersion(key)
+	if resourceVersion == "" {
+		current, err := p.resourceClient(k).Get(crdObjectName(k, key), metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return NewPersistentStoreError(KeyNotFoundErr, key)
+			}
+			return err
+		}
+		resourceVersion = current.GetResourceVersion()
+	}
+
+	obj := p.newObject(k, key, value)
+	obj.SetResourceVersion(resourceVersion)
+	updated, err := p.resourceClient(k).Update(obj, metav1.UpdateOptions{})
+	if err != nil {
+		switch {
+		case apierrors.IsNotFound(err):
+			p.forgetResourceVersion(key)
+			return NewPersistentStoreError(KeyNotFoundErr, key)
+		case apierrors.IsConflict(err):
+			// Forget the stale version so that the key may be updated again once re-read
+			p.forgetResourceVersion(key)
+			return NewPersistentStoreError(KeyConflictErr, key)
+		}
+		return err
+	}
+	p.rememberResourceVersion(key, updated)
+	return nil
+}
+
+// Set creates or replaces the value of a key, retrying if the key changes underneath it.
+func (p *CRDClient) Set(key, value string) error {
+	k, err := kindForKey(key)
+	if err != nil {
+		return err
+	}
+	resourceClient := p.resourceClient(k)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj := p.newObject(k, key, value)
+		current, err := resourceClient.Get(obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			created, err := resourceClient.Create(obj, metav1.CreateOptions{})
+			if err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					// Created by someone else since the Get, so try again as an update
+					return apierrors.NewConflict(k.gvr().GroupResource(), obj.GetName(), err)
+				}
+				return err
+			}
+			p.rememberResourceVersion(key, created)
+			return nil
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+		updated, err := resourceClient.Update(obj, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		p.rememberResourceVersion(key, updated)
+		return nil
+	})
+}
+
+func (p *CRDClient) Delete(key string) error {
+	k, err := kindForKey(key)
+	if err != nil {
+		return err
+	}
+	err = p.resourceClient(k).Delete(crdObjectName(k, key), &metav1.DeleteOptions{})
+	p.forgetResourceVersion(key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return NewPersistentStoreError(KeyNotFoundErr, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteKeys deletes all the keys with the designated prefix
+func (p *CRDClient) DeleteKeys(keyPrefix string) error {
+	keys, err := p.ReadKeys(keyPrefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = p.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetType returns the persistent store type
+func (p *CRDClient) GetType() StoreType {
+	return CRDStore
+}
+
+// Stop shuts down the CRD client
+func (p *CRDClient) Stop() error {
+	return nil
+}
+
+// GetConfig returns the configuration of the etcd server that state is migrated from,
+// which is empty if there isn't one.
+func (p *CRDClient) GetConfig() *ClientConfig {
+	if p.migrationSource == nil {
+		return &ClientConfig{}
+	}
+	return p.migrationSource
+}
+
+// GetVersion returns the version of the persistent data
+func (p *CRDClient) GetVersion() (*PersistentStateVersion, error) {
+	versionJSON, err := p.Read(config.StoreURL)
+	if err != nil {
+		return nil, err
+	}
+	version := &PersistentStateVersion{}
+	err = json.Unmarshal([]byte(versionJSON), version)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// SetVersion sets the version of the persistent data
+func (p *CRDClient) SetVersion(version *PersistentStateVersion) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+	return p.Set(config.StoreURL, string(versionJSON))
+}
+
+// AddBackend saves the minimally required backend state to the persistent store
+func (p *CRDClient) AddBackend(b *storage.Backend) error {
+	backend := b.ConstructPersistent()
+	backendJSON, err := json.Marshal(backend)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.BackendURL+"/"+backend.Name, string(backendJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetBackend retrieves a backend from the persistent store
+func (p *CRDClient) GetBackend(backendName string) (*storage.BackendPersistent, error) {
+	var backend storage.BackendPersistent
+	backendJSON, err := p.Read(config.BackendURL + "/" + backendName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(backendJSON), &backend)
+	if err != nil {
+		return nil, err
+	}
+	return &backend, nil
+}
+
+// UpdateBackend updates the backend state on the persistent store
+func (p *CRDClient) UpdateBackend(b *storage.Backend) error {
+	backend := b.ConstructPersistent()
+	backendJSON, err := json.Marshal(backend)
+	if err != nil {
+		return err
+	}
+	err = p.Update(config.BackendURL+"/"+backend.Name, string(backendJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteBackend deletes the backend state on the persistent store
+func (p *CRDClient) DeleteBackend(backend *storage.Backend) error {
+	err := p.Delete(config.BackendURL + "/" + backend.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceBackendAndUpdateVolumes renames a backend and updates all volumes to
+// reflect the new backend name
+func (p *CRDClient) ReplaceBackendAndUpdateVolumes(
+	origBackend, newBackend *storage.Backend) error {
+	// Custom resources can't be updated together atomically, so the steps are
+	// ordered such that every volume refers to a backend that exists at each
+	// step, and a failed rename may simply be retried.
+
+	// First, save the new backend.
+	backendJSON, err := json.Marshal(newBackend.ConstructPersistent())
+	if err != nil {
+		return err
+	}
+	if err = p.Set(config.BackendURL+"/"+newBackend.Name, string(backendJSON)); err != nil {
+		return err
+	}
+
+	// Second, update the volumes mapped to the old backend to the new backend.
+	volExternalList, err := p.GetVolumes()
+	if err != nil {
+		return err
+	}
+	for _, volExternal := range volExternalList {
+		if volExternal.Backend == origBackend.Name {
+			vol := storage.NewVolume(volExternal.Config,
+				newBackend.Name, volExternal.Pool, volExternal.Orphaned)
+			if err = p.UpdateVolume(vol); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Third, delete the old backend.
+	if err = p.DeleteBackend(origBackend); err != nil && !MatchKeyNotFoundErr(err) {
+		return err
+	}
+	return nil
+}
+
+// GetBackends retrieves all backends
+func (p *CRDClient) GetBackends() ([]*storage.BackendPersistent, error) {
+	backendList := make([]*storage.BackendPersistent, 0)
+	keys, err := p.ReadKeys(config.BackendURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return backendList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		backend, err := p.GetBackend(strings.TrimPrefix(key, config.BackendURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		backendList = append(backendList, backend)
+	}
+	return backendList, nil
+}
+
+// DeleteBackends deletes all backends
+func (p *CRDClient) DeleteBackends() error {
+	backends, err := p.ReadKeys(config.BackendURL)
+	if err != nil {
+		return err
+	}
+	for _, backend := range backends {
+		if err = p.Delete(backend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolume saves a volume's state to the persistent store
+func (p *CRDClient) AddVolume(vol *storage.Volume) error {
+	volExternal := vol.ConstructExternal()
+	volJSON, err := json.Marshal(volExternal)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.VolumeURL+"/"+vol.Config.Name, string(volJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolume retrieves a volume's state from the persistent store
+func (p *CRDClient) GetVolume(volName string) (*storage.VolumeExternal, error) {
+	volJSON, err := p.Read(config.VolumeURL + "/" + volName)
+	if err != nil {
+		return nil, err
+	}
+	volExternal := &storage.VolumeExternal{}
+	err = json.Unmarshal([]byte(volJSON), volExternal)
+	if err != nil {
+		return nil, err
+	}
+	return volExternal, nil
+}
+
+// UpdateVolume updates a volume's state on the persistent store
+func (p *CRDClient) UpdateVolume(vol *storage.Volume) error {
+	volExternal := vol.ConstructExternal()
+	volJSON, err := json.Marshal(volExternal)
+	if err != nil {
+		return err
+	}
+	err = p.Update(config.VolumeURL+"/"+vol.Config.Name, string(volJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteVolume deletes a volume's state from the persistent store
+func (p *CRDClient) DeleteVolume(vol *storage.Volume) error {
+	err := p.Delete(config.VolumeURL + "/" + vol.Config.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *CRDClient) DeleteVolumeIgnoreNotFound(vol *storage.Volume) error {
+	err := p.DeleteVolume(vol)
+	if MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// GetVolumes retrieves all volumes
+func (p *CRDClient) GetVolumes() ([]*storage.VolumeExternal, error) {
+	volumeList := make([]*storage.VolumeExternal, 0)
+	keys, err := p.ReadKeys(config.VolumeURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return volumeList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		vol, err := p.GetVolume(strings.TrimPrefix(key, config.VolumeURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		volumeList = append(volumeList, vol)
+	}
+	return volumeList, nil
+}
+
+// DeleteVolumes deletes all volumes
+func (p *CRDClient) DeleteVolumes() error {
+	volumes, err := p.ReadKeys(config.VolumeURL)
+	if err != nil {
+		return err
+	}
+	for _, vol := range volumes {
+		if err = p.Delete(vol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolumeTransaction logs an AddVolume operation
+func (p *CRDClient) AddVolumeTransaction(volTxn *VolumeTransaction) error {
+	volTxnJSON, err := json.Marshal(volTxn)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.TransactionURL+"/"+volTxn.getKey(),
+		string(volTxnJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolumeTransactions retrieves AddVolume logs
+func (p *CRDClient) GetVolumeTransactions() ([]*VolumeTransaction, error) {
+	volTxnList := make([]*VolumeTransaction, 0)
+	keys, err := p.ReadKeys(config.TransactionURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return volTxnList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		volTxn := &VolumeTransaction{}
+		volTxnJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(volTxnJSON), volTxn)
+		if err != nil {
+			return nil, err
+		}
+		volTxnList = append(volTxnList, volTxn)
+	}
+	return volTxnList, nil
+}
+
+// GetExistingVolumeTransaction returns an existing version of the current
+// volume transaction, if it exists.  If no volume transaction with the same
+// key exists, it returns nil.
+func (p *CRDClient) GetExistingVolumeTransaction(
+	volTxn *VolumeTransaction,
+) (*VolumeTransaction, error) {
+	var ret VolumeTransaction
+
+	key := volTxn.getKey()
+	txnJSON, err := p.Read(config.TransactionURL + "/" + key)
+	if err != nil {
+		if !MatchKeyNotFoundErr(err) {
+			return nil, fmt.Errorf("unable to read volume transaction key %s from the CRD store: %v", key, err)
+		} else {
+			return nil, nil
+		}
+	}
+	if err = json.Unmarshal([]byte(txnJSON), &ret); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal volume transaction JSON for %s: %v", key, err)
+	}
+	return &ret, nil
+}
+
+// DeleteVolumeTransaction deletes an AddVolume log
+func (p *CRDClient) DeleteVolumeTransaction(volTxn *VolumeTransaction) error {
+	err := p.Delete(config.TransactionURL + "/" + volTxn.getKey())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *CRDClient) AddStorageClass(sc *storageclass.StorageClass) error {
+	sClass := sc.ConstructPersistent()
+	storageClassJSON, err := json.Marshal(sClass)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.StorageClassURL+"/"+sClass.GetName(),
+		string(storageClassJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *CRDClient) GetStorageClass(scName string) (*storageclass.Persistent, error) {
+	var sc storageclass.Persistent
+	scJSON, err := p.Read(config.StorageClassURL + "/" + scName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(scJSON), &sc)
+	if err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (p *CRDClient) GetStorageClasses() ([]*storageclass.Persistent, error) {
+	storageClassList := make([]*storageclass.Persistent, 0)
+	keys, err := p.ReadKeys(config.StorageClassURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return storageClassList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		sc, err := p.GetStorageClass(strings.TrimPrefix(key,
+			config.StorageClassURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		storageClassList = append(storageClassList, sc)
+	}
+	return storageClassList, nil
+}
+
+// DeleteStorageClass deletes a storage class's state from the persistent store
+func (p *CRDClient) DeleteStorageClass(sc *storageclass.StorageClass) error {
+	err := p.Delete(config.StorageClassURL + "/" + sc.GetName())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddOrUpdateNode adds a CSI node object to the persistent store
+func (p *CRDClient) AddOrUpdateNode(n *utils.Node) error {
+	nodeJSON, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.NodeURL+"/"+n.Name, string(nodeJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *CRDClient) GetNode(nName string) (*utils.Node, error) {
+	var node utils.Node
+	nodeJSON, err := p.Read(config.NodeURL + "/" + nName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(nodeJSON), &node)
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func (p *CRDClient) GetNodes() ([]*utils.Node, error) {
+	nodeList := make([]*utils.Node, 0)
+	keys, err := p.ReadKeys(config.NodeURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nodeList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		node, err := p.GetNode(strings.TrimPrefix(key, config.NodeURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		nodeList = append(nodeList, node)
+	}
+	return nodeList, nil
+}
+
+// DeleteNode deletes a node from the persistent store
+func (p *CRDClient) DeleteNode(n *utils.Node) error {
+	err := p.Delete(config.NodeURL + "/" + n.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddSnapshot saves a snapshot's state to the persistent store
+func (p *CRDClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	snapPersistent := snapshot.ConstructPersistent()
+	snapJSON, err := json.Marshal(snapPersistent)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.SnapshotURL+"/"+snapshot.ID(), string(snapJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSnapshot retrieves a snapshot's state from the persistent store
+func (p *CRDClient) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	snapJSON, err := p.Read(config.SnapshotURL + "/" + storage.MakeSnapshotID(volumeName, snapshotName))
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &storage.SnapshotPersistent{}
+	err = json.Unmarshal([]byte(snapJSON), snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots retrieves all snapshots
+func (p *CRDClient) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	snapshotList := make([]*storage.SnapshotPersistent, 0)
+	keys, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return snapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		snapshot := &storage.SnapshotPersistent{}
+		snapJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(snapJSON), snapshot)
+		if err != nil {
+			return nil, err
+		}
+		snapshotList = append(snapshotList, snapshot)
+	}
+	return snapshotList, nil
+}
+
+// DeleteSnapshot deletes a snapshot's state from the persistent store
+func (p *CRDClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	err := p.Delete(config.SnapshotURL + "/" + snapshot.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *CRDClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	err := p.DeleteSnapshot(snapshot)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// DeleteSnapshots deletes all snapshots
+func (p *CRDClient) DeleteSnapshots() error {
+	snapshots, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if err = p.Delete(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolumePublication saves a volume publication to the persistent store,
+// replacing any existing publication of the volume to the same node
+func (p *CRDClient) AddVolumePublication(publication *storage.VolumePublication) error {
+	publicationJSON, err := json.Marshal(publication)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.PublicationURL+"/"+publication.ID(), string(publicationJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolumePublication retrieves a volume publication from the persistent store
+func (p *CRDClient) GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error) {
+	publicationJSON, err := p.Read(config.PublicationURL + "/" + storage.MakeVolumePublicationID(volumeName, nodeName))
+	if err != nil {
+		return nil, err
+	}
+	publication := &storage.VolumePublication{}
+	err = json.Unmarshal([]byte(publicationJSON), publication)
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+// GetVolumePublications retrieves all volume publications
+func (p *CRDClient) GetVolumePublications() ([]*storage.VolumePublication, error) {
+	publicationList := make([]*storage.VolumePublication, 0)
+	keys, err := p.ReadKeys(config.PublicationURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return publicationList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		publication := &storage.VolumePublication{}
+		publicationJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(publicationJSON), publication)
+		if err != nil {
+			return nil, err
+		}
+		publicationList = append(publicationList, publication)
+	}
+	return publicationList, nil
+}
+
+// DeleteVolumePublication deletes a volume publication from the persistent store
+func (p *CRDClient) DeleteVolumePublication(publication *storage.VolumePublication) error {
+	err := p.Delete(config.PublicationURL + "/" + publication.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteVolumePublications deletes all volume publications
+func (p *CRDClient) DeleteVolumePublications() error {
+	publications, err := p.ReadKeys(config.PublicationURL)
+	if err != nil {
+		return err
+	}
+	for _, publication := range publications {
+		if err = p.Delete(publication); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddEvent saves an event to the persistent store
+func (p *CRDClient) AddEvent(event *storage.Event) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.EventURL+"/"+event.ID, string(eventJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetEvents retrieves all events
+func (p *CRDClient) GetEvents() ([]*storage.Event, error) {
+	eventList := make([]*storage.Event, 0)
+	keys, err := p.ReadKeys(config.EventURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return eventList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		event := &storage.Event{}
+		eventJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(eventJSON), event)
+		if err != nil {
+			return nil, err
+		}
+		eventList = append(eventList, event)
+	}
+	return eventList, nil
+}
+
+// DeleteEvent deletes an event from the persistent store
+func (p *CRDClient) DeleteEvent(event *storage.Event) error {
+	err := p.Delete(config.EventURL + "/" + event.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEvents deletes all events
+func (p *CRDClient) DeleteEvents() error {
+	events, err := p.ReadKeys(config.EventURL)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err = p.Delete(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddOrUpdateQuota saves a quota to the persistent store
+func (p *CRDClient) AddOrUpdateQuota(quota *storage.Quota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.QuotaURL+"/"+quota.Name, string(quotaJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetQuota retrieves a quota from the persistent store
+func (p *CRDClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	quotaJSON, err := p.Read(config.QuotaURL + "/" + quotaName)
+	if err != nil {
+		return nil, err
+	}
+	quota := &storage.Quota{}
+	err = json.Unmarshal([]byte(quotaJSON), quota)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *CRDClient) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	keys, err := p.ReadKeys(config.QuotaURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return quotaList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		quota := &storage.Quota{}
+		quotaJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(quotaJSON), quota)
+		if err != nil {
+			return nil, err
+		}
+		quotaList = append(quotaList, quota)
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *CRDClient) DeleteQuota(quota *storage.Quota) error {
+	err := p.Delete(config.QuotaURL + "/" + quota.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteQuotas deletes all quotas
+func (p *CRDClient) DeleteQuotas() error {
+	quotas, err := p.ReadKeys(config.QuotaURL)
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		if err = p.Delete(quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddGroupSnapshot saves a group snapshot to the persistent store
+func (p *CRDClient) AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	groupSnapshotJSON, err := json.Marshal(groupSnapshot)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.GroupSnapshotURL+"/"+groupSnapshot.Config.Name, string(groupSnapshotJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetGroupSnapshot retrieves a group snapshot from the persistent store
+func (p *CRDClient) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error) {
+	groupSnapshotJSON, err := p.Read(config.GroupSnapshotURL + "/" + groupSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	groupSnapshot := &storage.GroupSnapshot{}
+	err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	return groupSnapshot, nil
+}
+
+// GetGroupSnapshots retrieves all group snapshots
+func (p *CRDClient) GetGroupSnapshots() ([]*storage.GroupSnapshot, error) {
+	groupSnapshotList := make([]*storage.GroupSnapshot, 0)
+	keys, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return groupSnapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		groupSnapshot := &storage.GroupSnapshot{}
+		groupSnapshotJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		groupSnapshotList = append(groupSnapshotList, groupSnapshot)
+	}
+	return groupSnapshotList, nil
+}
+
+// DeleteGroupSnapshot deletes a group snapshot from the persistent store
+func (p *CRDClient) DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	err := p.Delete(config.GroupSnapshotURL + "/" + groupSnapshot.Config.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteGroupSnapshots deletes all group snapshots
+func (p *CRDClient) DeleteGroupSnapshots() error {
+	groupSnapshots, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, groupSnapshot := range groupSnapshots {
+		if err = p.Delete(groupSnapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap"
+)
+
+func newFakeCRDClient() (*CRDClient, *dynamicfake.FakeDynamicClient) {
+	// The fake dynamic client needs list types registered to list unstructured objects
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Version: "v1", Kind: "List"},
+		&unstructured.UnstructuredList{})
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Version: "v1", Kind: "ListList"},
+		&unstructured.UnstructuredList{})
+	client := dynamicfake.NewSimpleDynamicClient(scheme)
+	return newCRDClient(client, "trident"), client
+}
+
+func TestCRDCRUD(t *testing.T) {
+	p, _ := newFakeCRDClient()
+	key := config.VolumeURL + "/vol1"
+
+	if err := p.Create(key, "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := p.Create(key, "val1"); err == nil || err.Error() != KeyExistsErr {
+		t.Errorf("Expected %s, got %v", KeyExistsErr, err)
+	}
+	if val, err := p.Read(key); err != nil || val != "val1" {
+		t.Errorf("Read failed; val: %s, err: %v", val, err)
+	}
+	if err := p.Update(key, "val2"); err != nil {
+		t.Error(err.Error())
+	}
+	if val, err := p.Read(key); err != nil || val != "val2" {
+		t.Errorf("Update failed; val: %s, err: %v", val, err)
+	}
+	if err := p.Set(key, "val3"); err != nil {
+		t.Error(err.Error())
+	}
+	if val, err := p.Read(key); err != nil || val != "val3" {
+		t.Errorf("Set failed; val: %s, err: %v", val, err)
+	}
+	if err := p.Delete(key); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := p.Read(key); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	if err := p.Update(key, "val4"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	if err := p.Delete(key); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+
+	// A key outside Trident's object types can't be stored
+	if err := p.Create("/trident/v1/unknown/key1", "val1"); err == nil {
+		t.Error("Expected an error for an unknown key type")
+	}
+}
+
+func TestCRDReadDeleteKeys(t *testing.T) {
+	p, _ := newFakeCRDClient()
+
+	for i := 1; i <= 5; i++ {
+		if err := p.Set(fmt.Sprintf("%s/vol%d", config.VolumeURL, i), fmt.Sprintf("val%d", i)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		if err := p.Set(fmt.Sprintf("%s/backend%d", config.BackendURL, i), fmt.Sprintf("val%d", i)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	keys, err := p.ReadKeys(config.VolumeURL)
+	if err != nil || len(keys) != 5 {
+		t.Fatalf("Reading volume keys failed; keys: %v, err: %v", keys, err)
+	}
+	for i := 1; i <= 5; i++ {
+		if keys[i-1] != fmt.Sprintf("%s/vol%d", config.VolumeURL, i) {
+			t.Errorf("Unexpected key %s", keys[i-1])
+		}
+	}
+	if keys, err = p.ReadKeys("/" + config.OrchestratorName); err != nil || len(keys) != 8 {
+		t.Errorf("Reading all keys failed; keys: %v, err: %v", keys, err)
+	}
+
+	if err = p.DeleteKeys(config.VolumeURL); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err = p.ReadKeys(config.VolumeURL); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	if keys, err = p.ReadKeys(config.BackendURL); err != nil || len(keys) != 3 {
+		t.Errorf("Backend keys should remain; keys: %v, err: %v", keys, err)
+	}
+}
+
+func TestCRDUpdateConflict(t *testing.T) {
+	p, client := newFakeCRDClient()
+	key := config.BackendURL + "/backend1"
+
+	if err := p.Create(key, "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Simulate another client having changed the resource since it was last read
+	conflict := true
+	client.PrependReactor("update", "tridentbackends",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if !conflict {
+				return false, nil, nil
+			}
+			conflict = false
+			return true, nil, apierrors.NewConflict(
+				schema.GroupResource{Group: CRDGroup, Resource: "tridentbackends"}, "backend1",
+				fmt.Errorf("the object has been modified"))
+		})
+
+	if err := p.Update(key, "val2"); !MatchKeyConflictErr(err) {
+		t.Fatalf("Expected %s, got %v", KeyConflictErr, err)
+	}
+	if val, err := p.Read(key); err != nil || val != "val1" {
+		t.Errorf("Conflicting update should not have been saved; val: %s, err: %v", val, err)
+	}
+	if err := p.Update(key, "val2"); err != nil {
+		t.Errorf("Update after re-reading failed: %v", err)
+	}
+	if val, err := p.Read(key); err != nil || val != "val2" {
+		t.Errorf("Update failed; val: %s, err: %v", val, err)
+	}
+}
+
+func TestCRDObjectName(t *testing.T) {
+	k, err := kindForKey(config.BackendURL + "/ontapnas_10.0.0.1")
+	if err != nil || k.resource != "tridentbackends" {
+		t.Fatalf("Unexpected kind %v for backend key: %v", k, err)
+	}
+
+	if name := crdObjectName(k, config.BackendURL+"/backend-1"); name != "backend-1" {
+		t.Errorf("Valid name was altered to %s", name)
+	}
+	underscore := crdObjectName(k, config.BackendURL+"/ontapnas_10.0.0.1")
+	dash := crdObjectName(k, config.BackendURL+"/ontapnas-10.0.0.1")
+	if underscore == dash {
+		t.Errorf("Distinct keys share the name %s", dash)
+	}
+	if !strings.HasPrefix(underscore, "ontapnas-10.0.0.1-") {
+		t.Errorf("Unexpected name %s", underscore)
+	}
+	long := crdObjectName(k, config.BackendURL+"/"+strings.Repeat("A", 300))
+	if len(long) > maxCRDNameLength || strings.ToLower(long) != long {
+		t.Errorf("Invalid name %s", long)
+	}
+
+	k, _ = kindForKey(config.StoreURL)
+	if name := crdObjectName(k, config.StoreURL); name != config.OrchestratorName {
+		t.Errorf("Unexpected name %s for the version key", name)
+	}
+}
+
+func TestCRDReplaceBackendAndUpdateVolumes(t *testing.T) {
+	p, _ := newFakeCRDClient()
+
+	NFSDriver := ontap.NASStorageDriver{
+		Config: drivers.OntapStorageDriverConfig{
+			CommonStorageDriverConfig: &drivers.CommonStorageDriverConfig{
+				StorageDriverName: drivers.OntapNASStorageDriverName,
+			},
+			ManagementLIF: "10.0.0.4",
+			DataLIF:       "10.0.0.100",
+			SVM:           "svm1",
+			Username:      "admin",
+			Password:      "netapp",
+		},
+	}
+	NFSServer := &storage.Backend{
+		Driver: &NFSDriver,
+		Name:   "ontapnas_10.0.0.100",
+	}
+	if err := p.AddBackend(NFSServer); err != nil {
+		t.Fatalf("Backend creation failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		vol := &storage.Volume{
+			Config: &storage.VolumeConfig{
+				Version:      config.OrchestratorAPIVersion,
+				Name:         fmt.Sprintf("vol%d", i),
+				Size:         "1GB",
+				Protocol:     config.File,
+				StorageClass: "gold",
+			},
+			Backend: NFSServer.Name,
+			Pool:    storagePool,
+		}
+		if err := p.AddVolume(vol); err != nil {
+			t.Fatalf("Volume creation failed: %v", err)
+		}
+	}
+
+	newNFSServer := &storage.Backend{
+		Driver: &NFSDriver,
+		Name:   "AFF",
+	}
+	if err := p.ReplaceBackendAndUpdateVolumes(NFSServer, newNFSServer); err != nil {
+		t.Fatalf("ReplaceBackendAndUpdateVolumes failed: %v", err)
+	}
+
+	backends, err := p.GetBackends()
+	if err != nil || len(backends) != 1 || backends[0].Name != newNFSServer.Name {
+		t.Fatalf("Backend retrieval failed; backends: %v, err: %v", backends, err)
+	}
+	volumes, err := p.GetVolumes()
+	if err != nil || len(volumes) != 5 {
+		t.Fatalf("Volume retrieval failed; volumes: %v, err: %v", volumes, err)
+	}
+	for _, volume := range volumes {
+		if volume.Backend != newNFSServer.Name {
+			t.Errorf("Volume %s was not moved to backend %s", volume.Config.Name, newNFSServer.Name)
+		}
+	}
+}
+
+func TestCRDVersion(t *testing.T) {
+	p, _ := newFakeCRDClient()
+
+	if _, err := p.GetVersion(); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	version := &PersistentStateVersion{
+		PersistentStoreVersion: string(CRDStore),
+		OrchestratorAPIVersion: config.OrchestratorAPIVersion,
+	}
+	if err := p.SetVersion(version); err != nil {
+		t.Fatal(err.Error())
+	}
+	if stored, err := p.GetVersion(); err != nil || *stored != *version {
+		t.Errorf("Version retrieval failed; version: %v, err: %v", stored, err)
+	}
+}
+
+func TestCRDDataMigrationWithoutSource(t *testing.T) {
+	p, _ := newFakeCRDClient()
+
+	// With no etcd server to migrate from, the migration has nothing to do
+	if err := NewDataMigrator(p, EtcdV2Store).Run("/"+config.OrchestratorName, false); err != nil {
+		t.Errorf("Data migration failed: %v", err)
+	}
+}
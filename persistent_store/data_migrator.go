@@ -15,6 +15,10 @@ type DataMigrator struct {
 }
 
 func NewDataMigrator(destClient Client, sourceType StoreType) *DataMigrator {
+	// Keys are copied as they are stored, so any encrypted credentials stay encrypted
+	if encryptingClient, ok := destClient.(*EncryptingClient); ok {
+		destClient = encryptingClient.Client
+	}
 	return &DataMigrator{
 		SourceType: sourceType,
 		DestClient: destClient,
@@ -27,14 +31,19 @@ func (m *DataMigrator) Run(keyPrefix string, deleteSrc bool) error {
 		return nil
 	}
 	// Determine if this is a supported data migration
-	// 1) DataMigrator currently only supports etcdv2 to etcdv3 migration
+	// 1) etcdv3 to CRD migration copies state from the etcd server the CRD
+	// store was configured with, if any.
+	if m.DestClient.GetType() == CRDStore {
+		return m.runCRDMigration(keyPrefix, deleteSrc)
+	}
+	// 2) Otherwise, DataMigrator only supports etcdv2 to etcdv3 migration
 	if m.DestClient.GetType() != EtcdV3Store &&
 		m.SourceType != EtcdV2Store {
 		// No transformation
 		log.Debug("DataMigrator currently only supports etcdv2 to etcdv3 migration.")
 		return nil
 	}
-	// 2) No transformation from etcdv2 to etcdv3 when the etcd server is
+	// 3) No transformation from etcdv2 to etcdv3 when the etcd server is
 	// configured with TLS because etcdv2 doesn't support TLS.
 	if m.DestClient.GetConfig().TLSConfig != nil {
 		log.Debug("No persistent state transformation happens between etcdv2 " +
@@ -83,3 +92,38 @@ func (m *DataMigrator) Run(keyPrefix string, deleteSrc bool) error {
 	}
 	return nil
 }
+
+// runCRDMigration copies all keys from an etcdv3 server to the CRD store.
+func (m *DataMigrator) runCRDMigration(keyPrefix string, deleteSrc bool) error {
+	destinationClient, ok := m.DestClient.(*CRDClient)
+	if !ok {
+		return fmt.Errorf("%v is not a valid persistent store version",
+			m.DestClient.GetType())
+	}
+	sourceConfig := destinationClient.GetConfig()
+	if sourceConfig.endpoints == "" {
+		log.Debug("No etcd server is configured to migrate persistent state from.")
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"current_store_version": string(EtcdV3Store),
+		"desired_store_version": string(CRDStore),
+		"etcd_server":           sourceConfig.endpoints,
+	}).Info("Transforming persistent state.")
+	srcClient, err := NewEtcdClientV3FromConfig(sourceConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create the source etcd client for data migration: %v",
+			err)
+	}
+	etcdDataMigrator := NewEtcdDataMigrator(srcClient, destinationClient)
+	// Moving all Trident objects for all API versions
+	if err = etcdDataMigrator.Start(keyPrefix, deleteSrc); err != nil {
+		return fmt.Errorf("etcd data migration failed: %v", err)
+	}
+	if err = etcdDataMigrator.Stop(); err != nil {
+		return fmt.Errorf("failed to shut down the etcd data migrator: %v",
+			err)
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// Backend credentials are encrypted at rest with envelope encryption.  Each
+// credential is encrypted with AES-GCM using a random data key, and the data key is
+// encrypted in turn with the active key of a keyring that the administrator provides.
+// The stored value records the ID of that key, so a keyring may keep older keys for
+// reading credentials that haven't been re-encrypted since the keys were rotated.
+// Credentials stored in plaintext are read as they are, and are encrypted the next
+// time their backend is written.
+
+// encryptedSecretPrefix begins each stored credential that is encrypted, and is
+// followed by the key ID, the encrypted data key and the encrypted credential,
+// separated by colons.
+const encryptedSecretPrefix = "enc:v1:"
+
+// KeyringSecretKey is the item of a Kubernetes secret that holds a keyring.
+const KeyringSecretKey = "keyring"
+
+// keyEncryptionKeySize is the size of the keys in a keyring, and of the data keys,
+// which selects AES-256.
+const keyEncryptionKeySize = 32
+
+// Keyring holds the keys with which backend credentials are encrypted.
+type Keyring struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// ParseKeyring reads a keyring.  Each line holds a key ID and a base64-encoded
+// 32-byte key, separated by a colon; blank lines and lines starting with # are
+// ignored.  The key on the first line is the active key, with which credentials are
+// encrypted; the others are only used to decrypt credentials.
+func ParseKeyring(data []byte) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string][]byte)}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, ":", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d of the keyring isn't a key ID and a key separated by a colon", i+1)
+		}
+		keyID := strings.TrimSpace(fields[0])
+		if keyID == "" || strings.ContainsAny(keyID, " \t") {
+			return nil, fmt.Errorf("line %d of the keyring has an invalid key ID", i+1)
+		}
+		if _, ok := keyring.keys[keyID]; ok {
+			return nil, fmt.Errorf("the keyring has more than one key with ID %s", keyID)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("key %s isn't base64-encoded; %v", keyID, err)
+		}
+		if len(key) != keyEncryptionKeySize {
+			return nil, fmt.Errorf("key %s is %d bytes long; expected %d", keyID, len(key),
+				keyEncryptionKeySize)
+		}
+		keyring.keys[keyID] = key
+		if keyring.activeKeyID == "" {
+			keyring.activeKeyID = keyID
+		}
+	}
+	if keyring.activeKeyID == "" {
+		return nil, fmt.Errorf("the keyring has no keys")
+	}
+	return keyring, nil
+}
+
+// ActiveKeyID returns the ID of the key with which credentials are encrypted.
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+// encrypt seals a credential with a new data key, which is sealed with the active key.
+func (k *Keyring) encrypt(plaintext string) (string, error) {
+	dataKey := make([]byte, keyEncryptionKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	ciphertext, err := utils.EncryptAESGCM(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	sealedKey, err := utils.EncryptAESGCM(k.keys[k.activeKeyID], dataKey)
+	if err != nil {
+		return "", err
+	}
+	return encryptedSecretPrefix + k.activeKeyID + ":" + base64.StdEncoding.EncodeToString(sealedKey) +
+		":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decrypt reverses encrypt, returning a credential stored in plaintext as it is.
+func (k *Keyring) decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedSecretPrefix) {
+		return value, nil
+	}
+	fields := strings.Split(strings.TrimPrefix(value, encryptedSecretPrefix), ":")
+	if len(fields) != 3 {
+		return "", fmt.Errorf("the encrypted credential is malformed")
+	}
+	key, ok := k.keys[fields[0]]
+	if !ok {
+		return "", fmt.Errorf("the credential is encrypted with key %s, which isn't in the keyring", fields[0])
+	}
+	sealedKey, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("the encrypted data key is malformed; %v", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(fields[2])
+	if err != nil {
+		return "", fmt.Errorf("the encrypted credential is malformed; %v", err)
+	}
+	dataKey, err := utils.DecryptAESGCM(key, sealedKey)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt the data key with key %s; %v", fields[0], err)
+	}
+	plaintext, err := utils.DecryptAESGCM(dataKey, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt the credential; %v", err)
+	}
+	return string(plaintext), nil
+}
+
+// hasKeys reports whether the keyring can decrypt each of the stored credentials.
+func (k *Keyring) hasKeys(values []*string) bool {
+	for _, value := range values {
+		if keyID := encryptedSecretKeyID(*value); keyID != "" {
+			if _, ok := k.keys[keyID]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// encryptedSecretKeyID returns the ID of the key with which a stored credential was
+// encrypted, or an empty string if it's in plaintext.
+func encryptedSecretKeyID(value string) string {
+	if !strings.HasPrefix(value, encryptedSecretPrefix) {
+		return ""
+	}
+	return strings.SplitN(strings.TrimPrefix(value, encryptedSecretPrefix), ":", 2)[0]
+}
+
+// EncryptingClient is a store client that encrypts backend credentials before they
+// are written to the wrapped store, and decrypts them as they are read, so that the
+// orchestrator only ever sees them in plaintext.
+type EncryptingClient struct {
+	Client
+	loadKeyring func() (*Keyring, error)
+	keyring     *Keyring
+	mutex       sync.RWMutex
+}
+
+// NewEncryptingClient wraps a store client, loading the keyring from its source,
+// such as a file or a Kubernetes secret.  The source is read again whenever the
+// keyring is reloaded, so that keys may be rotated without restarting.
+func NewEncryptingClient(client Client, loadKeyring func() (*Keyring, error)) (*EncryptingClient, error) {
+	if client.GetType() == PassthroughStore {
+		return nil, fmt.Errorf("the passthrough store doesn't persist backend credentials")
+	}
+	c := &EncryptingClient{
+		Client:      client,
+		loadKeyring: loadKeyring,
+	}
+	if err := c.ReloadKeyring(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ReloadKeyring reads the keyring from its source again.
+func (c *EncryptingClient) ReloadKeyring() error {
+	keyring, err := c.loadKeyring()
+	if err != nil {
+		return fmt.Errorf("could not load the encryption keyring; %v", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.keyring = keyring
+	log.WithField("activeKeyID", keyring.ActiveKeyID()).Debug("Loaded the encryption keyring.")
+	return nil
+}
+
+// ActiveKeyID returns the ID of the key with which credentials are encrypted.
+func (c *EncryptingClient) ActiveKeyID() string {
+	return c.getKeyring().ActiveKeyID()
+}
+
+func (c *EncryptingClient) getKeyring() *Keyring {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.keyring
+}
+
+func (c *EncryptingClient) AddBackend(b *storage.Backend) error {
+	sealed, err := c.sealBackend(b)
+	if err != nil {
+		return err
+	}
+	return c.Client.AddBackend(sealed)
+}
+
+func (c *EncryptingClient) GetBackend(backendName string) (*storage.BackendPersistent, error) {
+	backend, err := c.Client.GetBackend(backendName)
+	if err != nil {
+		return nil, err
+	}
+	return c.openBackend(backend)
+}
+
+func (c *EncryptingClient) UpdateBackend(b *storage.Backend) error {
+	sealed, err := c.sealBackend(b)
+	if err != nil {
+		return err
+	}
+	return c.Client.UpdateBackend(sealed)
+}
+
+func (c *EncryptingClient) GetBackends() ([]*storage.BackendPersistent, error) {
+	backends, err := c.Client.GetBackends()
+	if err != nil {
+		return nil, err
+	}
+	for i, backend := range backends {
+		if backends[i], err = c.openBackend(backend); err != nil {
+			return nil, err
+		}
+	}
+	return backends, nil
+}
+
+func (c *EncryptingClient) ReplaceBackendAndUpdateVolumes(origBackend, newBackend *storage.Backend) error {
+	sealed, err := c.sealBackend(newBackend)
+	if err != nil {
+		return err
+	}
+	return c.Client.ReplaceBackendAndUpdateVolumes(origBackend, sealed)
+}
+
+// sealedDriver stands in for the driver of a backend that is being written to the
+// store, so that the store persists a copy of its config with encrypted credentials.
+type sealedDriver struct {
+	storage.Driver
+	config *storage.PersistentStorageBackendConfig
+}
+
+func (d *sealedDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
+	*b = *d.config
+}
+
+// sealBackend returns a copy of a backend whose persistent config has encrypted
+// credentials.  Drivers share their live config with the persistent one, so the
+// credentials are encrypted in a copy of it.
+func (c *EncryptingClient) sealBackend(b *storage.Backend) (*storage.Backend, error) {
+	persistent := b.ConstructPersistent()
+	backendConfig := &storage.PersistentStorageBackendConfig{}
+	if err := copyJSON(&persistent.Config, backendConfig); err != nil {
+		return nil, fmt.Errorf("could not copy the config of backend %s; %v", b.Name, err)
+	}
+
+	keyring := c.getKeyring()
+	for _, secret := range backendConfig.Secrets() {
+		if *secret == "" {
+			continue
+		}
+		ciphertext, err := keyring.encrypt(*secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not encrypt the credentials of backend %s; %v", b.Name, err)
+		}
+		*secret = ciphertext
+	}
+
+	sealed := *b
+	sealed.Driver = &sealedDriver{Driver: b.Driver, config: backendConfig}
+	return &sealed, nil
+}
+
+// openBackend returns a persistent backend with its credentials decrypted, copying it
+// so that a store that keeps its objects in memory isn't altered.
+func (c *EncryptingClient) openBackend(backend *storage.BackendPersistent) (*storage.BackendPersistent, error) {
+	secrets := backend.Config.Secrets()
+	encrypted := false
+	for _, secret := range secrets {
+		if encryptedSecretKeyID(*secret) != "" {
+			encrypted = true
+		}
+	}
+	if !encrypted {
+		return backend, nil
+	}
+
+	// Another replica may have rotated the keys since the keyring was loaded
+	keyring := c.getKeyring()
+	if !keyring.hasKeys(secrets) {
+		if err := c.ReloadKeyring(); err != nil {
+			log.WithField("error", err).Warning("Could not reload the encryption keyring.")
+		}
+		keyring = c.getKeyring()
+	}
+
+	opened := &storage.BackendPersistent{}
+	if err := copyJSON(backend, opened); err != nil {
+		return nil, fmt.Errorf("could not copy backend %s; %v", backend.Name, err)
+	}
+	for _, secret := range opened.Config.Secrets() {
+		plaintext, err := keyring.decrypt(*secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt the credentials of backend %s; %v", backend.Name, err)
+		}
+		*secret = plaintext
+	}
+	return opened, nil
+}
+
+// copyJSON deep copies an object the way that the stores serialize it.
+func copyJSON(source, destination interface{}) error {
+	bytes, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, destination)
+}
@@ -0,0 +1,176 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+// testKeyringLine returns a keyring line for a key made of one repeated character.
+func testKeyringLine(keyID string, c string) string {
+	return keyID + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(c, keyEncryptionKeySize)))
+}
+
+// ontapTestDriver is just enough of a driver to be written to a store.
+type ontapTestDriver struct {
+	storage.Driver
+	config drivers.OntapStorageDriverConfig
+}
+
+func (d *ontapTestDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
+	b.OntapConfig = &d.config
+}
+
+func newOntapTestBackend(name, password string) *storage.Backend {
+	return &storage.Backend{
+		Name: name,
+		Driver: &ontapTestDriver{
+			config: drivers.OntapStorageDriverConfig{
+				CommonStorageDriverConfig: &drivers.CommonStorageDriverConfig{
+					StorageDriverName: drivers.OntapNASStorageDriverName,
+				},
+				ManagementLIF: "10.0.0.4",
+				Username:      "admin",
+				Password:      password,
+			},
+		},
+		Online: true,
+		State:  storage.Online,
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	keyring, err := ParseKeyring([]byte("# Rotated 2019-03-01\n\n" + testKeyringLine("key2", "b") +
+		"\n" + testKeyringLine("key1", "a") + "\n"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if keyring.ActiveKeyID() != "key2" || len(keyring.keys) != 2 {
+		t.Errorf("Unexpected keyring: active key %s, %d keys.", keyring.ActiveKeyID(), len(keyring.keys))
+	}
+
+	for _, data := range []string{
+		"",
+		"# No keys",
+		"key1",
+		":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", keyEncryptionKeySize))),
+		"key1:not base64!",
+		"key1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+		testKeyringLine("key1", "a") + "\n" + testKeyringLine("key1", "b"),
+	} {
+		if _, err = ParseKeyring([]byte(data)); err == nil {
+			t.Errorf("Expected keyring %q to be rejected.", data)
+		}
+	}
+}
+
+func TestEncryptingClient(t *testing.T) {
+	keyringData := testKeyringLine("key1", "a")
+	loadKeyring := func() (*Keyring, error) {
+		return ParseKeyring([]byte(keyringData))
+	}
+	store := NewInMemoryClient()
+	p, err := NewEncryptingClient(store, loadKeyring)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	backend := newOntapTestBackend("ontapnas_10.0.0.4", "netapp")
+	if err = p.AddBackend(backend); err != nil {
+		t.Fatal(err.Error())
+	}
+	stored, err := store.GetBackend(backend.Name)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if encryptedSecretKeyID(stored.Config.OntapConfig.Password) != "key1" ||
+		encryptedSecretKeyID(stored.Config.OntapConfig.Username) != "key1" {
+		t.Errorf("Expected the credentials to be stored encrypted with key1, got %s.",
+			stored.Config.OntapConfig.Password)
+	}
+	if stored.Config.OntapConfig.ManagementLIF != "10.0.0.4" {
+		t.Error("Expected the rest of the config to be stored in plaintext.")
+	}
+	if backend.Driver.(*ontapTestDriver).config.Password != "netapp" {
+		t.Error("Encrypting the stored credentials altered the driver's config.")
+	}
+
+	read, err := p.GetBackend(backend.Name)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if read.Config.OntapConfig.Password != "netapp" || read.Config.OntapConfig.Username != "admin" {
+		t.Errorf("Expected the credentials to be decrypted, got %s.", read.Config.OntapConfig.Password)
+	}
+	if encryptedSecretKeyID(stored.Config.OntapConfig.Password) != "key1" {
+		t.Error("Decrypting the credentials altered the stored backend.")
+	}
+
+	// Credentials stored before encryption was enabled are read as they are
+	if err = store.AddBackend(newOntapTestBackend("ontapnas_10.0.0.5", "plaintext")); err != nil {
+		t.Fatal(err.Error())
+	}
+	backends, err := p.GetBackends()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(backends) != 2 {
+		t.Fatalf("Expected 2 backends, got %d.", len(backends))
+	}
+	for _, b := range backends {
+		if password := b.Config.OntapConfig.Password; password != "netapp" && password != "plaintext" {
+			t.Errorf("Unexpected password %s for backend %s.", password, b.Name)
+		}
+	}
+
+	// A rotated key encrypts the credentials the next time they are written, and the
+	// previous key still decrypts the others
+	keyringData = testKeyringLine("key2", "b") + "\n" + testKeyringLine("key1", "a")
+	if err = p.ReloadKeyring(); err != nil {
+		t.Fatal(err.Error())
+	}
+	other := newOntapTestBackend("ontapnas_10.0.0.6", "other")
+	if err = p.AddBackend(other); err != nil {
+		t.Fatal(err.Error())
+	}
+	if stored, err = store.GetBackend(other.Name); err != nil {
+		t.Fatal(err.Error())
+	}
+	if encryptedSecretKeyID(stored.Config.OntapConfig.Password) != "key2" {
+		t.Error("Expected the credentials to be encrypted with the rotated key.")
+	}
+	if read, err = p.GetBackend(backend.Name); err != nil || read.Config.OntapConfig.Password != "netapp" {
+		t.Errorf("Could not read credentials encrypted with the previous key; %v", err)
+	}
+
+	// Once the previous key is removed, credentials that weren't re-encrypted can't be read
+	keyringData = testKeyringLine("key2", "b")
+	if err = p.ReloadKeyring(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err = p.GetBackend(backend.Name); err == nil {
+		t.Error("Expected credentials encrypted with a removed key to be unreadable.")
+	}
+	if err = p.UpdateBackend(backend); err != nil {
+		t.Fatal(err.Error())
+	}
+	if read, err = p.GetBackend(backend.Name); err != nil || read.Config.OntapConfig.Password != "netapp" {
+		t.Errorf("Could not read re-encrypted credentials; %v", err)
+	}
+
+	// A tampered credential fails to decrypt
+	stored, _ = store.GetBackend(other.Name)
+	stored.Config.OntapConfig.Password = stored.Config.OntapConfig.Password[:len(stored.Config.OntapConfig.Password)-4] + "AAAA"
+	if _, err = p.GetBackend(other.Name); err == nil {
+		t.Error("Expected a tampered credential to fail decryption.")
+	}
+
+	if _, err = NewEncryptingClient(&PassthroughClient{}, loadKeyring); err == nil {
+		t.Error("Expected encryption to be refused for the passthrough store.")
+	}
+}
@@ -7,6 +7,8 @@ const (
 	KeyExistsErr          = "Key already exists"
 	UnavailableClusterErr = "Unavailable etcd cluster"
 	NotSupported          = "Unsupported operation"
+	KeyConflictErr        = "Key was modified concurrently"
+	NotLeaderErr          = "No longer the elected leader"
 )
 
 // Error is used to turn etcd errors into something that callers can understand without
@@ -34,6 +36,20 @@ func MatchKeyNotFoundErr(err error) bool {
 	return false
 }
 
+func MatchKeyConflictErr(err error) bool {
+	if err != nil && err.Error() == KeyConflictErr {
+		return true
+	}
+	return false
+}
+
+func MatchNotLeaderErr(err error) bool {
+	if err != nil && err.Error() == NotLeaderErr {
+		return true
+	}
+	return false
+}
+
 func MatchUnavailableClusterErr(err error) bool {
 	if err != nil && err.Error() == UnavailableClusterErr {
 		return true
@@ -615,3 +615,369 @@ func (p *EtcdClientV2) DeleteNode(n *utils.Node) error {
 	}
 	return nil
 }
+
+// AddSnapshot saves a snapshot's state to the persistent store
+func (p *EtcdClientV2) AddSnapshot(snapshot *storage.Snapshot) error {
+	snapPersistent := snapshot.ConstructPersistent()
+	snapJSON, err := json.Marshal(snapPersistent)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.SnapshotURL+"/"+snapshot.ID(), string(snapJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSnapshot retrieves a snapshot's state from the persistent store
+func (p *EtcdClientV2) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	snapJSON, err := p.Read(config.SnapshotURL + "/" + storage.MakeSnapshotID(volumeName, snapshotName))
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &storage.SnapshotPersistent{}
+	err = json.Unmarshal([]byte(snapJSON), snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots retrieves all snapshots
+func (p *EtcdClientV2) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	snapshotList := make([]*storage.SnapshotPersistent, 0)
+	keys, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return snapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		snapshot := &storage.SnapshotPersistent{}
+		snapJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(snapJSON), snapshot)
+		if err != nil {
+			return nil, err
+		}
+		snapshotList = append(snapshotList, snapshot)
+	}
+	return snapshotList, nil
+}
+
+// DeleteSnapshot deletes a snapshot's state from the persistent store
+func (p *EtcdClientV2) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	err := p.Delete(config.SnapshotURL + "/" + snapshot.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *EtcdClientV2) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	err := p.DeleteSnapshot(snapshot)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// DeleteSnapshots deletes all snapshots
+func (p *EtcdClientV2) DeleteSnapshots() error {
+	snapshots, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if err = p.Delete(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolumePublication saves a volume publication to the persistent store,
+// replacing any existing publication of the volume to the same node
+func (p *EtcdClientV2) AddVolumePublication(publication *storage.VolumePublication) error {
+	publicationJSON, err := json.Marshal(publication)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.PublicationURL+"/"+publication.ID(), string(publicationJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolumePublication retrieves a volume publication from the persistent store
+func (p *EtcdClientV2) GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error) {
+	publicationJSON, err := p.Read(config.PublicationURL + "/" + storage.MakeVolumePublicationID(volumeName, nodeName))
+	if err != nil {
+		return nil, err
+	}
+	publication := &storage.VolumePublication{}
+	err = json.Unmarshal([]byte(publicationJSON), publication)
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+// GetVolumePublications retrieves all volume publications
+func (p *EtcdClientV2) GetVolumePublications() ([]*storage.VolumePublication, error) {
+	publicationList := make([]*storage.VolumePublication, 0)
+	keys, err := p.ReadKeys(config.PublicationURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return publicationList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		publication := &storage.VolumePublication{}
+		publicationJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(publicationJSON), publication)
+		if err != nil {
+			return nil, err
+		}
+		publicationList = append(publicationList, publication)
+	}
+	return publicationList, nil
+}
+
+// DeleteVolumePublication deletes a volume publication from the persistent store
+func (p *EtcdClientV2) DeleteVolumePublication(publication *storage.VolumePublication) error {
+	err := p.Delete(config.PublicationURL + "/" + publication.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteVolumePublications deletes all volume publications
+func (p *EtcdClientV2) DeleteVolumePublications() error {
+	publications, err := p.ReadKeys(config.PublicationURL)
+	if err != nil {
+		return err
+	}
+	for _, publication := range publications {
+		if err = p.Delete(publication); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddEvent saves an event to the persistent store
+func (p *EtcdClientV2) AddEvent(event *storage.Event) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.EventURL+"/"+event.ID, string(eventJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetEvents retrieves all events
+func (p *EtcdClientV2) GetEvents() ([]*storage.Event, error) {
+	eventList := make([]*storage.Event, 0)
+	keys, err := p.ReadKeys(config.EventURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return eventList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		event := &storage.Event{}
+		eventJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(eventJSON), event)
+		if err != nil {
+			return nil, err
+		}
+		eventList = append(eventList, event)
+	}
+	return eventList, nil
+}
+
+// DeleteEvent deletes an event from the persistent store
+func (p *EtcdClientV2) DeleteEvent(event *storage.Event) error {
+	err := p.Delete(config.EventURL + "/" + event.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEvents deletes all events
+func (p *EtcdClientV2) DeleteEvents() error {
+	events, err := p.ReadKeys(config.EventURL)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err = p.Delete(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddOrUpdateQuota saves a quota to the persistent store
+func (p *EtcdClientV2) AddOrUpdateQuota(quota *storage.Quota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.QuotaURL+"/"+quota.Name, string(quotaJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetQuota retrieves a quota from the persistent store
+func (p *EtcdClientV2) GetQuota(quotaName string) (*storage.Quota, error) {
+	quotaJSON, err := p.Read(config.QuotaURL + "/" + quotaName)
+	if err != nil {
+		return nil, err
+	}
+	quota := &storage.Quota{}
+	err = json.Unmarshal([]byte(quotaJSON), quota)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *EtcdClientV2) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	keys, err := p.ReadKeys(config.QuotaURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return quotaList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		quota := &storage.Quota{}
+		quotaJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(quotaJSON), quota)
+		if err != nil {
+			return nil, err
+		}
+		quotaList = append(quotaList, quota)
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *EtcdClientV2) DeleteQuota(quota *storage.Quota) error {
+	err := p.Delete(config.QuotaURL + "/" + quota.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteQuotas deletes all quotas
+func (p *EtcdClientV2) DeleteQuotas() error {
+	quotas, err := p.ReadKeys(config.QuotaURL)
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		if err = p.Delete(quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddGroupSnapshot saves a group snapshot to the persistent store
+func (p *EtcdClientV2) AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	groupSnapshotJSON, err := json.Marshal(groupSnapshot)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.GroupSnapshotURL+"/"+groupSnapshot.Config.Name, string(groupSnapshotJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetGroupSnapshot retrieves a group snapshot from the persistent store
+func (p *EtcdClientV2) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error) {
+	groupSnapshotJSON, err := p.Read(config.GroupSnapshotURL + "/" + groupSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	groupSnapshot := &storage.GroupSnapshot{}
+	err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	return groupSnapshot, nil
+}
+
+// GetGroupSnapshots retrieves all group snapshots
+func (p *EtcdClientV2) GetGroupSnapshots() ([]*storage.GroupSnapshot, error) {
+	groupSnapshotList := make([]*storage.GroupSnapshot, 0)
+	keys, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return groupSnapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		groupSnapshot := &storage.GroupSnapshot{}
+		groupSnapshotJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		groupSnapshotList = append(groupSnapshotList, groupSnapshot)
+	}
+	return groupSnapshotList, nil
+}
+
+// DeleteGroupSnapshot deletes a group snapshot from the persistent store
+func (p *EtcdClientV2) DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	err := p.Delete(config.GroupSnapshotURL + "/" + groupSnapshot.Config.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteGroupSnapshots deletes all group snapshots
+func (p *EtcdClientV2) DeleteGroupSnapshots() error {
+	groupSnapshots, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, groupSnapshot := range groupSnapshots {
+		if err = p.Delete(groupSnapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
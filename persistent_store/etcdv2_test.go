@@ -816,3 +816,70 @@ func TestEtcdv2GetNodes(t *testing.T) {
 		t.Error("Incorrect nodes returned from persistence")
 	}
 }
+
+func TestEtcdv2Snapshot(t *testing.T) {
+	p, err := NewEtcdClientV2(*etcdV2)
+	if err != nil {
+		t.Fatalf("Creating a new etcd client failed: %v", err)
+	}
+
+	snapshot1 := storage.NewSnapshot(&storage.SnapshotConfig{
+		Version:            "1",
+		Name:               "snap1",
+		InternalName:       "snap1",
+		VolumeName:         "vol1",
+		VolumeInternalName: "trident_vol1",
+	}, "2019-03-22T14:11:39Z", 1000000000, storage.SnapshotStateOnline)
+	snapshot2 := storage.NewSnapshot(&storage.SnapshotConfig{
+		Version:            "1",
+		Name:               "snap2",
+		InternalName:       "snap2",
+		VolumeName:         "vol1",
+		VolumeInternalName: "trident_vol1",
+	}, "2019-03-22T14:12:39Z", 1000000000, storage.SnapshotStateOnline)
+
+	// Adding snapshots
+	for _, snapshot := range []*storage.Snapshot{snapshot1, snapshot2} {
+		if err = p.AddSnapshot(snapshot); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// Getting a snapshot
+	recoveredSnapshot, err := p.GetSnapshot("vol1", "snap1")
+	if err != nil {
+		t.Error(err.Error())
+	} else if !reflect.DeepEqual(recoveredSnapshot.Snapshot, *snapshot1) {
+		t.Errorf("Incorrect snapshot retrieved; expected %v, found %v", *snapshot1, recoveredSnapshot.Snapshot)
+	}
+
+	// Getting all snapshots
+	snapshots, err := p.GetSnapshots()
+	if err != nil {
+		t.Error(err.Error())
+	} else if len(snapshots) != 2 {
+		t.Errorf("Expected 2 snapshots, found %d", len(snapshots))
+	}
+
+	// Deleting a snapshot
+	if err = p.DeleteSnapshot(snapshot1); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err = p.GetSnapshot("vol1", "snap1"); err == nil || !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected a key not found error for a deleted snapshot, got %v", err)
+	}
+	if err = p.DeleteSnapshotIgnoreNotFound(snapshot1); err != nil {
+		t.Errorf("Deleting a missing snapshot should have been ignored: %v", err)
+	}
+
+	// Deleting all snapshots
+	if err = p.DeleteSnapshots(); err != nil {
+		t.Error(err.Error())
+	}
+	snapshots, err = p.GetSnapshots()
+	if err != nil {
+		t.Error(err.Error())
+	} else if len(snapshots) != 0 {
+		t.Errorf("Expected no snapshots, found %d", len(snapshots))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"sync"
 
 	"github.com/coreos/etcd/clientv3"
 	log "github.com/sirupsen/logrus"
@@ -30,10 +31,19 @@ var (
 	ErrKeyNotFound = errors.New("etcdserver: key not found")
 )
 
+// EtcdClientV3 writes each key only if it hasn't changed since this client last
+// read or wrote it, so that concurrent writers, such as a second Trident replica,
+// can't silently overwrite each other's changes.  Once its elector has won a
+// campaign, it also writes only while the key of that campaign still exists, so
+// that a leader whose lease has expired can't overwrite its successor's changes.
 type EtcdClientV3 struct {
 	clientV3  *clientv3.Client
 	endpoints string
 	tlsConfig *tls.Config
+	revisions map[string]int64 // the revision of each key as last read or written by this client
+	leaderKey string           // the election key of the last won campaign, if any
+	leaderRev int64            // the revision at which the election key was created
+	mutex     sync.Mutex
 }
 
 func NewEtcdClientV3(endpoints string) (*EtcdClientV3, error) {
@@ -55,6 +65,7 @@ func NewEtcdClientV3(endpoints string) (*EtcdClientV3, error) {
 	etcdClientV3 := &EtcdClientV3{
 		clientV3:  clientV3,
 		endpoints: endpoints,
+		revisions: make(map[string]int64),
 	}
 
 	// Warn if etcd version isn't what we expect
@@ -101,6 +112,7 @@ func NewEtcdClientV3WithTLS(endpoints, etcdV3Cert, etcdV3CACert, etcdV3Key strin
 		clientV3:  clientV3,
 		endpoints: endpoints,
 		tlsConfig: tlsConfig,
+		revisions: make(map[string]int64),
 	}
 
 	// Warn if etcd version isn't what we expect
@@ -123,6 +135,7 @@ func NewEtcdClientV3FromConfig(etcdConfig *ClientConfig) (*EtcdClientV3, error)
 			clientV3:  clientV3,
 			endpoints: etcdConfig.endpoints,
 			tlsConfig: etcdConfig.TLSConfig,
+			revisions: make(map[string]int64),
 		}, nil
 	}
 	if err.Error() == grpc.ErrClientConnTimeout.Error() ||
@@ -154,16 +167,17 @@ func (p *EtcdClientV3) checkEtcdVersion() {
 	}
 }
 
-// Create creates a key in etcd
+// Create creates a key in etcd, failing if the key already exists
 func (p *EtcdClientV3) Create(key, value string) error {
-	_, err := p.Read(key)
-	if err != nil && !MatchKeyNotFoundErr(err) {
+	resp, err := p.txn(key, clientv3.Compare(clientv3.CreateRevision(key), "=", 0), clientv3.OpPut(key, value))
+	if err != nil {
 		return err
 	}
-	if err == nil {
+	if !resp.Succeeded {
 		return NewPersistentStoreError(KeyExistsErr, key)
 	}
-	return p.Set(key, value)
+	p.setRevision(key, resp.Header.Revision)
+	return nil
 }
 
 // Create creates a key in etcd using STM
@@ -198,6 +212,7 @@ func (p *EtcdClientV3) Read(key string) (string, error) {
 	if len(resp.Kvs) == 0 {
 		return "", NewPersistentStoreError(KeyNotFoundErr, key)
 	}
+	p.setRevision(key, resp.Kvs[0].ModRevision)
 	return string(resp.Kvs[0].Value[:]), nil
 }
 
@@ -236,12 +251,17 @@ func (p *EtcdClientV3) ReadKeys(keyPrefix string) ([]string, error) {
 	return keys, nil
 }
 
+// Update changes an existing key, failing with a conflict error if another client
+// has changed the key since this client last read or wrote it
 func (p *EtcdClientV3) Update(key, value string) error {
-	_, err := p.Read(key)
+	revision, err := p.revision(key)
 	if err != nil {
 		return err
 	}
-	return p.Set(key, value)
+	if revision == 0 {
+		return NewPersistentStoreError(KeyNotFoundErr, key)
+	}
+	return p.compareAndPut(key, value, revision, true)
 }
 
 func (p *EtcdClientV3) UpdateSTM(s conc.STM, key, value string) error {
@@ -252,14 +272,14 @@ func (p *EtcdClientV3) UpdateSTM(s conc.STM, key, value string) error {
 	return p.SetSTM(s, key, value)
 }
 
+// Set creates or changes a key, failing with a conflict error if another client
+// has changed the key since this client last read or wrote it
 func (p *EtcdClientV3) Set(key, value string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), config.PersistentStoreTimeout)
-	_, err := p.clientV3.Put(ctx, key, value)
-	cancel()
+	revision, err := p.revision(key)
 	if err != nil {
 		return err
 	}
-	return nil
+	return p.compareAndPut(key, value, revision, false)
 }
 
 func (p *EtcdClientV3) SetSTM(s conc.STM, key, value string) error {
@@ -267,16 +287,27 @@ func (p *EtcdClientV3) SetSTM(s conc.STM, key, value string) error {
 	return nil
 }
 
+// Delete deletes a key, failing with a conflict error if another client has changed
+// the key since this client last read or wrote it
 func (p *EtcdClientV3) Delete(key string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), config.PersistentStoreTimeout)
-	resp, err := p.clientV3.Delete(ctx, key)
-	cancel()
+	revision, err := p.revision(key)
 	if err != nil {
 		return err
 	}
-	if err == nil && resp.Deleted == 0 {
+	if revision == 0 {
 		return NewPersistentStoreError(KeyNotFoundErr, key)
 	}
+	resp, err := p.txn(key, clientv3.Compare(clientv3.ModRevision(key), "=", revision), clientv3.OpDelete(key))
+	if err != nil {
+		return err
+	}
+	p.forgetRevisions(key)
+	if !resp.Succeeded {
+		if !txnFoundKey(resp) {
+			return NewPersistentStoreError(KeyNotFoundErr, key)
+		}
+		return NewPersistentStoreError(KeyConflictErr, key)
+	}
 	return nil
 }
 
@@ -299,6 +330,143 @@ func (p *EtcdClientV3) DeleteKeys(keyPrefix string) error {
 	return nil
 }
 
+// compareAndPut writes a key if its revision still matches the specified one, which is
+// zero if the key must not exist.
+func (p *EtcdClientV3) compareAndPut(key, value string, revision int64, mustExist bool) error {
+	resp, err := p.txn(key, clientv3.Compare(clientv3.ModRevision(key), "=", revision), clientv3.OpPut(key, value))
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		p.forgetRevisions(key)
+		if mustExist && !txnFoundKey(resp) {
+			return NewPersistentStoreError(KeyNotFoundErr, key)
+		}
+		return NewPersistentStoreError(KeyConflictErr, key)
+	}
+	p.setRevision(key, resp.Header.Revision)
+	return nil
+}
+
+// txn applies an operation to a key if a comparison holds, and if this client is still
+// the leader when it has won a campaign.  If the comparison doesn't hold, the key is
+// read instead, so that the caller can tell whether the key exists.
+func (p *EtcdClientV3) txn(key string, cmp clientv3.Cmp, op clientv3.Op) (*clientv3.TxnResponse, error) {
+	cmps := []clientv3.Cmp{cmp}
+	elseOps := []clientv3.Op{clientv3.OpGet(key)}
+	leaderKey, leaderRev := p.leadership()
+	if leaderKey != "" {
+		cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(leaderKey), "=", leaderRev))
+		elseOps = append(elseOps, clientv3.OpGet(leaderKey))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.PersistentStoreTimeout)
+	defer cancel()
+	resp, err := p.clientV3.Txn(ctx).If(cmps...).Then(op).Else(elseOps...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Succeeded && leaderKey != "" && !txnFoundLeader(resp, leaderRev) {
+		return nil, NewPersistentStoreError(NotLeaderErr, key)
+	}
+	return resp, nil
+}
+
+// txnFoundLeader returns whether a failed txn found the election key of this client's
+// leadership, created at the specified revision.
+func txnFoundLeader(resp *clientv3.TxnResponse, leaderRev int64) bool {
+	if len(resp.Responses) < 2 {
+		return false
+	}
+	kvs := resp.Responses[1].GetResponseRange().Kvs
+	return len(kvs) > 0 && kvs[0].CreateRevision == leaderRev
+}
+
+// setLeadership makes all later writes require that the specified election key still
+// exists, having been created at the specified revision.
+func (p *EtcdClientV3) setLeadership(leaderKey string, leaderRev int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.leaderKey = leaderKey
+	p.leaderRev = leaderRev
+}
+
+// leadership returns the election key that writes require, and the revision at which
+// it was created, or an empty key if this client's elector hasn't won a campaign.
+func (p *EtcdClientV3) leadership() (string, int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.leaderKey, p.leaderRev
+}
+
+// checkLeadershipSTM fails unless this client is still the leader, if its elector
+// has won a campaign.  Reading the election key also makes the STM's commit fail if
+// the key changes in the meantime.
+func (p *EtcdClientV3) checkLeadershipSTM(s conc.STM) error {
+	// Each campaign's key is unique to its lease, so it is enough that the key exists
+	leaderKey, _ := p.leadership()
+	if leaderKey == "" {
+		return nil
+	}
+	if s.Get(leaderKey) == "" {
+		return NewPersistentStoreError(NotLeaderErr, leaderKey)
+	}
+	return nil
+}
+
+// txnFoundKey returns whether a failed txn found its key.
+func txnFoundKey(resp *clientv3.TxnResponse) bool {
+	return len(resp.Responses) > 0 && len(resp.Responses[0].GetResponseRange().Kvs) > 0
+}
+
+// revision returns the revision that the next write of a key must match: the revision
+// at which this client last read or wrote the key if it has, or else the key's current
+// revision, which is zero if the key doesn't exist.
+func (p *EtcdClientV3) revision(key string) (int64, error) {
+	p.mutex.Lock()
+	revision, ok := p.revisions[key]
+	p.mutex.Unlock()
+	if ok {
+		return revision, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.PersistentStoreTimeout)
+	resp, err := p.clientV3.Get(ctx, key)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return resp.Kvs[0].ModRevision, nil
+}
+
+func (p *EtcdClientV3) setRevision(key string, revision int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.revisions[key] = revision
+}
+
+// forgetRevisions discards the revisions of the specified keys, and of any keys under
+// them if they end with a slash, so that their next writes compare against their
+// current revisions.
+func (p *EtcdClientV3) forgetRevisions(keys ...string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "/") {
+			delete(p.revisions, key)
+			continue
+		}
+		for cached := range p.revisions {
+			if strings.HasPrefix(cached, key) {
+				delete(p.revisions, cached)
+			}
+		}
+	}
+}
+
 // GetType returns the persistent store type
 func (p *EtcdClientV3) GetType() StoreType {
 	return EtcdV3Store
@@ -458,6 +626,11 @@ func (p *EtcdClientV3) ReplaceBackendAndUpdateVolumes(origBackend, newBackend *s
 	_, err := conc.NewSTMSerializable(context.TODO(), p.clientV3,
 		func(s conc.STM) error {
 
+			// Only the leader may change the backends
+			if err := p.checkLeadershipSTM(s); err != nil {
+				return err
+			}
+
 			// First, create the new backend.
 			err := p.AddBackendSTM(s, newBackend)
 			if err != nil {
@@ -485,6 +658,10 @@ func (p *EtcdClientV3) ReplaceBackendAndUpdateVolumes(origBackend, newBackend *s
 			// Forth, delete the old backend.
 			return p.DeleteBackendSTM(s, origBackend)
 		})
+
+	// The transaction changed the revisions of these keys behind this client's back
+	p.forgetRevisions(config.BackendURL+"/"+origBackend.Name, config.BackendURL+"/"+newBackend.Name,
+		config.VolumeURL+"/")
 	return err
 }
 
@@ -834,3 +1011,369 @@ func (p *EtcdClientV3) DeleteNode(n *utils.Node) error {
 	}
 	return nil
 }
+
+// AddSnapshot saves a snapshot's state to the persistent store
+func (p *EtcdClientV3) AddSnapshot(snapshot *storage.Snapshot) error {
+	snapPersistent := snapshot.ConstructPersistent()
+	snapJSON, err := json.Marshal(snapPersistent)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.SnapshotURL+"/"+snapshot.ID(), string(snapJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSnapshot retrieves a snapshot's state from the persistent store
+func (p *EtcdClientV3) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	snapJSON, err := p.Read(config.SnapshotURL + "/" + storage.MakeSnapshotID(volumeName, snapshotName))
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &storage.SnapshotPersistent{}
+	err = json.Unmarshal([]byte(snapJSON), snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots retrieves all snapshots
+func (p *EtcdClientV3) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	snapshotList := make([]*storage.SnapshotPersistent, 0)
+	keys, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return snapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		snapshot := &storage.SnapshotPersistent{}
+		snapJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(snapJSON), snapshot)
+		if err != nil {
+			return nil, err
+		}
+		snapshotList = append(snapshotList, snapshot)
+	}
+	return snapshotList, nil
+}
+
+// DeleteSnapshot deletes a snapshot's state from the persistent store
+func (p *EtcdClientV3) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	err := p.Delete(config.SnapshotURL + "/" + snapshot.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *EtcdClientV3) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	err := p.DeleteSnapshot(snapshot)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// DeleteSnapshots deletes all snapshots
+func (p *EtcdClientV3) DeleteSnapshots() error {
+	snapshots, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if err = p.Delete(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolumePublication saves a volume publication to the persistent store,
+// replacing any existing publication of the volume to the same node
+func (p *EtcdClientV3) AddVolumePublication(publication *storage.VolumePublication) error {
+	publicationJSON, err := json.Marshal(publication)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.PublicationURL+"/"+publication.ID(), string(publicationJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolumePublication retrieves a volume publication from the persistent store
+func (p *EtcdClientV3) GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error) {
+	publicationJSON, err := p.Read(config.PublicationURL + "/" + storage.MakeVolumePublicationID(volumeName, nodeName))
+	if err != nil {
+		return nil, err
+	}
+	publication := &storage.VolumePublication{}
+	err = json.Unmarshal([]byte(publicationJSON), publication)
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+// GetVolumePublications retrieves all volume publications
+func (p *EtcdClientV3) GetVolumePublications() ([]*storage.VolumePublication, error) {
+	publicationList := make([]*storage.VolumePublication, 0)
+	keys, err := p.ReadKeys(config.PublicationURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return publicationList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		publication := &storage.VolumePublication{}
+		publicationJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(publicationJSON), publication)
+		if err != nil {
+			return nil, err
+		}
+		publicationList = append(publicationList, publication)
+	}
+	return publicationList, nil
+}
+
+// DeleteVolumePublication deletes a volume publication from the persistent store
+func (p *EtcdClientV3) DeleteVolumePublication(publication *storage.VolumePublication) error {
+	err := p.Delete(config.PublicationURL + "/" + publication.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteVolumePublications deletes all volume publications
+func (p *EtcdClientV3) DeleteVolumePublications() error {
+	publications, err := p.ReadKeys(config.PublicationURL)
+	if err != nil {
+		return err
+	}
+	for _, publication := range publications {
+		if err = p.Delete(publication); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddEvent saves an event to the persistent store
+func (p *EtcdClientV3) AddEvent(event *storage.Event) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.EventURL+"/"+event.ID, string(eventJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetEvents retrieves all events
+func (p *EtcdClientV3) GetEvents() ([]*storage.Event, error) {
+	eventList := make([]*storage.Event, 0)
+	keys, err := p.ReadKeys(config.EventURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return eventList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		event := &storage.Event{}
+		eventJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(eventJSON), event)
+		if err != nil {
+			return nil, err
+		}
+		eventList = append(eventList, event)
+	}
+	return eventList, nil
+}
+
+// DeleteEvent deletes an event from the persistent store
+func (p *EtcdClientV3) DeleteEvent(event *storage.Event) error {
+	err := p.Delete(config.EventURL + "/" + event.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEvents deletes all events
+func (p *EtcdClientV3) DeleteEvents() error {
+	events, err := p.ReadKeys(config.EventURL)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err = p.Delete(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddOrUpdateQuota saves a quota to the persistent store
+func (p *EtcdClientV3) AddOrUpdateQuota(quota *storage.Quota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.QuotaURL+"/"+quota.Name, string(quotaJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetQuota retrieves a quota from the persistent store
+func (p *EtcdClientV3) GetQuota(quotaName string) (*storage.Quota, error) {
+	quotaJSON, err := p.Read(config.QuotaURL + "/" + quotaName)
+	if err != nil {
+		return nil, err
+	}
+	quota := &storage.Quota{}
+	err = json.Unmarshal([]byte(quotaJSON), quota)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *EtcdClientV3) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	keys, err := p.ReadKeys(config.QuotaURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return quotaList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		quota := &storage.Quota{}
+		quotaJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(quotaJSON), quota)
+		if err != nil {
+			return nil, err
+		}
+		quotaList = append(quotaList, quota)
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *EtcdClientV3) DeleteQuota(quota *storage.Quota) error {
+	err := p.Delete(config.QuotaURL + "/" + quota.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteQuotas deletes all quotas
+func (p *EtcdClientV3) DeleteQuotas() error {
+	quotas, err := p.ReadKeys(config.QuotaURL)
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		if err = p.Delete(quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddGroupSnapshot saves a group snapshot to the persistent store
+func (p *EtcdClientV3) AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	groupSnapshotJSON, err := json.Marshal(groupSnapshot)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.GroupSnapshotURL+"/"+groupSnapshot.Config.Name, string(groupSnapshotJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetGroupSnapshot retrieves a group snapshot from the persistent store
+func (p *EtcdClientV3) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error) {
+	groupSnapshotJSON, err := p.Read(config.GroupSnapshotURL + "/" + groupSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	groupSnapshot := &storage.GroupSnapshot{}
+	err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	return groupSnapshot, nil
+}
+
+// GetGroupSnapshots retrieves all group snapshots
+func (p *EtcdClientV3) GetGroupSnapshots() ([]*storage.GroupSnapshot, error) {
+	groupSnapshotList := make([]*storage.GroupSnapshot, 0)
+	keys, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return groupSnapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		groupSnapshot := &storage.GroupSnapshot{}
+		groupSnapshotJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		groupSnapshotList = append(groupSnapshotList, groupSnapshot)
+	}
+	return groupSnapshotList, nil
+}
+
+// DeleteGroupSnapshot deletes a group snapshot from the persistent store
+func (p *EtcdClientV3) DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	err := p.Delete(config.GroupSnapshotURL + "/" + groupSnapshot.Config.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteGroupSnapshots deletes all group snapshots
+func (p *EtcdClientV3) DeleteGroupSnapshots() error {
+	groupSnapshots, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, groupSnapshot := range groupSnapshots {
+		if err = p.Delete(groupSnapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
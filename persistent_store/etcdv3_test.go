@@ -208,6 +208,78 @@ func TestEtcdv3CRUDFailure(t *testing.T) {
 	}
 }
 
+func TestEtcdv3ConcurrentUpdate(t *testing.T) {
+	p1, err := NewEtcdClientV3(*etcdV3)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	p2, err := NewEtcdClientV3(*etcdV3)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer p1.Delete("concurrentKey")
+
+	if err = p1.Create("concurrentKey", "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err = p2.Read("concurrentKey"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err = p1.Update("concurrentKey", "val2"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// The second client's revision is stale, so its writes must fail
+	if err = p2.Update("concurrentKey", "val3"); !(err != nil && MatchKeyConflictErr(err)) {
+		t.Errorf("Failed to catch a conflicting update, got %v!", err)
+	}
+	if val, err := p2.Read("concurrentKey"); err != nil || val != "val2" {
+		t.Errorf("Expected val2 after a conflicting update, got %s (%v)!", val, err)
+	}
+
+	// Once the second client has read the key again, it may update it
+	if err = p2.Update("concurrentKey", "val3"); err != nil {
+		t.Error(err.Error())
+	}
+	if err = p1.Delete("concurrentKey"); !(err != nil && MatchKeyConflictErr(err)) {
+		t.Errorf("Failed to catch a conflicting delete, got %v!", err)
+	}
+}
+
+func TestEtcdv3LeaderWrites(t *testing.T) {
+	leader, err := NewEtcdClientV3(*etcdV3)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	other, err := NewEtcdClientV3(*etcdV3)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer other.Delete("leaderKey")
+
+	elector := leader.NewLeaderElector("leader", config.LeaderElectionTTL)
+	if err = elector.Campaign(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err = leader.Create("leaderKey", "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Once the leadership is gone, the former leader's writes must fail
+	if err = elector.Resign(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err = leader.Update("leaderKey", "val2"); !(err != nil && MatchNotLeaderErr(err)) {
+		t.Errorf("Failed to catch an update by a former leader, got %v!", err)
+	}
+	if err = leader.Create("leaderKey2", "val1"); !(err != nil && MatchNotLeaderErr(err)) {
+		t.Errorf("Failed to catch a create by a former leader, got %v!", err)
+	}
+	if val, err := other.Read("leaderKey"); err != nil || val != "val1" {
+		t.Errorf("Expected val1 after a former leader's update, got %s (%v)!", val, err)
+	}
+}
+
 func TestEtcdv3Backend(t *testing.T) {
 	p, err := NewEtcdClientV3(*etcdV3)
 
@@ -1069,3 +1141,70 @@ func unorderedNodeSlicesEqual(x, y []*utils.Node) bool {
 	}
 	return false
 }
+
+func TestEtcdv3Snapshot(t *testing.T) {
+	p, err := NewEtcdClientV3(*etcdV3)
+	if err != nil {
+		t.Fatalf("Creating a new etcd client failed: %v", err)
+	}
+
+	snapshot1 := storage.NewSnapshot(&storage.SnapshotConfig{
+		Version:            "1",
+		Name:               "snap1",
+		InternalName:       "snap1",
+		VolumeName:         "vol1",
+		VolumeInternalName: "trident_vol1",
+	}, "2019-03-22T14:11:39Z", 1000000000, storage.SnapshotStateOnline)
+	snapshot2 := storage.NewSnapshot(&storage.SnapshotConfig{
+		Version:            "1",
+		Name:               "snap2",
+		InternalName:       "snap2",
+		VolumeName:         "vol1",
+		VolumeInternalName: "trident_vol1",
+	}, "2019-03-22T14:12:39Z", 1000000000, storage.SnapshotStateOnline)
+
+	// Adding snapshots
+	for _, snapshot := range []*storage.Snapshot{snapshot1, snapshot2} {
+		if err = p.AddSnapshot(snapshot); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// Getting a snapshot
+	recoveredSnapshot, err := p.GetSnapshot("vol1", "snap1")
+	if err != nil {
+		t.Error(err.Error())
+	} else if !reflect.DeepEqual(recoveredSnapshot.Snapshot, *snapshot1) {
+		t.Errorf("Incorrect snapshot retrieved; expected %v, found %v", *snapshot1, recoveredSnapshot.Snapshot)
+	}
+
+	// Getting all snapshots
+	snapshots, err := p.GetSnapshots()
+	if err != nil {
+		t.Error(err.Error())
+	} else if len(snapshots) != 2 {
+		t.Errorf("Expected 2 snapshots, found %d", len(snapshots))
+	}
+
+	// Deleting a snapshot
+	if err = p.DeleteSnapshot(snapshot1); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err = p.GetSnapshot("vol1", "snap1"); err == nil || !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected a key not found error for a deleted snapshot, got %v", err)
+	}
+	if err = p.DeleteSnapshotIgnoreNotFound(snapshot1); err != nil {
+		t.Errorf("Deleting a missing snapshot should have been ignored: %v", err)
+	}
+
+	// Deleting all snapshots
+	if err = p.DeleteSnapshots(); err != nil {
+		t.Error(err.Error())
+	}
+	snapshots, err = p.GetSnapshots()
+	if err != nil {
+		t.Error(err.Error())
+	} else if len(snapshots) != 0 {
+		t.Errorf("Expected no snapshots, found %d", len(snapshots))
+	}
+}
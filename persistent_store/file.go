@@ -0,0 +1,1134 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	fileStoreOpSet    = "set"
+	fileStoreOpDelete = "delete"
+
+	// fileStoreCompactionThreshold is how many records may be appended to the
+	// store file before it is rewritten to hold only the current keys.
+	fileStoreCompactionThreshold = 1000
+)
+
+// fileStoreOp is a single change to one key.
+type fileStoreOp struct {
+	Op    string `json:"op"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// fileStoreRecord is one line of the store file.  All of a record's changes are
+// applied together or, if the record was only partly written, not at all.
+type fileStoreRecord struct {
+	Ops []fileStoreOp `json:"ops"`
+}
+
+// FileClient keeps Trident's state in a single local file, so that a standalone
+// Docker host has durable state without running etcd.  The file is a journal of
+// JSON records, one per line, each of which is synced to disk before the write
+// returns.  A record left incomplete by a crash is discarded when the file is
+// next opened.  The file is periodically rewritten, via a temporary file that
+// replaces it atomically, to hold only the current keys.
+type FileClient struct {
+	path    string
+	file    *os.File
+	offset  int64
+	records int
+	data    map[string]string
+	mutex   *sync.Mutex
+}
+
+// NewFileClient opens the store file at the given path, creating it if needed.
+// The file is locked so that only one Trident instance may use it at a time.
+func NewFileClient(path string) (*FileClient, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("could not create the directory for store file %s; %v", path, err)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open store file %s; %v", path, err)
+	}
+	if err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("store file %s is in use by another process; %v", path, err)
+	}
+
+	p := &FileClient{
+		path:  path,
+		file:  file,
+		data:  make(map[string]string),
+		mutex: &sync.Mutex{},
+	}
+	if err = p.load(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"path": path,
+		"keys": len(p.data),
+	}).Debug("Opened store file.")
+
+	return p, nil
+}
+
+// load replays the records in the store file.  Each record is synced before the
+// next is written, so only the last one can have been left incomplete by a crash;
+// it is truncated away.  An unreadable record anywhere else means the file is
+// corrupt, so it is reported rather than discarding the records that follow it.
+func (p *FileClient) load() error {
+	if _, err := p.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("could not read store file %s; %v", p.path, err)
+	}
+	reader := bufio.NewReader(p.file)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				log.WithField("path", p.path).Warning("Discarding an incomplete record from the store file.")
+			}
+			break
+		} else if err != nil {
+			return fmt.Errorf("could not read store file %s; %v", p.path, err)
+		}
+		record := fileStoreRecord{}
+		if err = json.Unmarshal(bytes.TrimSpace(line), &record); err != nil {
+			if _, peekErr := reader.Peek(1); peekErr != io.EOF {
+				return fmt.Errorf("store file %s is corrupt; the record at offset %d is unreadable: %v",
+					p.path, offset, err)
+			}
+			log.WithFields(log.Fields{
+				"path":   p.path,
+				"offset": offset,
+			}).Warning("Discarding an unreadable last record from the store file.")
+			break
+		}
+		p.apply(record.Ops)
+		offset += int64(len(line))
+		p.records++
+	}
+
+	if err := p.file.Truncate(offset); err != nil {
+		return fmt.Errorf("could not truncate store file %s; %v", p.path, err)
+	}
+	if _, err := p.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("could not seek in store file %s; %v", p.path, err)
+	}
+	p.offset = offset
+	return nil
+}
+
+func (p *FileClient) apply(ops []fileStoreOp) {
+	for _, op := range ops {
+		switch op.Op {
+		case fileStoreOpSet:
+			p.data[op.Key] = op.Value
+		case fileStoreOpDelete:
+			delete(p.data, op.Key)
+		}
+	}
+}
+
+// commit durably appends a record of changes to the store file and then applies
+// them to the cached state.  The caller must hold the mutex.
+func (p *FileClient) commit(ops ...fileStoreOp) error {
+	if p.file == nil {
+		return fmt.Errorf("store file %s is closed", p.path)
+	}
+	recordJSON, err := json.Marshal(fileStoreRecord{Ops: ops})
+	if err != nil {
+		return err
+	}
+	recordJSON = append(recordJSON, '\n')
+
+	if _, err = p.file.Write(recordJSON); err == nil {
+		err = p.file.Sync()
+	}
+	if err != nil {
+		// Remove any partial record so later records aren't appended after it
+		if truncateErr := p.file.Truncate(p.offset); truncateErr == nil {
+			p.file.Seek(p.offset, io.SeekStart)
+		}
+		return fmt.Errorf("could not write to store file %s; %v", p.path, err)
+	}
+	p.offset += int64(len(recordJSON))
+	p.records++
+	p.apply(ops)
+
+	if p.records > fileStoreCompactionThreshold+len(p.data) {
+		if err = p.compact(); err != nil {
+			// The record was saved, so the write itself succeeded
+			log.WithField("path", p.path).Errorf("Could not compact the store file; %v", err)
+		}
+	}
+	return nil
+}
+
+// compact rewrites the store file as a single record holding the current keys.
+// The new file is synced and then renamed over the old one, so a crash leaves
+// one or the other intact.  The caller must hold the mutex.
+func (p *FileClient) compact() error {
+	keys := make([]string, 0, len(p.data))
+	for key := range p.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ops := make([]fileStoreOp, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, fileStoreOp{Op: fileStoreOpSet, Key: key, Value: p.data[key]})
+	}
+	recordJSON, err := json.Marshal(fileStoreRecord{Ops: ops})
+	if err != nil {
+		return err
+	}
+	recordJSON = append(recordJSON, '\n')
+
+	tempPath := p.path + ".tmp"
+	tempFile, err := os.OpenFile(tempPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = tempFile.Write(recordJSON); err == nil {
+		err = tempFile.Sync()
+	}
+	if err == nil {
+		err = syscall.Flock(int(tempFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	}
+	if err == nil {
+		err = os.Rename(tempPath, p.path)
+	}
+	if err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(p.path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	p.file.Close()
+	p.file = tempFile
+	p.offset = int64(len(recordJSON))
+	p.records = 1
+
+	log.WithFields(log.Fields{
+		"path": p.path,
+		"keys": len(keys),
+	}).Debug("Compacted store file.")
+
+	return nil
+}
+
+// Create is the abstract CRUD interface
+func (p *FileClient) Create(key, value string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.data[key]; ok {
+		return NewPersistentStoreError(KeyExistsErr, key)
+	}
+	return p.commit(fileStoreOp{Op: fileStoreOpSet, Key: key, Value: value})
+}
+
+func (p *FileClient) Read(key string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	value, ok := p.data[key]
+	if !ok {
+		return "", NewPersistentStoreError(KeyNotFoundErr, key)
+	}
+	return value, nil
+}
+
+// ReadKeys returns all the keys with the designated prefix
+func (p *FileClient) ReadKeys(keyPrefix string) ([]string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	keys := p.keysWithPrefix(keyPrefix)
+	if len(keys) == 0 {
+		return keys, NewPersistentStoreError(KeyNotFoundErr, keyPrefix)
+	}
+	return keys, nil
+}
+
+// keysWithPrefix returns the sorted keys with the designated prefix.  The caller
+// must hold the mutex.
+func (p *FileClient) keysWithPrefix(keyPrefix string) []string {
+	keys := make([]string, 0)
+	for key := range p.data {
+		if strings.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *FileClient) Update(key, value string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.data[key]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, key)
+	}
+	return p.commit(fileStoreOp{Op: fileStoreOpSet, Key: key, Value: value})
+}
+
+func (p *FileClient) Set(key, value string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.commit(fileStoreOp{Op: fileStoreOpSet, Key: key, Value: value})
+}
+
+func (p *FileClient) Delete(key string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.data[key]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, key)
+	}
+	return p.commit(fileStoreOp{Op: fileStoreOpDelete, Key: key})
+}
+
+// DeleteKeys deletes all the keys with the designated prefix in one transaction
+func (p *FileClient) DeleteKeys(keyPrefix string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	keys := p.keysWithPrefix(keyPrefix)
+	if len(keys) == 0 {
+		return NewPersistentStoreError(KeyNotFoundErr, keyPrefix)
+	}
+	ops := make([]fileStoreOp, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, fileStoreOp{Op: fileStoreOpDelete, Key: key})
+	}
+	return p.commit(ops...)
+}
+
+// GetType returns the persistent store type
+func (p *FileClient) GetType() StoreType {
+	return FileStore
+}
+
+// Stop closes the store file
+func (p *FileClient) Stop() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.file == nil {
+		return nil
+	}
+	err := p.file.Close()
+	p.file = nil
+	return err
+}
+
+// GetConfig returns the configuration for the file client
+func (p *FileClient) GetConfig() *ClientConfig {
+	return &ClientConfig{}
+}
+
+// GetVersion returns the version of the persistent data
+func (p *FileClient) GetVersion() (*PersistentStateVersion, error) {
+	versionJSON, err := p.Read(config.StoreURL)
+	if err != nil {
+		return nil, err
+	}
+	version := &PersistentStateVersion{}
+	err = json.Unmarshal([]byte(versionJSON), version)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// SetVersion sets the version of the persistent data
+func (p *FileClient) SetVersion(version *PersistentStateVersion) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+	return p.Set(config.StoreURL, string(versionJSON))
+}
+
+// AddBackend saves the minimally required backend state to the persistent store
+func (p *FileClient) AddBackend(b *storage.Backend) error {
+	backend := b.ConstructPersistent()
+	backendJSON, err := json.Marshal(backend)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.BackendURL+"/"+backend.Name, string(backendJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetBackend retrieves a backend from the persistent store
+func (p *FileClient) GetBackend(backendName string) (*storage.BackendPersistent, error) {
+	var backend storage.BackendPersistent
+	backendJSON, err := p.Read(config.BackendURL + "/" + backendName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(backendJSON), &backend)
+	if err != nil {
+		return nil, err
+	}
+	return &backend, nil
+}
+
+// UpdateBackend updates the backend state on the persistent store
+func (p *FileClient) UpdateBackend(b *storage.Backend) error {
+	backend := b.ConstructPersistent()
+	backendJSON, err := json.Marshal(backend)
+	if err != nil {
+		return err
+	}
+	err = p.Update(config.BackendURL+"/"+backend.Name, string(backendJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteBackend deletes the backend state on the persistent store
+func (p *FileClient) DeleteBackend(backend *storage.Backend) error {
+	err := p.Delete(config.BackendURL + "/" + backend.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceBackendAndUpdateVolumes renames a backend and updates all volumes to
+// reflect the new backend name
+func (p *FileClient) ReplaceBackendAndUpdateVolumes(
+	origBackend, newBackend *storage.Backend) error {
+	backendJSON, err := json.Marshal(newBackend.ConstructPersistent())
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// All changes are saved as one record, so they happen atomically.
+	ops := []fileStoreOp{{Op: fileStoreOpSet, Key: config.BackendURL + "/" + newBackend.Name, Value: string(backendJSON)}}
+	for _, key := range p.keysWithPrefix(config.VolumeURL + "/") {
+		volExternal := &storage.VolumeExternal{}
+		if err = json.Unmarshal([]byte(p.data[key]), volExternal); err != nil {
+			return err
+		}
+		if volExternal.Backend != origBackend.Name {
+			continue
+		}
+		vol := storage.NewVolume(volExternal.Config,
+			newBackend.Name, volExternal.Pool, volExternal.Orphaned)
+		volJSON, err := json.Marshal(vol.ConstructExternal())
+		if err != nil {
+			return err
+		}
+		ops = append(ops, fileStoreOp{Op: fileStoreOpSet, Key: key, Value: string(volJSON)})
+	}
+	ops = append(ops, fileStoreOp{Op: fileStoreOpDelete, Key: config.BackendURL + "/" + origBackend.Name})
+	return p.commit(ops...)
+}
+
+// GetBackends retrieves all backends
+func (p *FileClient) GetBackends() ([]*storage.BackendPersistent, error) {
+	backendList := make([]*storage.BackendPersistent, 0)
+	keys, err := p.ReadKeys(config.BackendURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return backendList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		backend, err := p.GetBackend(strings.TrimPrefix(key, config.BackendURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		backendList = append(backendList, backend)
+	}
+	return backendList, nil
+}
+
+// DeleteBackends deletes all backends
+func (p *FileClient) DeleteBackends() error {
+	backends, err := p.ReadKeys(config.BackendURL)
+	if err != nil {
+		return err
+	}
+	for _, backend := range backends {
+		if err = p.Delete(backend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolume saves a volume's state to the persistent store
+func (p *FileClient) AddVolume(vol *storage.Volume) error {
+	volExternal := vol.ConstructExternal()
+	volJSON, err := json.Marshal(volExternal)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.VolumeURL+"/"+vol.Config.Name, string(volJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolume retrieves a volume's state from the persistent store
+func (p *FileClient) GetVolume(volName string) (*storage.VolumeExternal, error) {
+	volJSON, err := p.Read(config.VolumeURL + "/" + volName)
+	if err != nil {
+		return nil, err
+	}
+	volExternal := &storage.VolumeExternal{}
+	err = json.Unmarshal([]byte(volJSON), volExternal)
+	if err != nil {
+		return nil, err
+	}
+	return volExternal, nil
+}
+
+// UpdateVolume updates a volume's state on the persistent store
+func (p *FileClient) UpdateVolume(vol *storage.Volume) error {
+	volExternal := vol.ConstructExternal()
+	volJSON, err := json.Marshal(volExternal)
+	if err != nil {
+		return err
+	}
+	err = p.Update(config.VolumeURL+"/"+vol.Config.Name, string(volJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteVolume deletes a volume's state from the persistent store
+func (p *FileClient) DeleteVolume(vol *storage.Volume) error {
+	err := p.Delete(config.VolumeURL + "/" + vol.Config.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *FileClient) DeleteVolumeIgnoreNotFound(vol *storage.Volume) error {
+	err := p.DeleteVolume(vol)
+	if MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// GetVolumes retrieves all volumes
+func (p *FileClient) GetVolumes() ([]*storage.VolumeExternal, error) {
+	volumeList := make([]*storage.VolumeExternal, 0)
+	keys, err := p.ReadKeys(config.VolumeURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return volumeList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		vol, err := p.GetVolume(strings.TrimPrefix(key, config.VolumeURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		volumeList = append(volumeList, vol)
+	}
+	return volumeList, nil
+}
+
+// DeleteVolumes deletes all volumes
+func (p *FileClient) DeleteVolumes() error {
+	volumes, err := p.ReadKeys(config.VolumeURL)
+	if err != nil {
+		return err
+	}
+	for _, vol := range volumes {
+		if err = p.Delete(vol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolumeTransaction logs an AddVolume operation
+func (p *FileClient) AddVolumeTransaction(volTxn *VolumeTransaction) error {
+	volTxnJSON, err := json.Marshal(volTxn)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.TransactionURL+"/"+volTxn.getKey(),
+		string(volTxnJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolumeTransactions retrieves AddVolume logs
+func (p *FileClient) GetVolumeTransactions() ([]*VolumeTransaction, error) {
+	volTxnList := make([]*VolumeTransaction, 0)
+	keys, err := p.ReadKeys(config.TransactionURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return volTxnList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		volTxn := &VolumeTransaction{}
+		volTxnJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(volTxnJSON), volTxn)
+		if err != nil {
+			return nil, err
+		}
+		volTxnList = append(volTxnList, volTxn)
+	}
+	return volTxnList, nil
+}
+
+// GetExistingVolumeTransaction returns an existing version of the current
+// volume transaction, if it exists.  If no volume transaction with the same
+// key exists, it returns nil.
+func (p *FileClient) GetExistingVolumeTransaction(
+	volTxn *VolumeTransaction,
+) (*VolumeTransaction, error) {
+	var ret VolumeTransaction
+
+	key := volTxn.getKey()
+	txnJSON, err := p.Read(config.TransactionURL + "/" + key)
+	if err != nil {
+		if !MatchKeyNotFoundErr(err) {
+			return nil, fmt.Errorf("unable to read volume transaction key %s from the store file: %v", key, err)
+		} else {
+			return nil, nil
+		}
+	}
+	if err = json.Unmarshal([]byte(txnJSON), &ret); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal volume transaction JSON for %s: %v", key, err)
+	}
+	return &ret, nil
+}
+
+// DeleteVolumeTransaction deletes an AddVolume log
+func (p *FileClient) DeleteVolumeTransaction(volTxn *VolumeTransaction) error {
+	err := p.Delete(config.TransactionURL + "/" + volTxn.getKey())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *FileClient) AddStorageClass(sc *storageclass.StorageClass) error {
+	sClass := sc.ConstructPersistent()
+	storageClassJSON, err := json.Marshal(sClass)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.StorageClassURL+"/"+sClass.GetName(),
+		string(storageClassJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *FileClient) GetStorageClass(scName string) (*storageclass.Persistent, error) {
+	var sc storageclass.Persistent
+	scJSON, err := p.Read(config.StorageClassURL + "/" + scName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(scJSON), &sc)
+	if err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (p *FileClient) GetStorageClasses() ([]*storageclass.Persistent, error) {
+	storageClassList := make([]*storageclass.Persistent, 0)
+	keys, err := p.ReadKeys(config.StorageClassURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return storageClassList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		sc, err := p.GetStorageClass(strings.TrimPrefix(key,
+			config.StorageClassURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		storageClassList = append(storageClassList, sc)
+	}
+	return storageClassList, nil
+}
+
+// DeleteStorageClass deletes a storage class's state from the persistent store
+func (p *FileClient) DeleteStorageClass(sc *storageclass.StorageClass) error {
+	err := p.Delete(config.StorageClassURL + "/" + sc.GetName())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddOrUpdateNode adds a CSI node object to the persistent store
+func (p *FileClient) AddOrUpdateNode(n *utils.Node) error {
+	nodeJSON, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.NodeURL+"/"+n.Name, string(nodeJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *FileClient) GetNode(nName string) (*utils.Node, error) {
+	var node utils.Node
+	nodeJSON, err := p.Read(config.NodeURL + "/" + nName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(nodeJSON), &node)
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func (p *FileClient) GetNodes() ([]*utils.Node, error) {
+	nodeList := make([]*utils.Node, 0)
+	keys, err := p.ReadKeys(config.NodeURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nodeList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		node, err := p.GetNode(strings.TrimPrefix(key, config.NodeURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		nodeList = append(nodeList, node)
+	}
+	return nodeList, nil
+}
+
+// DeleteNode deletes a node from the persistent store
+func (p *FileClient) DeleteNode(n *utils.Node) error {
+	err := p.Delete(config.NodeURL + "/" + n.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddSnapshot saves a snapshot's state to the persistent store
+func (p *FileClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	snapPersistent := snapshot.ConstructPersistent()
+	snapJSON, err := json.Marshal(snapPersistent)
+	if err != nil {
+		return err
+	}
+	err = p.Create(config.SnapshotURL+"/"+snapshot.ID(), string(snapJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSnapshot retrieves a snapshot's state from the persistent store
+func (p *FileClient) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	snapJSON, err := p.Read(config.SnapshotURL + "/" + storage.MakeSnapshotID(volumeName, snapshotName))
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &storage.SnapshotPersistent{}
+	err = json.Unmarshal([]byte(snapJSON), snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots retrieves all snapshots
+func (p *FileClient) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	snapshotList := make([]*storage.SnapshotPersistent, 0)
+	keys, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return snapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		snapshot := &storage.SnapshotPersistent{}
+		snapJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(snapJSON), snapshot)
+		if err != nil {
+			return nil, err
+		}
+		snapshotList = append(snapshotList, snapshot)
+	}
+	return snapshotList, nil
+}
+
+// DeleteSnapshot deletes a snapshot's state from the persistent store
+func (p *FileClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	err := p.Delete(config.SnapshotURL + "/" + snapshot.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *FileClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	err := p.DeleteSnapshot(snapshot)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// DeleteSnapshots deletes all snapshots
+func (p *FileClient) DeleteSnapshots() error {
+	snapshots, err := p.ReadKeys(config.SnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if err = p.Delete(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddVolumePublication saves a volume publication to the persistent store,
+// replacing any existing publication of the volume to the same node
+func (p *FileClient) AddVolumePublication(publication *storage.VolumePublication) error {
+	publicationJSON, err := json.Marshal(publication)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.PublicationURL+"/"+publication.ID(), string(publicationJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVolumePublication retrieves a volume publication from the persistent store
+func (p *FileClient) GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error) {
+	publicationJSON, err := p.Read(config.PublicationURL + "/" + storage.MakeVolumePublicationID(volumeName, nodeName))
+	if err != nil {
+		return nil, err
+	}
+	publication := &storage.VolumePublication{}
+	err = json.Unmarshal([]byte(publicationJSON), publication)
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+// GetVolumePublications retrieves all volume publications
+func (p *FileClient) GetVolumePublications() ([]*storage.VolumePublication, error) {
+	publicationList := make([]*storage.VolumePublication, 0)
+	keys, err := p.ReadKeys(config.PublicationURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return publicationList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		publication := &storage.VolumePublication{}
+		publicationJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(publicationJSON), publication)
+		if err != nil {
+			return nil, err
+		}
+		publicationList = append(publicationList, publication)
+	}
+	return publicationList, nil
+}
+
+// DeleteVolumePublication deletes a volume publication from the persistent store
+func (p *FileClient) DeleteVolumePublication(publication *storage.VolumePublication) error {
+	err := p.Delete(config.PublicationURL + "/" + publication.ID())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteVolumePublications deletes all volume publications
+func (p *FileClient) DeleteVolumePublications() error {
+	publications, err := p.ReadKeys(config.PublicationURL)
+	if err != nil {
+		return err
+	}
+	for _, publication := range publications {
+		if err = p.Delete(publication); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddEvent saves an event to the persistent store
+func (p *FileClient) AddEvent(event *storage.Event) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.EventURL+"/"+event.ID, string(eventJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetEvents retrieves all events
+func (p *FileClient) GetEvents() ([]*storage.Event, error) {
+	eventList := make([]*storage.Event, 0)
+	keys, err := p.ReadKeys(config.EventURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return eventList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		event := &storage.Event{}
+		eventJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(eventJSON), event)
+		if err != nil {
+			return nil, err
+		}
+		eventList = append(eventList, event)
+	}
+	return eventList, nil
+}
+
+// DeleteEvent deletes an event from the persistent store
+func (p *FileClient) DeleteEvent(event *storage.Event) error {
+	err := p.Delete(config.EventURL + "/" + event.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEvents deletes all events
+func (p *FileClient) DeleteEvents() error {
+	events, err := p.ReadKeys(config.EventURL)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err = p.Delete(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddOrUpdateQuota saves a quota to the persistent store
+func (p *FileClient) AddOrUpdateQuota(quota *storage.Quota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.QuotaURL+"/"+quota.Name, string(quotaJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetQuota retrieves a quota from the persistent store
+func (p *FileClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	quotaJSON, err := p.Read(config.QuotaURL + "/" + quotaName)
+	if err != nil {
+		return nil, err
+	}
+	quota := &storage.Quota{}
+	err = json.Unmarshal([]byte(quotaJSON), quota)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *FileClient) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	keys, err := p.ReadKeys(config.QuotaURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return quotaList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		quota := &storage.Quota{}
+		quotaJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(quotaJSON), quota)
+		if err != nil {
+			return nil, err
+		}
+		quotaList = append(quotaList, quota)
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *FileClient) DeleteQuota(quota *storage.Quota) error {
+	err := p.Delete(config.QuotaURL + "/" + quota.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteQuotas deletes all quotas
+func (p *FileClient) DeleteQuotas() error {
+	quotas, err := p.ReadKeys(config.QuotaURL)
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		if err = p.Delete(quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddGroupSnapshot saves a group snapshot to the persistent store
+func (p *FileClient) AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	groupSnapshotJSON, err := json.Marshal(groupSnapshot)
+	if err != nil {
+		return err
+	}
+	err = p.Set(config.GroupSnapshotURL+"/"+groupSnapshot.Config.Name, string(groupSnapshotJSON))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetGroupSnapshot retrieves a group snapshot from the persistent store
+func (p *FileClient) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error) {
+	groupSnapshotJSON, err := p.Read(config.GroupSnapshotURL + "/" + groupSnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	groupSnapshot := &storage.GroupSnapshot{}
+	err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	return groupSnapshot, nil
+}
+
+// GetGroupSnapshots retrieves all group snapshots
+func (p *FileClient) GetGroupSnapshots() ([]*storage.GroupSnapshot, error) {
+	groupSnapshotList := make([]*storage.GroupSnapshot, 0)
+	keys, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return groupSnapshotList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		groupSnapshot := &storage.GroupSnapshot{}
+		groupSnapshotJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(groupSnapshotJSON), groupSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		groupSnapshotList = append(groupSnapshotList, groupSnapshot)
+	}
+	return groupSnapshotList, nil
+}
+
+// DeleteGroupSnapshot deletes a group snapshot from the persistent store
+func (p *FileClient) DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	err := p.Delete(config.GroupSnapshotURL + "/" + groupSnapshot.Config.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteGroupSnapshots deletes all group snapshots
+func (p *FileClient) DeleteGroupSnapshots() error {
+	groupSnapshots, err := p.ReadKeys(config.GroupSnapshotURL)
+	if err != nil {
+		return err
+	}
+	for _, groupSnapshot := range groupSnapshots {
+		if err = p.Delete(groupSnapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap"
+)
+
+func newTestFileClient(t *testing.T) (*FileClient, string) {
+	dir, err := ioutil.TempDir("", "trident-store")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	path := filepath.Join(dir, "trident.db")
+	p, err := NewFileClient(path)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err.Error())
+	}
+	return p, path
+}
+
+func TestFileCRUD(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer p.Stop()
+
+	if err := p.Create("key1", "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := p.Create("key1", "val1"); err == nil || err.Error() != KeyExistsErr {
+		t.Errorf("Expected %s, got %v", KeyExistsErr, err)
+	}
+	if val, err := p.Read("key1"); err != nil || val != "val1" {
+		t.Errorf("Read failed; val: %s, err: %v", val, err)
+	}
+	if err := p.Update("key1", "val2"); err != nil {
+		t.Error(err.Error())
+	}
+	if val, err := p.Read("key1"); err != nil || val != "val2" {
+		t.Errorf("Update failed; val: %s, err: %v", val, err)
+	}
+	if err := p.Update("key2", "val2"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	if err := p.Delete("key1"); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := p.Read("key1"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	if err := p.Delete("key1"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+}
+
+func TestFileReadDeleteKeys(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer p.Stop()
+
+	for i := 1; i <= 5; i++ {
+		if err := p.Set(fmt.Sprintf("/volume/vol%d", i), fmt.Sprintf("val%d", i)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if err := p.Set("/backend/backend1", "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	keys, err := p.ReadKeys("/volume")
+	if err != nil || len(keys) != 5 {
+		t.Fatalf("Reading all the keys failed; keys: %v, err: %v", keys, err)
+	}
+	for i := 1; i <= 5; i++ {
+		if keys[i-1] != fmt.Sprintf("/volume/vol%d", i) {
+			t.Errorf("Unexpected key %s", keys[i-1])
+		}
+	}
+	if err = p.DeleteKeys("/volume"); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err = p.ReadKeys("/volume"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Expected %s, got %v", KeyNotFoundErr, err)
+	}
+	if _, err = p.Read("/backend/backend1"); err != nil {
+		t.Errorf("Key outside the prefix was deleted: %v", err)
+	}
+}
+
+func TestFilePersistence(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	for i := 1; i <= 3; i++ {
+		if err := p.Set(fmt.Sprintf("key%d", i), fmt.Sprintf("val%d", i)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if err := p.Delete("key2"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// A second client can't use the file while it is open
+	if _, err := NewFileClient(path); err == nil {
+		t.Error("Expected the store file to be locked")
+	}
+	p.Stop()
+
+	p, err := NewFileClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer p.Stop()
+	if val, err := p.Read("key1"); err != nil || val != "val1" {
+		t.Errorf("Reopened store lost key1; val: %s, err: %v", val, err)
+	}
+	if _, err := p.Read("key2"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Reopened store restored deleted key2: %v", err)
+	}
+	if val, err := p.Read("key3"); err != nil || val != "val3" {
+		t.Errorf("Reopened store lost key3; val: %s, err: %v", val, err)
+	}
+}
+
+func TestFileIncompleteRecord(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	if err := p.Set("key1", "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+	p.Stop()
+
+	// Simulate a crash part way through writing a record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	file.WriteString(`{"ops":[{"op":"set","key":"key2","val`)
+	file.Close()
+
+	p, err = NewFileClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if val, err := p.Read("key1"); err != nil || val != "val1" {
+		t.Errorf("Complete record was lost; val: %s, err: %v", val, err)
+	}
+	if _, err := p.Read("key2"); !MatchKeyNotFoundErr(err) {
+		t.Errorf("Incomplete record was applied: %v", err)
+	}
+
+	// Records written after recovery must be readable
+	if err := p.Set("key3", "val3"); err != nil {
+		t.Fatal(err.Error())
+	}
+	p.Stop()
+	p, err = NewFileClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer p.Stop()
+	if val, err := p.Read("key3"); err != nil || val != "val3" {
+		t.Errorf("Record written after recovery was lost; val: %s, err: %v", val, err)
+	}
+}
+
+func TestFileCorruptRecord(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	if err := p.Set("key1", "val1"); err != nil {
+		t.Fatal(err.Error())
+	}
+	p.Stop()
+
+	// An unreadable last record is the remains of an interrupted write
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	file.WriteString("{\"ops\":[{\"op\":\"set\",\"key\":\"key2\"\n")
+	file.Close()
+
+	if p, err = NewFileClient(path); err != nil {
+		t.Fatal(err.Error())
+	}
+	if val, err := p.Read("key1"); err != nil || val != "val1" {
+		t.Errorf("Complete record was lost; val: %s, err: %v", val, err)
+	}
+	if err = p.Set("key3", "val3"); err != nil {
+		t.Fatal(err.Error())
+	}
+	p.Stop()
+
+	// An unreadable record followed by others means the file is corrupt, and the
+	// records after it must not be discarded
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	corrupt := append([]byte("garbage\n"), data...)
+	if err = ioutil.WriteFile(path, corrupt, 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err = NewFileClient(path); err == nil {
+		t.Error("Expected a corrupt store file to be rejected.")
+	}
+	if data, err = ioutil.ReadFile(path); err != nil || len(data) != len(corrupt) {
+		t.Errorf("Expected a corrupt store file to be left as it was; %v", err)
+	}
+}
+
+func TestFileCompaction(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	for i := 0; i <= fileStoreCompactionThreshold+10; i++ {
+		if err := p.Set("key1", fmt.Sprintf("val%d", i)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if p.records > fileStoreCompactionThreshold {
+		t.Errorf("Store file was not compacted; %d records", p.records)
+	}
+	p.Stop()
+
+	p, err := NewFileClient(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer p.Stop()
+	expected := fmt.Sprintf("val%d", fileStoreCompactionThreshold+10)
+	if val, err := p.Read("key1"); err != nil || val != expected {
+		t.Errorf("Compaction lost the latest value; val: %s, err: %v", val, err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Temporary compaction file was left behind")
+	}
+}
+
+func TestFileReplaceBackendAndUpdateVolumes(t *testing.T) {
+	p, path := newTestFileClient(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer p.Stop()
+
+	NFSDriver := ontap.NASStorageDriver{
+		Config: drivers.OntapStorageDriverConfig{
+			CommonStorageDriverConfig: &drivers.CommonStorageDriverConfig{
+				StorageDriverName: drivers.OntapNASStorageDriverName,
+			},
+			ManagementLIF: "10.0.0.4",
+			DataLIF:       "10.0.0.100",
+			SVM:           "svm1",
+			Username:      "admin",
+			Password:      "netapp",
+		},
+	}
+	NFSServer := &storage.Backend{
+		Driver: &NFSDriver,
+		Name:   "ontapnas_10.0.0.100",
+	}
+	if err := p.AddBackend(NFSServer); err != nil {
+		t.Fatalf("Backend creation failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		vol := &storage.Volume{
+			Config: &storage.VolumeConfig{
+				Version:      config.OrchestratorAPIVersion,
+				Name:         fmt.Sprintf("vol%d", i),
+				Size:         "1GB",
+				Protocol:     config.File,
+				StorageClass: "gold",
+			},
+			Backend: NFSServer.Name,
+			Pool:    storagePool,
+		}
+		if err := p.AddVolume(vol); err != nil {
+			t.Fatalf("Volume creation failed: %v", err)
+		}
+	}
+
+	newNFSServer := &storage.Backend{
+		Driver: &NFSDriver,
+		Name:   "AFF",
+	}
+	records := p.records
+	if err := p.ReplaceBackendAndUpdateVolumes(NFSServer, newNFSServer); err != nil {
+		t.Fatalf("ReplaceBackendAndUpdateVolumes failed: %v", err)
+	}
+	if p.records != records+1 {
+		t.Errorf("Backend rename was not saved as a single record")
+	}
+
+	backends, err := p.GetBackends()
+	if err != nil || len(backends) != 1 || backends[0].Name != newNFSServer.Name {
+		t.Fatalf("Backend retrieval failed; backends: %v, err: %v", backends, err)
+	}
+	volumes, err := p.GetVolumes()
+	if err != nil || len(volumes) != 5 {
+		t.Fatalf("Volume retrieval failed; volumes: %v, err: %v", volumes, err)
+	}
+	for _, volume := range volumes {
+		if volume.Backend != newNFSServer.Name {
+			t.Errorf("Volume %s was not moved to backend %s", volume.Config.Name, newNFSServer.Name)
+		}
+	}
+}
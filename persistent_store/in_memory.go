@@ -4,6 +4,7 @@ package persistentstore
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/storage"
@@ -12,6 +13,7 @@ import (
 )
 
 type InMemoryClient struct {
+	mutex               *sync.Mutex
 	backends            map[string]*storage.BackendPersistent
 	backendsAdded       int
 	volumes             map[string]*storage.VolumeExternal
@@ -23,15 +25,31 @@ type InMemoryClient struct {
 	version             *PersistentStateVersion
 	nodes               map[string]*utils.Node
 	nodesAdded          int
+	snapshots           map[string]*storage.SnapshotPersistent
+	snapshotsAdded      int
+	publications        map[string]*storage.VolumePublication
+	publicationsAdded   int
+	events              map[string]*storage.Event
+	eventsAdded         int
+	quotas              map[string]*storage.Quota
+	quotasAdded         int
+	groupSnapshots      map[string]*storage.GroupSnapshot
+	groupSnapshotsAdded int
 }
 
 func NewInMemoryClient() *InMemoryClient {
 	return &InMemoryClient{
+		mutex:          &sync.Mutex{},
 		backends:       make(map[string]*storage.BackendPersistent),
 		volumes:        make(map[string]*storage.VolumeExternal),
 		storageClasses: make(map[string]*sc.Persistent),
 		volumeTxns:     make(map[string]*VolumeTransaction),
 		nodes:          make(map[string]*utils.Node),
+		snapshots:      make(map[string]*storage.SnapshotPersistent),
+		publications:   make(map[string]*storage.VolumePublication),
+		events:         make(map[string]*storage.Event),
+		quotas:         make(map[string]*storage.Quota),
+		groupSnapshots: make(map[string]*storage.GroupSnapshot),
 		version: &PersistentStateVersion{
 			"memory", config.OrchestratorAPIVersion,
 		},
@@ -43,11 +61,19 @@ func (c *InMemoryClient) GetType() StoreType {
 }
 
 func (c *InMemoryClient) Stop() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	c.backendsAdded = 0
 	c.volumesAdded = 0
 	c.storageClassesAdded = 0
 	c.volumeTxnsAdded = 0
 	c.nodesAdded = 0
+	c.snapshotsAdded = 0
+	c.publicationsAdded = 0
+	c.eventsAdded = 0
+	c.quotasAdded = 0
+	c.groupSnapshotsAdded = 0
 	return nil
 }
 
@@ -56,6 +82,9 @@ func (c *InMemoryClient) GetConfig() *ClientConfig {
 }
 
 func (c *InMemoryClient) GetVersion() (*PersistentStateVersion, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	return c.version, nil
 }
 
@@ -64,6 +93,9 @@ func (c *InMemoryClient) SetVersion(version *PersistentStateVersion) error {
 }
 
 func (c *InMemoryClient) AddBackend(b *storage.Backend) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	backend := b.ConstructPersistent()
 	if _, ok := c.backends[backend.Name]; ok {
 		return fmt.Errorf("backend %s already exists", backend.Name)
@@ -74,6 +106,9 @@ func (c *InMemoryClient) AddBackend(b *storage.Backend) error {
 }
 
 func (c *InMemoryClient) GetBackend(backendName string) (*storage.BackendPersistent, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret, ok := c.backends[backendName]
 	if !ok {
 		return nil, NewPersistentStoreError(KeyNotFoundErr, backendName)
@@ -82,6 +117,9 @@ func (c *InMemoryClient) GetBackend(backendName string) (*storage.BackendPersist
 }
 
 func (c *InMemoryClient) UpdateBackend(b *storage.Backend) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	// UpdateBackend requires the backend to already exist.
 	if _, ok := c.backends[b.Name]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, b.Name)
@@ -91,6 +129,9 @@ func (c *InMemoryClient) UpdateBackend(b *storage.Backend) error {
 }
 
 func (c *InMemoryClient) DeleteBackend(b *storage.Backend) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if _, ok := c.backends[b.Name]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, b.Name)
 	}
@@ -107,6 +148,9 @@ func (c *InMemoryClient) ReplaceBackendAndUpdateVolumes(
 }
 
 func (c *InMemoryClient) GetBackends() ([]*storage.BackendPersistent, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	backendList := make([]*storage.BackendPersistent, 0)
 	if c.backendsAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
@@ -119,6 +163,9 @@ func (c *InMemoryClient) GetBackends() ([]*storage.BackendPersistent, error) {
 }
 
 func (c *InMemoryClient) DeleteBackends() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.backendsAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
 		return NewPersistentStoreError(KeyNotFoundErr, "Backends")
@@ -128,6 +175,9 @@ func (c *InMemoryClient) DeleteBackends() error {
 }
 
 func (c *InMemoryClient) AddVolume(vol *storage.Volume) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	volume := vol.ConstructExternal()
 	if _, ok := c.volumes[volume.Config.Name]; ok {
 		return fmt.Errorf("volume %s already exists", volume.Config.Name)
@@ -140,6 +190,9 @@ func (c *InMemoryClient) AddVolume(vol *storage.Volume) error {
 func (c *InMemoryClient) GetVolume(volumeName string) (
 	*storage.VolumeExternal, error,
 ) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret, ok := c.volumes[volumeName]
 	if !ok {
 		return nil, NewPersistentStoreError(KeyNotFoundErr, volumeName)
@@ -148,6 +201,9 @@ func (c *InMemoryClient) GetVolume(volumeName string) (
 }
 
 func (c *InMemoryClient) UpdateVolume(vol *storage.Volume) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	// UpdateVolume requires the volume to already exist.
 	if _, ok := c.volumes[vol.Config.Name]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, vol.Config.Name)
@@ -157,6 +213,9 @@ func (c *InMemoryClient) UpdateVolume(vol *storage.Volume) error {
 }
 
 func (c *InMemoryClient) DeleteVolume(vol *storage.Volume) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if _, ok := c.volumes[vol.Config.Name]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, vol.Config.Name)
 	}
@@ -165,11 +224,17 @@ func (c *InMemoryClient) DeleteVolume(vol *storage.Volume) error {
 }
 
 func (c *InMemoryClient) DeleteVolumeIgnoreNotFound(vol *storage.Volume) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	delete(c.volumes, vol.Config.Name)
 	return nil
 }
 
 func (c *InMemoryClient) GetVolumes() ([]*storage.VolumeExternal, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret := make([]*storage.VolumeExternal, 0, len(c.volumes))
 	if c.volumesAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
@@ -182,6 +247,9 @@ func (c *InMemoryClient) GetVolumes() ([]*storage.VolumeExternal, error) {
 }
 
 func (c *InMemoryClient) DeleteVolumes() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.volumesAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
 		return NewPersistentStoreError(KeyNotFoundErr, "Volumes")
@@ -191,6 +259,9 @@ func (c *InMemoryClient) DeleteVolumes() error {
 }
 
 func (c *InMemoryClient) AddVolumeTransaction(volTxn *VolumeTransaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	// AddVolumeTransaction overwrites existing keys, unlike the other methods
 	c.volumeTxns[volTxn.getKey()] = volTxn
 	c.volumeTxnsAdded++
@@ -198,6 +269,9 @@ func (c *InMemoryClient) AddVolumeTransaction(volTxn *VolumeTransaction) error {
 }
 
 func (c *InMemoryClient) GetVolumeTransactions() ([]*VolumeTransaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.volumeTxnsAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
 		return nil, NewPersistentStoreError(KeyNotFoundErr, "VolumesTransactions")
@@ -212,6 +286,9 @@ func (c *InMemoryClient) GetVolumeTransactions() ([]*VolumeTransaction, error) {
 func (c *InMemoryClient) GetExistingVolumeTransaction(
 	volTxn *VolumeTransaction) (*VolumeTransaction, error,
 ) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	vt, ok := c.volumeTxns[volTxn.getKey()]
 	if !ok {
 		return nil, nil
@@ -220,6 +297,9 @@ func (c *InMemoryClient) GetExistingVolumeTransaction(
 }
 
 func (c *InMemoryClient) DeleteVolumeTransaction(volTxn *VolumeTransaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if _, ok := c.volumeTxns[volTxn.getKey()]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, "VolumesTransactions")
 	}
@@ -228,6 +308,9 @@ func (c *InMemoryClient) DeleteVolumeTransaction(volTxn *VolumeTransaction) erro
 }
 
 func (c *InMemoryClient) AddStorageClass(s *sc.StorageClass) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	storageClass := s.ConstructPersistent()
 	if _, ok := c.storageClasses[storageClass.GetName()]; ok {
 		return fmt.Errorf("storage class %s already exists", storageClass.GetName())
@@ -240,6 +323,9 @@ func (c *InMemoryClient) AddStorageClass(s *sc.StorageClass) error {
 func (c *InMemoryClient) GetStorageClass(scName string) (
 	*sc.Persistent, error,
 ) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret, ok := c.storageClasses[scName]
 	if !ok {
 		return nil, NewPersistentStoreError(KeyNotFoundErr, scName)
@@ -250,6 +336,9 @@ func (c *InMemoryClient) GetStorageClass(scName string) (
 func (c *InMemoryClient) GetStorageClasses() (
 	[]*sc.Persistent, error,
 ) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret := make([]*sc.Persistent, 0, len(c.storageClasses))
 	if c.storageClassesAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
@@ -262,6 +351,9 @@ func (c *InMemoryClient) GetStorageClasses() (
 }
 
 func (c *InMemoryClient) DeleteStorageClass(s *sc.StorageClass) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if _, ok := c.storageClasses[s.GetName()]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, s.GetName())
 	}
@@ -270,6 +362,9 @@ func (c *InMemoryClient) DeleteStorageClass(s *sc.StorageClass) error {
 }
 
 func (c *InMemoryClient) AddOrUpdateNode(n *utils.Node) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	exists := false
 	if _, ok := c.nodes[n.Name]; ok {
 		exists = true
@@ -282,6 +377,9 @@ func (c *InMemoryClient) AddOrUpdateNode(n *utils.Node) error {
 }
 
 func (c *InMemoryClient) GetNode(nName string) (*utils.Node, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret, ok := c.nodes[nName]
 	if !ok {
 		return nil, NewPersistentStoreError(KeyNotFoundErr, nName)
@@ -290,6 +388,9 @@ func (c *InMemoryClient) GetNode(nName string) (*utils.Node, error) {
 }
 
 func (c *InMemoryClient) GetNodes() ([]*utils.Node, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	ret := make([]*utils.Node, 0, len(c.nodes))
 	if c.nodesAdded == 0 {
 		// Try to match etcd semantics as closely as possible.
@@ -302,9 +403,311 @@ func (c *InMemoryClient) GetNodes() ([]*utils.Node, error) {
 }
 
 func (c *InMemoryClient) DeleteNode(n *utils.Node) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if _, ok := c.nodes[n.Name]; !ok {
 		return NewPersistentStoreError(KeyNotFoundErr, n.Name)
 	}
 	delete(c.nodes, n.Name)
 	return nil
 }
+
+func (c *InMemoryClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	snap := snapshot.ConstructPersistent()
+	if _, ok := c.snapshots[snap.ID()]; ok {
+		return fmt.Errorf("snapshot %s already exists", snap.ID())
+	}
+	c.snapshots[snap.ID()] = snap
+	c.snapshotsAdded++
+	return nil
+}
+
+func (c *InMemoryClient) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	snapshotID := storage.MakeSnapshotID(volumeName, snapshotName)
+	ret, ok := c.snapshots[snapshotID]
+	if !ok {
+		return nil, NewPersistentStoreError(KeyNotFoundErr, snapshotID)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]*storage.SnapshotPersistent, 0, len(c.snapshots))
+	if c.snapshotsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return ret, nil
+	}
+	for _, s := range c.snapshots {
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.snapshots[snapshot.ID()]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, snapshot.ID())
+	}
+	delete(c.snapshots, snapshot.ID())
+	return nil
+}
+
+func (c *InMemoryClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.snapshots, snapshot.ID())
+	return nil
+}
+
+func (c *InMemoryClient) DeleteSnapshots() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.snapshotsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return NewPersistentStoreError(KeyNotFoundErr, "Snapshots")
+	}
+	c.snapshots = make(map[string]*storage.SnapshotPersistent)
+	return nil
+}
+
+func (c *InMemoryClient) AddVolumePublication(publication *storage.VolumePublication) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.publications[publication.ID()]; !ok {
+		c.publicationsAdded++
+	}
+	c.publications[publication.ID()] = publication
+	return nil
+}
+
+func (c *InMemoryClient) GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	publicationID := storage.MakeVolumePublicationID(volumeName, nodeName)
+	ret, ok := c.publications[publicationID]
+	if !ok {
+		return nil, NewPersistentStoreError(KeyNotFoundErr, publicationID)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) GetVolumePublications() ([]*storage.VolumePublication, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]*storage.VolumePublication, 0, len(c.publications))
+	if c.publicationsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return ret, nil
+	}
+	for _, p := range c.publications {
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteVolumePublication(publication *storage.VolumePublication) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.publications[publication.ID()]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, publication.ID())
+	}
+	delete(c.publications, publication.ID())
+	return nil
+}
+
+func (c *InMemoryClient) DeleteVolumePublications() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.publicationsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return NewPersistentStoreError(KeyNotFoundErr, "VolumePublications")
+	}
+	c.publications = make(map[string]*storage.VolumePublication)
+	return nil
+}
+
+func (c *InMemoryClient) AddEvent(event *storage.Event) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.events[event.ID]; !ok {
+		c.eventsAdded++
+	}
+	c.events[event.ID] = event
+	return nil
+}
+
+func (c *InMemoryClient) GetEvents() ([]*storage.Event, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]*storage.Event, 0, len(c.events))
+	if c.eventsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return ret, nil
+	}
+	for _, e := range c.events {
+		ret = append(ret, e)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteEvent(event *storage.Event) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.events[event.ID]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, event.ID)
+	}
+	delete(c.events, event.ID)
+	return nil
+}
+
+func (c *InMemoryClient) DeleteEvents() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.eventsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return NewPersistentStoreError(KeyNotFoundErr, "Events")
+	}
+	c.events = make(map[string]*storage.Event)
+	return nil
+}
+
+func (c *InMemoryClient) AddOrUpdateQuota(quota *storage.Quota) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.quotas[quota.Name]; !ok {
+		c.quotasAdded++
+	}
+	c.quotas[quota.Name] = quota
+	return nil
+}
+
+func (c *InMemoryClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret, ok := c.quotas[quotaName]
+	if !ok {
+		return nil, NewPersistentStoreError(KeyNotFoundErr, quotaName)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) GetQuotas() ([]*storage.Quota, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]*storage.Quota, 0, len(c.quotas))
+	if c.quotasAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return ret, nil
+	}
+	for _, q := range c.quotas {
+		ret = append(ret, q)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteQuota(quota *storage.Quota) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.quotas[quota.Name]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, quota.Name)
+	}
+	delete(c.quotas, quota.Name)
+	return nil
+}
+
+func (c *InMemoryClient) DeleteQuotas() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.quotasAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return NewPersistentStoreError(KeyNotFoundErr, "Quotas")
+	}
+	c.quotas = make(map[string]*storage.Quota)
+	return nil
+}
+
+func (c *InMemoryClient) AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.groupSnapshots[groupSnapshot.Config.Name] = groupSnapshot
+	c.groupSnapshotsAdded++
+	return nil
+}
+
+func (c *InMemoryClient) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret, ok := c.groupSnapshots[groupSnapshotName]
+	if !ok {
+		return nil, NewPersistentStoreError(KeyNotFoundErr, groupSnapshotName)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) GetGroupSnapshots() ([]*storage.GroupSnapshot, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := make([]*storage.GroupSnapshot, 0, len(c.groupSnapshots))
+	if c.groupSnapshotsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return ret, nil
+	}
+	for _, g := range c.groupSnapshots {
+		ret = append(ret, g)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.groupSnapshots[groupSnapshot.Config.Name]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, groupSnapshot.Config.Name)
+	}
+	delete(c.groupSnapshots, groupSnapshot.Config.Name)
+	return nil
+}
+
+func (c *InMemoryClient) DeleteGroupSnapshots() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.groupSnapshotsAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return NewPersistentStoreError(KeyNotFoundErr, "GroupSnapshots")
+	}
+	c.groupSnapshots = make(map[string]*storage.GroupSnapshot)
+	return nil
+}
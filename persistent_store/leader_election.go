@@ -0,0 +1,132 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	conc "github.com/coreos/etcd/clientv3/concurrency"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/netapp/trident/config"
+)
+
+// EtcdLeaderElector elects a leader using an etcd election.  Each campaign holds a
+// lease that is renewed for as long as the replica can reach etcd; once the lease
+// expires, the replica has lost the leadership and another may be elected.  Once a
+// campaign is won, the store client that made the elector writes only while the
+// campaign's key exists, which is deleted when its lease expires.
+type EtcdLeaderElector struct {
+	store    *EtcdClientV3
+	client   *clientv3.Client
+	identity string
+	ttl      time.Duration
+	session  *conc.Session
+	election *conc.Election
+	mutex    sync.Mutex
+}
+
+// NewLeaderElector returns an elector that campaigns with the specified identity,
+// such as the pod name, using the connection of this client.
+func (p *EtcdClientV3) NewLeaderElector(identity string, ttl time.Duration) *EtcdLeaderElector {
+	return &EtcdLeaderElector{
+		store:    p,
+		client:   p.clientV3,
+		identity: identity,
+		ttl:      ttl,
+	}
+}
+
+// Campaign blocks until this replica is elected leader.
+func (e *EtcdLeaderElector) Campaign() error {
+	ttlSeconds := int(e.ttl / time.Second)
+	if ttlSeconds < 1 {
+		ttlSeconds = 1
+	}
+	session, err := conc.NewSession(e.client, conc.WithTTL(ttlSeconds))
+	if err != nil {
+		return fmt.Errorf("could not create etcd session; %v", err)
+	}
+	election := conc.NewElection(session, config.LeaderElectionKey)
+
+	log.WithFields(log.Fields{
+		"identity": e.identity,
+		"lease":    session.Lease(),
+	}).Debug("Campaigning for leadership.")
+
+	if err = election.Campaign(context.Background(), e.identity); err != nil {
+		session.Close()
+		return fmt.Errorf("could not campaign for leadership; %v", err)
+	}
+
+	e.mutex.Lock()
+	oldSession := e.session
+	e.session = session
+	e.election = election
+	e.mutex.Unlock()
+
+	// Writes from here on are only accepted while this campaign's key exists
+	e.store.setLeadership(election.Key(), election.Rev())
+
+	// The session of a lost leadership has nothing left to renew
+	if oldSession != nil {
+		oldSession.Close()
+	}
+
+	log.WithField("identity", e.identity).Info("Elected leader.")
+	return nil
+}
+
+// Lost returns a channel that is closed when the lease of the last won campaign
+// expires or is revoked.
+func (e *EtcdLeaderElector) Lost() <-chan struct{} {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.session == nil {
+		lost := make(chan struct{})
+		close(lost)
+		return lost
+	}
+	return e.session.Done()
+}
+
+// Resign gives up the leadership, if this replica holds it, and revokes its lease.
+func (e *EtcdLeaderElector) Resign() error {
+	e.mutex.Lock()
+	session, election := e.session, e.election
+	e.session, e.election = nil, nil
+	e.mutex.Unlock()
+
+	if session == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.PersistentStoreTimeout)
+	err := election.Resign(ctx)
+	cancel()
+	if closeErr := session.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Leader returns the identity of the current leader, or an empty string if there
+// is none.
+func (e *EtcdLeaderElector) Leader() (string, error) {
+	// The leader is the campaigner whose key under the election prefix was created first
+	ctx, cancel := context.WithTimeout(context.Background(), config.PersistentStoreTimeout)
+	resp, err := e.client.Get(ctx, config.LeaderElectionKey+"/", clientv3.WithFirstCreate()...)
+	cancel()
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
@@ -408,3 +408,109 @@ func (c *PassthroughClient) GetNodes() ([]*utils.Node, error) {
 func (c *PassthroughClient) DeleteNode(n *utils.Node) error {
 	return nil
 }
+
+func (c *PassthroughClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	return nil
+}
+
+// GetSnapshot is not called by the orchestrator, which caches all snapshots in
+// memory after bootstrapping.  So this method need not do anything.
+func (c *PassthroughClient) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	return nil, NewPersistentStoreError(KeyNotFoundErr, storage.MakeSnapshotID(volumeName, snapshotName))
+}
+
+func (c *PassthroughClient) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	return make([]*storage.SnapshotPersistent, 0), nil
+}
+
+func (c *PassthroughClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	return nil
+}
+
+func (c *PassthroughClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	return nil
+}
+
+func (c *PassthroughClient) DeleteSnapshots() error {
+	return nil
+}
+
+func (c *PassthroughClient) AddVolumePublication(publication *storage.VolumePublication) error {
+	return nil
+}
+
+// GetVolumePublication is not called by the orchestrator, which caches all publications
+// in memory after bootstrapping.  So this method need not do anything.
+func (c *PassthroughClient) GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error) {
+	return nil, NewPersistentStoreError(KeyNotFoundErr, storage.MakeVolumePublicationID(volumeName, nodeName))
+}
+
+func (c *PassthroughClient) GetVolumePublications() ([]*storage.VolumePublication, error) {
+	return make([]*storage.VolumePublication, 0), nil
+}
+
+func (c *PassthroughClient) DeleteVolumePublication(publication *storage.VolumePublication) error {
+	return nil
+}
+
+func (c *PassthroughClient) DeleteVolumePublications() error {
+	return nil
+}
+
+func (c *PassthroughClient) AddEvent(event *storage.Event) error {
+	return nil
+}
+
+func (c *PassthroughClient) GetEvents() ([]*storage.Event, error) {
+	return make([]*storage.Event, 0), nil
+}
+
+func (c *PassthroughClient) DeleteEvent(event *storage.Event) error {
+	return nil
+}
+
+func (c *PassthroughClient) DeleteEvents() error {
+	return nil
+}
+
+func (c *PassthroughClient) AddOrUpdateQuota(quota *storage.Quota) error {
+	return nil
+}
+
+// GetQuota is not called by the orchestrator, which caches all quotas
+func (c *PassthroughClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	return nil, NewPersistentStoreError(KeyNotFoundErr, quotaName)
+}
+
+func (c *PassthroughClient) GetQuotas() ([]*storage.Quota, error) {
+	return make([]*storage.Quota, 0), nil
+}
+
+func (c *PassthroughClient) DeleteQuota(quota *storage.Quota) error {
+	return nil
+}
+
+func (c *PassthroughClient) DeleteQuotas() error {
+	return nil
+}
+
+func (c *PassthroughClient) AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	return nil
+}
+
+// GetGroupSnapshot is not called by the orchestrator, which caches all group snapshots
+func (c *PassthroughClient) GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error) {
+	return nil, NewPersistentStoreError(KeyNotFoundErr, groupSnapshotName)
+}
+
+func (c *PassthroughClient) GetGroupSnapshots() ([]*storage.GroupSnapshot, error) {
+	return make([]*storage.GroupSnapshot, 0), nil
+}
+
+func (c *PassthroughClient) DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error {
+	return nil
+}
+
+func (c *PassthroughClient) DeleteGroupSnapshots() error {
+	return nil
+}
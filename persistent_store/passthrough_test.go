@@ -74,7 +74,7 @@ func getFakeVolumeTransaction() *VolumeTransaction {
 		InternalName: "really_fake_volume",
 	}
 
-	return &VolumeTransaction{volumeConfig, AddVolume}
+	return &VolumeTransaction{Config: volumeConfig, Op: AddVolume}
 }
 
 func getFakeStorageClass() *sc.StorageClass {
@@ -17,6 +17,8 @@ const (
 	EtcdV2Store      StoreType = "etcdv2"
 	EtcdV3Store      StoreType = "etcdv3"
 	PassthroughStore StoreType = "passthrough"
+	CRDStore         StoreType = "crd"
+	FileStore        StoreType = "file"
 )
 
 type PersistentStateVersion struct {
@@ -67,6 +69,50 @@ type Client interface {
 	GetNode(nName string) (*utils.Node, error)
 	GetNodes() ([]*utils.Node, error)
 	DeleteNode(n *utils.Node) error
+
+	AddSnapshot(snapshot *storage.Snapshot) error
+	GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error)
+	GetSnapshots() ([]*storage.SnapshotPersistent, error)
+	DeleteSnapshot(snapshot *storage.Snapshot) error
+	DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error
+	DeleteSnapshots() error
+
+	AddVolumePublication(publication *storage.VolumePublication) error
+	GetVolumePublication(volumeName, nodeName string) (*storage.VolumePublication, error)
+	GetVolumePublications() ([]*storage.VolumePublication, error)
+	DeleteVolumePublication(publication *storage.VolumePublication) error
+	DeleteVolumePublications() error
+
+	AddEvent(event *storage.Event) error
+	GetEvents() ([]*storage.Event, error)
+	DeleteEvent(event *storage.Event) error
+	DeleteEvents() error
+
+	AddOrUpdateQuota(quota *storage.Quota) error
+	GetQuota(quotaName string) (*storage.Quota, error)
+	GetQuotas() ([]*storage.Quota, error)
+	DeleteQuota(quota *storage.Quota) error
+	DeleteQuotas() error
+
+	AddGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error
+	GetGroupSnapshot(groupSnapshotName string) (*storage.GroupSnapshot, error)
+	GetGroupSnapshots() ([]*storage.GroupSnapshot, error)
+	DeleteGroupSnapshot(groupSnapshot *storage.GroupSnapshot) error
+	DeleteGroupSnapshots() error
+}
+
+// LeaderElector elects one leader among the Trident replicas that share a store.
+type LeaderElector interface {
+	// Campaign blocks until this replica is elected leader.
+	Campaign() error
+	// Lost returns a channel that is closed when this replica loses the leadership
+	// it last won, such as when its lease expires.
+	Lost() <-chan struct{}
+	// Resign gives up the leadership, so that another replica may take over at once.
+	Resign() error
+	// Leader returns the identity of the current leader, or an empty string if
+	// there is none.
+	Leader() (string, error)
 }
 
 type EtcdClient interface {
@@ -1,9 +1,9 @@
-// Copyright 2018 NetApp, Inc. All Rights Reserved.
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
 
 package persistentstore
 
 import (
-	"fmt"
+	"net/url"
 
 	"github.com/netapp/trident/storage"
 )
@@ -11,21 +11,43 @@ import (
 type VolumeOperation string
 
 const (
-	AddVolume    VolumeOperation = "addVolume"
-	DeleteVolume VolumeOperation = "deleteVolume"
-	ImportVolume VolumeOperation = "importVolume"
-	ResizeVolume VolumeOperation = "resizeVolume"
+	AddVolume      VolumeOperation = "addVolume"
+	DeleteVolume   VolumeOperation = "deleteVolume"
+	ImportVolume   VolumeOperation = "importVolume"
+	ResizeVolume   VolumeOperation = "resizeVolume"
+	AddSnapshot    VolumeOperation = "addSnapshot"
+	DeleteSnapshot VolumeOperation = "deleteSnapshot"
+	MigrateVolume  VolumeOperation = "migrateVolume"
+	UnmanageVolume VolumeOperation = "unmanageVolume"
+	MoveVolume     VolumeOperation = "moveVolume"
 )
 
 type VolumeTransaction struct {
-	Config *storage.VolumeConfig
-	Op     VolumeOperation
+	Config         *storage.VolumeConfig
+	SnapshotConfig *storage.SnapshotConfig
+	Op             VolumeOperation
+	Migration      *storage.VolumeMigration
+	NewName        string // The name an unmanaged volume is given on its backend
+	Pool           string // The storage pool a volume is being moved to
+}
+
+// Name returns a unique identifier for the VolumeTransaction.  Volume
+// transactions are identified by the volume name, while snapshot transactions
+// are identified by the snapshot ID, which includes the volume name.
+func (vt *VolumeTransaction) Name() string {
+	switch vt.Op {
+	case AddSnapshot, DeleteSnapshot:
+		return vt.SnapshotConfig.ID()
+	default:
+		return vt.Config.Name
+	}
 }
 
 // getKey returns a unique identifier for the VolumeTransaction.  Volume
 // transactions should only be identified by their name.  It's possible that
 // some situations will leave a delete transaction dangling; an add transaction
-// should overwrite this.
+// should overwrite this.  The name is escaped so that snapshot IDs, which
+// contain a slash, remain a single key in the store.
 func (vt *VolumeTransaction) getKey() string {
-	return fmt.Sprintf("%s", vt.Config.Name)
+	return url.QueryEscape(vt.Name())
 }
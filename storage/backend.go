@@ -43,7 +43,19 @@ type Driver interface {
 	GetStorageBackendSpecs(backend *Backend) error
 	GetProtocol() tridentconfig.Protocol
 	Publish(name string, publishInfo *utils.VolumePublishInfo) error
-	SnapshotList(name string) ([]Snapshot, error)
+	// CreateSnapshot creates a snapshot of the volume identified in the snapshot config.
+	CreateSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error)
+	// GetSnapshot returns the snapshot identified in the snapshot config.  To distinguish
+	// between an API error reading the snapshot and a nonexistent snapshot, this method
+	// returns (nil, nil) if the snapshot doesn't exist.
+	GetSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error)
+	// GetSnapshots returns all snapshots of the specified volume.
+	GetSnapshots(volConfig *VolumeConfig) ([]*Snapshot, error)
+	// DeleteSnapshot deletes a snapshot.  Deleting a nonexistent snapshot is not an error.
+	DeleteSnapshot(snapConfig *SnapshotConfig) error
+	// RestoreSnapshot reverts the volume identified in the snapshot config to the snapshot,
+	// discarding any changes made to the volume since the snapshot was created.
+	RestoreSnapshot(snapConfig *SnapshotConfig) error
 	StoreConfig(b *PersistentStorageBackendConfig)
 	// GetExternalConfig returns a version of the driver configuration that
 	// lacks confidential information, such as usernames and passwords.
@@ -58,13 +70,75 @@ type Driver interface {
 	GetUpdateType(driver Driver) *roaring.Bitmap
 }
 
+// PoolCapacityReporter is implemented by drivers that can measure the capacity of their
+// storage pools.  Such drivers should also set each pool's capacity in GetStorageBackendSpecs.
+type PoolCapacityReporter interface {
+	// GetPoolCapacity returns the current capacity of the driver's storage pools, keyed by
+	// pool name.  Pools whose capacity cannot be measured may be omitted.
+	GetPoolCapacity() (map[string]PoolCapacity, error)
+}
+
+// HealthChecker is implemented by drivers that can verify that their storage system is
+// still reachable and accepts their credentials.
+type HealthChecker interface {
+	// CheckHealth contacts the storage system's management endpoint and returns an error
+	// if it can't be reached or rejects the driver's credentials.
+	CheckHealth() error
+}
+
+// GroupSnapshotter is implemented by drivers that can snapshot several of their volumes
+// at the same point in time.
+type GroupSnapshotter interface {
+	// CreateGroupSnapshot creates a snapshot of each volume identified in the snapshot
+	// configs, all at the same point in time, and returns the snapshots in the same order.
+	// The snapshots all have the same name.  If any snapshot can't be created, none are.
+	CreateGroupSnapshot(snapConfigs []*SnapshotConfig) ([]*Snapshot, error)
+}
+
+// VolumeLister is implemented by drivers that can enumerate every volume on their storage
+// system, including volumes that Trident did not create, so that they may be imported.
+type VolumeLister interface {
+	// ListVolumes returns the names of all volumes the driver could import, as they are
+	// known to the storage system.
+	ListVolumes() ([]string, error)
+}
+
+// VolumeMover is implemented by drivers that can move a volume between their storage pools
+// without disrupting access to it.
+type VolumeMover interface {
+	// MoveVolume moves the volume to the storage pool, returning once the move is complete.
+	MoveVolume(volConfig *VolumeConfig, pool *Pool) error
+}
+
+// VolumeReplicator is implemented by drivers that can copy a volume from another backend
+// using the storage systems' own replication, so that no node has to read or write its data.
+type VolumeReplicator interface {
+	// CanReplicateFrom reports whether volumes on the source driver's storage system may be
+	// replicated to this driver's storage system.
+	CanReplicateFrom(source Driver) bool
+	// StartReplication creates the volume identified in volConfig as a replica of the source
+	// volume and begins copying the source volume's data to it.  The replica isn't writable.
+	StartReplication(source Driver, sourceConfig, volConfig *VolumeConfig, pool *Pool) error
+	// WaitForReplication waits until the replica holds a complete copy of the source volume.
+	WaitForReplication(source Driver, sourceConfig, volConfig *VolumeConfig) error
+	// CompleteReplication copies any changes made to the source volume since it was last
+	// replicated, and makes the replica an independent, writable volume.
+	CompleteReplication(source Driver, sourceConfig, volConfig *VolumeConfig) error
+	// AbortReplication stops replicating the source volume, leaving the replica to be destroyed.
+	// Aborting a replication that doesn't exist is not an error.
+	AbortReplication(source Driver, sourceConfig, volConfig *VolumeConfig) error
+}
+
 type Backend struct {
 	Driver  Driver
 	Name    string
 	Online  bool
 	State   BackendState
 	Storage map[string]*Pool
-	Volumes map[string]*Volume
+	Volumes map[string]*Volume // Maintained by the orchestrator, which guards it with its own lock
+	// MonitorFailed is set when the backend health monitor, rather than an administrator
+	// or a failed initialization, put the backend in the Failed state.
+	MonitorFailed bool
 }
 
 type UpdateBackendStateRequest struct {
@@ -162,6 +236,34 @@ func (b *Backend) GetProtocol() tridentconfig.Protocol {
 	return b.Driver.GetProtocol()
 }
 
+// CheckVolumeSizeLimit returns an error if a volume of the specified size would exceed
+// the limitVolumeSize setting of the backend's driver.
+func (b *Backend) CheckVolumeSizeLimit(sizeBytes uint64) error {
+
+	var persistentConfig PersistentStorageBackendConfig
+	b.Driver.StoreConfig(&persistentConfig)
+
+	var commonConfig *drivers.CommonStorageDriverConfig
+	switch {
+	case persistentConfig.OntapConfig != nil:
+		commonConfig = persistentConfig.OntapConfig.CommonStorageDriverConfig
+	case persistentConfig.SolidfireConfig != nil:
+		commonConfig = persistentConfig.SolidfireConfig.CommonStorageDriverConfig
+	case persistentConfig.EseriesConfig != nil:
+		commonConfig = persistentConfig.EseriesConfig.CommonStorageDriverConfig
+	case persistentConfig.AWSConfig != nil:
+		commonConfig = persistentConfig.AWSConfig.CommonStorageDriverConfig
+	case persistentConfig.FakeStorageDriverConfig != nil:
+		commonConfig = persistentConfig.FakeStorageDriverConfig.CommonStorageDriverConfig
+	}
+	if commonConfig == nil {
+		return nil
+	}
+
+	_, _, err := drivers.CheckVolumeSizeLimits(sizeBytes, commonConfig)
+	return err
+}
+
 func (b *Backend) AddVolume(
 	volConfig *VolumeConfig, storagePool *Pool, volAttributes map[string]sa.Request,
 ) (*Volume, error) {
@@ -224,9 +326,7 @@ func (b *Backend) AddVolume(
 		return nil, err
 	}
 
-	vol := NewVolume(volConfig, b.Name, storagePool.Name, false)
-	b.Volumes[vol.Config.Name] = vol
-	return vol, nil
+	return NewVolume(volConfig, b.Name, storagePool.Name, false), nil
 }
 
 func (b *Backend) CloneVolume(volConfig *VolumeConfig) (*Volume, error) {
@@ -288,9 +388,7 @@ func (b *Backend) CloneVolume(volConfig *VolumeConfig) (*Volume, error) {
 		}
 		return nil, err
 	}
-	vol := NewVolume(volConfig, b.Name, drivers.UnsetPool, false)
-	b.Volumes[vol.Config.Name] = vol
-	return vol, nil
+	return NewVolume(volConfig, b.Name, drivers.UnsetPool, false), nil
 }
 
 func (b *Backend) GetVolumeExternal(volumeName string) (*VolumeExternal, error) {
@@ -335,12 +433,7 @@ func (b *Backend) ImportVolume(volConfig *VolumeConfig, originalName string, not
 		return nil, fmt.Errorf("failed post import volume operations : %v", err)
 	}
 
-	volume := NewVolume(volConfig, b.Name, drivers.UnsetPool, false)
-	if !notManaged {
-		// The volume is managed
-		b.Volumes[volume.Config.Name] = volume
-	}
-	return volume, nil
+	return NewVolume(volConfig, b.Name, drivers.UnsetPool, false), nil
 }
 
 func (b *Backend) ResizeVolume(volName, newSize string) error {
@@ -364,15 +457,123 @@ func (b *Backend) ResizeVolume(volName, newSize string) error {
 }
 
 func (b *Backend) RemoveVolume(vol *Volume) error {
-	if err := b.Driver.Destroy(vol.Config.InternalName); err != nil {
-		// TODO:  Check the error being returned once the nDVP throws errors
-		// for volumes that aren't found.
-		return err
+	// TODO:  Check the error being returned once the nDVP throws errors
+	// for volumes that aren't found.
+	return b.Driver.Destroy(vol.Config.InternalName)
+}
+
+func (b *Backend) CreateSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error) {
+
+	log.WithFields(log.Fields{
+		"backend":        b.Name,
+		"volume":         snapConfig.VolumeName,
+		"volumeInternal": snapConfig.VolumeInternalName,
+		"snapshot":       snapConfig.Name,
+	}).Debug("Attempting snapshot create.")
+
+	// Ensure the backend can reach its storage before attempting the operation
+	if !b.State.IsOnline() {
+		return nil, fmt.Errorf("backend %s is not online", b.Name)
 	}
-	if _, ok := b.Volumes[vol.Config.Name]; ok {
-		delete(b.Volumes, vol.Config.Name)
+
+	snapshot, err := b.Driver.CreateSnapshot(snapConfig)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return snapshot, nil
+}
+
+// CreateGroupSnapshot creates a snapshot of each of several volumes on the backend, and
+// returns the snapshots in the same order as their configs.  If the driver can snapshot
+// the volumes at the same point in time, it does so and native is true; otherwise the
+// snapshots are created one after another.  If any snapshot can't be created, none are.
+func (b *Backend) CreateGroupSnapshot(snapConfigs []*SnapshotConfig) (
+	snapshots []*Snapshot, native bool, err error,
+) {
+	log.WithFields(log.Fields{
+		"backend":   b.Name,
+		"snapshots": len(snapConfigs),
+	}).Debug("Attempting group snapshot create.")
+
+	// Ensure the backend can reach its storage before attempting the operation
+	if !b.State.IsOnline() {
+		return nil, false, fmt.Errorf("backend %s is not online", b.Name)
+	}
+
+	if groupSnapshotter, ok := b.Driver.(GroupSnapshotter); ok {
+		snapshots, err = groupSnapshotter.CreateGroupSnapshot(snapConfigs)
+		if err != nil {
+			return nil, true, err
+		}
+		return snapshots, true, nil
+	}
+
+	snapshots = make([]*Snapshot, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		snapshot, err := b.Driver.CreateSnapshot(snapConfig)
+		if err != nil {
+			// Don't leave part of the group behind
+			for _, created := range snapshots {
+				if deleteErr := b.Driver.DeleteSnapshot(created.Config); deleteErr != nil {
+					log.WithFields(log.Fields{
+						"backend":  b.Name,
+						"volume":   created.Config.VolumeName,
+						"snapshot": created.Config.Name,
+						"error":    deleteErr,
+					}).Warning("Could not delete a snapshot of a failed group snapshot.")
+				}
+			}
+			return nil, false, fmt.Errorf("could not create snapshot of volume %s: %v",
+				snapConfig.VolumeName, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, false, nil
+}
+
+// CanGroupSnapshot returns whether the backend can snapshot several of its volumes at the
+// same point in time.
+func (b *Backend) CanGroupSnapshot() bool {
+	_, ok := b.Driver.(GroupSnapshotter)
+	return ok
+}
+
+func (b *Backend) GetSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error) {
+	return b.Driver.GetSnapshot(snapConfig)
+}
+
+func (b *Backend) GetSnapshots(volConfig *VolumeConfig) ([]*Snapshot, error) {
+	return b.Driver.GetSnapshots(volConfig)
+}
+
+func (b *Backend) DeleteSnapshot(snapConfig *SnapshotConfig) error {
+
+	log.WithFields(log.Fields{
+		"backend":        b.Name,
+		"volume":         snapConfig.VolumeName,
+		"volumeInternal": snapConfig.VolumeInternalName,
+		"snapshot":       snapConfig.Name,
+	}).Debug("Attempting snapshot delete.")
+
+	return b.Driver.DeleteSnapshot(snapConfig)
+}
+
+// RestoreSnapshot reverts a volume to one of its snapshots
+func (b *Backend) RestoreSnapshot(snapConfig *SnapshotConfig) error {
+
+	log.WithFields(log.Fields{
+		"backend":        b.Name,
+		"volume":         snapConfig.VolumeName,
+		"volumeInternal": snapConfig.VolumeInternalName,
+		"snapshot":       snapConfig.Name,
+	}).Debug("Attempting snapshot restore.")
+
+	// Ensure the backend can reach its storage before attempting the operation
+	if !b.State.IsOnline() {
+		return fmt.Errorf("backend %s is not online", b.Name)
+	}
+
+	return b.Driver.RestoreSnapshot(snapConfig)
 }
 
 const (
@@ -431,15 +632,182 @@ func (b *Backend) ConstructExternal() *BackendExternal {
 		Volumes:  make([]string, 0),
 	}
 
+	poolExternals := make(map[string]*PoolExternal)
 	for name, pool := range b.Storage {
-		backendExternal.Storage[name] = pool.ConstructExternal()
+		poolExternals[name] = pool.ConstructExternal()
+		backendExternal.Storage[name] = poolExternals[name]
 	}
-	for volName := range b.Volumes {
+	for volName, vol := range b.Volumes {
 		backendExternal.Volumes = append(backendExternal.Volumes, volName)
+		if poolExternal, ok := poolExternals[vol.Pool]; ok {
+			if size, err := utils.ConvertSizeToBytes(vol.Config.Size); err == nil {
+				if sizeBytes, err := strconv.ParseUint(size, 10, 64); err == nil {
+					poolExternal.CommittedBytes += sizeBytes
+				}
+			}
+		}
 	}
 	return &backendExternal
 }
 
+// GetPoolCapacity asks the driver for the current capacity of the backend's storage
+// pools.  It returns nil if the driver doesn't report pool capacity.
+func (b *Backend) GetPoolCapacity() (map[string]PoolCapacity, error) {
+	reporter, ok := b.Driver.(PoolCapacityReporter)
+	if !ok {
+		return nil, nil
+	}
+	return reporter.GetPoolCapacity()
+}
+
+// CheckHealth probes the backend's storage system, or does nothing if the driver can't
+// check its health.
+func (b *Backend) CheckHealth() error {
+	checker, ok := b.Driver.(HealthChecker)
+	if !ok {
+		return nil
+	}
+	return checker.CheckHealth()
+}
+
+// ListVolumes returns the names of all volumes on the backend's storage system, whether
+// or not Trident manages them.
+func (b *Backend) ListVolumes() ([]string, error) {
+	lister, ok := b.Driver.(VolumeLister)
+	if !ok {
+		return nil, fmt.Errorf("backend %s cannot list its volumes", b.Name)
+	}
+	if !b.State.IsOnline() {
+		return nil, fmt.Errorf("backend %s is not online", b.Name)
+	}
+	return lister.ListVolumes()
+}
+
+// CanMoveVolumes reports whether the backend's driver can move volumes between its pools.
+func (b *Backend) CanMoveVolumes() bool {
+	_, ok := b.Driver.(VolumeMover)
+	return ok
+}
+
+// MoveVolume moves a volume to another of the backend's storage pools.
+func (b *Backend) MoveVolume(volConfig *VolumeConfig, pool *Pool) error {
+	mover, ok := b.Driver.(VolumeMover)
+	if !ok {
+		return fmt.Errorf("backend %s cannot move volumes between its storage pools", b.Name)
+	}
+	if !b.State.IsOnline() {
+		return fmt.Errorf("backend %s is not online", b.Name)
+	}
+	if pool.Backend != b {
+		return fmt.Errorf("storage pool %s does not belong to backend %s", pool.Name, b.Name)
+	}
+	return mover.MoveVolume(volConfig, pool)
+}
+
+// CanReplicateVolumesFrom reports whether the backend's driver can natively replicate
+// volumes from the source backend.
+func (b *Backend) CanReplicateVolumesFrom(source *Backend) bool {
+	replicator, ok := b.Driver.(VolumeReplicator)
+	return ok && replicator.CanReplicateFrom(source.Driver)
+}
+
+// ReplicateVolume creates a replica of a volume on the source backend in one of this
+// backend's storage pools, and begins copying the source volume's data to it.
+func (b *Backend) ReplicateVolume(source *Backend, sourceConfig, volConfig *VolumeConfig, pool *Pool) error {
+	replicator, ok := b.Driver.(VolumeReplicator)
+	if !ok {
+		return fmt.Errorf("backend %s cannot replicate volumes", b.Name)
+	}
+	if !b.State.IsOnline() {
+		return fmt.Errorf("backend %s is not online", b.Name)
+	}
+	if pool.Backend != b {
+		return fmt.Errorf("storage pool %s does not belong to backend %s", pool.Name, b.Name)
+	}
+
+	log.WithFields(log.Fields{
+		"backend":        b.Name,
+		"volume":         volConfig.InternalName,
+		"storage_pool":   pool.Name,
+		"source_backend": source.Name,
+		"source_volume":  sourceConfig.InternalName,
+	}).Debug("Attempting volume replication.")
+
+	if err := b.Driver.CreatePrepare(volConfig); err != nil {
+		return err
+	}
+	return replicator.StartReplication(source.Driver, sourceConfig, volConfig, pool)
+}
+
+// WaitForVolumeReplication waits until a volume being replicated from the source backend
+// has been copied in full.
+func (b *Backend) WaitForVolumeReplication(source *Backend, sourceConfig, volConfig *VolumeConfig) error {
+	replicator, ok := b.Driver.(VolumeReplicator)
+	if !ok {
+		return fmt.Errorf("backend %s cannot replicate volumes", b.Name)
+	}
+	return replicator.WaitForReplication(source.Driver, sourceConfig, volConfig)
+}
+
+// CompleteVolumeReplication finishes replicating a volume from the source backend, and
+// then adds the information needed to access the now independent replica to volConfig.
+func (b *Backend) CompleteVolumeReplication(source *Backend, sourceConfig, volConfig *VolumeConfig) error {
+	replicator, ok := b.Driver.(VolumeReplicator)
+	if !ok {
+		return fmt.Errorf("backend %s cannot replicate volumes", b.Name)
+	}
+	if err := replicator.CompleteReplication(source.Driver, sourceConfig, volConfig); err != nil {
+		return err
+	}
+	return b.Driver.CreateFollowup(volConfig)
+}
+
+// AbortVolumeReplication stops replicating a volume from the source backend.  The caller
+// remains responsible for destroying the replica.
+func (b *Backend) AbortVolumeReplication(source *Backend, sourceConfig, volConfig *VolumeConfig) error {
+	replicator, ok := b.Driver.(VolumeReplicator)
+	if !ok {
+		return nil
+	}
+	return replicator.AbortReplication(source.Driver, sourceConfig, volConfig)
+}
+
+// StoragePrefix returns the prefix the backend's driver prepends to the names of
+// the volumes it creates, or an empty string if the driver doesn't use one.
+func (b *Backend) StoragePrefix() string {
+
+	var config PersistentStorageBackendConfig
+	b.Driver.StoreConfig(&config)
+
+	var commonConfig *drivers.CommonStorageDriverConfig
+	switch {
+	case config.OntapConfig != nil:
+		commonConfig = config.OntapConfig.CommonStorageDriverConfig
+	case config.SolidfireConfig != nil:
+		commonConfig = config.SolidfireConfig.CommonStorageDriverConfig
+	case config.EseriesConfig != nil:
+		commonConfig = config.EseriesConfig.CommonStorageDriverConfig
+	case config.AWSConfig != nil:
+		commonConfig = config.AWSConfig.CommonStorageDriverConfig
+	case config.FakeStorageDriverConfig != nil:
+		commonConfig = config.FakeStorageDriverConfig.CommonStorageDriverConfig
+	}
+
+	if commonConfig == nil {
+		return ""
+	}
+	if commonConfig.StoragePrefix != nil {
+		return *commonConfig.StoragePrefix
+	}
+
+	// Some drivers only store the serialized form of the prefix
+	var prefix string
+	if err := json.Unmarshal(commonConfig.StoragePrefixRaw, &prefix); err != nil {
+		return ""
+	}
+	return prefix
+}
+
 // Used to store the requisite info for a backend in etcd.  Other than
 // the configuration, all other data will be reconstructed during the bootstrap
 // phase
@@ -452,21 +820,43 @@ type PersistentStorageBackendConfig struct {
 	FakeStorageDriverConfig *drivers.FakeStorageDriverConfig      `json:"fake_config,omitempty"`
 }
 
+// Secrets returns the credential fields of the config, such as passwords and API
+// keys, so that they may be redacted or encrypted in place.  The SolidFire
+// endpoint is included because it embeds the cluster admin credentials.
+func (c *PersistentStorageBackendConfig) Secrets() []*string {
+	switch {
+	case c.OntapConfig != nil:
+		return []*string{&c.OntapConfig.Username, &c.OntapConfig.Password}
+	case c.SolidfireConfig != nil:
+		return []*string{&c.SolidfireConfig.EndPoint}
+	case c.EseriesConfig != nil:
+		return []*string{&c.EseriesConfig.Username, &c.EseriesConfig.Password,
+			&c.EseriesConfig.PasswordArray}
+	case c.AWSConfig != nil:
+		return []*string{&c.AWSConfig.APIKey, &c.AWSConfig.SecretKey}
+	default:
+		return []*string{}
+	}
+}
+
 type BackendPersistent struct {
 	Version string                         `json:"version"`
 	Config  PersistentStorageBackendConfig `json:"config"`
 	Name    string                         `json:"name"`
 	Online  bool                           `json:"online"`
 	State   BackendState                   `json:"state"`
+	// MonitorFailed is set when the backend health monitor failed the backend
+	MonitorFailed bool `json:"monitorFailed,omitempty"`
 }
 
 func (b *Backend) ConstructPersistent() *BackendPersistent {
 	persistentBackend := &BackendPersistent{
-		Version: tridentconfig.OrchestratorAPIVersion,
-		Config:  PersistentStorageBackendConfig{},
-		Name:    b.Name,
-		Online:  b.Online,
-		State:   b.State,
+		Version:       tridentconfig.OrchestratorAPIVersion,
+		Config:        PersistentStorageBackendConfig{},
+		Name:          b.Name,
+		Online:        b.Online,
+		State:         b.State,
+		MonitorFailed: b.MonitorFailed,
 	}
 	b.Driver.StoreConfig(&persistentBackend.Config)
 	return persistentBackend
@@ -0,0 +1,57 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"time"
+)
+
+const (
+	EventOutcomeSuccess = "success"
+	EventOutcomeFailure = "failure"
+)
+
+const (
+	EventObjectBackend       = "backend"
+	EventObjectVolume        = "volume"
+	EventObjectSnapshot      = "snapshot"
+	EventObjectPublication   = "publication"
+	EventObjectStorageClass  = "storageclass"
+	EventObjectNode          = "node"
+	EventObjectQuota         = "quota"
+	EventObjectGroupSnapshot = "groupsnapshot"
+	EventObjectOrchestrator  = "orchestrator"
+)
+
+// Event records an operation that changed, or tried to change, the orchestrator's state
+type Event struct {
+	ID         string `json:"id"`
+	Time       string `json:"time"` // The UTC time that the operation started, in RFC3339 format
+	Operation  string `json:"operation"`
+	ObjectType string `json:"objectType"`
+	ObjectName string `json:"objectName,omitempty"`
+	Source     string `json:"source"` // The frontend or internal task that requested the operation
+	Outcome    string `json:"outcome"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewEvent returns an event describing the outcome of an operation that started at the
+// specified time and has just finished.  The ID is assigned when the event is recorded.
+func NewEvent(operation, objectType, objectName, source string, start time.Time, err error) *Event {
+
+	event := &Event{
+		Time:       start.UTC().Format(time.RFC3339),
+		Operation:  operation,
+		ObjectType: objectType,
+		ObjectName: objectName,
+		Source:     source,
+		Outcome:    EventOutcomeSuccess,
+		DurationMs: int64(time.Since(start) / time.Millisecond),
+	}
+	if err != nil {
+		event.Outcome = EventOutcomeFailure
+		event.Error = err.Error()
+	}
+	return event
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"fmt"
+)
+
+// GroupSnapshotConfig identifies a set of volumes to be snapshotted at the same point in time.
+// Each volume's snapshot has the same name as the group.
+type GroupSnapshotConfig struct {
+	Version     string   `json:"version,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	VolumeNames []string `json:"volumeNames,omitempty"`
+	// AllowSequential permits taking the snapshots one after another where the storage
+	// can't take them together.  Such snapshots aren't crash-consistent with each other,
+	// since writes to the volumes continue between them.
+	AllowSequential bool `json:"allowSequential,omitempty"`
+}
+
+// Validate checks that a group snapshot is named and lists each of its volumes once
+func (c *GroupSnapshotConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("the following field for \"GroupSnapshot\" is mandatory: name")
+	}
+	if len(c.VolumeNames) == 0 {
+		return fmt.Errorf("the following field for \"GroupSnapshot\" is mandatory: volumeNames")
+	}
+	seen := make(map[string]bool, len(c.VolumeNames))
+	for _, volumeName := range c.VolumeNames {
+		if volumeName == "" {
+			return fmt.Errorf("group snapshot %s has an empty volume name", c.Name)
+		}
+		if seen[volumeName] {
+			return fmt.Errorf("volume %s is listed more than once in group snapshot %s", volumeName, c.Name)
+		}
+		seen[volumeName] = true
+	}
+	return nil
+}
+
+// SnapshotConfig returns the config of the group's snapshot of a volume
+func (c *GroupSnapshotConfig) SnapshotConfig(volumeName string) *SnapshotConfig {
+	return &SnapshotConfig{
+		Version:    c.Version,
+		Name:       c.Name,
+		VolumeName: volumeName,
+	}
+}
+
+// SnapshotIDs returns the IDs of the group's snapshots, one per volume
+func (c *GroupSnapshotConfig) SnapshotIDs() []string {
+	snapshotIDs := make([]string, 0, len(c.VolumeNames))
+	for _, volumeName := range c.VolumeNames {
+		snapshotIDs = append(snapshotIDs, MakeSnapshotID(volumeName, c.Name))
+	}
+	return snapshotIDs
+}
+
+// GroupSnapshot records the volumes whose snapshots were taken together
+type GroupSnapshot struct {
+	Config  *GroupSnapshotConfig `json:"config"`
+	Created string               `json:"dateCreated"` // The UTC time that the group was created, in RFC3339 format
+	// Native is true if the storage took all of the group's snapshots at the same instant;
+	// otherwise they were taken one after another, as the group's config allowed, while the
+	// volumes were fenced from other orchestrator operations but not from I/O.
+	Native bool `json:"native"`
+}
+
+func NewGroupSnapshot(config *GroupSnapshotConfig, created string, native bool) *GroupSnapshot {
+	return &GroupSnapshot{
+		Config:  config,
+		Created: created,
+		Native:  native,
+	}
+}
+
+// GroupSnapshotExternal reports a group snapshot together with its members
+type GroupSnapshotExternal struct {
+	GroupSnapshot
+	Snapshots []*SnapshotExternal `json:"snapshots"`
+}
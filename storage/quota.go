@@ -0,0 +1,87 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	QuotaScopeNamespace    = "namespace"
+	QuotaScopeLabel        = "label"
+	QuotaScopeStorageClass = "storageClass"
+)
+
+// Quota limits the total capacity and number of volumes provisioned for an owner,
+// which is a Kubernetes namespace, the volumes matching a label selector, or a
+// storage class.
+type Quota struct {
+	Name       string `json:"name"`
+	Scope      string `json:"scope"`
+	Owner      string `json:"owner"`             // The namespace, label selector or storage class name
+	MaxSize    string `json:"maxSize,omitempty"` // The total size of the owner's volumes, e.g. "10Ti"
+	MaxVolumes int    `json:"maxVolumes,omitempty"`
+}
+
+// QuotaExternal reports a quota together with the owner's current usage
+type QuotaExternal struct {
+	Quota
+	UsedBytes   uint64 `json:"usedBytes"`
+	UsedVolumes int    `json:"usedVolumes"`
+}
+
+// Validate checks that a quota identifies its owner and sets valid limits
+func (q *Quota) Validate() error {
+	if q.Name == "" || q.Owner == "" {
+		return fmt.Errorf("the following fields for \"Quota\" are mandatory: name and owner")
+	}
+	switch q.Scope {
+	case QuotaScopeNamespace, QuotaScopeStorageClass:
+	case QuotaScopeLabel:
+		if _, err := labels.Parse(q.Owner); err != nil {
+			return fmt.Errorf("invalid label selector %s: %v", q.Owner, err)
+		}
+	default:
+		return fmt.Errorf("%s is an unsupported quota scope; acceptable values: %s, %s, %s",
+			q.Scope, QuotaScopeNamespace, QuotaScopeLabel, QuotaScopeStorageClass)
+	}
+	if _, err := q.MaxBytes(); err != nil {
+		return err
+	}
+	if q.MaxVolumes < 0 {
+		return fmt.Errorf("invalid maximum volume count %d", q.MaxVolumes)
+	}
+	return nil
+}
+
+// MaxBytes returns the quota's capacity limit in bytes, or zero if capacity isn't limited
+func (q *Quota) MaxBytes() (uint64, error) {
+	if q.MaxSize == "" {
+		return 0, nil
+	}
+	size, err := utils.ConvertSizeToBytes(q.MaxSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quota size %s: %v", q.MaxSize, err)
+	}
+	return strconv.ParseUint(size, 10, 64)
+}
+
+// Applies reports whether a volume counts against the quota
+func (q *Quota) Applies(volConfig *VolumeConfig) bool {
+	switch q.Scope {
+	case QuotaScopeNamespace:
+		return volConfig.Namespace == q.Owner
+	case QuotaScopeStorageClass:
+		return volConfig.StorageClass == q.Owner
+	case QuotaScopeLabel:
+		selector, err := labels.Parse(q.Owner)
+		return err == nil && selector.Matches(labels.Set(volConfig.Labels))
+	default:
+		return false
+	}
+}
@@ -1,11 +1,93 @@
-// Copyright 2018 NetApp, Inc. All Rights Reserved.
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
 
 package storage
 
-// Snapshot contains the normalized volume snapshot format we report to Docker
+import (
+	"fmt"
+	"strings"
+)
+
+// SnapshotConfig contains the information needed to identify a volume snapshot
+type SnapshotConfig struct {
+	Version            string `json:"version,omitempty"`
+	Name               string `json:"name,omitempty"`
+	InternalName       string `json:"internalName,omitempty"`
+	VolumeName         string `json:"volumeName,omitempty"`
+	VolumeInternalName string `json:"volumeInternalName,omitempty"`
+}
+
+// ID returns a name that uniquely identifies a snapshot across all volumes
+func (c *SnapshotConfig) ID() string {
+	return MakeSnapshotID(c.VolumeName, c.Name)
+}
+
+// Validate checks that the fields required to identify a snapshot are present
+func (c *SnapshotConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("the following field for \"Snapshot\" is mandatory: name")
+	}
+	if c.VolumeName == "" {
+		return fmt.Errorf("the following field for \"Snapshot\" is mandatory: volumeName")
+	}
+	return nil
+}
+
+type SnapshotState string
+
+const (
+	SnapshotStateCreating      = SnapshotState("creating")
+	SnapshotStateOnline        = SnapshotState("online")
+	SnapshotStateMissingVolume = SnapshotState("missing_volume")
+)
+
+func (s SnapshotState) IsCreating() bool {
+	return s == SnapshotStateCreating
+}
+
+func (s SnapshotState) IsOnline() bool {
+	return s == SnapshotStateOnline
+}
+
+func (s SnapshotState) IsMissingVolume() bool {
+	return s == SnapshotStateMissingVolume
+}
+
+// Snapshot contains the normalized volume snapshot format we report to clients
 type Snapshot struct {
-	Name    string // The snapshot name or other identifier you would use to reference it
-	Created string // The UTC time that the snapshot was created, in RFC3339 format
+	Config    *SnapshotConfig `json:"config"`
+	Created   string          `json:"dateCreated"` // The UTC time that the snapshot was created, in RFC3339 format
+	SizeBytes int64           `json:"size"`        // The size of the source volume when the snapshot was created
+	State     SnapshotState   `json:"state"`
+}
+
+func NewSnapshot(config *SnapshotConfig, created string, sizeBytes int64, state SnapshotState) *Snapshot {
+	return &Snapshot{
+		Config:    config,
+		Created:   created,
+		SizeBytes: sizeBytes,
+		State:     state,
+	}
+}
+
+// ID returns a name that uniquely identifies a snapshot across all volumes
+func (s *Snapshot) ID() string {
+	return s.Config.ID()
+}
+
+// MakeSnapshotID combines a volume name and a snapshot name into a single
+// identifier, since snapshot names need only be unique per volume.
+func MakeSnapshotID(volumeName, snapshotName string) string {
+	return fmt.Sprintf("%s/%s", volumeName, snapshotName)
+}
+
+// ParseSnapshotID splits a snapshot ID created by MakeSnapshotID into its
+// volume name and snapshot name.
+func ParseSnapshotID(snapshotID string) (string, string, error) {
+	components := strings.SplitN(snapshotID, "/", 2)
+	if len(components) != 2 || components[0] == "" || components[1] == "" {
+		return "", "", fmt.Errorf("%s is not a valid snapshot ID", snapshotID)
+	}
+	return components[0], components[1], nil
 }
 
 type SnapshotExternal struct {
@@ -15,3 +97,15 @@ type SnapshotExternal struct {
 func (s *Snapshot) ConstructExternal() *SnapshotExternal {
 	return &SnapshotExternal{*s}
 }
+
+type SnapshotPersistent struct {
+	Snapshot
+}
+
+func (s *Snapshot) ConstructPersistent() *SnapshotPersistent {
+	return &SnapshotPersistent{*s}
+}
+
+func (s *SnapshotPersistent) ConstructExternal() *SnapshotExternal {
+	return &SnapshotExternal{s.Snapshot}
+}
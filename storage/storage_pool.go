@@ -15,6 +15,32 @@ type Pool struct {
 	Backend            *Backend
 	Attributes         map[string]sa.Offer // These attributes are used to match storage classes
 	InternalAttributes map[string]string   // These attributes are defined & used internally by storage drivers
+	Capacity           PoolCapacity        // Reported by storage drivers and refreshed periodically by the orchestrator
+}
+
+// PoolCapacity describes the space in a storage pool against which new volumes are
+// provisioned.  A TotalBytes value of zero means the driver doesn't know the capacity.
+type PoolCapacity struct {
+	TotalBytes uint64 `json:"totalBytes"`
+	UsedBytes  uint64 `json:"usedBytes"`
+	// ReservedBytes is the space committed to volumes created in the pool since the
+	// driver last reported its capacity.  It is not reported by drivers.
+	ReservedBytes uint64 `json:"-"`
+}
+
+// Known returns whether the driver reported a capacity for the pool.
+func (c PoolCapacity) Known() bool {
+	return c.TotalBytes > 0
+}
+
+// FreeBytes returns the space remaining in the pool, less any space reserved since
+// the capacity was reported.
+func (c PoolCapacity) FreeBytes() uint64 {
+	committed := c.UsedBytes + c.ReservedBytes
+	if committed >= c.TotalBytes {
+		return 0
+	}
+	return c.TotalBytes - committed
 }
 
 func NewStoragePool(backend *Backend, name string) *Pool {
@@ -51,6 +77,11 @@ type PoolExternal struct {
 	StorageClasses []string `json:"storageClasses"`
 	//TODO: can't have an interface here for unmarshalling
 	Attributes map[string]sa.Offer `json:"storageAttributes"`
+	// Capacity fields are omitted if the driver doesn't report the pool's capacity
+	TotalBytes     uint64 `json:"totalBytes,omitempty"`
+	UsedBytes      uint64 `json:"usedBytes,omitempty"`
+	FreeBytes      uint64 `json:"freeBytes,omitempty"`
+	CommittedBytes uint64 `json:"committedBytes"` // The total size of the Trident volumes in the pool
 }
 
 func (pool *Pool) ConstructExternal() *PoolExternal {
@@ -59,6 +90,11 @@ func (pool *Pool) ConstructExternal() *PoolExternal {
 		StorageClasses: pool.StorageClasses,
 		Attributes:     pool.Attributes,
 	}
+	if pool.Capacity.Known() {
+		external.TotalBytes = pool.Capacity.TotalBytes
+		external.UsedBytes = pool.Capacity.UsedBytes
+		external.FreeBytes = pool.Capacity.FreeBytes()
+	}
 
 	// We want to sort these so that the output remains consistent;
 	// there are cases where the order won't always be the same.
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/utils"
 )
@@ -39,6 +41,8 @@ type VolumeConfig struct {
 	QoS                       string                 `json:"qos,omitempty"`
 	QoSType                   string                 `json:"type,omitempty"`
 	ServiceLevel              string                 `json:"serviceLevel,omitempty"`
+	Labels                    map[string]string      `json:"labels,omitempty"`
+	Namespace                 string                 `json:"namespace,omitempty"`
 }
 
 func (c *VolumeConfig) Validate() error {
@@ -51,6 +55,20 @@ func (c *VolumeConfig) Validate() error {
 			strings.Join([]string(config.GetValidProtocolNames()), ", "),
 		)
 	}
+	return ValidateVolumeLabels(c.Labels)
+}
+
+// ValidateVolumeLabels checks that volume labels follow the same rules as Kubernetes
+// labels, so that volumes may be selected using Kubernetes label selectors.
+func ValidateVolumeLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid volume label key %s: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value for volume label %s: %s", key, strings.Join(errs, "; "))
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,53 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+// VolumeMigrationState describes how far a volume migration has progressed.
+type VolumeMigrationState string
+
+const (
+	// MigrationCopying means the target volume exists and the volume's data is being copied to it.
+	MigrationCopying = VolumeMigrationState("copying")
+	// MigrationCutover means the data has been copied and the volume is being switched to the target.
+	MigrationCutover = VolumeMigrationState("cutover")
+	// MigrationComplete means the volume now lives on the target backend.
+	MigrationComplete = VolumeMigrationState("complete")
+	// MigrationFailed means the migration was abandoned and the volume remains on its source backend.
+	MigrationFailed = VolumeMigrationState("failed")
+)
+
+// VolumeMigration records the progress of moving a volume to another backend.  The volume
+// keeps its name, but its configuration on the target backend, such as its internal name
+// and access information, may differ from that on the source backend.
+type VolumeMigration struct {
+	VolumeName    string               `json:"volumeName"`
+	SourceBackend string               `json:"sourceBackend"`
+	SourcePool    string               `json:"sourcePool"`
+	SourceConfig  *VolumeConfig        `json:"sourceConfig"`
+	TargetBackend string               `json:"targetBackend"`
+	TargetPool    string               `json:"targetPool,omitempty"`
+	TargetConfig  *VolumeConfig        `json:"targetConfig"`
+	Native        bool                 `json:"native"` // Copied by the storage systems rather than by a node
+	State         VolumeMigrationState `json:"state"`
+	Started       string               `json:"started"` // The UTC time the migration started, in RFC3339 format
+	Error         string               `json:"error,omitempty"`
+	TargetCreated bool                 `json:"targetCreated,omitempty"` // Set once the target volume may exist
+}
+
+// Done reports whether the migration has finished, successfully or not.
+func (m *VolumeMigration) Done() bool {
+	return m.State == MigrationComplete || m.State == MigrationFailed
+}
+
+// ConstructExternal returns a copy of the migration that is safe to hand to callers
+// while the migration continues in the background.
+func (m *VolumeMigration) ConstructExternal() *VolumeMigration {
+	external := *m
+	if m.SourceConfig != nil {
+		external.SourceConfig = m.SourceConfig.ConstructClone()
+	}
+	if m.TargetConfig != nil {
+		external.TargetConfig = m.TargetConfig.ConstructClone()
+	}
+	return &external
+}
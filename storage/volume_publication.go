@@ -0,0 +1,39 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"fmt"
+
+	tridentconfig "github.com/netapp/trident/config"
+)
+
+// VolumePublication records that a volume has been made available to a node
+type VolumePublication struct {
+	VolumeName string                   `json:"volumeName"`
+	NodeName   string                   `json:"nodeName"`
+	ReadOnly   bool                     `json:"readOnly"`
+	AccessMode tridentconfig.AccessMode `json:"accessMode,omitempty"`
+}
+
+// ID returns a name that uniquely identifies a publication across all volumes
+func (p *VolumePublication) ID() string {
+	return MakeVolumePublicationID(p.VolumeName, p.NodeName)
+}
+
+// Validate checks that the fields required to identify a publication are present
+func (p *VolumePublication) Validate() error {
+	if p.VolumeName == "" {
+		return fmt.Errorf("the following field for \"VolumePublication\" is mandatory: volumeName")
+	}
+	if p.NodeName == "" {
+		return fmt.Errorf("the following field for \"VolumePublication\" is mandatory: nodeName")
+	}
+	return nil
+}
+
+// MakeVolumePublicationID combines a volume name and a node name into a single
+// identifier, since a volume is published at most once to each node.
+func MakeVolumePublicationID(volumeName, nodeName string) string {
+	return fmt.Sprintf("%s/%s", volumeName, nodeName)
+}
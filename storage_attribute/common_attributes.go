@@ -37,6 +37,11 @@ const (
 	StoragePools           = "storagePools"
 	AdditionalStoragePools = "additionalStoragePools"
 	ExcludeStoragePools    = "excludeStoragePools"
+	PlacementPolicy        = "placementPolicy"
+	PlacementLabel         = "placementLabel"
+	MinVolumeSize          = "minVolumeSize"
+	MaxVolumeSize          = "maxVolumeSize"
+	VolumeDefaultPrefix    = "defaults." // e.g. defaults.snapshotPolicy
 )
 
 var attrTypes = map[string]Type{
@@ -64,6 +64,17 @@ func (o *labelOffer) String() string {
 	return fmt.Sprintf("{Offers: %v}", o.Offers)
 }
 
+// GetLabelValue returns the value of the named label from a label offer, and whether
+// the offer contains that label.
+func GetLabelValue(offer Offer, labelName string) (string, bool) {
+	labels, ok := offer.(*labelOffer)
+	if !ok {
+		return "", false
+	}
+	value, ok := labels.Offers[labelName]
+	return value, ok
+}
+
 func NewLabelRequest(request string) (Request, error) {
 
 	log.WithField("request", request).Debug("NewLabelRequest")
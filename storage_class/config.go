@@ -18,6 +18,11 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 		RequiredStorage map[string][]string `json:"requiredStorage,omitempty"`
 		AdditionalPools map[string][]string `json:"additionalStoragePools,omitempty"`
 		ExcludePools    map[string][]string `json:"excludeStoragePools,omitempty"`
+		PlacementPolicy string              `json:"placementPolicy,omitempty"`
+		PlacementLabel  string              `json:"placementLabel,omitempty"`
+		VolumeDefaults  map[string]string   `json:"volumeDefaults,omitempty"`
+		MinVolumeSize   string              `json:"minVolumeSize,omitempty"`
+		MaxVolumeSize   string              `json:"maxVolumeSize,omitempty"`
 	}
 	err := json.Unmarshal(data, &tmp)
 	if err != nil {
@@ -36,6 +41,11 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	}
 
 	c.ExcludePools = tmp.ExcludePools
+	c.PlacementPolicy = tmp.PlacementPolicy
+	c.PlacementLabel = tmp.PlacementLabel
+	c.VolumeDefaults = tmp.VolumeDefaults
+	c.MinVolumeSize = tmp.MinVolumeSize
+	c.MaxVolumeSize = tmp.MaxVolumeSize
 
 	return err
 }
@@ -49,12 +59,22 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 		Pools           map[string][]string `json:"storagePools,omitempty"`
 		AdditionalPools map[string][]string `json:"additionalStoragePools,omitempty"`
 		ExcludePools    map[string][]string `json:"excludeStoragePools,omitempty"`
+		PlacementPolicy string              `json:"placementPolicy,omitempty"`
+		PlacementLabel  string              `json:"placementLabel,omitempty"`
+		VolumeDefaults  map[string]string   `json:"volumeDefaults,omitempty"`
+		MinVolumeSize   string              `json:"minVolumeSize,omitempty"`
+		MaxVolumeSize   string              `json:"maxVolumeSize,omitempty"`
 	}
 	tmp.Version = c.Version
 	tmp.Name = c.Name
 	tmp.Pools = c.Pools
 	tmp.AdditionalPools = c.AdditionalPools
 	tmp.ExcludePools = c.ExcludePools
+	tmp.PlacementPolicy = c.PlacementPolicy
+	tmp.PlacementLabel = c.PlacementLabel
+	tmp.VolumeDefaults = c.VolumeDefaults
+	tmp.MinVolumeSize = c.MinVolumeSize
+	tmp.MaxVolumeSize = c.MaxVolumeSize
 	attrs, err := storageattribute.MarshalRequestMap(c.Attributes)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,127 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storageclass
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// volumeDefaultFields maps the names of the volume settings that a storage class may
+// provide defaults for to the corresponding volume config fields.
+var volumeDefaultFields = map[string]func(*storage.VolumeConfig) *string{
+	"size":              func(c *storage.VolumeConfig) *string { return &c.Size },
+	"spaceReserve":      func(c *storage.VolumeConfig) *string { return &c.SpaceReserve },
+	"securityStyle":     func(c *storage.VolumeConfig) *string { return &c.SecurityStyle },
+	"snapshotPolicy":    func(c *storage.VolumeConfig) *string { return &c.SnapshotPolicy },
+	"snapshotReserve":   func(c *storage.VolumeConfig) *string { return &c.SnapshotReserve },
+	"snapshotDirectory": func(c *storage.VolumeConfig) *string { return &c.SnapshotDir },
+	"exportPolicy":      func(c *storage.VolumeConfig) *string { return &c.ExportPolicy },
+	"unixPermissions":   func(c *storage.VolumeConfig) *string { return &c.UnixPermissions },
+	"blockSize":         func(c *storage.VolumeConfig) *string { return &c.BlockSize },
+	"fileSystem":        func(c *storage.VolumeConfig) *string { return &c.FileSystem },
+	"encryption":        func(c *storage.VolumeConfig) *string { return &c.Encryption },
+	"splitOnClone":      func(c *storage.VolumeConfig) *string { return &c.SplitOnClone },
+	"qos":               func(c *storage.VolumeConfig) *string { return &c.QoS },
+	"serviceLevel":      func(c *storage.VolumeConfig) *string { return &c.ServiceLevel },
+}
+
+// sizeBytes parses a volume size, which may include units.  An empty size is zero.
+func sizeBytes(size string) (uint64, error) {
+	if size == "" {
+		return 0, nil
+	}
+	bytes, err := utils.ConvertSizeToBytes(size)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(bytes, 10, 64)
+}
+
+// ValidateVolumeDefaults checks that a storage class config only provides defaults for
+// known volume settings, and that its volume size limits are consistent.
+func (c *Config) ValidateVolumeDefaults() error {
+
+	for name, value := range c.VolumeDefaults {
+		if _, ok := volumeDefaultFields[name]; !ok {
+			names := make([]string, 0, len(volumeDefaultFields))
+			for n := range volumeDefaultFields {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("storage class %s can't provide a default for volume setting %s; "+
+				"acceptable settings: %s", c.Name, name, strings.Join(names, ", "))
+		}
+		if name == "size" {
+			if _, err := sizeBytes(value); err != nil {
+				return fmt.Errorf("invalid default volume size %s: %v", value, err)
+			}
+		}
+	}
+
+	minBytes, err := sizeBytes(c.MinVolumeSize)
+	if err != nil {
+		return fmt.Errorf("invalid minimum volume size %s: %v", c.MinVolumeSize, err)
+	}
+	maxBytes, err := sizeBytes(c.MaxVolumeSize)
+	if err != nil {
+		return fmt.Errorf("invalid maximum volume size %s: %v", c.MaxVolumeSize, err)
+	}
+	if maxBytes > 0 && minBytes > maxBytes {
+		return fmt.Errorf("minimum volume size %s exceeds maximum volume size %s",
+			c.MinVolumeSize, c.MaxVolumeSize)
+	}
+	return nil
+}
+
+// ApplyVolumeDefaults fills in each volume setting that a request left unspecified with
+// the storage class's default, if it has one.  A zero size counts as unspecified.
+func (s *StorageClass) ApplyVolumeDefaults(volConfig *storage.VolumeConfig) {
+
+	for name, value := range s.config.VolumeDefaults {
+		getField, ok := volumeDefaultFields[name]
+		if !ok {
+			continue
+		}
+		field := getField(volConfig)
+		if name == "size" {
+			// Sizes are passed to the backends in bytes
+			if bytes, err := sizeBytes(value); err == nil {
+				value = strconv.FormatUint(bytes, 10)
+			}
+			if *field == "0" {
+				*field = ""
+			}
+		}
+		if *field == "" {
+			*field = value
+		}
+	}
+}
+
+// CheckVolumeSize returns an error if a volume size lies outside the storage class's
+// limits.  Unspecified sizes are left for the backend to default, so they aren't checked.
+func (s *StorageClass) CheckVolumeSize(size string) error {
+
+	bytes, err := sizeBytes(size)
+	if err != nil {
+		return fmt.Errorf("invalid volume size %s: %v", size, err)
+	}
+	if bytes == 0 {
+		return nil
+	}
+	if minBytes, err := sizeBytes(s.config.MinVolumeSize); err == nil && bytes < minBytes {
+		return fmt.Errorf("volume size %s is smaller than the minimum size %s allowed by storage class %s",
+			size, s.config.MinVolumeSize, s.config.Name)
+	}
+	if maxBytes, err := sizeBytes(s.config.MaxVolumeSize); err == nil && maxBytes > 0 && bytes > maxBytes {
+		return fmt.Errorf("volume size %s is larger than the maximum size %s allowed by storage class %s",
+			size, s.config.MaxVolumeSize, s.config.Name)
+	}
+	return nil
+}
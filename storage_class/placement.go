@@ -0,0 +1,281 @@
+// Copyright 2019 NetApp, Inc. All Rights Reserved.
+
+package storageclass
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_attribute"
+)
+
+const (
+	PlacementPolicyRandom         = "random"
+	PlacementPolicyRoundRobin     = "roundRobin"
+	PlacementPolicyLeastAllocated = "leastAllocated"
+	PlacementPolicyFewestVolumes  = "fewestVolumes"
+	PlacementPolicyLabelWeighted  = "labelWeighted"
+)
+
+// PoolUsage summarizes the volumes that have been provisioned in a storage pool.
+type PoolUsage struct {
+	AllocatedBytes uint64
+	Volumes        int
+}
+
+// PlacementPolicy orders the storage pools that could host a new volume, so that
+// volume creation may be attempted on each pool in turn until one succeeds.
+// RankPools may advance the policy's state, so callers must serialize it; PreviewPools
+// ranks the pools the same way without changing any state, so that it may be used to
+// explain a placement, and may run concurrently with other previews.
+type PlacementPolicy interface {
+	Name() string
+	RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool
+	PreviewPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool
+}
+
+// NewPlacementPolicy returns the placement policy specified in a storage class config.
+// If the config doesn't specify a policy, pools are ranked randomly.
+func NewPlacementPolicy(c *Config) (PlacementPolicy, error) {
+
+	random := newRandom()
+
+	switch c.PlacementPolicy {
+	case "", PlacementPolicyRandom:
+		return &randomPolicy{random: random}, nil
+	case PlacementPolicyRoundRobin:
+		return &roundRobinPolicy{}, nil
+	case PlacementPolicyLeastAllocated:
+		return &leastAllocatedPolicy{random: random}, nil
+	case PlacementPolicyFewestVolumes:
+		return &fewestVolumesPolicy{random: random}, nil
+	case PlacementPolicyLabelWeighted:
+		if c.PlacementLabel == "" {
+			return nil, fmt.Errorf("the %s placement policy requires a placement label",
+				PlacementPolicyLabelWeighted)
+		}
+		return &labelWeightedPolicy{label: c.PlacementLabel, random: random}, nil
+	default:
+		return nil, fmt.Errorf("unknown placement policy: %s", c.PlacementPolicy)
+	}
+}
+
+// newRandom returns a new source of randomness for ranking pools.  A rand.Rand isn't
+// safe for concurrent use, so previews each use their own.
+func newRandom() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// shufflePools returns a copy of a pool list in random order.
+func shufflePools(pools []*storage.Pool, random *rand.Rand) []*storage.Pool {
+	ranked := make([]*storage.Pool, len(pools))
+	for i, j := range random.Perm(len(pools)) {
+		ranked[i] = pools[j]
+	}
+	return ranked
+}
+
+// randomPolicy distributes volumes across pools at random.
+type randomPolicy struct {
+	random *rand.Rand
+}
+
+func (p *randomPolicy) Name() string {
+	return PlacementPolicyRandom
+}
+
+func (p *randomPolicy) RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return shufflePools(pools, p.random)
+}
+
+func (p *randomPolicy) PreviewPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return shufflePools(pools, newRandom())
+}
+
+// roundRobinPolicy places each volume on the pool following the one chosen for the
+// previous volume, with pools ordered by backend and pool name.
+type roundRobinPolicy struct {
+	next int
+}
+
+func (p *roundRobinPolicy) Name() string {
+	return PlacementPolicyRoundRobin
+}
+
+func (p *roundRobinPolicy) RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+
+	if len(pools) == 0 {
+		return pools
+	}
+
+	start := p.next % len(pools)
+	p.next = start + 1
+
+	return p.rank(pools, start)
+}
+
+func (p *roundRobinPolicy) PreviewPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+
+	if len(pools) == 0 {
+		return pools
+	}
+	return p.rank(pools, p.next%len(pools))
+}
+
+// rank orders the pools by backend and pool name, starting with the pool at the
+// specified position.
+func (p *roundRobinPolicy) rank(pools []*storage.Pool, start int) []*storage.Pool {
+
+	sorted := make([]*storage.Pool, len(pools))
+	copy(sorted, pools)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Backend.Name != sorted[j].Backend.Name {
+			return sorted[i].Backend.Name < sorted[j].Backend.Name
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return append(sorted[start:], sorted[:start]...)
+}
+
+// leastAllocatedPolicy prefers the pools with the fewest bytes provisioned in them.
+type leastAllocatedPolicy struct {
+	random *rand.Rand
+}
+
+func (p *leastAllocatedPolicy) Name() string {
+	return PlacementPolicyLeastAllocated
+}
+
+func (p *leastAllocatedPolicy) RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return p.rank(pools, usage, p.random)
+}
+
+func (p *leastAllocatedPolicy) PreviewPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return p.rank(pools, usage, newRandom())
+}
+
+func (p *leastAllocatedPolicy) rank(
+	pools []*storage.Pool, usage map[*storage.Pool]PoolUsage, random *rand.Rand,
+) []*storage.Pool {
+
+	// Shuffle first so that ties are broken randomly
+	ranked := shufflePools(pools, random)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return usage[ranked[i]].AllocatedBytes < usage[ranked[j]].AllocatedBytes
+	})
+	return ranked
+}
+
+// fewestVolumesPolicy prefers the pools with the fewest volumes provisioned in them.
+type fewestVolumesPolicy struct {
+	random *rand.Rand
+}
+
+func (p *fewestVolumesPolicy) Name() string {
+	return PlacementPolicyFewestVolumes
+}
+
+func (p *fewestVolumesPolicy) RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return p.rank(pools, usage, p.random)
+}
+
+func (p *fewestVolumesPolicy) PreviewPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return p.rank(pools, usage, newRandom())
+}
+
+func (p *fewestVolumesPolicy) rank(
+	pools []*storage.Pool, usage map[*storage.Pool]PoolUsage, random *rand.Rand,
+) []*storage.Pool {
+
+	// Shuffle first so that ties are broken randomly
+	ranked := shufflePools(pools, random)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return usage[ranked[i]].Volumes < usage[ranked[j]].Volumes
+	})
+	return ranked
+}
+
+// labelWeightedPolicy distributes volumes across pools at random, in proportion to
+// the integer weight each pool specifies in the placement label.  Pools without a
+// positive weight are only tried after all weighted pools.
+type labelWeightedPolicy struct {
+	label  string
+	random *rand.Rand
+}
+
+func (p *labelWeightedPolicy) Name() string {
+	return PlacementPolicyLabelWeighted
+}
+
+// weight returns the weight of a pool as specified by its placement label.
+func (p *labelWeightedPolicy) weight(pool *storage.Pool) int {
+
+	offer, ok := pool.Attributes[storageattribute.Labels]
+	if !ok {
+		return 0
+	}
+	value, ok := storageattribute.GetLabelValue(offer, p.label)
+	if !ok {
+		return 0
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 0 {
+		log.WithFields(log.Fields{
+			"pool":    pool.Name,
+			"backend": pool.Backend.Name,
+			"label":   p.label,
+			"value":   value,
+		}).Warning("Invalid placement weight for storage pool.")
+		return 0
+	}
+	return weight
+}
+
+func (p *labelWeightedPolicy) RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return p.rank(pools, p.random)
+}
+
+func (p *labelWeightedPolicy) PreviewPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return p.rank(pools, newRandom())
+}
+
+func (p *labelWeightedPolicy) rank(pools []*storage.Pool, random *rand.Rand) []*storage.Pool {
+
+	weighted := make([]*storage.Pool, 0, len(pools))
+	weights := make([]int, 0, len(pools))
+	unweighted := make([]*storage.Pool, 0)
+	total := 0
+
+	for _, pool := range shufflePools(pools, random) {
+		if weight := p.weight(pool); weight > 0 {
+			weighted = append(weighted, pool)
+			weights = append(weights, weight)
+			total += weight
+		} else {
+			unweighted = append(unweighted, pool)
+		}
+	}
+
+	// Repeatedly choose among the remaining weighted pools in proportion to their weights
+	ranked := make([]*storage.Pool, 0, len(pools))
+	for len(weighted) > 0 {
+		choice := random.Intn(total)
+		i := 0
+		for ; choice >= weights[i]; i++ {
+			choice -= weights[i]
+		}
+		ranked = append(ranked, weighted[i])
+		total -= weights[i]
+		weighted = append(weighted[:i], weighted[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+
+	return append(ranked, unweighted...)
+}
@@ -20,9 +20,19 @@ func New(c *Config) *StorageClass {
 	if c.Version == "" {
 		c.Version = config.OrchestratorAPIVersion
 	}
+	policy, err := NewPlacementPolicy(c)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"storageClass":    c.Name,
+			"placementPolicy": c.PlacementPolicy,
+			"error":           err,
+		}).Warning("Invalid placement policy, so volumes will be placed randomly.")
+		policy, _ = NewPlacementPolicy(&Config{})
+	}
 	return &StorageClass{
 		config: c,
 		pools:  make([]*storage.Pool, 0),
+		policy: policy,
 	}
 }
 
@@ -48,10 +58,7 @@ func NewFromAttributes(attributes map[string]storageattribute.Request) *StorageC
 		AdditionalPools: make(map[string][]string),
 		ExcludePools:    make(map[string][]string),
 	}
-	return &StorageClass{
-		config: cfg,
-		pools:  make([]*storage.Pool, 0),
-	}
+	return New(cfg)
 }
 
 func (s *StorageClass) regexMatcherImpl(storagePool *storage.Pool, storagePoolBackendName string, storagePoolList []string) bool {
@@ -132,6 +139,12 @@ func (s *StorageClass) regexMatcher(storagePool *storage.Pool, poolMap map[strin
 }
 
 func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
+	return s.ExplainMatch(storagePool).Matched
+}
+
+// ExplainMatch checks whether a storage pool satisfies the storage class, recording the
+// outcome of each of the storage class's criteria.
+func (s *StorageClass) ExplainMatch(storagePool *storage.Pool) *PoolExplanation {
 
 	log.WithFields(log.Fields{
 		"storageClass": s.GetName(),
@@ -140,17 +153,35 @@ func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
 		"poolBackend":  storagePool.Backend.Name,
 	}).Debug("Checking if storage pool matches.")
 
+	explanation := &PoolExplanation{
+		Backend: storagePool.Backend.Name,
+		Pool:    storagePool.Name,
+		Matched: true,
+		Checks:  make([]Check, 0),
+	}
+
 	// Check excludeStoragePools first, since it can reject a match
 	if len(s.config.ExcludePools) > 0 {
 		if matches := s.regexMatcher(storagePool, s.config.ExcludePools); matches {
-			return false
+			explanation.AddCheck(Check{
+				Criterion: "excludeStoragePools",
+				Passed:    false,
+				Reason:    "pool is excluded by the storage class",
+			})
+			return explanation
 		}
+		explanation.AddCheck(Check{Criterion: "excludeStoragePools", Passed: true})
 	}
 
 	// Check additionalStoragePools next, since it can yield a match result by itself
 	if len(s.config.AdditionalPools) > 0 {
 		if matches := s.regexMatcher(storagePool, s.config.AdditionalPools); matches {
-			return true
+			explanation.AddCheck(Check{
+				Criterion: "additionalStoragePools",
+				Passed:    true,
+				Reason:    "pool is added by the storage class regardless of its other criteria",
+			})
+			return explanation
 		}
 
 		// Handle the sub-case where additionalStoragePools is specified (but didn't match) and
@@ -161,22 +192,45 @@ func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
 				"storageClass": s.GetName(),
 				"pool":         storagePool.Name,
 			}).Debug("Pool failed to match storage class additionalStoragePools attribute.")
-			return false
+			explanation.AddCheck(Check{
+				Criterion: "additionalStoragePools",
+				Passed:    false,
+				Reason:    "pool isn't one of the storage class's additional storage pools",
+			})
+			return explanation
 		}
 	}
 
 	// Attributes are used to narrow the pool selection.  Therefore if no attributes are
 	// specified, then all pools can match.  If one or more attributes are specified in the
-	// storage class, then all must match.
+	// storage class, then all must match.  Every attribute is checked, so that the
+	// explanation is complete.
 	attributesMatch := true
-	for name, request := range s.config.Attributes {
+	names := make([]string, 0, len(s.config.Attributes))
+	for name := range s.config.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, requestName := range names {
+
+		request := s.config.Attributes[requestName]
+		check := Check{
+			Criterion: requestName,
+			Requested: request.String(),
+			Passed:    true,
+		}
 
 		// Remap the "selector" storage class attribute to the "labels" pool attribute
+		name := requestName
 		if name == "selector" {
 			name = "labels"
 		}
 
-		if offer, ok := storagePool.Attributes[name]; !ok || !offer.Matches(request) {
+		offer, ok := storagePool.Attributes[name]
+		if ok {
+			check.Offered = fmt.Sprintf("%v", offer)
+		}
+		if !ok || !offer.Matches(request) {
 			log.WithFields(log.Fields{
 				"offer":        offer,
 				"request":      request,
@@ -186,8 +240,14 @@ func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
 				"found":        ok,
 			}).Debug("Attribute for storage pool failed to match storage class.")
 			attributesMatch = false
-			break
+			check.Passed = false
+			if !ok {
+				check.Reason = fmt.Sprintf("pool doesn't offer attribute %s", name)
+			} else {
+				check.Reason = "pool's offer doesn't satisfy the request"
+			}
 		}
+		explanation.AddCheck(check)
 	}
 
 	// The storagePools list is used to narrow the pool selection.  Therefore, if no pools are
@@ -196,6 +256,11 @@ func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
 	poolsMatch := true
 	if len(s.config.Pools) > 0 {
 		poolsMatch = s.regexMatcher(storagePool, s.config.Pools)
+		check := Check{Criterion: "storagePools", Passed: poolsMatch}
+		if !poolsMatch {
+			check.Reason = "pool isn't one of the storage class's storage pools"
+		}
+		explanation.AddCheck(check)
 	}
 
 	result := attributesMatch && poolsMatch
@@ -208,7 +273,7 @@ func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
 		"storageClass":    s.GetName(),
 	}).Debug("Result of pool match for storage class.")
 
-	return result
+	return explanation
 }
 
 // CheckAndAddBackend iterates through each of the storage pools
@@ -291,6 +356,18 @@ func (s *StorageClass) GetStoragePoolsForProtocol(p config.Protocol) []*storage.
 	return ret
 }
 
+// RankPools orders the specified pools according to the storage class's placement policy,
+// most preferred first.
+func (s *StorageClass) RankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return s.policy.RankPools(pools, usage)
+}
+
+// PreviewRankPools orders the specified pools as RankPools would, without advancing the
+// placement policy, so that the next volume is still placed as it would have been.
+func (s *StorageClass) PreviewRankPools(pools []*storage.Pool, usage map[*storage.Pool]PoolUsage) []*storage.Pool {
+	return s.policy.PreviewPools(pools, usage)
+}
+
 func (s *StorageClass) Pools() []*storage.Pool {
 	return s.pools
 }
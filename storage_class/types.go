@@ -3,6 +3,7 @@
 package storageclass
 
 import (
+	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/storage_attribute"
 )
@@ -10,6 +11,7 @@ import (
 type StorageClass struct {
 	config *Config
 	pools  []*storage.Pool
+	policy PlacementPolicy
 }
 
 type Config struct {
@@ -21,6 +23,11 @@ type Config struct {
 	Pools           map[string][]string                 `json:"storagePools,omitempty"`
 	AdditionalPools map[string][]string                 `json:"additionalStoragePools,omitempty"`
 	ExcludePools    map[string][]string                 `json:"excludeStoragePools,omitempty"`
+	PlacementPolicy string                              `json:"placementPolicy,omitempty"`
+	PlacementLabel  string                              `json:"placementLabel,omitempty"`
+	VolumeDefaults  map[string]string                   `json:"volumeDefaults,omitempty"`
+	MinVolumeSize   string                              `json:"minVolumeSize,omitempty"`
+	MaxVolumeSize   string                              `json:"maxVolumeSize,omitempty"`
 }
 
 type External struct {
@@ -35,3 +42,41 @@ type External struct {
 type Persistent struct {
 	Config *Config `json:"config"`
 }
+
+// Check records the outcome of one of the criteria that decide where a volume may be
+// provisioned.
+type Check struct {
+	Criterion string `json:"criterion"`
+	Requested string `json:"requested,omitempty"`
+	Offered   string `json:"offered,omitempty"`
+	Passed    bool   `json:"passed"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// PoolExplanation reports whether a storage pool may hold a volume, and why.
+type PoolExplanation struct {
+	Backend string  `json:"backend"`
+	Pool    string  `json:"pool"`
+	Matched bool    `json:"matched"`
+	Checks  []Check `json:"checks"`
+}
+
+// AddCheck records the outcome of a criterion, and marks the pool as unmatched if it failed.
+func (e *PoolExplanation) AddCheck(check Check) {
+	e.Checks = append(e.Checks, check)
+	if !check.Passed {
+		e.Matched = false
+	}
+}
+
+// VolumeExplanation reports where a volume could be provisioned, and why, without
+// creating anything.  Checks holds the criteria that apply to the volume as a whole.
+type VolumeExplanation struct {
+	Volume        string             `json:"volume"`
+	StorageClass  string             `json:"storageClass"`
+	Protocol      config.Protocol    `json:"protocol"`
+	Size          string             `json:"size"`
+	Provisionable bool               `json:"provisionable"`
+	Checks        []Check            `json:"checks"`
+	Pools         []*PoolExplanation `json:"pools"`
+}
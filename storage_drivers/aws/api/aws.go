@@ -718,6 +718,63 @@ func (d *Client) CreateSnapshot(request *SnapshotCreateRequest) (*Snapshot, erro
 	return &snapshot, nil
 }
 
+func (d *Client) DeleteSnapshot(filesystem *FileSystem, snapshot *Snapshot) error {
+
+	resourcePath := fmt.Sprintf("/FileSystems/%s/Snapshots/%s", filesystem.FileSystemID, snapshot.SnapshotID)
+
+	response, responseBody, err := d.InvokeAPI(nil, "DELETE", d.makeURL(resourcePath))
+	if err != nil {
+		return errors.New("failed to delete snapshot")
+	}
+
+	err = d.getErrorFromAPIResponse(response, responseBody)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"snapshot": snapshot.Name,
+		"volume":   filesystem.CreationToken,
+	}).Debug("Deleted snapshot.")
+
+	return nil
+}
+
+// RestoreSnapshot reverts a filesystem to one of its snapshots
+func (d *Client) RestoreSnapshot(filesystem *FileSystem, snapshot *Snapshot) error {
+
+	resourcePath := fmt.Sprintf("/FileSystems/%s/Revert", filesystem.FileSystemID)
+
+	request := &SnapshotRevertRequest{
+		FileSystemID: filesystem.FileSystemID,
+		Region:       filesystem.Region,
+		SnapshotID:   snapshot.SnapshotID,
+	}
+
+	jsonRequest, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("could not marshal JSON request: %v; %v", request, err)
+	}
+
+	response, responseBody, err := d.InvokeAPI(jsonRequest, "POST", d.makeURL(resourcePath))
+	if err != nil {
+		return err
+	}
+
+	err = d.getErrorFromAPIResponse(response, responseBody)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"snapshot":   snapshot.Name,
+		"volume":     filesystem.CreationToken,
+		"statusCode": response.StatusCode,
+	}).Debug("Filesystem reverted to snapshot.")
+
+	return nil
+}
+
 func (d *Client) getErrorFromAPIResponse(response *http.Response, responseBody []byte) error {
 
 	if response.StatusCode >= 300 {
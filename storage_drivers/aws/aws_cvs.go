@@ -886,21 +886,82 @@ func (d *NFSStorageDriver) Publish(name string, publishInfo *utils.VolumePublish
 	return nil
 }
 
-// Return the list of snapshots associated with the named volume
-func (d *NFSStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func (d *NFSStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "NFSStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "NFSStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
 	// Get the volume
-	creationToken := name
+	creationToken := internalVolName
+
+	volumeExists, extantVolume, err := d.API.VolumeExistsByCreationToken(creationToken)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for existing volume %s: %v", creationToken, err)
+	}
+	if !volumeExists {
+		// If the volume doesn't exist, neither does the snapshot
+		return nil, nil
+	}
+
+	snapshots, err := d.API.GetSnapshotsForVolume(extantVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range *snapshots {
+		if snapshot.Name == internalSnapName {
+
+			created := snapshot.Created.UTC().Format(time.RFC3339)
+
+			log.WithFields(log.Fields{
+				"snapshotName": internalSnapName,
+				"volumeName":   internalVolName,
+				"created":      created,
+			}).Debug("Found snapshot.")
+
+			return storage.NewSnapshot(snapConfig, created, extantVolume.QuotaInBytes,
+				snapshotStateFromLifeCycleState(snapshot.LifeCycleState)), nil
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Warning("Snapshot not found.")
+
+	return nil, nil
+}
+
+// Return the list of snapshots associated with the specified volume
+func (d *NFSStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	internalVolName := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "NFSStorageDriver",
+			"volumeName": internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+	}
+
+	// Get the volume
+	creationToken := internalVolName
 
 	volume, err := d.API.GetVolumeByCreationToken(creationToken)
 	if err != nil {
@@ -912,7 +973,7 @@ func (d *NFSStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error)
 		return nil, err
 	}
 
-	snapshotList := make([]storage.Snapshot, 0)
+	snapshotList := make([]*storage.Snapshot, 0)
 
 	for _, snapshot := range *snapshots {
 
@@ -921,15 +982,164 @@ func (d *NFSStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error)
 			continue
 		}
 
-		snapshotList = append(snapshotList, storage.Snapshot{
-			Name:    snapshot.Name,
-			Created: snapshot.Created.Format(time.RFC3339),
-		})
+		snapshotConfig := &storage.SnapshotConfig{
+			Version:            tridentconfig.OrchestratorAPIVersion,
+			Name:               snapshot.Name,
+			InternalName:       snapshot.Name,
+			VolumeName:         volConfig.Name,
+			VolumeInternalName: volConfig.InternalName,
+		}
+
+		snapshotList = append(snapshotList, storage.NewSnapshot(snapshotConfig,
+			snapshot.Created.UTC().Format(time.RFC3339), volume.QuotaInBytes,
+			storage.SnapshotStateOnline))
 	}
 
 	return snapshotList, nil
 }
 
+// CreateSnapshot creates a snapshot for the given volume
+func (d *NFSStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "NFSStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	// Check if volume exists
+	volumeExists, sourceVolume, err := d.API.VolumeExistsByCreationToken(internalVolName)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for existing volume %s: %v", internalVolName, err)
+	}
+	if !volumeExists {
+		return nil, fmt.Errorf("volume %s does not exist", internalVolName)
+	}
+
+	snapshotCreateRequest := &api.SnapshotCreateRequest{
+		FileSystemID: sourceVolume.FileSystemID,
+		Name:         internalSnapName,
+		Region:       sourceVolume.Region,
+	}
+
+	snapshot, err := d.API.CreateSnapshot(snapshotCreateRequest)
+	if err != nil {
+		return nil, fmt.Errorf("could not create snapshot: %v", err)
+	}
+
+	// Wait for snapshot creation to complete
+	err = d.API.WaitForSnapshotState(snapshot, api.StateAvailable, []string{api.StateError})
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": snapConfig.InternalName,
+		"volumeName":   snapConfig.VolumeInternalName,
+	}).Info("Snapshot created.")
+
+	return storage.NewSnapshot(snapConfig, snapshot.Created.UTC().Format(time.RFC3339),
+		sourceVolume.QuotaInBytes, storage.SnapshotStateOnline), nil
+}
+
+// DeleteSnapshot deletes a snapshot of a volume
+func (d *NFSStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "NFSStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	// If volume doesn't exist, the snapshot is already gone
+	volumeExists, extantVolume, err := d.API.VolumeExistsByCreationToken(internalVolName)
+	if err != nil {
+		return fmt.Errorf("error checking for existing volume %s: %v", internalVolName, err)
+	}
+	if !volumeExists {
+		log.WithField("volume", internalVolName).Warn("Volume for snapshot not found.")
+		return nil
+	}
+
+	snapshots, err := d.API.GetSnapshotsForVolume(extantVolume)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range *snapshots {
+		if snapshot.Name == internalSnapName {
+			return d.API.DeleteSnapshot(extantVolume, &snapshot)
+		}
+	}
+
+	// If snapshot doesn't exist, return success
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Warn("Snapshot already deleted.")
+
+	return nil
+}
+
+// RestoreSnapshot reverts a volume to a snapshot
+func (d *NFSStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "NFSStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	volume, err := d.API.GetVolumeByCreationToken(internalVolName)
+	if err != nil {
+		return fmt.Errorf("could not find volume %s: %v", internalVolName, err)
+	}
+
+	snapshot, err := d.API.GetSnapshotForVolume(volume, internalSnapName)
+	if err != nil {
+		return fmt.Errorf("could not find snapshot %s of volume %s: %v", internalSnapName, internalVolName, err)
+	}
+
+	if err = d.API.RestoreSnapshot(volume, snapshot); err != nil {
+		return fmt.Errorf("could not restore snapshot %s of volume %s: %v", internalSnapName, internalVolName, err)
+	}
+
+	// Wait for the volume to become available again
+	return d.API.WaitForVolumeState(volume, api.StateAvailable, []string{api.StateError})
+}
+
+// snapshotStateFromLifeCycleState maps a CVS snapshot lifecycle state to a Trident snapshot state
+func snapshotStateFromLifeCycleState(lifeCycleState string) storage.SnapshotState {
+	if lifeCycleState == api.StateAvailable {
+		return storage.SnapshotStateOnline
+	}
+	return storage.SnapshotStateCreating
+}
+
 // Return the list of volumes associated with this tenant
 func (d *NFSStorageDriver) List() ([]string, error) {
 
@@ -1040,6 +1250,34 @@ func (d *NFSStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 	return nil
 }
 
+// CheckHealth verifies that the CVS API can be reached with the configured credentials.
+func (d *NFSStorageDriver) CheckHealth() error {
+
+	if _, _, err := d.API.GetVersion(); err != nil {
+		return fmt.Errorf("could not read CVS API version: %v", err)
+	}
+	return nil
+}
+
+// ListVolumes returns the creation tokens of all available volumes in the account.
+func (d *NFSStorageDriver) ListVolumes() ([]string, error) {
+
+	volumes, err := d.API.GetVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(*volumes))
+	for _, volume := range *volumes {
+		switch volume.LifeCycleState {
+		case api.StateDisabled, api.StateDeleting, api.StateDeleted, api.StateError:
+			continue
+		}
+		names = append(names, volume.CreationToken)
+	}
+	return names, nil
+}
+
 func (d *NFSStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) error {
 
 	if volConfig.InternalName == "" {
@@ -126,7 +126,9 @@ type VolumeGroupEx struct {
 	WorldWideName  string `json:"worldWideName"`
 	VolumeGroupRef string `json:"volumeGroupRef"`
 	Label          string `json:"label"`
-	FreeSpace      string `json:"freeSpace"`      // Documentation says this is an int but really it is a string!
+	FreeSpace      string `json:"freeSpace"` // Documentation says this is an int but really it is a string!
+	UsedSpace      string `json:"usedSpace"` // Also a string, like FreeSpace
+	TotalSpace     string `json:"totalRaidedSpace"`
 	DriveMediaType string `json:"driveMediaType"` // 'hdd', 'ssd'
 }
 
@@ -618,21 +618,93 @@ func (d *SANStorageDriver) MapVolumeToLocalHost(volume api.VolumeEx) (api.LUNMap
 	return mapping, nil
 }
 
-// SnapshotList returns the list of snapshots associated with the named volume. The E-series volume plugin does not support snapshots,
-// so this method always returns an empty array.
-func (d *SANStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot returns a snapshot of a volume.  The E-series volume plugin does not support snapshots,
+// so this method always returns nil.
+func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "SANStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+	}
+
+	return nil, nil
+}
+
+// GetSnapshots returns the list of snapshots associated with the specified volume. The E-series volume
+// plugin does not support snapshots, so this method always returns an empty array.
+func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "SANStorageDriver",
+			"volumeName": volConfig.InternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+	}
+
+	return make([]*storage.Snapshot, 0), nil
+}
+
+// CreateSnapshot creates a snapshot for the given volume. The E-series volume plugin does not support
+// snapshots, so this method always returns an error.
+func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	return nil, fmt.Errorf("snapshots are not supported by backend type %s", d.Name())
+}
+
+// DeleteSnapshot deletes a snapshot of a volume. The E-series volume plugin does not support snapshots,
+// so this method always returns an error.
+func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
-	return make([]storage.Snapshot, 0), nil
+	return fmt.Errorf("snapshots are not supported by backend type %s", d.Name())
+}
+
+// RestoreSnapshot reverts a volume to a snapshot. The E-series volume plugin does not support snapshots,
+// so this method always returns an error.
+func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	return fmt.Errorf("snapshots are not supported by backend type %s", d.Name())
 }
 
 // CreateClone creates a new volume from the named volume, either by direct clone or from the named snapshot. The E-series volume plugin
@@ -734,6 +806,8 @@ func (d *SANStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 		vc.Attributes[sa.Encryption] = sa.NewBoolOffer(false)
 		vc.Attributes[sa.ProvisioningType] = sa.NewStringOffer("thick")
 
+		vc.Capacity = d.getPoolCapacity(pool)
+
 		backend.AddStoragePool(vc)
 
 		log.WithFields(log.Fields{
@@ -746,6 +820,44 @@ func (d *SANStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 	return nil
 }
 
+// getPoolCapacity returns the size and used space of a volume group or disk pool, or
+// an unknown capacity if the array didn't report them.
+func (d *SANStorageDriver) getPoolCapacity(pool api.VolumeGroupEx) storage.PoolCapacity {
+
+	totalBytes, totalErr := strconv.ParseUint(pool.TotalSpace, 10, 64)
+	usedBytes, usedErr := strconv.ParseUint(pool.UsedSpace, 10, 64)
+	if totalErr != nil || usedErr != nil {
+		return storage.PoolCapacity{}
+	}
+	return storage.PoolCapacity{TotalBytes: totalBytes, UsedBytes: usedBytes}
+}
+
+// GetPoolCapacity reports the size and used space of each storage pool on the array.
+func (d *SANStorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+
+	pools, err := d.API.GetVolumePools("", 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not get storage pools from array: %v", err)
+	}
+
+	capacity := make(map[string]storage.PoolCapacity)
+	for _, pool := range pools {
+		if poolCapacity := d.getPoolCapacity(pool); poolCapacity.Known() {
+			capacity[pool.Label] = poolCapacity
+		}
+	}
+	return capacity, nil
+}
+
+// CheckHealth verifies that the Web Services Proxy can reach the array with the configured credentials.
+func (d *SANStorageDriver) CheckHealth() error {
+
+	if _, err := d.API.GetStorageSystem(); err != nil {
+		return fmt.Errorf("could not read storage system: %v", err)
+	}
+	return nil
+}
+
 func (d *SANStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) error {
 
 	// 1. Sanitize the volume name
@@ -9,8 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/RoaringBitmap/roaring"
 	log "github.com/sirupsen/logrus"
@@ -48,8 +51,20 @@ type StorageDriver struct {
 	// state.
 	DestroyedVolumes map[string]bool
 
+	// Snapshots saves info about Snapshots created on this driver, keyed
+	// by the internal volume name and then the internal snapshot name
+	Snapshots map[string]map[string]*storage.Snapshot
+
+	// HealthError is returned by CheckHealth, so that tests can simulate
+	// a storage system that can't be reached.
+	HealthError error
+
 	physicalPools map[string]*storage.Pool
 	virtualPools  map[string]*storage.Pool
+
+	// mutex guards the volumes, snapshots and pool sizes, since the orchestrator
+	// may call the driver for several volumes at once
+	mutex sync.Mutex
 }
 
 func NewFakeStorageBackend(configJSON string) (sb *storage.Backend, err error) {
@@ -79,6 +94,7 @@ func NewFakeStorageDriver(config drivers.FakeStorageDriverConfig) *StorageDriver
 		Config:           config,
 		Volumes:          make(map[string]fake.Volume),
 		DestroyedVolumes: make(map[string]bool),
+		Snapshots:        make(map[string]map[string]*storage.Snapshot),
 	}
 	driver.populateConfigurationDefaults(&config)
 	driver.initializeStoragePools()
@@ -166,6 +182,7 @@ func (d *StorageDriver) Initialize(
 
 	d.Volumes = make(map[string]fake.Volume)
 	d.DestroyedVolumes = make(map[string]bool)
+	d.Snapshots = make(map[string]map[string]*storage.Snapshot)
 	d.Config.SerialNumbers = []string{d.Config.InstanceName + "_SN"}
 
 	s, _ := json.Marshal(d.Config)
@@ -382,6 +399,8 @@ func (d *StorageDriver) validate() error {
 func (d *StorageDriver) Create(
 	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	name := volConfig.InternalName
 	if _, ok := d.Volumes[name]; ok {
@@ -506,6 +525,8 @@ func (d *StorageDriver) getPoolsForCreate(
 }
 
 func (d *StorageDriver) CreateClone(volConfig *storage.VolumeConfig) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	name := volConfig.InternalName
 	source := volConfig.CloneSourceVolumeInternal
@@ -559,6 +580,8 @@ func (d *StorageDriver) CreateClone(volConfig *storage.VolumeConfig) error {
 }
 
 func (d *StorageDriver) Import(volumeConfig *storage.VolumeConfig, originalName string, notManaged bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	log.WithFields(log.Fields{
 		"volumeConfig": volumeConfig,
@@ -581,6 +604,8 @@ func (d *StorageDriver) Import(volumeConfig *storage.VolumeConfig, originalName
 }
 
 func (d *StorageDriver) Rename(name string, newName string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	log.WithFields(log.Fields{
 		"name":    name,
@@ -598,6 +623,8 @@ func (d *StorageDriver) Rename(name string, newName string) error {
 }
 
 func (d *StorageDriver) Destroy(name string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	d.DestroyedVolumes[name] = true
 
@@ -614,6 +641,7 @@ func (d *StorageDriver) Destroy(name string) error {
 
 	fakePool.Bytes += volume.SizeBytes
 	delete(d.Volumes, name)
+	delete(d.Snapshots, name)
 
 	log.WithFields(log.Fields{
 		"backend":       d.Config.InstanceName,
@@ -630,11 +658,114 @@ func (d *StorageDriver) Publish(name string, publishInfo *utils.VolumePublishInf
 	return errors.New("fake driver does not support Publish")
 }
 
-func (d *StorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
-	return nil, errors.New("fake driver does not support SnapshotList")
+// GetSnapshot returns a snapshot of a volume, or nil if the snapshot does not exist.
+func (d *StorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	snapshots, ok := d.Snapshots[snapConfig.VolumeInternalName]
+	if !ok {
+		return nil, nil
+	}
+
+	snapshot, ok := snapshots[snapConfig.InternalName]
+	if !ok {
+		return nil, nil
+	}
+
+	return snapshot, nil
+}
+
+// GetSnapshots returns the list of snapshots associated with the specified volume.
+func (d *StorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	snapshotList := make([]*storage.Snapshot, 0)
+
+	for _, snapshot := range d.Snapshots[volConfig.InternalName] {
+		snapshotList = append(snapshotList, snapshot)
+	}
+
+	return snapshotList, nil
+}
+
+// CreateSnapshot creates a snapshot for the given volume.
+func (d *StorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	volume, ok := d.Volumes[snapConfig.VolumeInternalName]
+	if !ok {
+		return nil, fmt.Errorf("could not find volume %s", snapConfig.VolumeInternalName)
+	}
+
+	if _, ok = d.Snapshots[volume.Name]; !ok {
+		d.Snapshots[volume.Name] = make(map[string]*storage.Snapshot)
+	}
+
+	if _, ok = d.Snapshots[volume.Name][snapConfig.InternalName]; ok {
+		return nil, fmt.Errorf("snapshot %s already exists on volume %s", snapConfig.InternalName, volume.Name)
+	}
+
+	snapshot := storage.NewSnapshot(snapConfig, time.Now().UTC().Format(time.RFC3339),
+		int64(volume.SizeBytes), storage.SnapshotStateOnline)
+	d.Snapshots[volume.Name][snapConfig.InternalName] = snapshot
+
+	log.WithFields(log.Fields{
+		"backend":      d.Config.InstanceName,
+		"volumeName":   volume.Name,
+		"snapshotName": snapConfig.InternalName,
+	}).Debug("Created fake snapshot.")
+
+	return snapshot, nil
+}
+
+// DeleteSnapshot deletes a snapshot of a volume.
+func (d *StorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if snapshots, ok := d.Snapshots[snapConfig.VolumeInternalName]; ok {
+		delete(snapshots, snapConfig.InternalName)
+	}
+
+	log.WithFields(log.Fields{
+		"backend":      d.Config.InstanceName,
+		"volumeName":   snapConfig.VolumeInternalName,
+		"snapshotName": snapConfig.InternalName,
+	}).Debug("Deleted fake snapshot.")
+
+	return nil
+}
+
+// RestoreSnapshot reverts a volume to a snapshot.  Fake volumes have no data, so this
+// only checks that the snapshot exists.
+func (d *StorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	snapshots, ok := d.Snapshots[snapConfig.VolumeInternalName]
+	if !ok {
+		return fmt.Errorf("could not find volume %s", snapConfig.VolumeInternalName)
+	}
+	if _, ok = snapshots[snapConfig.InternalName]; !ok {
+		return fmt.Errorf("could not find snapshot %s on volume %s", snapConfig.InternalName,
+			snapConfig.VolumeInternalName)
+	}
+
+	log.WithFields(log.Fields{
+		"backend":      d.Config.InstanceName,
+		"volumeName":   snapConfig.VolumeInternalName,
+		"snapshotName": snapConfig.InternalName,
+	}).Debug("Restored fake snapshot.")
+
+	return nil
 }
 
 func (d *StorageDriver) Get(name string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	_, ok := d.Volumes[name]
 	if !ok {
@@ -646,6 +777,9 @@ func (d *StorageDriver) Get(name string) error {
 
 // Resize expands the volume size.
 func (d *StorageDriver) Resize(name string, sizeBytes uint64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	vol := d.Volumes[name]
 
 	if vol.SizeBytes == sizeBytes {
@@ -673,8 +807,11 @@ func (d *StorageDriver) GetStorageBackendSpecs(backend *storage.Backend) error {
 
 	virtual := len(d.virtualPools) > 0
 
-	for _, pool := range d.physicalPools {
+	capacity, _ := d.GetPoolCapacity()
+
+	for name, pool := range d.physicalPools {
 		pool.Backend = backend
+		pool.Capacity = capacity[name]
 		if !virtual {
 			backend.AddStoragePool(pool)
 		}
@@ -690,6 +827,133 @@ func (d *StorageDriver) GetStorageBackendSpecs(backend *storage.Backend) error {
 	return nil
 }
 
+// GetPoolCapacity reports the capacity of each physical pool, which is the sum of the
+// space remaining in the pool and the sizes of the volumes created in it.
+func (d *StorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	capacity := make(map[string]storage.PoolCapacity)
+	for name, fakePool := range d.Config.Pools {
+		capacity[name] = storage.PoolCapacity{TotalBytes: fakePool.Bytes}
+	}
+	for _, volume := range d.Volumes {
+		if poolCapacity, ok := capacity[volume.PhysicalPool]; ok {
+			poolCapacity.TotalBytes += volume.SizeBytes
+			poolCapacity.UsedBytes += volume.SizeBytes
+			capacity[volume.PhysicalPool] = poolCapacity
+		}
+	}
+	return capacity, nil
+}
+
+// CheckHealth returns the error, if any, that the test has set in HealthError.
+func (d *StorageDriver) CheckHealth() error {
+	return d.HealthError
+}
+
+// ListVolumes returns the names of all fake volumes, sorted so that tests are repeatable.
+func (d *StorageDriver) ListVolumes() ([]string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	names := make([]string, 0, len(d.Volumes))
+	for name := range d.Volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// MoveVolume moves a fake volume to another physical pool, if that pool has room for it.
+func (d *StorageDriver) MoveVolume(volConfig *storage.VolumeConfig, pool *storage.Pool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	volume, ok := d.Volumes[volConfig.InternalName]
+	if !ok {
+		return fmt.Errorf("volume %s not found", volConfig.InternalName)
+	}
+	if _, ok = d.physicalPools[pool.Name]; !ok {
+		return fmt.Errorf("volumes may only be moved to physical pools, not %s", pool.Name)
+	}
+	if volume.PhysicalPool == pool.Name {
+		return nil
+	}
+
+	newPool, ok := d.Config.Pools[pool.Name]
+	if !ok {
+		return fmt.Errorf("fake pool %s not found", pool.Name)
+	}
+	if volume.SizeBytes > newPool.Bytes {
+		return fmt.Errorf("requested volume is too large, requested %d bytes, have %d available in pool %s",
+			volume.SizeBytes, newPool.Bytes, pool.Name)
+	}
+	if oldPool, ok := d.Config.Pools[volume.PhysicalPool]; ok {
+		oldPool.Bytes += volume.SizeBytes
+	}
+	newPool.Bytes -= volume.SizeBytes
+
+	volume.RequestedPool = pool.Name
+	volume.PhysicalPool = pool.Name
+	d.Volumes[volConfig.InternalName] = volume
+
+	log.WithFields(log.Fields{
+		"backend": d.Config.InstanceName,
+		"name":    volConfig.InternalName,
+		"pool":    pool.Name,
+	}).Debug("Moved fake volume.")
+
+	return nil
+}
+
+// CanReplicateFrom reports whether volumes may be replicated from the source driver.  Much as
+// storage systems must be peered before they replicate, fake backends replicate only from
+// other fake backends in the same region.
+func (d *StorageDriver) CanReplicateFrom(source storage.Driver) bool {
+	fakeSource, ok := source.(*StorageDriver)
+	return ok && fakeSource.Config.Region == d.Config.Region
+}
+
+func (d *StorageDriver) StartReplication(
+	source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig, pool *storage.Pool,
+) error {
+
+	if !d.CanReplicateFrom(source) {
+		return fmt.Errorf("volumes cannot be replicated from a %s backend", source.Name())
+	}
+	if err := source.(*StorageDriver).Get(sourceConfig.InternalName); err != nil {
+		return fmt.Errorf("source volume %s not found", sourceConfig.InternalName)
+	}
+	return d.Create(volConfig, pool, make(map[string]sa.Request))
+}
+
+func (d *StorageDriver) WaitForReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	return nil
+}
+
+func (d *StorageDriver) CompleteReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+
+	if err := source.(*StorageDriver).Get(sourceConfig.InternalName); err != nil {
+		return fmt.Errorf("source volume %s not found", sourceConfig.InternalName)
+	}
+	if err := d.Get(volConfig.InternalName); err != nil {
+		return fmt.Errorf("replica volume %s not found", volConfig.InternalName)
+	}
+
+	log.WithFields(log.Fields{
+		"backend": d.Config.InstanceName,
+		"source":  sourceConfig.InternalName,
+		"name":    volConfig.InternalName,
+	}).Debug("Replicated fake volume.")
+
+	return nil
+}
+
+func (d *StorageDriver) AbortReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	return nil
+}
+
 func (d *StorageDriver) GetInternalVolumeName(name string) string {
 	if tridentconfig.UsingPassthroughStore {
 		// With a passthrough store, the name mapping must remain reversible
@@ -768,6 +1032,8 @@ func (d *StorageDriver) GetExternalConfig() interface{} {
 }
 
 func (d *StorageDriver) GetVolumeExternal(name string) (*storage.VolumeExternal, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
 	volume, ok := d.Volumes[name]
 	if !ok {
@@ -784,7 +1050,13 @@ func (d *StorageDriver) GetVolumeExternalWrappers(
 	defer close(channel)
 
 	// Convert all volumes to VolumeExternal and write them to the channel
+	d.mutex.Lock()
+	volumes := make([]fake.Volume, 0, len(d.Volumes))
 	for _, volume := range d.Volumes {
+		volumes = append(volumes, volume)
+	}
+	d.mutex.Unlock()
+	for _, volume := range volumes {
 		channel <- &storage.VolumeExternalWrapper{Volume: d.getVolumeExternal(volume), Error: nil}
 	}
 }
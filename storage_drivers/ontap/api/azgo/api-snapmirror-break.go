@@ -0,0 +1,115 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapmirrorBreakRequest is a structure to represent a snapmirror-break Request ZAPI object
+type SnapmirrorBreakRequest struct {
+	XMLName                xml.Name `xml:"snapmirror-break"`
+	DestinationLocationPtr *string  `xml:"destination-location"`
+}
+
+// SnapmirrorBreakResponse is a structure to represent a snapmirror-break Response ZAPI object
+type SnapmirrorBreakResponse struct {
+	XMLName         xml.Name                      `xml:"netapp"`
+	ResponseVersion string                        `xml:"version,attr"`
+	ResponseXmlns   string                        `xml:"xmlns,attr"`
+	Result          SnapmirrorBreakResponseResult `xml:"results"`
+}
+
+// NewSnapmirrorBreakResponse is a factory method for creating new instances of SnapmirrorBreakResponse objects
+func NewSnapmirrorBreakResponse() *SnapmirrorBreakResponse {
+	return &SnapmirrorBreakResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorBreakResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorBreakResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapmirrorBreakResponseResult is a structure to represent a snapmirror-break Response Result ZAPI object
+type SnapmirrorBreakResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewSnapmirrorBreakRequest is a factory method for creating new instances of SnapmirrorBreakRequest objects
+func NewSnapmirrorBreakRequest() *SnapmirrorBreakRequest {
+	return &SnapmirrorBreakRequest{}
+}
+
+// NewSnapmirrorBreakResponseResult is a factory method for creating new instances of SnapmirrorBreakResponseResult objects
+func NewSnapmirrorBreakResponseResult() *SnapmirrorBreakResponseResult {
+	return &SnapmirrorBreakResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorBreakRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorBreakResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorBreakRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorBreakResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorBreakRequest) ExecuteUsing(zr *ZapiRunner) (*SnapmirrorBreakResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorBreakRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapmirrorBreakResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapmirrorBreakRequest", NewSnapmirrorBreakResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapmirrorBreakResponse), err
+}
+
+// DestinationLocation is a 'getter' method
+func (o *SnapmirrorBreakRequest) DestinationLocation() string {
+	r := *o.DestinationLocationPtr
+	return r
+}
+
+// SetDestinationLocation is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorBreakRequest) SetDestinationLocation(newValue string) *SnapmirrorBreakRequest {
+	o.DestinationLocationPtr = &newValue
+	return o
+}
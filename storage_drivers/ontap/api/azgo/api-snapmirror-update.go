@@ -0,0 +1,167 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapmirrorUpdateRequest is a structure to represent a snapmirror-update Request ZAPI object
+type SnapmirrorUpdateRequest struct {
+	XMLName                xml.Name `xml:"snapmirror-update"`
+	DestinationLocationPtr *string  `xml:"destination-location"`
+}
+
+// SnapmirrorUpdateResponse is a structure to represent a snapmirror-update Response ZAPI object
+type SnapmirrorUpdateResponse struct {
+	XMLName         xml.Name                       `xml:"netapp"`
+	ResponseVersion string                         `xml:"version,attr"`
+	ResponseXmlns   string                         `xml:"xmlns,attr"`
+	Result          SnapmirrorUpdateResponseResult `xml:"results"`
+}
+
+// NewSnapmirrorUpdateResponse is a factory method for creating new instances of SnapmirrorUpdateResponse objects
+func NewSnapmirrorUpdateResponse() *SnapmirrorUpdateResponse {
+	return &SnapmirrorUpdateResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorUpdateResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorUpdateResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapmirrorUpdateResponseResult is a structure to represent a snapmirror-update Response Result ZAPI object
+type SnapmirrorUpdateResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewSnapmirrorUpdateRequest is a factory method for creating new instances of SnapmirrorUpdateRequest objects
+func NewSnapmirrorUpdateRequest() *SnapmirrorUpdateRequest {
+	return &SnapmirrorUpdateRequest{}
+}
+
+// NewSnapmirrorUpdateResponseResult is a factory method for creating new instances of SnapmirrorUpdateResponseResult objects
+func NewSnapmirrorUpdateResponseResult() *SnapmirrorUpdateResponseResult {
+	return &SnapmirrorUpdateResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorUpdateRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorUpdateResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorUpdateRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorUpdateResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorUpdateRequest) ExecuteUsing(zr *ZapiRunner) (*SnapmirrorUpdateResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorUpdateRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapmirrorUpdateResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapmirrorUpdateRequest", NewSnapmirrorUpdateResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapmirrorUpdateResponse), err
+}
+
+// DestinationLocation is a 'getter' method
+func (o *SnapmirrorUpdateRequest) DestinationLocation() string {
+	r := *o.DestinationLocationPtr
+	return r
+}
+
+// SetDestinationLocation is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorUpdateRequest) SetDestinationLocation(newValue string) *SnapmirrorUpdateRequest {
+	o.DestinationLocationPtr = &newValue
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *SnapmirrorUpdateResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorUpdateResponseResult) SetResultErrorCode(newValue int) *SnapmirrorUpdateResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *SnapmirrorUpdateResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorUpdateResponseResult) SetResultErrorMessage(newValue string) *SnapmirrorUpdateResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *SnapmirrorUpdateResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorUpdateResponseResult) SetResultJobid(newValue int) *SnapmirrorUpdateResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *SnapmirrorUpdateResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorUpdateResponseResult) SetResultStatus(newValue string) *SnapmirrorUpdateResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
@@ -0,0 +1,230 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotMulticreateRequest is a structure to represent a snapshot-multicreate Request ZAPI object
+type SnapshotMulticreateRequest struct {
+	XMLName            xml.Name                               `xml:"snapshot-multicreate"`
+	CleanupPtr         *bool                                  `xml:"cleanup"`
+	CommentPtr         *string                                `xml:"comment"`
+	SnapmirrorLabelPtr *string                                `xml:"snapmirror-label"`
+	SnapshotPtr        *string                                `xml:"snapshot"`
+	VolumeNamesPtr     *SnapshotMulticreateRequestVolumeNames `xml:"volume-names"`
+}
+
+// SnapshotMulticreateResponse is a structure to represent a snapshot-multicreate Response ZAPI object
+type SnapshotMulticreateResponse struct {
+	XMLName         xml.Name                          `xml:"netapp"`
+	ResponseVersion string                            `xml:"version,attr"`
+	ResponseXmlns   string                            `xml:"xmlns,attr"`
+	Result          SnapshotMulticreateResponseResult `xml:"results"`
+}
+
+// NewSnapshotMulticreateResponse is a factory method for creating new instances of SnapshotMulticreateResponse objects
+func NewSnapshotMulticreateResponse() *SnapshotMulticreateResponse {
+	return &SnapshotMulticreateResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotMulticreateResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotMulticreateResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotMulticreateResponseResult is a structure to represent a snapshot-multicreate Response Result ZAPI object
+type SnapshotMulticreateResponseResult struct {
+	XMLName          xml.Name                                       `xml:"results"`
+	ResultStatusAttr string                                         `xml:"status,attr"`
+	ResultReasonAttr string                                         `xml:"reason,attr"`
+	ResultErrnoAttr  string                                         `xml:"errno,attr"`
+	VolumeErrorsPtr  *SnapshotMulticreateResponseResultVolumeErrors `xml:"volume-errors"`
+}
+
+// NewSnapshotMulticreateRequest is a factory method for creating new instances of SnapshotMulticreateRequest objects
+func NewSnapshotMulticreateRequest() *SnapshotMulticreateRequest {
+	return &SnapshotMulticreateRequest{}
+}
+
+// NewSnapshotMulticreateResponseResult is a factory method for creating new instances of SnapshotMulticreateResponseResult objects
+func NewSnapshotMulticreateResponseResult() *SnapshotMulticreateResponseResult {
+	return &SnapshotMulticreateResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotMulticreateRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotMulticreateResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotMulticreateRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotMulticreateResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotMulticreateRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotMulticreateResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotMulticreateRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotMulticreateResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotMulticreateRequest", NewSnapshotMulticreateResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotMulticreateResponse), err
+}
+
+// Cleanup is a 'getter' method
+func (o *SnapshotMulticreateRequest) Cleanup() bool {
+	r := *o.CleanupPtr
+	return r
+}
+
+// SetCleanup is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateRequest) SetCleanup(newValue bool) *SnapshotMulticreateRequest {
+	o.CleanupPtr = &newValue
+	return o
+}
+
+// Comment is a 'getter' method
+func (o *SnapshotMulticreateRequest) Comment() string {
+	r := *o.CommentPtr
+	return r
+}
+
+// SetComment is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateRequest) SetComment(newValue string) *SnapshotMulticreateRequest {
+	o.CommentPtr = &newValue
+	return o
+}
+
+// SnapmirrorLabel is a 'getter' method
+func (o *SnapshotMulticreateRequest) SnapmirrorLabel() string {
+	r := *o.SnapmirrorLabelPtr
+	return r
+}
+
+// SetSnapmirrorLabel is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateRequest) SetSnapmirrorLabel(newValue string) *SnapshotMulticreateRequest {
+	o.SnapmirrorLabelPtr = &newValue
+	return o
+}
+
+// Snapshot is a 'getter' method
+func (o *SnapshotMulticreateRequest) Snapshot() string {
+	r := *o.SnapshotPtr
+	return r
+}
+
+// SetSnapshot is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateRequest) SetSnapshot(newValue string) *SnapshotMulticreateRequest {
+	o.SnapshotPtr = &newValue
+	return o
+}
+
+// SnapshotMulticreateRequestVolumeNames is a wrapper
+type SnapshotMulticreateRequestVolumeNames struct {
+	XMLName       xml.Name         `xml:"volume-names"`
+	VolumeNamePtr []VolumeNameType `xml:"volume-name"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotMulticreateRequestVolumeNames) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// VolumeName is a 'getter' method
+func (o *SnapshotMulticreateRequestVolumeNames) VolumeName() []VolumeNameType {
+	r := o.VolumeNamePtr
+	return r
+}
+
+// SetVolumeName is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateRequestVolumeNames) SetVolumeName(newValue []VolumeNameType) *SnapshotMulticreateRequestVolumeNames {
+	newSlice := make([]VolumeNameType, len(newValue))
+	copy(newSlice, newValue)
+	o.VolumeNamePtr = newSlice
+	return o
+}
+
+// VolumeNames is a 'getter' method
+func (o *SnapshotMulticreateRequest) VolumeNames() SnapshotMulticreateRequestVolumeNames {
+	r := *o.VolumeNamesPtr
+	return r
+}
+
+// SetVolumeNames is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateRequest) SetVolumeNames(newValue SnapshotMulticreateRequestVolumeNames) *SnapshotMulticreateRequest {
+	o.VolumeNamesPtr = &newValue
+	return o
+}
+
+// SnapshotMulticreateResponseResultVolumeErrors is a wrapper
+type SnapshotMulticreateResponseResultVolumeErrors struct {
+	XMLName        xml.Name          `xml:"volume-errors"`
+	VolumeErrorPtr []VolumeErrorType `xml:"volume-error"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotMulticreateResponseResultVolumeErrors) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// VolumeError is a 'getter' method
+func (o *SnapshotMulticreateResponseResultVolumeErrors) VolumeError() []VolumeErrorType {
+	r := o.VolumeErrorPtr
+	return r
+}
+
+// SetVolumeError is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateResponseResultVolumeErrors) SetVolumeError(newValue []VolumeErrorType) *SnapshotMulticreateResponseResultVolumeErrors {
+	newSlice := make([]VolumeErrorType, len(newValue))
+	copy(newSlice, newValue)
+	o.VolumeErrorPtr = newSlice
+	return o
+}
+
+// VolumeErrors is a 'getter' method
+func (o *SnapshotMulticreateResponseResult) VolumeErrors() SnapshotMulticreateResponseResultVolumeErrors {
+	r := *o.VolumeErrorsPtr
+	return r
+}
+
+// SetVolumeErrors is a fluent style 'setter' method that can be chained
+func (o *SnapshotMulticreateResponseResult) SetVolumeErrors(newValue SnapshotMulticreateResponseResultVolumeErrors) *SnapshotMulticreateResponseResult {
+	o.VolumeErrorsPtr = &newValue
+	return o
+}
@@ -20,6 +20,7 @@ const EVDISK_ERROR_SIZE_TOO_SMALL = "9041"
 const EAPIERROR = "13001"
 const EAPIPRIVILEGE = "13003"
 const EAPINOTFOUND = "13005"
+const ESNAPSHOTBUSY = "13023"
 const EVOLUMEDOESNOTEXIST = "13040"
 const EINTERNALERROR = "13114"
 const EINVALIDINPUTERROR = "13115"
@@ -23,6 +23,9 @@ const (
 	defaultZapiRecords   = 100
 	NumericalValueNotSet = -1
 	maxFlexGroupWait     = 30 * time.Second
+	maxVolumeMoveWait    = 10 * time.Minute
+
+	maxSnapmirrorReleaseWait = 2 * time.Minute
 )
 
 // ClientConfig holds the configuration data for Client objects
@@ -773,6 +776,15 @@ func (d Client) FlexGroupGetAll(prefix string) (*azgo.VolumeGetIterResponse, err
 	return d.volumeGetIterAll(prefix, queryVolIDAttrs, queryVolStateAttrs)
 }
 
+// FlexGroupGetAllReadWrite returns all relevant details for the online, read-write FlexGroups
+// in the SVM, leaving out data protection mirrors
+func (d Client) FlexGroupGetAllReadWrite() (*azgo.VolumeGetIterResponse, error) {
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType("*")).SetType("rw")
+	queryVolStateAttrs := azgo.NewVolumeStateAttributesType().SetState("online")
+	queryVolIDAttrs.SetStyleExtended("flexgroup")
+	return d.volumeGetIterAll("", queryVolIDAttrs, queryVolStateAttrs)
+}
+
 // waitForAsyncResponse handles waiting for an AsyncResponse to return successfully or return an error.
 func (d Client) waitForAsyncResponse(zapiResult interface{}, maxWaitTime time.Duration) error {
 
@@ -901,6 +913,18 @@ func (d Client) VolumeCreate(name, aggregateName, size, spaceReserve, snapshotPo
 	return response, err
 }
 
+// VolumeCreateReplica creates a data protection volume that may be the destination of a SnapMirror relationship
+// equivalent to filer::> volume create -vserver iscsi_vs -volume v -aggregate aggr1 -size 1g -type DP
+func (d Client) VolumeCreateReplica(name, aggregateName, size string) (*azgo.VolumeCreateResponse, error) {
+	response, err := azgo.NewVolumeCreateRequest().
+		SetVolume(name).
+		SetContainingAggrName(aggregateName).
+		SetSize(size).
+		SetVolumeType("dp").
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
 // VolumeCloneCreate clones a volume from a snapshot
 func (d Client) VolumeCloneCreate(name, source, snapshot string) (*azgo.VolumeCloneCreateResponse, error) {
 	response, err := azgo.NewVolumeCloneCreateRequest().
@@ -939,6 +963,26 @@ func (d Client) VolumeDisableSnapshotDirectoryAccess(name string) (*azgo.VolumeM
 	return response, err
 }
 
+// VolumeModifyExportPolicy sets the export policy of a volume
+// equivalent to filer::> volume modify -vserver iscsi_vs -volume v -policy default
+func (d Client) VolumeModifyExportPolicy(name, exportPolicy string) (*azgo.VolumeModifyIterResponse, error) {
+	volattr := &azgo.VolumeModifyIterRequestAttributes{}
+	exportattr := azgo.NewVolumeExportAttributesType().SetPolicy(exportPolicy)
+	volExportAttrs := azgo.NewVolumeAttributesType().SetVolumeExportAttributes(*exportattr)
+	volattr.SetVolumeAttributes(*volExportAttrs)
+
+	queryattr := &azgo.VolumeModifyIterRequestQuery{}
+	volidattr := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(name))
+	volIdAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*volidattr)
+	queryattr.SetVolumeAttributes(*volIdAttrs)
+
+	response, err := azgo.NewVolumeModifyIterRequest().
+		SetQuery(*queryattr).
+		SetAttributes(*volattr).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
 // VolumeExists tests for the existence of a Flexvol
 func (d Client) VolumeExists(name string) (bool, error) {
 	response, err := azgo.NewVolumeSizeRequest().
@@ -982,6 +1026,26 @@ func (d Client) VolumeSetSize(name, newSize string) (*azgo.VolumeSizeResponse, e
 	return response, err
 }
 
+// VolumeMove moves a volume to another aggregate and waits for the move to complete.  Moving
+// volumes is a cluster-scoped operation, so the request isn't tunneled to the SVM.
+func (d Client) VolumeMove(name, destAggr string) (*azgo.VolumeMoveStartResponse, error) {
+	response, err := azgo.NewVolumeMoveStartRequest().
+		SetSourceVolume(name).
+		SetVserver(d.config.SVM).
+		SetDestAggr(destAggr).
+		ExecuteUsing(d.GetNontunneledZapiRunner())
+	if zerr := GetError(response, err); zerr != nil {
+		return response, zerr
+	}
+
+	err = d.waitForAsyncResponse(*response, maxVolumeMoveWait)
+	if err != nil {
+		return response, fmt.Errorf("error waiting for volume move: %v", err)
+	}
+
+	return response, nil
+}
+
 // VolumeMount mounts a volume at the specified junction
 func (d Client) VolumeMount(name, junctionPath string) (*azgo.VolumeMountResponse, error) {
 	response, err := azgo.NewVolumeMountRequest().
@@ -1073,6 +1137,19 @@ func (d Client) VolumeGetAll(prefix string) (response *azgo.VolumeGetIterRespons
 	return d.volumeGetIterAll(prefix, queryVolIDAttrs, queryVolStateAttrs)
 }
 
+// VolumeGetAllReadWrite returns all relevant details for the online, read-write FlexVols in the SVM
+// other than its root volume, leaving out data protection and load-sharing mirrors
+func (d Client) VolumeGetAllReadWrite() (*azgo.VolumeGetIterResponse, error) {
+
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType("*")).SetType("rw")
+	queryVolStateAttrs := azgo.NewVolumeStateAttributesType().SetState("online").SetIsVserverRoot(false)
+	if d.SupportsFeature(FlexGroupsFilter) {
+		queryVolIDAttrs.SetStyleExtended("flexvol")
+	}
+
+	return d.volumeGetIterAll("", queryVolIDAttrs, queryVolStateAttrs)
+}
+
 func (d Client) volumeGetIterAll(prefix string, queryVolIDAttrs *azgo.VolumeIdAttributesType,
 	queryVolStateAttrs *azgo.VolumeStateAttributesType) (*azgo.VolumeGetIterResponse, error) {
 
@@ -1587,6 +1664,20 @@ func (d Client) SnapshotCreate(name, volumeName string) (*azgo.SnapshotCreateRes
 	return response, err
 }
 
+// SnapshotMulticreate creates a snapshot of each of several volumes at the same point in time.
+// If any snapshot can't be created, ONTAP deletes the others.
+func (d Client) SnapshotMulticreate(name string, volumeNames []string) (*azgo.SnapshotMulticreateResponse, error) {
+	volumes := azgo.SnapshotMulticreateRequestVolumeNames{}
+	volumes.SetVolumeName(volumeNames)
+
+	response, err := azgo.NewSnapshotMulticreateRequest().
+		SetSnapshot(name).
+		SetVolumeNames(volumes).
+		SetCleanup(true).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
 // SnapshotGetByVolume returns the list of snapshots associated with a volume
 func (d Client) SnapshotGetByVolume(volumeName string) (*azgo.SnapshotGetIterResponse, error) {
 	query := &azgo.SnapshotGetIterRequestQuery{}
@@ -1600,6 +1691,25 @@ func (d Client) SnapshotGetByVolume(volumeName string) (*azgo.SnapshotGetIterRes
 	return response, err
 }
 
+// SnapshotRestoreVolume reverts a volume to a snapshot
+func (d Client) SnapshotRestoreVolume(name, volumeName string) (*azgo.SnapshotRestoreVolumeResponse, error) {
+	response, err := azgo.NewSnapshotRestoreVolumeRequest().
+		SetSnapshot(name).
+		SetVolume(volumeName).
+		SetPreserveLunIds(true).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
+// SnapshotDelete deletes a snapshot of a volume
+func (d Client) SnapshotDelete(name, volumeName string) (*azgo.SnapshotDeleteResponse, error) {
+	response, err := azgo.NewSnapshotDeleteRequest().
+		SetSnapshot(name).
+		SetVolume(volumeName).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
 // SNAPSHOT operations END
 /////////////////////////////////////////////////////////////////////////////
 
@@ -1928,6 +2038,97 @@ func (d Client) SnapmirrorUpdateLoadSharingMirrors(
 	return response, err
 }
 
+// SnapmirrorCreate creates a SnapMirror relationship that mirrors a volume and all of its snapshots
+// equivalent to filer::> snapmirror create -source-path -destination-path -type XDP -policy MirrorAllSnapshots
+func (d Client) SnapmirrorCreate(sourceLocation, destinationLocation string) (*azgo.SnapmirrorCreateResponse, error) {
+	response, err := azgo.NewSnapmirrorCreateRequest().
+		SetSourceLocation(sourceLocation).
+		SetDestinationLocation(destinationLocation).
+		SetRelationshipType("extended_data_protection").
+		SetPolicy("MirrorAllSnapshots").
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
+// SnapmirrorInitialize starts the baseline transfer of a SnapMirror relationship
+// equivalent to filer::> snapmirror initialize -destination-path
+func (d Client) SnapmirrorInitialize(
+	sourceLocation, destinationLocation string,
+) (*azgo.SnapmirrorInitializeResponse, error) {
+	response, err := azgo.NewSnapmirrorInitializeRequest().
+		SetSourceLocation(sourceLocation).
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
+// SnapmirrorUpdate starts an incremental transfer of a SnapMirror relationship
+// equivalent to filer::> snapmirror update -destination-path
+func (d Client) SnapmirrorUpdate(destinationLocation string) (*azgo.SnapmirrorUpdateResponse, error) {
+	response, err := azgo.NewSnapmirrorUpdateRequest().
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
+// SnapmirrorBreak makes the destination of a SnapMirror relationship writable
+// equivalent to filer::> snapmirror break -destination-path
+func (d Client) SnapmirrorBreak(destinationLocation string) (*azgo.SnapmirrorBreakResponse, error) {
+	response, err := azgo.NewSnapmirrorBreakRequest().
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
+// SnapmirrorDelete removes a SnapMirror relationship from its destination
+// equivalent to filer::> snapmirror delete -destination-path
+func (d Client) SnapmirrorDelete(destinationLocation string) (*azgo.SnapmirrorDeleteResponse, error) {
+	response, err := azgo.NewSnapmirrorDeleteRequest().
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr)
+	return response, err
+}
+
+// SnapmirrorRelease removes a SnapMirror relationship from its source, along with the
+// snapshots that were kept for it
+// equivalent to filer::> snapmirror release -destination-path
+func (d Client) SnapmirrorRelease(sourceLocation, destinationLocation string) error {
+	response, err := azgo.NewSnapmirrorReleaseRequest().
+		SetSourceLocation(sourceLocation).
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr)
+	if zerr := GetError(response, err); zerr != nil {
+		return zerr
+	}
+
+	if err = d.waitForAsyncResponse(*response, maxSnapmirrorReleaseWait); err != nil {
+		return fmt.Errorf("error waiting for SnapMirror release: %v", err)
+	}
+	return nil
+}
+
+// SnapmirrorGet returns the SnapMirror relationship with the specified destination, or
+// nil if there is no such relationship
+// equivalent to filer::> snapmirror show -destination-path
+func (d Client) SnapmirrorGet(destinationLocation string) (*azgo.SnapmirrorInfoType, error) {
+
+	query := &azgo.SnapmirrorGetIterRequestQuery{}
+	info := azgo.NewSnapmirrorInfoType().SetDestinationLocation(destinationLocation)
+	query.SetSnapmirrorInfo(*info)
+
+	response, err := azgo.NewSnapmirrorGetIterRequest().
+		SetQuery(*query).
+		ExecuteUsing(d.zr)
+	if err = GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	if response.Result.AttributesListPtr == nil || len(response.Result.AttributesListPtr.SnapmirrorInfoPtr) == 0 {
+		return nil, nil
+	}
+	return &response.Result.AttributesListPtr.SnapmirrorInfoPtr[0], nil
+}
+
 // SNAPMIRROR operations END
 /////////////////////////////////////////////////////////////////////////////
 
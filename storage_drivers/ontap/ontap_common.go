@@ -29,6 +29,7 @@ const (
 	LSMirrorIdleTimeoutSecs      = 30
 	MinimumVolumeSizeBytes       = 20971520 // 20 MiB
 	HousekeepingStartupDelaySecs = 10
+	ReplicationIdleTimeout       = 24 * time.Hour
 )
 
 type Telemetry struct {
@@ -762,45 +763,607 @@ func CreateOntapClone(
 	return nil
 }
 
-// Return the list of snapshots associated with the named volume
-func GetSnapshotList(name string, config *drivers.OntapStorageDriverConfig, client *api.Client) ([]storage.Snapshot, error) {
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func GetSnapshot(
+	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	sizeGetter func(string) (int, error),
+) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "GetSnapshotList",
-			"Type":   "ontap_common",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "ontap_common",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshotList")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshotList")
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
-	snapResponse, err := client.SnapshotGetByVolume(name)
-	if err = api.GetError(snapResponse, err); err != nil {
+	size, err := sizeGetter(internalVolName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading volume size: %v", err)
+	}
+
+	snapListResponse, err := client.SnapshotGetByVolume(internalVolName)
+	if err = api.GetError(snapListResponse, err); err != nil {
 		return nil, fmt.Errorf("error enumerating snapshots: %v", err)
 	}
 
-	log.Debugf("Returned %v snapshots.", snapResponse.Result.NumRecords())
-	snapshots := []storage.Snapshot{}
+	if snapListResponse.Result.AttributesListPtr != nil {
+		for _, snap := range snapListResponse.Result.AttributesListPtr.SnapshotInfoPtr {
+			if snap.Name() == internalSnapName {
+
+				log.WithFields(log.Fields{
+					"snapshotName": internalSnapName,
+					"volumeName":   internalVolName,
+					"created":      snap.AccessTime(),
+				}).Debug("Found snapshot.")
+
+				return storage.NewSnapshot(snapConfig, formatSnapshotTime(snap.AccessTime()), int64(size),
+					storage.SnapshotStateOnline), nil
+			}
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Warning("Snapshot not found.")
 
-	if snapResponse.Result.AttributesListPtr != nil {
-		for _, snap := range snapResponse.Result.AttributesListPtr.SnapshotInfoPtr {
+	return nil, nil
+}
+
+// GetSnapshots returns the list of snapshots associated with the named volume.
+func GetSnapshots(
+	volConfig *storage.VolumeConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	sizeGetter func(string) (int, error),
+) ([]*storage.Snapshot, error) {
+
+	internalVolName := volConfig.InternalName
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "ontap_common",
+			"volumeName": internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+	}
+
+	size, err := sizeGetter(internalVolName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading volume size: %v", err)
+	}
+
+	snapListResponse, err := client.SnapshotGetByVolume(internalVolName)
+	if err = api.GetError(snapListResponse, err); err != nil {
+		return nil, fmt.Errorf("error enumerating snapshots: %v", err)
+	}
+
+	log.Debugf("Returned %v snapshots.", snapListResponse.Result.NumRecords())
+	snapshots := make([]*storage.Snapshot, 0)
+
+	if snapListResponse.Result.AttributesListPtr != nil {
+		for _, snap := range snapListResponse.Result.AttributesListPtr.SnapshotInfoPtr {
 
 			log.WithFields(log.Fields{
 				"name":       snap.Name(),
 				"accessTime": snap.AccessTime(),
 			}).Debug("Snapshot")
 
-			// Time format: yyyy-mm-ddThh:mm:ssZ
-			snapTime := time.Unix(int64(snap.AccessTime()), 0).UTC().Format("2006-01-02T15:04:05Z")
+			snapshot := &storage.Snapshot{
+				Config: &storage.SnapshotConfig{
+					Version:            tridentconfig.OrchestratorAPIVersion,
+					Name:               snap.Name(),
+					InternalName:       snap.Name(),
+					VolumeName:         volConfig.Name,
+					VolumeInternalName: volConfig.InternalName,
+				},
+				Created:   formatSnapshotTime(snap.AccessTime()),
+				SizeBytes: int64(size),
+				State:     storage.SnapshotStateOnline,
+			}
 
-			snapshots = append(snapshots, storage.Snapshot{Name: snap.Name(), Created: snapTime})
+			snapshots = append(snapshots, snapshot)
 		}
 	}
 
 	return snapshots, nil
 }
 
+// CreateSnapshot creates a snapshot for the given volume.
+func CreateSnapshot(
+	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	sizeGetter func(string) (int, error),
+) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "ontap_common",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	snapResponse, err := client.SnapshotCreate(internalSnapName, internalVolName)
+	if err = api.GetError(snapResponse, err); err != nil {
+		return nil, fmt.Errorf("could not create snapshot: %v", err)
+	}
+
+	// Fetching list of snapshots to get snapshot access time
+	snapshot, err := GetSnapshot(snapConfig, config, client, sizeGetter)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("could not find snapshot %s after creating it", internalSnapName)
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": snapConfig.InternalName,
+		"volumeName":   snapConfig.VolumeInternalName,
+	}).Info("Snapshot created.")
+
+	return snapshot, nil
+}
+
+// CreateGroupSnapshot creates a snapshot of each of several Flexvols at the same point in
+// time, using a single snapshot-multicreate call.
+func CreateGroupSnapshot(
+	snapConfigs []*storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	sizeGetter func(string) (int, error),
+) ([]*storage.Snapshot, error) {
+
+	if len(snapConfigs) == 0 {
+		return nil, errors.New("no volumes were specified for the group snapshot")
+	}
+
+	// snapshot-multicreate gives each snapshot the same name
+	internalSnapName := snapConfigs[0].InternalName
+	internalVolNames := make([]string, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		if snapConfig.InternalName != internalSnapName {
+			return nil, fmt.Errorf("snapshots of a group must have the same name, not %s and %s",
+				internalSnapName, snapConfig.InternalName)
+		}
+		internalVolNames = append(internalVolNames, snapConfig.VolumeInternalName)
+	}
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateGroupSnapshot",
+			"Type":         "ontap_common",
+			"snapshotName": internalSnapName,
+			"volumeNames":  internalVolNames,
+		}
+		log.WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	snapResponse, err := client.SnapshotMulticreate(internalSnapName, internalVolNames)
+	if err = api.GetError(snapResponse, err); err != nil {
+		return nil, fmt.Errorf("could not create group snapshot: %v", err)
+	}
+	if volumeErrors := snapResponse.Result.VolumeErrorsPtr; volumeErrors != nil &&
+		len(volumeErrors.VolumeErrorPtr) > 0 {
+
+		errorMessages := make([]string, 0, len(volumeErrors.VolumeErrorPtr))
+		failedVolNames := make(map[string]bool, len(volumeErrors.VolumeErrorPtr))
+		for _, volumeError := range volumeErrors.VolumeErrorPtr {
+			name, reason := "", ""
+			if volumeError.NamePtr != nil {
+				name = volumeError.Name()
+			}
+			if volumeError.ReasonPtr != nil {
+				reason = volumeError.Reason()
+			}
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", name, reason))
+			failedVolNames[name] = true
+		}
+
+		// Only the volumes without errors got a snapshot, which is deleted again
+		createdSnapConfigs := make([]*storage.SnapshotConfig, 0, len(snapConfigs))
+		for _, snapConfig := range snapConfigs {
+			if !failedVolNames[snapConfig.VolumeInternalName] {
+				createdSnapConfigs = append(createdSnapConfigs, snapConfig)
+			}
+		}
+		return nil, deleteGroupSnapshot(createdSnapConfigs, config, client,
+			fmt.Errorf("could not create group snapshot: %s", strings.Join(errorMessages, "; ")))
+	}
+
+	// Fetch each snapshot to get its creation time
+	snapshots := make([]*storage.Snapshot, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		snapshot, err := GetSnapshot(snapConfig, config, client, sizeGetter)
+		if err != nil {
+			return nil, deleteGroupSnapshot(snapConfigs, config, client, err)
+		}
+		if snapshot == nil {
+			return nil, deleteGroupSnapshot(snapConfigs, config, client,
+				fmt.Errorf("could not find snapshot %s of volume %s after creating it",
+					internalSnapName, snapConfig.VolumeInternalName))
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeNames":  internalVolNames,
+	}).Info("Group snapshot created.")
+
+	return snapshots, nil
+}
+
+// deleteGroupSnapshot deletes whichever snapshots of a group snapshot were created, so that
+// none are left behind when the group snapshot fails part way.  It returns the error that
+// caused the group snapshot to fail, along with any errors deleting its snapshots.
+func deleteGroupSnapshot(
+	snapConfigs []*storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	err error,
+) error {
+
+	for _, snapConfig := range snapConfigs {
+		if deleteErr := DeleteSnapshot(snapConfig, config, client); deleteErr != nil {
+			log.WithFields(log.Fields{
+				"snapshotName": snapConfig.InternalName,
+				"volumeName":   snapConfig.VolumeInternalName,
+			}).Errorf("Unable to delete snapshot of incomplete group snapshot: %v", deleteErr)
+			err = fmt.Errorf("%v; unable to delete snapshot %s of volume %s: %v",
+				err, snapConfig.InternalName, snapConfig.VolumeInternalName, deleteErr)
+		}
+	}
+	return err
+}
+
+// DeleteSnapshot deletes a single snapshot.
+func DeleteSnapshot(
+	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "ontap_common",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	snapResponse, err := client.SnapshotDelete(internalSnapName, internalVolName)
+	if err != nil {
+		return fmt.Errorf("error deleting snapshot: %v", err)
+	}
+	if zerr := api.NewZapiError(snapResponse); !zerr.IsPassed() {
+
+		// It's not an error if the snapshot or its volume no longer exists
+		switch zerr.Code() {
+		case azgo.EOBJECTNOTFOUND, azgo.EVOLUMEDOESNOTEXIST:
+			log.WithFields(log.Fields{
+				"snapshotName": internalSnapName,
+				"volumeName":   internalVolName,
+			}).Warn("Snapshot already deleted.")
+			return nil
+		case azgo.ESNAPSHOTBUSY:
+			return fmt.Errorf("snapshot %s of volume %s is busy, possibly because it is the source of a "+
+				"clone: %v", internalSnapName, internalVolName, zerr)
+		default:
+			return fmt.Errorf("error deleting snapshot: %v", zerr)
+		}
+	}
+
+	log.WithField("snapshotName", internalSnapName).Debug("Deleted snapshot.")
+	return nil
+}
+
+// MoveVolume moves a Flexvol to the aggregate that backs a storage pool.
+func MoveVolume(
+	volConfig *storage.VolumeConfig, pool *storage.Pool, config *drivers.OntapStorageDriverConfig,
+	client *api.Client,
+) error {
+
+	name := volConfig.InternalName
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "MoveVolume",
+			"Type":       "ontap_common",
+			"volumeName": name,
+			"aggregate":  pool.Name,
+		}
+		log.WithFields(fields).Debug(">>>> MoveVolume")
+		defer log.WithFields(fields).Debug("<<<< MoveVolume")
+	}
+
+	if config.Aggregate != "" && config.Aggregate != pool.Name {
+		return fmt.Errorf("backend is limited to aggregate %s", config.Aggregate)
+	}
+
+	if _, err := client.VolumeMove(name, pool.Name); err != nil {
+		return fmt.Errorf("error moving volume %s to aggregate %s: %v", name, pool.Name, err)
+	}
+
+	log.WithFields(log.Fields{
+		"volume":    name,
+		"aggregate": pool.Name,
+	}).Info("Moved volume.")
+
+	return nil
+}
+
+// replicationLocation returns the SnapMirror location of a Flexvol.
+func replicationLocation(config *drivers.OntapStorageDriverConfig, volumeName string) string {
+	return config.SVM + ":" + volumeName
+}
+
+// ReplicationSource returns the source driver as an ONTAP driver if its Flexvols may be
+// replicated to a driver of the named type using SnapMirror.  SnapMirror itself requires
+// that the two SVMs be peered, which is checked only when the relationship is created.
+func ReplicationSource(source storage.Driver, driverName string) (StorageDriver, bool) {
+	ontapSource, ok := source.(StorageDriver)
+	if !ok || ontapSource.Name() != driverName {
+		return nil, false
+	}
+	return ontapSource, true
+}
+
+// StartReplication creates a data protection Flexvol in the aggregate that backs a storage
+// pool, and starts mirroring a Flexvol on the source driver's SVM to it using SnapMirror.
+func StartReplication(
+	source StorageDriver, sourceConfig, volConfig *storage.VolumeConfig, pool *storage.Pool,
+	config *drivers.OntapStorageDriverConfig, client *api.Client,
+) error {
+
+	name := volConfig.InternalName
+	sourceLocation := replicationLocation(source.GetConfig(), sourceConfig.InternalName)
+	destinationLocation := replicationLocation(config, name)
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":      "StartReplication",
+			"Type":        "ontap_common",
+			"source":      sourceLocation,
+			"destination": destinationLocation,
+			"aggregate":   pool.Name,
+		}
+		log.WithFields(fields).Debug(">>>> StartReplication")
+		defer log.WithFields(fields).Debug("<<<< StartReplication")
+	}
+
+	if config.Aggregate != "" && config.Aggregate != pool.Name {
+		return fmt.Errorf("backend is limited to aggregate %s", config.Aggregate)
+	}
+
+	volExists, err := client.VolumeExists(name)
+	if err != nil {
+		return fmt.Errorf("error checking for existing volume: %v", err)
+	}
+	if volExists {
+		return drivers.NewVolumeExistsError(name)
+	}
+
+	// The replica must be at least as large as the volume it mirrors
+	sourceSize, err := source.GetAPI().VolumeSize(sourceConfig.InternalName)
+	if err != nil {
+		return fmt.Errorf("error getting size of source volume %s: %v", sourceConfig.InternalName, err)
+	}
+	size := strconv.Itoa(sourceSize)
+	if err := checkAggregateLimits(pool.Name, "none", uint64(sourceSize), *config, client); err != nil {
+		return err
+	}
+
+	createResponse, err := client.VolumeCreateReplica(name, pool.Name, size)
+	if err = api.GetError(createResponse, err); err != nil {
+		return fmt.Errorf("error creating replica volume: %v", err)
+	}
+
+	smCreateResponse, err := client.SnapmirrorCreate(sourceLocation, destinationLocation)
+	if err = api.GetError(smCreateResponse, err); err != nil {
+		return fmt.Errorf("error creating SnapMirror relationship: %v", err)
+	}
+
+	smInitResponse, err := client.SnapmirrorInitialize(sourceLocation, destinationLocation)
+	if err = api.GetError(smInitResponse, err); err != nil {
+		return fmt.Errorf("error initializing SnapMirror relationship: %v", err)
+	}
+
+	log.WithFields(log.Fields{
+		"source":      sourceLocation,
+		"destination": destinationLocation,
+	}).Info("Started replicating volume.")
+
+	return nil
+}
+
+// waitForReplicationIdle waits for a SnapMirror relationship to finish any transfer in
+// progress, and returns an error if the relationship isn't mirrored once it's idle.
+func waitForReplicationIdle(destinationLocation string, client *api.Client) error {
+
+	checkRelationshipIdle := func() error {
+		relationship, err := client.SnapmirrorGet(destinationLocation)
+		if err != nil {
+			return err
+		}
+		if relationship == nil {
+			return backoff.Permanent(fmt.Errorf("SnapMirror relationship for %s not found", destinationLocation))
+		}
+		if relationship.RelationshipStatusPtr == nil || relationship.RelationshipStatus() != "idle" {
+			return fmt.Errorf("SnapMirror relationship for %s is not yet idle", destinationLocation)
+		}
+		if relationship.MirrorStatePtr == nil || relationship.MirrorState() != "snapmirrored" {
+			transferError := "unknown error"
+			if relationship.LastTransferErrorPtr != nil {
+				transferError = relationship.LastTransferError()
+			}
+			return backoff.Permanent(fmt.Errorf("SnapMirror relationship for %s failed to transfer: %s",
+				destinationLocation, transferError))
+		}
+		return nil
+	}
+	relationshipIdleNotify := func(err error, duration time.Duration) {
+		log.WithField("increment", duration).Debug("SnapMirror transfer in progress, waiting.")
+	}
+	relationshipBackoff := backoff.NewExponentialBackOff()
+	relationshipBackoff.InitialInterval = 5 * time.Second
+	relationshipBackoff.MaxInterval = 5 * time.Minute
+	relationshipBackoff.Multiplier = 2
+	relationshipBackoff.RandomizationFactor = 0.1
+	relationshipBackoff.MaxElapsedTime = ReplicationIdleTimeout
+
+	return backoff.RetryNotify(checkRelationshipIdle, relationshipBackoff, relationshipIdleNotify)
+}
+
+// WaitForReplication waits for the baseline transfer of a SnapMirror relationship to finish.
+func WaitForReplication(volConfig *storage.VolumeConfig, config *drivers.OntapStorageDriverConfig, client *api.Client) error {
+
+	destinationLocation := replicationLocation(config, volConfig.InternalName)
+	if err := waitForReplicationIdle(destinationLocation, client); err != nil {
+		return fmt.Errorf("error waiting for SnapMirror baseline transfer: %v", err)
+	}
+	return nil
+}
+
+// CompleteReplication transfers any changes made to the source of a SnapMirror relationship
+// since its last transfer, and then breaks and removes the relationship so that the replica
+// becomes an independent, writable Flexvol.
+func CompleteReplication(
+	source StorageDriver, sourceConfig, volConfig *storage.VolumeConfig,
+	config *drivers.OntapStorageDriverConfig, client *api.Client,
+) error {
+
+	sourceLocation := replicationLocation(source.GetConfig(), sourceConfig.InternalName)
+	destinationLocation := replicationLocation(config, volConfig.InternalName)
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":      "CompleteReplication",
+			"Type":        "ontap_common",
+			"source":      sourceLocation,
+			"destination": destinationLocation,
+		}
+		log.WithFields(fields).Debug(">>>> CompleteReplication")
+		defer log.WithFields(fields).Debug("<<<< CompleteReplication")
+	}
+
+	if err := waitForReplicationIdle(destinationLocation, client); err != nil {
+		return fmt.Errorf("error waiting for SnapMirror baseline transfer: %v", err)
+	}
+
+	updateResponse, err := client.SnapmirrorUpdate(destinationLocation)
+	if err = api.GetError(updateResponse, err); err != nil {
+		return fmt.Errorf("error updating SnapMirror relationship: %v", err)
+	}
+	if err := waitForReplicationIdle(destinationLocation, client); err != nil {
+		return fmt.Errorf("error waiting for SnapMirror update: %v", err)
+	}
+
+	breakResponse, err := client.SnapmirrorBreak(destinationLocation)
+	if err = api.GetError(breakResponse, err); err != nil {
+		return fmt.Errorf("error breaking SnapMirror relationship: %v", err)
+	}
+
+	return removeReplication(source, sourceLocation, destinationLocation, client)
+}
+
+// AbortReplication removes a SnapMirror relationship, whether or not its transfers are complete.
+func AbortReplication(
+	source StorageDriver, sourceConfig, volConfig *storage.VolumeConfig,
+	config *drivers.OntapStorageDriverConfig, client *api.Client,
+) error {
+
+	sourceLocation := replicationLocation(source.GetConfig(), sourceConfig.InternalName)
+	destinationLocation := replicationLocation(config, volConfig.InternalName)
+
+	relationship, err := client.SnapmirrorGet(destinationLocation)
+	if err != nil {
+		return fmt.Errorf("error checking for SnapMirror relationship: %v", err)
+	}
+	if relationship == nil {
+		return nil
+	}
+	return removeReplication(source, sourceLocation, destinationLocation, client)
+}
+
+// removeReplication deletes a SnapMirror relationship from its destination, then releases
+// it on its source.  The source Flexvol is about to be destroyed or left as it was, so a
+// failure to release it is only logged.
+func removeReplication(source StorageDriver, sourceLocation, destinationLocation string, client *api.Client) error {
+
+	deleteResponse, err := client.SnapmirrorDelete(destinationLocation)
+	if err = api.GetError(deleteResponse, err); err != nil {
+		return fmt.Errorf("error deleting SnapMirror relationship: %v", err)
+	}
+
+	if err := source.GetAPI().SnapmirrorRelease(sourceLocation, destinationLocation); err != nil {
+		log.WithFields(log.Fields{
+			"source":      sourceLocation,
+			"destination": destinationLocation,
+			"error":       err,
+		}).Warn("Could not release SnapMirror relationship on its source.")
+	}
+
+	log.WithFields(log.Fields{
+		"source":      sourceLocation,
+		"destination": destinationLocation,
+	}).Info("Removed SnapMirror relationship.")
+
+	return nil
+}
+
+// RestoreSnapshot reverts a Flexvol to a snapshot, discarding any changes made since the
+// snapshot was created.
+func RestoreSnapshot(
+	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "ontap_common",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	snapResponse, err := client.SnapshotRestoreVolume(internalSnapName, internalVolName)
+	if err = api.GetError(snapResponse, err); err != nil {
+		return fmt.Errorf("error restoring snapshot %s of volume %s: %v", internalSnapName, internalVolName, err)
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Debug("Restored snapshot.")
+	return nil
+}
+
+// formatSnapshotTime converts an ONTAP snapshot timestamp to the format yyyy-mm-ddThh:mm:ssZ
+func formatSnapshotTime(accessTime int) string {
+	return time.Unix(int64(accessTime), 0).UTC().Format("2006-01-02T15:04:05Z")
+}
+
 // GetVolume checks for the existence of a volume.  It returns nil if the volume
 // exists and an error if it does not (or the API call fails).
 func GetVolume(name string, client *api.Client, config *drivers.OntapStorageDriverConfig) error {
@@ -998,9 +1561,87 @@ func getStorageBackendSpecsCommon(
 		backend.AddStoragePool(pool)
 	}
 
+	// Record the space in each aggregate, if the user is permitted to read it
+	if capacity, capacityErr := getAggregateCapacity(d); capacityErr != nil {
+		log.Warningf("Could not obtain aggregate space; capacity of pools on this backend will not be "+
+			"reported: %v.", capacityErr)
+	} else {
+		for name, pool := range storagePools {
+			pool.Capacity = capacity[name]
+		}
+	}
+
 	return
 }
 
+// getAggregateCapacity reads the size and used space of every aggregate visible to the driver.
+func getAggregateCapacity(d StorageDriver) (map[string]storage.PoolCapacity, error) {
+
+	response, err := d.GetAPI().AggrSpaceGetIterRequest("")
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	capacity := make(map[string]storage.PoolCapacity)
+	if response.Result.AttributesListPtr != nil {
+		for _, aggrSpace := range response.Result.AttributesListPtr.SpaceInformationPtr {
+			if aggrSpace.AggregatePtr == nil || aggrSpace.AggregateSizePtr == nil ||
+				aggrSpace.UsedIncludingSnapshotReservePtr == nil {
+				continue
+			}
+			capacity[aggrSpace.Aggregate()] = storage.PoolCapacity{
+				TotalBytes: uint64(aggrSpace.AggregateSize()),
+				UsedBytes:  uint64(aggrSpace.UsedIncludingSnapshotReserve()),
+			}
+		}
+	}
+	return capacity, nil
+}
+
+// checkHealthCommon verifies that the management LIF is reachable and accepts the driver's credentials.
+func checkHealthCommon(d StorageDriver) error {
+
+	response, err := d.GetAPI().SystemGetVersion()
+	if err = api.GetError(response, err); err != nil {
+		return fmt.Errorf("could not read ONTAP version: %v", err)
+	}
+	return nil
+}
+
+// getVolumeNames returns the names of the volumes in a volume-get-iter response.
+func getVolumeNames(response *azgo.VolumeGetIterResponse, err error) ([]string, error) {
+
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	if response.Result.AttributesListPtr != nil {
+		for _, volume := range response.Result.AttributesListPtr.VolumeAttributesPtr {
+			if volume.VolumeIdAttributesPtr != nil {
+				names = append(names, string(volume.VolumeIdAttributesPtr.Name()))
+			}
+		}
+	}
+	return names, nil
+}
+
+// getLUNVolumeNames returns the names of the FlexVols that hold the LUNs in a LUN list response.
+func getLUNVolumeNames(response *azgo.LunGetIterResponse, err error) (map[string]bool, error) {
+
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	if response.Result.AttributesListPtr != nil {
+		for _, lun := range response.Result.AttributesListPtr.LunInfoPtr {
+			names[lun.Volume()] = true
+		}
+	}
+	return names, nil
+}
+
 // getVserverAggregateAttributes gets pool attributes using vserver-show-aggr-get-iter, which will only succeed on Data ONTAP 9 and later.
 // If the aggregate attributes are read successfully, the pools passed to this function are updated accordingly.
 func getVserverAggregateAttributes(d StorageDriver, storagePools *map[string]*storage.Pool) error {
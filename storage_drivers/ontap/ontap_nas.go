@@ -352,20 +352,105 @@ func (d *NASStorageDriver) Publish(name string, publishInfo *utils.VolumePublish
 	return nil
 }
 
-// Return the list of snapshots associated with the named volume
-func (d *NASStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func (d *NASStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "NASStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "NASStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+	}
+
+	return GetSnapshot(snapConfig, &d.Config, d.API, d.API.VolumeSize)
+}
+
+// Return the list of snapshots associated with the specified volume
+func (d *NASStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "NASStorageDriver",
+			"volumeName": volConfig.InternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+	}
+
+	return GetSnapshots(volConfig, &d.Config, d.API, d.API.VolumeSize)
+}
+
+// CreateSnapshot creates a snapshot for the given volume
+func (d *NASStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "NASStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	return CreateSnapshot(snapConfig, &d.Config, d.API, d.API.VolumeSize)
+}
+
+// CreateGroupSnapshot creates a snapshot of each of several volumes at the same point in time
+func (d *NASStorageDriver) CreateGroupSnapshot(snapConfigs []*storage.SnapshotConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":    "CreateGroupSnapshot",
+			"Type":      "NASStorageDriver",
+			"snapshots": len(snapConfigs),
+		}
+		log.WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	return CreateGroupSnapshot(snapConfigs, &d.Config, d.API, d.API.VolumeSize)
+}
+
+// DeleteSnapshot deletes a snapshot of a volume
+func (d *NASStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "NASStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	return DeleteSnapshot(snapConfig, &d.Config, d.API)
+}
+
+// RestoreSnapshot reverts a volume to a snapshot
+func (d *NASStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "NASStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
-	return GetSnapshotList(name, &d.Config, d.API)
+	return RestoreSnapshot(snapConfig, &d.Config, d.API)
 }
 
 // Test for the existence of a volume
@@ -392,6 +477,98 @@ func (d *NASStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 	return getStorageBackendSpecsCommon(d, backend, poolAttrs)
 }
 
+// GetPoolCapacity reports the size and used space of the backend's aggregates.
+func (d *NASStorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+	return getAggregateCapacity(d)
+}
+
+// CheckHealth verifies that the backend's management LIF can be reached with the configured credentials.
+func (d *NASStorageDriver) CheckHealth() error {
+	return checkHealthCommon(d)
+}
+
+// ListVolumes returns the names of all online, read-write FlexVols in the SVM other than
+// its root volume.
+func (d *NASStorageDriver) ListVolumes() ([]string, error) {
+	return getVolumeNames(d.API.VolumeGetAllReadWrite())
+}
+
+// MoveVolume moves a volume to the aggregate that backs another of the backend's pools.
+func (d *NASStorageDriver) MoveVolume(volConfig *storage.VolumeConfig, pool *storage.Pool) error {
+	return MoveVolume(volConfig, pool, &d.Config, d.API)
+}
+
+// CanReplicateFrom reports whether the source driver's volumes may be mirrored to this backend.
+func (d *NASStorageDriver) CanReplicateFrom(source storage.Driver) bool {
+	_, ok := ReplicationSource(source, d.Name())
+	return ok
+}
+
+// StartReplication creates a data protection volume and starts mirroring the source volume to it.
+func (d *NASStorageDriver) StartReplication(
+	source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig, pool *storage.Pool,
+) error {
+	ontapSource, ok := ReplicationSource(source, d.Name())
+	if !ok {
+		return fmt.Errorf("volumes cannot be replicated from a %s backend", source.Name())
+	}
+	return StartReplication(ontapSource, sourceConfig, volConfig, pool, &d.Config, d.API)
+}
+
+// WaitForReplication waits for the source volume's baseline transfer to finish.
+func (d *NASStorageDriver) WaitForReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	return WaitForReplication(volConfig, &d.Config, d.API)
+}
+
+// CompleteReplication makes the mirrored volume writable, then exports it as Create would.
+func (d *NASStorageDriver) CompleteReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	ontapSource, ok := ReplicationSource(source, d.Name())
+	if !ok {
+		return fmt.Errorf("volumes cannot be replicated from a %s backend", source.Name())
+	}
+	if err := CompleteReplication(ontapSource, sourceConfig, volConfig, &d.Config, d.API); err != nil {
+		return err
+	}
+
+	name := volConfig.InternalName
+	opts, err := d.GetVolumeOpts(volConfig, nil, make(map[string]sa.Request))
+	if err != nil {
+		return err
+	}
+	exportPolicy := utils.GetV(opts, "exportPolicy", d.Config.ExportPolicy)
+	enableSnapshotDir, err := strconv.ParseBool(utils.GetV(opts, "snapshotDir", d.Config.SnapshotDir))
+	if err != nil {
+		return fmt.Errorf("invalid boolean value for snapshotDir: %v", err)
+	}
+
+	exportResponse, err := d.API.VolumeModifyExportPolicy(name, exportPolicy)
+	if err = api.GetError(exportResponse, err); err != nil {
+		return fmt.Errorf("error setting export policy: %v", err)
+	}
+	if !enableSnapshotDir {
+		snapDirResponse, err := d.API.VolumeDisableSnapshotDirectoryAccess(name)
+		if err = api.GetError(snapDirResponse, err); err != nil {
+			return fmt.Errorf("error disabling snapshot directory access: %v", err)
+		}
+	}
+	mountResponse, err := d.API.VolumeMount(name, "/"+name)
+	if err = api.GetError(mountResponse, err); err != nil {
+		return fmt.Errorf("error mounting volume to junction: %v", err)
+	}
+	UpdateLoadSharingMirrors(d.API)
+
+	return nil
+}
+
+// AbortReplication stops mirroring the source volume.
+func (d *NASStorageDriver) AbortReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	ontapSource, ok := ReplicationSource(source, d.Name())
+	if !ok {
+		return nil
+	}
+	return AbortReplication(ontapSource, sourceConfig, volConfig, &d.Config, d.API)
+}
+
 func (d *NASStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
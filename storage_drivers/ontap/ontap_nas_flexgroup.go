@@ -328,20 +328,89 @@ func (d *NASFlexGroupStorageDriver) Publish(name string, publishInfo *utils.Volu
 	return nil
 }
 
-// Return the list of snapshots associated with the named volume
-func (d *NASFlexGroupStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func (d *NASFlexGroupStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "NASFlexGroupStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "NASFlexGroupStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+	}
+
+	return GetSnapshot(snapConfig, &d.Config, d.API, d.API.FlexGroupSize)
+}
+
+// Return the list of snapshots associated with the specified volume
+func (d *NASFlexGroupStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "NASFlexGroupStorageDriver",
+			"volumeName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
-	return GetSnapshotList(name, &d.Config, d.API)
+	return GetSnapshots(volConfig, &d.Config, d.API, d.API.FlexGroupSize)
+}
+
+// CreateSnapshot creates a snapshot for the given volume
+func (d *NASFlexGroupStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "NASFlexGroupStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	return CreateSnapshot(snapConfig, &d.Config, d.API, d.API.FlexGroupSize)
+}
+
+// DeleteSnapshot deletes a snapshot of a volume
+func (d *NASFlexGroupStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "NASFlexGroupStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	return DeleteSnapshot(snapConfig, &d.Config, d.API)
+}
+
+// RestoreSnapshot reverts a volume to a snapshot
+func (d *NASFlexGroupStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "NASFlexGroupStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	return RestoreSnapshot(snapConfig, &d.Config, d.API)
 }
 
 // Tests the existence of a FlexGroup. Returns nil if the FlexGroup
@@ -401,9 +470,47 @@ func (d *NASFlexGroupStorageDriver) getStorageBackendSpecs(
 	}
 	backend.AddStoragePool(pool)
 
+	if capacity, capacityErr := d.GetPoolCapacity(); capacityErr != nil {
+		log.Warningf("Could not obtain aggregate space; capacity of the pool on this backend will not be "+
+			"reported: %v.", capacityErr)
+	} else {
+		pool.Capacity = capacity[config.SVM]
+	}
+
 	return
 }
 
+// GetPoolCapacity reports the combined size and used space of the aggregates assigned to the SVM,
+// which together make up the backend's only pool.
+func (d *NASFlexGroupStorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+
+	vserverAggrs, err := d.vserverAggregates(d.Config.SVM)
+	if err != nil {
+		return nil, err
+	}
+	aggrCapacity, err := getAggregateCapacity(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var poolCapacity storage.PoolCapacity
+	for _, aggrName := range vserverAggrs {
+		poolCapacity.TotalBytes += aggrCapacity[aggrName].TotalBytes
+		poolCapacity.UsedBytes += aggrCapacity[aggrName].UsedBytes
+	}
+	return map[string]storage.PoolCapacity{d.Config.SVM: poolCapacity}, nil
+}
+
+// CheckHealth verifies that the backend's management LIF can be reached with the configured credentials.
+func (d *NASFlexGroupStorageDriver) CheckHealth() error {
+	return checkHealthCommon(d)
+}
+
+// ListVolumes returns the names of all online, read-write FlexGroups in the SVM.
+func (d *NASFlexGroupStorageDriver) ListVolumes() ([]string, error) {
+	return getVolumeNames(d.API.FlexGroupGetAllReadWrite())
+}
+
 func (d *NASFlexGroupStorageDriver) vserverAggregates(svmName string) ([]string, error) {
 	var err error
 	// Get the aggregates assigned to the SVM.  There must be at least one!
@@ -443,21 +443,89 @@ func (d *NASQtreeStorageDriver) Publish(name string, publishInfo *utils.VolumePu
 	return nil
 }
 
-// Return the list of snapshots associated with the named volume
-func (d *NASQtreeStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot returns a snapshot of a volume.  Qtrees can't have snapshots, so this always returns nil.
+func (d *NASQtreeStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "NASQtreeStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "NASQtreeStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+	}
+
+	return nil, nil
+}
+
+// Return the list of snapshots associated with the specified volume
+func (d *NASQtreeStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "NASQtreeStorageDriver",
+			"volumeName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
 	// Qtrees can't have snapshots, so return an empty list
-	return []storage.Snapshot{}, nil
+	return make([]*storage.Snapshot, 0), nil
+}
+
+// CreateSnapshot creates a snapshot for the given volume.  Qtrees can't have snapshots.
+func (d *NASQtreeStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "NASQtreeStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	return nil, fmt.Errorf("snapshots are not supported by backend type %s", d.Name())
+}
+
+// DeleteSnapshot deletes a snapshot of a volume.  Qtrees can't have snapshots.
+func (d *NASQtreeStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "NASQtreeStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	return fmt.Errorf("snapshots are not supported by backend type %s", d.Name())
+}
+
+// RestoreSnapshot reverts a volume to a snapshot.  Qtrees can't have snapshots.
+func (d *NASQtreeStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "NASQtreeStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	return fmt.Errorf("snapshots are not supported by backend type %s", d.Name())
 }
 
 // Test for the existence of a volume
@@ -1040,6 +1108,16 @@ func (d *NASQtreeStorageDriver) GetStorageBackendSpecs(backend *storage.Backend)
 	return getStorageBackendSpecsCommon(d, backend, poolAttrs)
 }
 
+// GetPoolCapacity reports the size and used space of the backend's aggregates.
+func (d *NASQtreeStorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+	return getAggregateCapacity(d)
+}
+
+// CheckHealth verifies that the backend's management LIF can be reached with the configured credentials.
+func (d *NASQtreeStorageDriver) CheckHealth() error {
+	return checkHealthCommon(d)
+}
+
 func (d *NASQtreeStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
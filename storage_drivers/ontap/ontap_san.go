@@ -569,20 +569,115 @@ func (d *SANStorageDriver) getISCSITargetInfo() (iSCSINodeName string, iSCSIInte
 	return
 }
 
-// Return the list of snapshots associated with the named volume
-func (d *SANStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "SANStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+	}
+
+	return GetSnapshot(snapConfig, &d.Config, d.API, d.getLUNSize)
+}
+
+// Return the list of snapshots associated with the specified volume
+func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "SANStorageDriver",
+			"volumeName": volConfig.InternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+	}
+
+	return GetSnapshots(volConfig, &d.Config, d.API, d.getLUNSize)
+}
+
+// CreateSnapshot creates a snapshot for the given volume
+func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	return CreateSnapshot(snapConfig, &d.Config, d.API, d.getLUNSize)
+}
+
+// CreateGroupSnapshot creates a snapshot of each of several volumes at the same point in time
+func (d *SANStorageDriver) CreateGroupSnapshot(snapConfigs []*storage.SnapshotConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":    "CreateGroupSnapshot",
+			"Type":      "SANStorageDriver",
+			"snapshots": len(snapConfigs),
+		}
+		log.WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	return CreateGroupSnapshot(snapConfigs, &d.Config, d.API, d.getLUNSize)
+}
+
+// DeleteSnapshot deletes a snapshot of a volume
+func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
-	return GetSnapshotList(name, &d.Config, d.API)
+	return DeleteSnapshot(snapConfig, &d.Config, d.API)
+}
+
+// RestoreSnapshot reverts a volume to a snapshot
+func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	return RestoreSnapshot(snapConfig, &d.Config, d.API)
+}
+
+// getLUNSize returns the size of the LUN in the named Flexvol
+func (d *SANStorageDriver) getLUNSize(name string) (int, error) {
+
+	lunInfo, err := d.API.LunGet(lunPath(name))
+	if err != nil {
+		return 0, err
+	}
+	return lunInfo.Size(), nil
 }
 
 // Test for the existence of a volume
@@ -609,6 +704,82 @@ func (d *SANStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 	return getStorageBackendSpecsCommon(d, backend, poolAttrs)
 }
 
+// GetPoolCapacity reports the size and used space of the backend's aggregates.
+func (d *SANStorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+	return getAggregateCapacity(d)
+}
+
+// CheckHealth verifies that the backend's management LIF can be reached with the configured credentials.
+func (d *SANStorageDriver) CheckHealth() error {
+	return checkHealthCommon(d)
+}
+
+// ListVolumes returns the names of all online, read-write FlexVols in the SVM that hold a
+// LUN, other than its root volume.
+func (d *SANStorageDriver) ListVolumes() ([]string, error) {
+	volumeNames, err := getVolumeNames(d.API.VolumeGetAllReadWrite())
+	if err != nil {
+		return nil, err
+	}
+	lunVolumeNames, err := getLUNVolumeNames(d.API.LunGetAll("/vol/*/*"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, name := range volumeNames {
+		if lunVolumeNames[name] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// MoveVolume moves a volume to the aggregate that backs another of the backend's pools.
+func (d *SANStorageDriver) MoveVolume(volConfig *storage.VolumeConfig, pool *storage.Pool) error {
+	return MoveVolume(volConfig, pool, &d.Config, d.API)
+}
+
+// CanReplicateFrom reports whether the source driver's volumes may be mirrored to this backend.
+func (d *SANStorageDriver) CanReplicateFrom(source storage.Driver) bool {
+	_, ok := ReplicationSource(source, d.Name())
+	return ok
+}
+
+// StartReplication creates a data protection volume and starts mirroring the source volume to it.
+func (d *SANStorageDriver) StartReplication(
+	source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig, pool *storage.Pool,
+) error {
+	ontapSource, ok := ReplicationSource(source, d.Name())
+	if !ok {
+		return fmt.Errorf("volumes cannot be replicated from a %s backend", source.Name())
+	}
+	return StartReplication(ontapSource, sourceConfig, volConfig, pool, &d.Config, d.API)
+}
+
+// WaitForReplication waits for the source volume's baseline transfer to finish.
+func (d *SANStorageDriver) WaitForReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	return WaitForReplication(volConfig, &d.Config, d.API)
+}
+
+// CompleteReplication makes the mirrored volume, and so its LUN, writable.
+func (d *SANStorageDriver) CompleteReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	ontapSource, ok := ReplicationSource(source, d.Name())
+	if !ok {
+		return fmt.Errorf("volumes cannot be replicated from a %s backend", source.Name())
+	}
+	return CompleteReplication(ontapSource, sourceConfig, volConfig, &d.Config, d.API)
+}
+
+// AbortReplication stops mirroring the source volume.
+func (d *SANStorageDriver) AbortReplication(source storage.Driver, sourceConfig, volConfig *storage.VolumeConfig) error {
+	ontapSource, ok := ReplicationSource(source, d.Name())
+	if !ok {
+		return nil
+	}
+	return AbortReplication(ontapSource, sourceConfig, volConfig, &d.Config, d.API)
+}
+
 func (d *SANStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
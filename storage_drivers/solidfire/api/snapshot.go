@@ -19,6 +19,22 @@ func (c *Client) CreateSnapshot(req *CreateSnapshotRequest) (snapshot Snapshot,
 	return c.GetSnapshot(req.VolumeID, result.Result.SnapshotID, "")
 }
 
+// CreateGroupSnapshot creates a snapshot of each of several volumes at the same point in time,
+// and returns the members of the group snapshot.
+func (c *Client) CreateGroupSnapshot(req *CreateGroupSnapshotRequest) (members []GroupSnapshotMember, err error) {
+	response, err := c.Request("CreateGroupSnapshot", req, NewReqID())
+	if err != nil {
+		log.Errorf("Error in CreateGroupSnapshot: %+v", err)
+		return nil, errors.New("failed to create group snapshot")
+	}
+	var result CreateGroupSnapshotResult
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		log.Errorf("Error detected unmarshalling CreateGroupSnapshot json response: %+v", err)
+		return nil, errors.New("json decode error")
+	}
+	return result.Result.Members, nil
+}
+
 func (c *Client) GetSnapshot(snapID, volID int64, sfName string) (s Snapshot, err error) {
 	var listReq ListSnapshotsRequest
 	listReq.VolumeID = volID
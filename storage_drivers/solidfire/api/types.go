@@ -153,6 +153,30 @@ type CreateSnapshotResult struct {
 	} `json:"result"`
 }
 
+type CreateGroupSnapshotRequest struct {
+	Volumes                 []int64     `json:"volumes"`
+	Name                    string      `json:"name"`
+	EnableRemoteReplication bool        `json:"enableRemoteReplication"`
+	Retention               string      `json:"retention"`
+	Attributes              interface{} `json:"attributes"`
+}
+
+type GroupSnapshotMember struct {
+	VolumeID     int64  `json:"volumeID"`
+	SnapshotID   int64  `json:"snapshotID"`
+	SnapshotUUID string `json:"snapshotUUID"`
+	Checksum     string `json:"checksum"`
+}
+
+type CreateGroupSnapshotResult struct {
+	ID     int `json:"id"`
+	Result struct {
+		GroupSnapshotID   int64                 `json:"groupSnapshotID"`
+		GroupSnapshotUUID string                `json:"groupSnapshotUUID"`
+		Members           []GroupSnapshotMember `json:"members"`
+	} `json:"result"`
+}
+
 type ListSnapshotsRequest struct {
 	VolumeID int64 `json:"volumeID"`
 }
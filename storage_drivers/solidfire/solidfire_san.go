@@ -832,45 +832,349 @@ func (d *SANStorageDriver) Publish(name string, publishInfo *utils.VolumePublish
 	return nil
 }
 
-// SnapshotList returns the list of snapshots associated with the named volume
-func (d *SANStorageDriver) SnapshotList(name string) ([]storage.Snapshot, error) {
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "SnapshotList",
-			"Type":   "SANStorageDriver",
-			"name":   name,
+			"Method":       "GetSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> SnapshotList")
-		defer log.WithFields(fields).Debug("<<<< SnapshotList")
+		log.WithFields(fields).Debug(">>>> GetSnapshot")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
-	v, err := d.GetVolume(name)
+	v, err := d.GetVolume(internalVolName)
+	if err != nil {
+		log.Errorf("Unable to locate parent volume in snapshot get: %+v", err)
+		return nil, fmt.Errorf("volume %s not found", internalVolName)
+	}
+
+	snapshots, err := d.Client.ListSnapshots(&api.ListSnapshotsRequest{VolumeID: v.VolumeID})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list snapshots for volume %s: %v", internalVolName, err)
+	}
+
+	for _, snap := range snapshots {
+		if snap.Name == internalSnapName {
+			log.WithFields(log.Fields{
+				"snapshotName": internalSnapName,
+				"volumeName":   internalVolName,
+				"created":      snap.CreateTime,
+			}).Debug("Found snapshot.")
+			return storage.NewSnapshot(snapConfig, snap.CreateTime, v.TotalSize, storage.SnapshotStateOnline), nil
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Warning("Snapshot not found.")
+
+	return nil, nil
+}
+
+// GetSnapshots returns the list of snapshots associated with the specified volume
+func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":     "GetSnapshots",
+			"Type":       "SANStorageDriver",
+			"volumeName": volConfig.InternalName,
+		}
+		log.WithFields(fields).Debug(">>>> GetSnapshots")
+		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+	}
+
+	v, err := d.GetVolume(volConfig.InternalName)
 	if err != nil {
 		log.Errorf("Unable to locate parent volume in snapshot list: %+v", err)
 		return nil, errors.New("volume not found")
 	}
 
-	var req api.ListSnapshotsRequest
-	req.VolumeID = v.VolumeID
-
-	s, err := d.Client.ListSnapshots(&req)
+	s, err := d.Client.ListSnapshots(&api.ListSnapshotsRequest{VolumeID: v.VolumeID})
 	if err != nil {
 		log.Errorf("Unable to locate snapshot: %+v", err)
 		return nil, errors.New("snapshot not found")
 	}
 
 	log.Debugf("Returned %d snapshots", len(s))
-	var snapshots []storage.Snapshot
+	snapshots := make([]*storage.Snapshot, 0)
 
 	for _, snap := range s {
 		log.Debugf("Snapshot name: %s, date: %s", snap.Name, snap.CreateTime)
-		snapshots = append(snapshots, storage.Snapshot{Name: snap.Name, Created: snap.CreateTime})
+
+		snapshot := &storage.Snapshot{
+			Config: &storage.SnapshotConfig{
+				Version:            tridentconfig.OrchestratorAPIVersion,
+				Name:               snap.Name,
+				InternalName:       snap.Name,
+				VolumeName:         volConfig.Name,
+				VolumeInternalName: volConfig.InternalName,
+			},
+			Created:   snap.CreateTime,
+			SizeBytes: v.TotalSize,
+			State:     storage.SnapshotStateOnline,
+		}
+		snapshots = append(snapshots, snapshot)
 	}
 
 	return snapshots, nil
 }
 
+// CreateSnapshot creates a snapshot for the given volume
+func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	v, err := d.GetVolume(internalVolName)
+	if err != nil {
+		log.Errorf("Unable to locate parent volume in snapshot create: %+v", err)
+		return nil, fmt.Errorf("volume %s not found", internalVolName)
+	}
+
+	telemetry, _ := json.Marshal(d.getTelemetry())
+	var meta = map[string]string{
+		"trident":     string(telemetry),
+		"docker-name": internalSnapName,
+	}
+
+	var req api.CreateSnapshotRequest
+	req.VolumeID = v.VolumeID
+	req.Name = internalSnapName
+	req.Attributes = meta
+
+	snap, err := d.Client.CreateSnapshot(&req)
+	if err != nil {
+		return nil, fmt.Errorf("could not create snapshot: %v", err)
+	}
+	if snap.SnapshotID == 0 {
+		return nil, fmt.Errorf("could not find snapshot %s after creating it", internalSnapName)
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Info("Snapshot created.")
+
+	return storage.NewSnapshot(snapConfig, snap.CreateTime, v.TotalSize, storage.SnapshotStateOnline), nil
+}
+
+// CreateGroupSnapshot creates a snapshot of each of several volumes at the same point in time
+func (d *SANStorageDriver) CreateGroupSnapshot(snapConfigs []*storage.SnapshotConfig) (
+	[]*storage.Snapshot, error,
+) {
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":    "CreateGroupSnapshot",
+			"Type":      "SANStorageDriver",
+			"snapshots": len(snapConfigs),
+		}
+		log.WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	if len(snapConfigs) == 0 {
+		return nil, errors.New("no volumes were specified for the group snapshot")
+	}
+
+	// A group snapshot gives each member snapshot the group's name
+	internalSnapName := snapConfigs[0].InternalName
+	volumes := make([]api.Volume, 0, len(snapConfigs))
+	volumeIDs := make([]int64, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		if snapConfig.InternalName != internalSnapName {
+			return nil, fmt.Errorf("snapshots of a group must have the same name, not %s and %s",
+				internalSnapName, snapConfig.InternalName)
+		}
+		v, err := d.GetVolume(snapConfig.VolumeInternalName)
+		if err != nil {
+			log.Errorf("Unable to locate parent volume in group snapshot create: %+v", err)
+			return nil, fmt.Errorf("volume %s not found", snapConfig.VolumeInternalName)
+		}
+		volumes = append(volumes, v)
+		volumeIDs = append(volumeIDs, v.VolumeID)
+	}
+
+	telemetry, _ := json.Marshal(d.getTelemetry())
+	var meta = map[string]string{
+		"trident":     string(telemetry),
+		"docker-name": internalSnapName,
+	}
+
+	var req api.CreateGroupSnapshotRequest
+	req.Volumes = volumeIDs
+	req.Name = internalSnapName
+	req.Attributes = meta
+
+	members, err := d.Client.CreateGroupSnapshot(&req)
+	if err != nil {
+		return nil, fmt.Errorf("could not create group snapshot: %v", err)
+	}
+
+	// If the group snapshot can't be completed, delete its members again so that
+	// none are left behind
+	deleteMembers := func(err error) error {
+		for _, member := range members {
+			if deleteErr := d.Client.DeleteSnapshot(member.SnapshotID); deleteErr != nil {
+				log.WithFields(log.Fields{
+					"snapshotName": internalSnapName,
+					"snapshotID":   member.SnapshotID,
+					"volumeID":     member.VolumeID,
+				}).Errorf("Unable to delete snapshot of incomplete group snapshot: %v", deleteErr)
+				err = fmt.Errorf("%v; unable to delete snapshot %d of volume %d: %v",
+					err, member.SnapshotID, member.VolumeID, deleteErr)
+			}
+		}
+		return err
+	}
+
+	if len(members) != len(volumes) {
+		return nil, deleteMembers(fmt.Errorf("group snapshot %s has %d members instead of %d",
+			internalSnapName, len(members), len(volumes)))
+	}
+
+	snapIDs := make(map[int64]int64, len(members))
+	for _, member := range members {
+		snapIDs[member.VolumeID] = member.SnapshotID
+	}
+
+	// Fetch each snapshot to get its creation time
+	snapshots := make([]*storage.Snapshot, 0, len(snapConfigs))
+	for i, snapConfig := range snapConfigs {
+		snap, err := d.Client.GetSnapshot(snapIDs[volumes[i].VolumeID], volumes[i].VolumeID, internalSnapName)
+		if err != nil {
+			return nil, deleteMembers(fmt.Errorf("unable to find snapshot %s: %v", internalSnapName, err))
+		}
+		if snap.SnapshotID == 0 {
+			return nil, deleteMembers(fmt.Errorf("could not find snapshot %s of volume %s after creating it",
+				internalSnapName, snapConfig.VolumeInternalName))
+		}
+		snapshots = append(snapshots, storage.NewSnapshot(snapConfig, snap.CreateTime, volumes[i].TotalSize,
+			storage.SnapshotStateOnline))
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumes":      len(snapConfigs),
+	}).Info("Group snapshot created.")
+
+	return snapshots, nil
+}
+
+// DeleteSnapshot deletes a snapshot of a volume
+func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	v, err := d.GetVolume(internalVolName)
+	if err != nil && err.Error() != "volume not found" {
+		log.Errorf("Unable to locate parent volume in snapshot delete: %+v", err)
+		return err
+	} else if err != nil {
+		// Volume wasn't found, so its snapshots are gone too. No action needs to be taken.
+		log.Warnf("Volume %s doesn't exist.", internalVolName)
+		return nil
+	}
+
+	s, err := d.Client.GetSnapshot(0, v.VolumeID, internalSnapName)
+	if err != nil {
+		return fmt.Errorf("unable to find snapshot %s: %v", internalSnapName, err)
+	}
+	if s.SnapshotID == 0 {
+		log.WithFields(log.Fields{
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}).Warn("Snapshot already deleted.")
+		return nil
+	}
+
+	if err = d.Client.DeleteSnapshot(s.SnapshotID); err != nil {
+		return fmt.Errorf("unable to delete snapshot %s: %v", internalSnapName, err)
+	}
+
+	return nil
+}
+
+// RestoreSnapshot reverts a volume to a snapshot
+func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "SANStorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	v, err := d.GetVolume(internalVolName)
+	if err != nil {
+		log.Errorf("Unable to locate parent volume in snapshot restore: %+v", err)
+		return fmt.Errorf("volume %s not found", internalVolName)
+	}
+
+	s, err := d.Client.GetSnapshot(0, v.VolumeID, internalSnapName)
+	if err != nil {
+		return fmt.Errorf("unable to find snapshot %s: %v", internalSnapName, err)
+	}
+	if s.SnapshotID == 0 {
+		return fmt.Errorf("snapshot %s of volume %s not found", internalSnapName, internalVolName)
+	}
+
+	var req api.RollbackToSnapshotRequest
+	req.VolumeID = v.VolumeID
+	req.SnapshotID = s.SnapshotID
+	req.SaveCurrentState = false
+
+	if _, err = d.Client.RollbackToSnapshot(&req); err != nil {
+		return fmt.Errorf("unable to restore snapshot %s: %v", internalSnapName, err)
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeName":   internalVolName,
+	}).Info("Snapshot restored.")
+
+	return nil
+}
+
 // Get tests for the existence of a volume
 func (d *SANStorageDriver) Get(name string) error {
 
@@ -970,8 +1274,15 @@ func (d *SANStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 			},
 		}
 	}
+	clusterCapacity, capacityErr := d.getClusterCapacity()
+	if capacityErr != nil {
+		log.Warningf("Could not obtain cluster capacity; capacity of pools on this backend will not be "+
+			"reported: %v.", capacityErr)
+	}
+
 	for _, volType := range volTypes {
 		pool := storage.NewStoragePool(backend, volType.Type)
+		pool.Capacity = clusterCapacity
 
 		pool.Attributes[sa.Media] = sa.NewStringOffer(sa.SSD)
 		pool.Attributes[sa.IOPS] = sa.NewIntOffer(int(volType.QOS.MinIOPS),
@@ -993,6 +1304,66 @@ func (d *SANStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) erro
 	return nil
 }
 
+// getClusterCapacity returns the provisioned space of the cluster, which is thin provisioned,
+// relative to the limit the cluster places on provisioning.
+func (d *SANStorageDriver) getClusterCapacity() (storage.PoolCapacity, error) {
+
+	capacity, err := d.Client.GetClusterCapacity()
+	if err != nil {
+		return storage.PoolCapacity{}, err
+	}
+	return storage.PoolCapacity{
+		TotalBytes: uint64(capacity.MaxOverProvisionableSpace),
+		UsedBytes:  uint64(capacity.ProvisionedSpace),
+	}, nil
+}
+
+// GetPoolCapacity reports the capacity of the cluster for every pool, since all volume
+// types share the same cluster space.
+func (d *SANStorageDriver) GetPoolCapacity() (map[string]storage.PoolCapacity, error) {
+
+	clusterCapacity, err := d.getClusterCapacity()
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := make(map[string]storage.PoolCapacity)
+	capacity[sfDefaultVolTypeName] = clusterCapacity
+	for _, volType := range *d.Client.VolumeTypes {
+		capacity[volType.Type] = clusterCapacity
+	}
+	return capacity, nil
+}
+
+// CheckHealth verifies that the cluster's management endpoint can be reached with the
+// configured credentials.
+func (d *SANStorageDriver) CheckHealth() error {
+
+	if _, err := d.Client.GetClusterCapacity(); err != nil {
+		return fmt.Errorf("could not read cluster capacity: %v", err)
+	}
+	return nil
+}
+
+// ListVolumes returns the names of all volumes owned by the backend's tenant account.
+func (d *SANStorageDriver) ListVolumes() ([]string, error) {
+
+	var req api.ListVolumesForAccountRequest
+	req.AccountID = d.AccountID
+	volumes, err := d.Client.ListVolumesForAccount(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		if volume.Status != "deleted" {
+			names = append(names, volume.Name)
+		}
+	}
+	return names, nil
+}
+
 func (d *SANStorageDriver) GetInternalVolumeName(name string) string {
 
 	if tridentconfig.UsingPassthroughStore {
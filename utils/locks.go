@@ -7,8 +7,8 @@ import (
 )
 
 type locks struct {
-	lockMap    map[string]*sync.Mutex
-	createLock *sync.Mutex
+	lockMap    map[string]*sync.RWMutex
+	createLock *sync.RWMutex
 }
 
 var sharedLocks *locks
@@ -16,26 +16,28 @@ var sharedLocks *locks
 // init initializes the shared locks struct exactly once per runtime.
 func init() {
 	sharedLocks = &locks{
-		lockMap:    map[string]*sync.Mutex{},
-		createLock: &sync.Mutex{},
+		lockMap:    map[string]*sync.RWMutex{},
+		createLock: &sync.RWMutex{},
 	}
 }
 
 // getLock returns a mutex with the specified ID.  If the lock does not exist, one is created.
 // This method uses the check-lock-check pattern to defend against race conditions where multiple
-// callers try to get a non-existent lock at the same time.
-func getLock(lockID string) *sync.Mutex {
+// callers try to get a non-existent lock at the same time.  The lock map itself is guarded by
+// createLock, so lookups of existing locks only need to share it.
+func getLock(lockID string) *sync.RWMutex {
 
-	var lock *sync.Mutex
-	var ok bool
+	sharedLocks.createLock.RLock()
+	lock, ok := sharedLocks.lockMap[lockID]
+	sharedLocks.createLock.RUnlock()
 
-	if lock, ok = sharedLocks.lockMap[lockID]; !ok {
+	if !ok {
 
 		sharedLocks.createLock.Lock()
 		defer sharedLocks.createLock.Unlock()
 
 		if lock, ok = sharedLocks.lockMap[lockID]; !ok {
-			lock = &sync.Mutex{}
+			lock = &sync.RWMutex{}
 			sharedLocks.lockMap[lockID] = lock
 			log.WithField("lock", lockID).Debug("Created shared lock.")
 		}
@@ -58,3 +60,19 @@ func Unlock(ctx, lockID string) {
 	getLock(lockID).Unlock()
 	log.WithField("lock", lockID).Debugf("Released shared lock (%s).", ctx)
 }
+
+// RLock acquires a mutex with the specified ID for reading, so that any number of readers may hold
+// it at once while excluding callers of Lock.  The semantics of this method are intentionally
+// identical to sync.RWMutex.RLock().
+func RLock(ctx, lockID string) {
+	log.WithField("lock", lockID).Debugf("Attempting to acquire shared read lock (%s).", ctx)
+	getLock(lockID).RLock()
+	log.WithField("lock", lockID).Debugf("Acquired shared read lock (%s).", ctx)
+}
+
+// RUnlock releases a read lock acquired with RLock.  The semantics of this method are intentionally
+// identical to sync.RWMutex.RUnlock().
+func RUnlock(ctx, lockID string) {
+	getLock(lockID).RUnlock()
+	log.WithField("lock", lockID).Debugf("Released shared read lock (%s).", ctx)
+}
@@ -99,3 +99,32 @@ func TestLockBehavior(t *testing.T) {
 		t.Error("Expected done2 followed by done1.")
 	}
 }
+
+func TestReadLockBehavior(t *testing.T) {
+
+	// Multiple readers may hold the lock at once
+	RLock("testContext1", "readLock")
+	RLock("testContext2", "readLock")
+
+	acquired := make(chan bool)
+	go func() {
+		Lock("testContext3", "readLock")
+		acquired <- true
+		Unlock("testContext3", "readLock")
+	}()
+
+	// A writer must wait until all readers are done
+	RUnlock("testContext1", "readLock")
+	select {
+	case <-acquired:
+		t.Fatal("Did not expect write lock to be acquired while a read lock is held.")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	RUnlock("testContext2", "readLock")
+	select {
+	case <-acquired:
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected write lock to be acquired after read locks were released.")
+	}
+}